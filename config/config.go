@@ -1,8 +1,10 @@
 package config
 
 import (
+	crand "crypto/rand"
+	"encoding/base64"
 	"fmt"
-	"math/rand"
+	"math/big"
 	"os"
 
 	"github.com/gin-gonic/gin"
@@ -13,9 +15,16 @@ const (
 	TokenHeader    = "Authorization"
 	Bearer         = "Bearer "
 	CurrentUserKey = "currentUser"
-	ListKey        = "list"
-	CardKey        = "card"
-	StateCookieKey = "state"
+	ClaimKey       = "claim"
+	// パーソナルアクセストークンで認証した場合のみ設定する
+	PersonalAccessTokenKey = "personalAccessToken"
+	BoardKey               = "board"
+	ListKey                = "list"
+	CardKey                = "card"
+	StateCookieKey         = "state"
+	// PKCEのcode_verifierとid_tokenのnonceもstateと一緒にcookieに保存する
+	CodeVerifierCookieKey = "code_verifier"
+	NonceCookieKey        = "nonce"
 )
 
 var (
@@ -49,10 +58,26 @@ func Init() {
 func MakeRandomStr(digit int) string {
 	const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 
+	// stateのように推測されてはいけない用途にも使うのでcrypto/randで生成する
 	bytes := make([]byte, digit)
+	max := big.NewInt(int64(len(letters)))
 	for i := range bytes {
-		bytes[i] = letters[rand.Intn(len(letters))]
+		n, err := crand.Int(crand.Reader, max)
+		if err != nil {
+			panic(err)
+		}
+		bytes[i] = letters[n.Int64()]
 	}
 
 	return string(bytes)
 }
+
+// リフレッシュトークン等の推測されてはいけない文字列を生成する
+func MakeRandomToken(byteLength int) string {
+	bytes := make([]byte, byteLength)
+	if _, err := crand.Read(bytes); err != nil {
+		panic(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
@@ -7,13 +7,41 @@ import (
 )
 
 var (
-	UniqueUserError             = errors.New("not unique user")
-	AlreadyActivatedUserError   = errors.New("alreay activated user")
-	PasswordAuthenticationError = errors.New("password is not authenticated")
-	EmailClientError            = errors.New("email client error")
-	ForbiddenError              = errors.New("forbidden")
-	CsrfError                   = errors.New("csrf error")
-	StandardError               = errors.New("standard error")
+	UniqueUserError                 = errors.New("not unique user")
+	AlreadyActivatedUserError       = errors.New("alreay activated user")
+	PasswordAuthenticationError     = errors.New("password is not authenticated")
+	EmailClientError                = errors.New("email client error")
+	ForbiddenError                  = errors.New("forbidden")
+	CsrfError                       = errors.New("csrf error")
+	StandardError                   = errors.New("standard error")
+	InvalidRefreshTokenError        = errors.New("invalid refresh token")
+	ReusedRefreshTokenError         = errors.New("refresh token is reused")
+	InvalidPasswordResetTokenError  = errors.New("invalid password reset token")
+	InvalidEmailChangeTokenError    = errors.New("invalid email change token")
+	NotActivatedUserError           = errors.New("not activated user")
+	ActivationEmailThrottledError   = errors.New("activation email was sent recently")
+	AccountLockedError              = errors.New("account is locked")
+	TooManyLoginAttemptsError       = errors.New("too many login attempts")
+	TotpAlreadyEnabledError         = errors.New("totp is already enabled")
+	TotpNotEnrolledError            = errors.New("totp is not enrolled")
+	InvalidTotpCodeError            = errors.New("invalid totp code")
+	InvalidChallengeTokenError      = errors.New("invalid challenge token")
+	AccountLinkConflictError        = errors.New("account with the same email cannot be linked")
+	IdentityAlreadyLinkedError      = errors.New("identity is already linked to another user")
+	OauthProviderNotFoundError      = errors.New("oauth provider not found")
+	InvalidNonceError               = errors.New("id token nonce does not match")
+	UnknownSigningKeyError          = errors.New("jwt is signed with an unknown key")
+	InvalidPersonalAccessTokenError = errors.New("invalid personal access token")
+	SessionRevokedError             = errors.New("session has been revoked")
+	AccessTokenExpiredError         = errors.New("access token has expired")
+	UnlinkLastLoginMethodError      = errors.New("cannot unlink the only login method")
+	StorageObjectNotFoundError      = errors.New("storage object not found")
+	AttachmentTooLargeError         = errors.New("attachment is too large")
+	AttachmentQuotaExceededError    = errors.New("attachment quota exceeded")
+	ListInTrashError                = errors.New("list of the card is in the trash")
+	AlreadyBoardMemberError         = errors.New("user is already a member of the board")
+	LastBoardOwnerError             = errors.New("board must have at least one owner")
+	InvalidRateLimitError           = errors.New("rate limit must be positive")
 )
 
 type ErrorResponse struct {
@@ -81,6 +109,116 @@ var (
 		Code: 403,
 		Json: createJson(ForbiddenError.Error()),
 	}
+
+	InvalidRefreshTokenErrorResponse = ErrorResponse{
+		Code: 401,
+		Json: createJson(InvalidRefreshTokenError.Error()),
+	}
+
+	InvalidPasswordResetTokenErrorResponse = ErrorResponse{
+		Code: 400,
+		Json: createJson(InvalidPasswordResetTokenError.Error()),
+	}
+
+	InvalidEmailChangeTokenErrorResponse = ErrorResponse{
+		Code: 400,
+		Json: createJson(InvalidEmailChangeTokenError.Error()),
+	}
+
+	NotActivatedUserErrorResponse = ErrorResponse{
+		Code: 403,
+		Json: createJson(NotActivatedUserError.Error()),
+	}
+
+	AccountLockedErrorResponse = ErrorResponse{
+		Code: 423,
+		Json: createJson(AccountLockedError.Error()),
+	}
+
+	TooManyLoginAttemptsErrorResponse = ErrorResponse{
+		Code: 429,
+		Json: createJson(TooManyLoginAttemptsError.Error()),
+	}
+
+	TotpAlreadyEnabledErrorResponse = ErrorResponse{
+		Code: 400,
+		Json: createJson(TotpAlreadyEnabledError.Error()),
+	}
+
+	TotpNotEnrolledErrorResponse = ErrorResponse{
+		Code: 400,
+		Json: createJson(TotpNotEnrolledError.Error()),
+	}
+
+	InvalidTotpCodeErrorResponse = ErrorResponse{
+		Code: 401,
+		Json: createJson(InvalidTotpCodeError.Error()),
+	}
+
+	InvalidChallengeTokenErrorResponse = ErrorResponse{
+		Code: 401,
+		Json: createJson(InvalidChallengeTokenError.Error()),
+	}
+
+	AccountLinkConflictErrorResponse = ErrorResponse{
+		Code: 409,
+		Json: createJson(AccountLinkConflictError.Error()),
+	}
+
+	IdentityAlreadyLinkedErrorResponse = ErrorResponse{
+		Code: 409,
+		Json: createJson(IdentityAlreadyLinkedError.Error()),
+	}
+
+	OauthProviderNotFoundErrorResponse = ErrorResponse{
+		Code: 404,
+		Json: createJson(OauthProviderNotFoundError.Error()),
+	}
+
+	InvalidNonceErrorResponse = ErrorResponse{
+		Code: 401,
+		Json: createJson(InvalidNonceError.Error()),
+	}
+
+	UnlinkLastLoginMethodErrorResponse = ErrorResponse{
+		Code: 400,
+		Json: createJson(UnlinkLastLoginMethodError.Error()),
+	}
+
+	AttachmentTooLargeErrorResponse = ErrorResponse{
+		Code: 413,
+		Json: createJson(AttachmentTooLargeError.Error()),
+	}
+
+	AttachmentQuotaExceededErrorResponse = ErrorResponse{
+		Code: 413,
+		Json: createJson(AttachmentQuotaExceededError.Error()),
+	}
+
+	ListInTrashErrorResponse = ErrorResponse{
+		Code: 409,
+		Json: createJson(ListInTrashError.Error()),
+	}
+
+	AlreadyBoardMemberErrorResponse = ErrorResponse{
+		Code: 409,
+		Json: createJson(AlreadyBoardMemberError.Error()),
+	}
+
+	LastBoardOwnerErrorResponse = ErrorResponse{
+		Code: 409,
+		Json: createJson(LastBoardOwnerError.Error()),
+	}
+
+	InsufficientScopeErrorResponse = ErrorResponse{
+		Code: 403,
+		Json: createJson("personal access token does not have the required scope"),
+	}
+
+	RateLimitErrorResponse = ErrorResponse{
+		Code: 429,
+		Json: createJson("too many requests"),
+	}
 )
 
 func createJson(content string) gin.H {
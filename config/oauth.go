@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// OpenID Connectのプロバイダーの設定
+// OAUTH_PROVIDERSにカンマ区切りでプロバイダー名を並べ、プロバイダーごとに以下の環境変数を設定する
+// OAUTH_<NAME>_ISSUER, OAUTH_<NAME>_CLIENT_ID, OAUTH_<NAME>_CLIENT_SECRET, OAUTH_<NAME>_REDIRECT_URL, OAUTH_<NAME>_SCOPES(スペース区切り)
+// OAUTH_<NAME>_TRUST_EMAIL=true の場合のみプロバイダーが確認済みとしたメールアドレスで既存のユーザーに自動で紐付ける
+// 信頼しないプロバイダーはログイン中に紐付けてもらう
+type OauthProvider struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	TrustEmail   bool
+}
+
+// googleは以前からの環境変数名でもクライアントIDとシークレットを設定できるようにする
+var legacyOauthEnv = map[string]map[string]string{
+	"google": {
+		"CLIENT_ID":     "CLIENT_ID",
+		"CLIENT_SECRET": "CLIENT_SECRET",
+	},
+}
+
+func OauthProviders() []OauthProvider {
+	var providers []OauthProvider
+	for _, name := range strings.Split(os.Getenv("OAUTH_PROVIDERS"), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		providers = append(providers, newOauthProvider(name))
+	}
+
+	return providers
+}
+
+// 設定されていないプロバイダー名の場合はOauthProviderNotFoundErrorを返す
+func FindOauthProvider(name string) (OauthProvider, error) {
+	for _, provider := range OauthProviders() {
+		if provider.Name == name {
+			return provider, nil
+		}
+	}
+
+	return OauthProvider{}, OauthProviderNotFoundError
+}
+
+func newOauthProvider(name string) OauthProvider {
+	scopes := strings.Fields(oauthEnv(name, "SCOPES"))
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email"}
+	}
+
+	return OauthProvider{
+		Name:         name,
+		Issuer:       oauthEnv(name, "ISSUER"),
+		ClientID:     oauthEnv(name, "CLIENT_ID"),
+		ClientSecret: oauthEnv(name, "CLIENT_SECRET"),
+		RedirectURL:  oauthEnv(name, "REDIRECT_URL"),
+		Scopes:       scopes,
+		TrustEmail:   oauthEnv(name, "TRUST_EMAIL") == "true",
+	}
+}
+
+func oauthEnv(name, key string) string {
+	value := os.Getenv(fmt.Sprintf("OAUTH_%v_%v", strings.ToUpper(name), key))
+	if value != "" {
+		return value
+	}
+
+	if legacyKey, ok := legacyOauthEnv[name][key]; ok {
+		return os.Getenv(legacyKey)
+	}
+	return ""
+}
@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"gorm.io/gorm"
+)
+
+type AttachmentController interface {
+	Index(*gin.Context)    // GET /api/cards/:id/attachments
+	Create(*gin.Context)   // POST /api/cards/:id/attachments
+	Download(*gin.Context) // GET /api/cards/:id/attachments/:attachmentID
+	Destroy(*gin.Context)  // DELETE /api/cards/:id/attachments/:attachmentID
+}
+
+type attachmentController struct {
+	service service.AttachmentService
+}
+
+func NewAttachmentController() AttachmentController {
+	return &attachmentController{service: service.NewAttachmentService()}
+}
+
+func (c *attachmentController) Index(ctx *gin.Context) {
+	attachments, err := c.service.Index(ctx)
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.JSON(200, model.ToJsonAttachmentSlice(attachments))
+}
+
+func (c *attachmentController) Create(ctx *gin.Context) {
+	attachment, err := c.service.Create(ctx)
+	if err == http.ErrMissingFile {
+		ctx.JSON(config.ValidationErrorResponse.Code, config.ValidationErrorResponse.Json)
+		return
+	}
+
+	if err == config.AttachmentTooLargeError {
+		ctx.JSON(config.AttachmentTooLargeErrorResponse.Code, config.AttachmentTooLargeErrorResponse.Json)
+		return
+	}
+
+	if err == config.AttachmentQuotaExceededError {
+		ctx.JSON(config.AttachmentQuotaExceededErrorResponse.Code, config.AttachmentQuotaExceededErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.JSON(200, attachment.ToJson())
+}
+
+// ブラウザで開いてもスクリプトが実行されないように常にダウンロードさせる
+func (c *attachmentController) Download(ctx *gin.Context) {
+	attachment, body, err := c.service.Download(ctx)
+	if err == gorm.ErrRecordNotFound {
+		ctx.JSON(config.RecordNotFoundErrorResponse.Code, config.RecordNotFoundErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+	defer body.Close()
+
+	ctx.DataFromReader(200, attachment.Size, attachment.ContentType, body, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func (c *attachmentController) Destroy(ctx *gin.Context) {
+	err := c.service.Destroy(ctx)
+	if err == gorm.ErrRecordNotFound {
+		ctx.JSON(config.RecordNotFoundErrorResponse.Code, config.RecordNotFoundErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.Status(200)
+}
+
+// test用
+func TestNewAttachmentController(s service.AttachmentService) AttachmentController {
+	return &attachmentController{service: s}
+}
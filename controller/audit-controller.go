@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+)
+
+type AuditController interface {
+	Index(*gin.Context)      // GET /api/users/audit
+	AdminIndex(*gin.Context) // GET /api/admin/audit-logs
+}
+
+type auditController struct {
+	service service.AuditService
+}
+
+func NewAuditController() AuditController {
+	return &auditController{service: service.NewAuditService()}
+}
+
+func (c *auditController) Index(ctx *gin.Context) {
+	logs, err := c.service.Index(ctx)
+	c.render(ctx, logs, err)
+}
+
+func (c *auditController) AdminIndex(ctx *gin.Context) {
+	logs, err := c.service.AdminIndex(ctx)
+	c.render(ctx, logs, err)
+}
+
+func (c *auditController) render(ctx *gin.Context, logs []model.AuditLog, err error) {
+	// クエリパラメーターが数値でない場合もバリデーションエラーにする
+	_, isNumError := err.(*strconv.NumError)
+	if _, ok := err.(validator.ValidationErrors); ok || isNumError {
+		ctx.JSON(config.ValidationErrorResponse.Code, config.ValidationErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.JSON(200, model.ToJsonAuditLogSlice(logs))
+}
+
+// test用
+func TestNewAuditController(s service.AuditService) AuditController {
+	return &auditController{service: s}
+}
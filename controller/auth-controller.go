@@ -4,6 +4,7 @@ import (
 	"os"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 	"github.com/kuritaeiji/todo-gin-back/config"
 	"github.com/kuritaeiji/todo-gin-back/service"
 	"gorm.io/gorm"
@@ -11,8 +12,14 @@ import (
 
 type AuthController interface {
 	Login(*gin.Context)       // GET /api/login
-	Google(*gin.Context)      // GET /api/google
-	GoogleLogin(*gin.Context) // POST /api/google/login
+	Oauth(*gin.Context)       // GET /api/oauth/:provider
+	OauthLogin(*gin.Context)  // POST /api/oauth/:provider/login
+	TotpLogin(*gin.Context)   // POST /api/login/totp
+	LinkOauth(*gin.Context)   // POST /api/users/oauth/:provider
+	UnlinkOauth(*gin.Context) // DELETE /api/users/oauth/:provider
+	Refresh(*gin.Context)     // POST /api/token/refresh
+	Logout(*gin.Context)      // DELETE /api/logout
+	LogoutAll(*gin.Context)   // DELETE /api/sessions
 }
 
 type authController struct {
@@ -26,7 +33,7 @@ func NewAuthController() AuthController {
 }
 
 func (c *authController) Login(ctx *gin.Context) {
-	tokenString, err := c.service.Login(ctx)
+	tokenPair, challengeToken, err := c.service.Login(ctx)
 	if err == gorm.ErrRecordNotFound {
 		ctx.JSON(config.RecordNotFoundErrorResponse.Code, config.RecordNotFoundErrorResponse.Json)
 		return
@@ -37,46 +44,237 @@ func (c *authController) Login(ctx *gin.Context) {
 		return
 	}
 
+	if err == config.AccountLockedError {
+		ctx.JSON(config.AccountLockedErrorResponse.Code, config.AccountLockedErrorResponse.Json)
+		return
+	}
+
+	if err == config.TooManyLoginAttemptsError {
+		ctx.JSON(config.TooManyLoginAttemptsErrorResponse.Code, config.TooManyLoginAttemptsErrorResponse.Json)
+		return
+	}
+
+	if err == config.NotActivatedUserError {
+		ctx.JSON(config.NotActivatedUserErrorResponse.Code, config.NotActivatedUserErrorResponse.Json)
+		return
+	}
+
 	if err != nil {
 		ctx.AbortWithStatus(500)
 		return
 	}
 
-	ctx.JSON(200, gin.H{
-		"token": tokenString,
-	})
+	if challengeToken != "" {
+		ctx.JSON(200, challengeJson(challengeToken))
+		return
+	}
+
+	ctx.JSON(200, tokenPair.ToJson())
 }
 
-func (c *authController) Google(ctx *gin.Context) {
-	url, state, err := c.service.Google(ctx)
+func (c *authController) Oauth(ctx *gin.Context) {
+	authorization, err := c.service.Oauth(ctx)
+	if err == config.OauthProviderNotFoundError {
+		ctx.JSON(config.OauthProviderNotFoundErrorResponse.Code, config.OauthProviderNotFoundErrorResponse.Json)
+		return
+	}
+
 	if err != nil {
 		ctx.AbortWithStatus(500)
 		return
 	}
 
+	setOauthCookie(ctx, config.StateCookieKey, authorization.State, 3600)
+	setOauthCookie(ctx, config.CodeVerifierCookieKey, authorization.CodeVerifier, 3600)
+	setOauthCookie(ctx, config.NonceCookieKey, authorization.Nonce, 3600)
+
+	ctx.JSON(200, gin.H{
+		"url":   authorization.URL,
+		"state": authorization.State,
+	})
+}
+
+// state, code_verifier, nonceは一度しか使えないようにコールバックの処理後に削除する
+func clearOauthCookies(ctx *gin.Context) {
+	setOauthCookie(ctx, config.StateCookieKey, "", -1)
+	setOauthCookie(ctx, config.CodeVerifierCookieKey, "", -1)
+	setOauthCookie(ctx, config.NonceCookieKey, "", -1)
+}
+
+func setOauthCookie(ctx *gin.Context, name, value string, maxAge int) {
 	var secure bool
 	if gin.Mode() == gin.ReleaseMode {
 		secure = true
 	}
 
-	ctx.SetCookie(config.StateCookieKey, state, 3600, "", os.Getenv("DOMAIN"), secure, true)
+	ctx.SetCookie(name, value, maxAge, "", os.Getenv("DOMAIN"), secure, true)
+}
 
-	ctx.JSON(200, gin.H{
-		"url":   url,
-		"state": state,
-	})
+func (c *authController) OauthLogin(ctx *gin.Context) {
+	tokenPair, challengeToken, err := c.service.OauthLogin(ctx)
+	clearOauthCookies(ctx)
+	if err == config.OauthProviderNotFoundError {
+		ctx.JSON(config.OauthProviderNotFoundErrorResponse.Code, config.OauthProviderNotFoundErrorResponse.Json)
+		return
+	}
+
+	if err == config.AccountLinkConflictError {
+		ctx.JSON(config.AccountLinkConflictErrorResponse.Code, config.AccountLinkConflictErrorResponse.Json)
+		return
+	}
+
+	if err == config.InvalidNonceError {
+		ctx.JSON(config.InvalidNonceErrorResponse.Code, config.InvalidNonceErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	if challengeToken != "" {
+		ctx.JSON(200, challengeJson(challengeToken))
+		return
+	}
+
+	ctx.JSON(200, tokenPair.ToJson())
 }
 
-func (c *authController) GoogleLogin(ctx *gin.Context) {
-	tokenString, err := c.service.GoogleLogin(ctx)
+func (c *authController) TotpLogin(ctx *gin.Context) {
+	tokenPair, err := c.service.TotpLogin(ctx)
+	if _, ok := err.(validator.ValidationErrors); ok {
+		ctx.JSON(config.ValidationErrorResponse.Code, config.ValidationErrorResponse.Json)
+		return
+	}
+
+	if err == config.InvalidChallengeTokenError {
+		ctx.JSON(config.InvalidChallengeTokenErrorResponse.Code, config.InvalidChallengeTokenErrorResponse.Json)
+		return
+	}
+
+	if err == config.InvalidTotpCodeError {
+		ctx.JSON(config.InvalidTotpCodeErrorResponse.Code, config.InvalidTotpCodeErrorResponse.Json)
+		return
+	}
+
+	if err == config.AccountLockedError {
+		ctx.JSON(config.AccountLockedErrorResponse.Code, config.AccountLockedErrorResponse.Json)
+		return
+	}
+
+	if err == config.TooManyLoginAttemptsError {
+		ctx.JSON(config.TooManyLoginAttemptsErrorResponse.Code, config.TooManyLoginAttemptsErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.JSON(200, tokenPair.ToJson())
+}
+
+func (c *authController) LinkOauth(ctx *gin.Context) {
+	err := c.service.LinkOauth(ctx)
+	clearOauthCookies(ctx)
+	if err == config.OauthProviderNotFoundError {
+		ctx.JSON(config.OauthProviderNotFoundErrorResponse.Code, config.OauthProviderNotFoundErrorResponse.Json)
+		return
+	}
+
+	if err == config.IdentityAlreadyLinkedError {
+		ctx.JSON(config.IdentityAlreadyLinkedErrorResponse.Code, config.IdentityAlreadyLinkedErrorResponse.Json)
+		return
+	}
+
+	if err == config.InvalidNonceError {
+		ctx.JSON(config.InvalidNonceErrorResponse.Code, config.InvalidNonceErrorResponse.Json)
+		return
+	}
+
 	if err != nil {
 		ctx.Error(err)
 		ctx.AbortWithStatus(500)
+		return
 	}
 
-	ctx.JSON(200, gin.H{
-		"token": tokenString,
-	})
+	ctx.Status(200)
+}
+
+func (c *authController) UnlinkOauth(ctx *gin.Context) {
+	err := c.service.UnlinkOauth(ctx)
+	if err == config.OauthProviderNotFoundError {
+		ctx.JSON(config.OauthProviderNotFoundErrorResponse.Code, config.OauthProviderNotFoundErrorResponse.Json)
+		return
+	}
+
+	if err == gorm.ErrRecordNotFound {
+		ctx.JSON(config.RecordNotFoundErrorResponse.Code, config.RecordNotFoundErrorResponse.Json)
+		return
+	}
+
+	if err == config.UnlinkLastLoginMethodError {
+		ctx.JSON(config.UnlinkLastLoginMethodErrorResponse.Code, config.UnlinkLastLoginMethodErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.Status(200)
+}
+
+func (c *authController) Refresh(ctx *gin.Context) {
+	tokenPair, err := c.service.Refresh(ctx)
+	if _, ok := err.(validator.ValidationErrors); ok {
+		ctx.JSON(config.ValidationErrorResponse.Code, config.ValidationErrorResponse.Json)
+		return
+	}
+
+	if err == config.InvalidRefreshTokenError || err == config.ReusedRefreshTokenError || err == config.SessionRevokedError {
+		ctx.JSON(config.InvalidRefreshTokenErrorResponse.Code, config.InvalidRefreshTokenErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.JSON(200, tokenPair.ToJson())
+}
+
+func (c *authController) Logout(ctx *gin.Context) {
+	err := c.service.Logout(ctx)
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.Status(200)
+}
+
+func (c *authController) LogoutAll(ctx *gin.Context) {
+	err := c.service.LogoutAll(ctx)
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.Status(200)
+}
+
+// 2段階認証が有効な場合はチャレンジトークンと認証コードで/login/totpにリクエストしてもらう
+func challengeJson(challengeToken string) gin.H {
+	return gin.H{
+		"twoFactorRequired": true,
+		"challengeToken":    challengeToken,
+	}
 }
 
 // test
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+)
+
+type BoardController interface {
+	Index(*gin.Context)   // GET /api/boards
+	Create(*gin.Context)  // POST /api/boards
+	Update(*gin.Context)  // PUT /api/boards/:id
+	Destroy(*gin.Context) // DELETE /api/boards/:id
+	Move(*gin.Context)    // PUT /api/boards/:id/move
+}
+
+type boardController struct {
+	service service.BoardService
+}
+
+func NewBoardController() BoardController {
+	return &boardController{service: service.NewBoardService()}
+}
+
+func (c *boardController) Index(ctx *gin.Context) {
+	boards, err := c.service.Index(ctx)
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.JSON(200, model.ToJsonBoardSlice(boards))
+}
+
+func (c *boardController) Create(ctx *gin.Context) {
+	board, err := c.service.Create(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.JSON(200, board.ToJson())
+}
+
+func (c *boardController) Update(ctx *gin.Context) {
+	board, err := c.service.Update(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.JSON(200, board.ToJson())
+}
+
+func (c *boardController) Destroy(ctx *gin.Context) {
+	err := c.service.Destroy(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.Status(200)
+}
+
+func (c *boardController) Move(ctx *gin.Context) {
+	err := c.service.Move(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.Status(200)
+}
+
+// エラーがあればレスポンスを返してtrueを返す
+func (c *boardController) renderError(ctx *gin.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if _, ok := err.(validator.ValidationErrors); ok {
+		ctx.JSON(config.ValidationErrorResponse.Code, config.ValidationErrorResponse.Json)
+		return true
+	}
+
+	ctx.AbortWithStatus(500)
+	return true
+}
+
+// test用
+func TestNewBoardController(s service.BoardService) BoardController {
+	return &boardController{service: s}
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/gateway"
+	"github.com/kuritaeiji/todo-gin-back/service"
+)
+
+type BoardEventController interface {
+	Stream(*gin.Context) // GET /api/boards/:id/events
+}
+
+type boardEventController struct {
+	service           service.BoardEventService
+	heartbeatInterval time.Duration
+}
+
+func NewBoardEventController() BoardEventController {
+	return &boardEventController{service: service.NewBoardEventService(), heartbeatInterval: boardEventHeartbeatInterval}
+}
+
+// プロキシに接続を切られないように送るコメントの間隔 送る前にトークンとボードの権限を確認し直す
+const boardEventHeartbeatInterval = 15 * time.Second
+
+// Server-Sent Eventsで配信する EventSourceはヘッダーを付けられないのでfetchで受信する
+// 取りこぼしがある場合はresetイベントを送るので、クライアントはリストを取得し直す
+func (c *boardEventController) Stream(ctx *gin.Context) {
+	subscription, err := c.service.Subscribe(ctx)
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+	defer subscription.Close()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(200)
+	if subscription.Gap {
+		fmt.Fprint(ctx.Writer, "event: reset\ndata: {}\n\n")
+	}
+	for _, event := range subscription.Missed {
+		c.writeEvent(ctx.Writer, event)
+	}
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(c.heartbeatInterval)
+	defer heartbeat.Stop()
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-subscription.Events:
+			if !ok {
+				return false
+			}
+			c.writeEvent(w, event)
+			return true
+		case <-heartbeat.C:
+			// 無効になった場合は切断し、クライアントが再接続する際に認証し直させる
+			if err := c.service.Authorize(ctx); err != nil {
+				return false
+			}
+			fmt.Fprint(w, ": heartbeat\n\n")
+			return true
+		case <-ctx.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+func (c *boardEventController) writeEvent(w io.Writer, event gateway.BoardEvent) {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "id: %v\nevent: %v\ndata: %s\n\n", event.ID, event.Type, data)
+}
+
+// test用
+func TestNewBoardEventController(s service.BoardEventService, heartbeatInterval time.Duration) BoardEventController {
+	return &boardEventController{service: s, heartbeatInterval: heartbeatInterval}
+}
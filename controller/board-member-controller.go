@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"gorm.io/gorm"
+)
+
+type BoardMemberController interface {
+	Index(*gin.Context)       // GET /api/boards/:id/members
+	Invite(*gin.Context)      // POST /api/boards/:id/members
+	Update(*gin.Context)      // PUT /api/boards/:id/members/:memberID
+	Destroy(*gin.Context)     // DELETE /api/boards/:id/members/:memberID
+	Invitations(*gin.Context) // GET /api/invitations
+	Accept(*gin.Context)      // PUT /api/invitations/:id
+	Decline(*gin.Context)     // DELETE /api/invitations/:id
+}
+
+type boardMemberController struct {
+	service service.BoardMemberService
+}
+
+func NewBoardMemberController() BoardMemberController {
+	return &boardMemberController{service: service.NewBoardMemberService()}
+}
+
+func (c *boardMemberController) Index(ctx *gin.Context) {
+	members, err := c.service.Index(ctx)
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.JSON(200, model.ToJsonBoardMemberSlice(members))
+}
+
+func (c *boardMemberController) Invite(ctx *gin.Context) {
+	member, err := c.service.Invite(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.JSON(200, member.ToJson())
+}
+
+func (c *boardMemberController) Update(ctx *gin.Context) {
+	member, err := c.service.Update(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.JSON(200, member.ToJson())
+}
+
+func (c *boardMemberController) Destroy(ctx *gin.Context) {
+	err := c.service.Destroy(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.Status(200)
+}
+
+func (c *boardMemberController) Invitations(ctx *gin.Context) {
+	members, err := c.service.Invitations(ctx)
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.JSON(200, model.ToInvitationJsonSlice(members))
+}
+
+func (c *boardMemberController) Accept(ctx *gin.Context) {
+	member, err := c.service.Accept(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.JSON(200, member.ToInvitationJson())
+}
+
+func (c *boardMemberController) Decline(ctx *gin.Context) {
+	err := c.service.Decline(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.Status(200)
+}
+
+// エラーがあればレスポンスを返してtrueを返す
+func (c *boardMemberController) renderError(ctx *gin.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if _, ok := err.(validator.ValidationErrors); ok {
+		ctx.JSON(config.ValidationErrorResponse.Code, config.ValidationErrorResponse.Json)
+		return true
+	}
+
+	if err == gorm.ErrRecordNotFound {
+		ctx.JSON(config.RecordNotFoundErrorResponse.Code, config.RecordNotFoundErrorResponse.Json)
+		return true
+	}
+
+	if err == config.AlreadyBoardMemberError {
+		ctx.JSON(config.AlreadyBoardMemberErrorResponse.Code, config.AlreadyBoardMemberErrorResponse.Json)
+		return true
+	}
+
+	if err == config.LastBoardOwnerError {
+		ctx.JSON(config.LastBoardOwnerErrorResponse.Code, config.LastBoardOwnerErrorResponse.Json)
+		return true
+	}
+
+	if err == config.EmailClientError {
+		ctx.JSON(config.EmailClientErrorResponse.Code, config.EmailClientErrorResponse.Json)
+		return true
+	}
+
+	ctx.AbortWithStatus(500)
+	return true
+}
+
+// test用
+func TestNewBoardMemberController(s service.BoardMemberService) BoardMemberController {
+	return &boardMemberController{service: s}
+}
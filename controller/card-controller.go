@@ -16,6 +16,7 @@ type CardController interface {
 	Update(*gin.Context)  // PUT /api/cards/:id
 	Destroy(*gin.Context) // DELETE /api/cards/:id
 	Move(*gin.Context)    // PUT /api/cards/:id/move
+	Archive(*gin.Context) // PUT /api/cards/:id/archive
 }
 
 func NewCardController() CardController {
@@ -76,6 +77,17 @@ func (c *cardController) Move(ctx *gin.Context) {
 	ctx.Status(200)
 }
 
+func (c *cardController) Archive(ctx *gin.Context) {
+	err := c.service.Archive(ctx)
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.Status(200)
+}
+
 // test
 func TestNewCardController(cardService service.CardService) CardController {
 	return &cardController{service: cardService}
@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"gorm.io/gorm"
+)
+
+type ChecklistController interface {
+	Index(*gin.Context)       // GET /api/cards/:id/checklists
+	Create(*gin.Context)      // POST /api/cards/:id/checklists
+	Update(*gin.Context)      // PUT /api/cards/:id/checklists/:checklistID
+	Destroy(*gin.Context)     // DELETE /api/cards/:id/checklists/:checklistID
+	Move(*gin.Context)        // PUT /api/cards/:id/checklists/:checklistID/move
+	CreateItem(*gin.Context)  // POST /api/cards/:id/checklists/:checklistID/items
+	UpdateItem(*gin.Context)  // PUT /api/cards/:id/checklists/:checklistID/items/:itemID
+	DestroyItem(*gin.Context) // DELETE /api/cards/:id/checklists/:checklistID/items/:itemID
+	MoveItem(*gin.Context)    // PUT /api/cards/:id/checklists/:checklistID/items/:itemID/move
+}
+
+type checklistController struct {
+	service service.ChecklistService
+}
+
+func NewChecklistController() ChecklistController {
+	return &checklistController{service: service.NewChecklistService()}
+}
+
+func (c *checklistController) Index(ctx *gin.Context) {
+	checklists, err := c.service.Index(ctx)
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.JSON(200, model.ToJsonChecklistSlice(checklists))
+}
+
+func (c *checklistController) Create(ctx *gin.Context) {
+	checklist, err := c.service.Create(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.JSON(200, checklist.ToJson())
+}
+
+func (c *checklistController) Update(ctx *gin.Context) {
+	checklist, err := c.service.Update(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.JSON(200, checklist.ToJson())
+}
+
+func (c *checklistController) Destroy(ctx *gin.Context) {
+	err := c.service.Destroy(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.Status(200)
+}
+
+func (c *checklistController) Move(ctx *gin.Context) {
+	err := c.service.Move(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.Status(200)
+}
+
+func (c *checklistController) CreateItem(ctx *gin.Context) {
+	item, err := c.service.CreateItem(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.JSON(200, item.ToJson())
+}
+
+func (c *checklistController) UpdateItem(ctx *gin.Context) {
+	item, err := c.service.UpdateItem(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.JSON(200, item.ToJson())
+}
+
+func (c *checklistController) DestroyItem(ctx *gin.Context) {
+	err := c.service.DestroyItem(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.Status(200)
+}
+
+func (c *checklistController) MoveItem(ctx *gin.Context) {
+	err := c.service.MoveItem(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.Status(200)
+}
+
+// エラーがあればレスポンスを返してtrueを返す
+func (c *checklistController) renderError(ctx *gin.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if _, ok := err.(validator.ValidationErrors); ok {
+		ctx.JSON(config.ValidationErrorResponse.Code, config.ValidationErrorResponse.Json)
+		return true
+	}
+
+	if err == gorm.ErrRecordNotFound {
+		ctx.JSON(config.RecordNotFoundErrorResponse.Code, config.RecordNotFoundErrorResponse.Json)
+		return true
+	}
+
+	ctx.AbortWithStatus(500)
+	return true
+}
+
+// test用
+func TestNewChecklistController(s service.ChecklistService) ChecklistController {
+	return &checklistController{service: s}
+}
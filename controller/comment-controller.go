@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"gorm.io/gorm"
+)
+
+type CommentController interface {
+	Index(*gin.Context)   // GET /api/cards/:id/comments
+	Create(*gin.Context)  // POST /api/cards/:id/comments
+	Update(*gin.Context)  // PUT /api/comments/:id
+	Destroy(*gin.Context) // DELETE /api/comments/:id
+}
+
+type commentController struct {
+	service service.CommentService
+}
+
+func NewCommentController() CommentController {
+	return &commentController{service: service.NewCommentService()}
+}
+
+func (c *commentController) Index(ctx *gin.Context) {
+	comments, err := c.service.Index(ctx)
+	// beforeが数値でない場合もバリデーションエラーにする
+	_, isNumError := err.(*strconv.NumError)
+	if _, ok := err.(validator.ValidationErrors); ok || isNumError {
+		ctx.JSON(config.ValidationErrorResponse.Code, config.ValidationErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.JSON(200, model.ToJsonCommentSlice(comments))
+}
+
+func (c *commentController) Create(ctx *gin.Context) {
+	comment, err := c.service.Create(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.JSON(200, comment.ToJson())
+}
+
+func (c *commentController) Update(ctx *gin.Context) {
+	comment, err := c.service.Update(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.JSON(200, comment.ToJson())
+}
+
+func (c *commentController) Destroy(ctx *gin.Context) {
+	err := c.service.Destroy(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.Status(200)
+}
+
+// エラーがあればレスポンスを返してtrueを返す
+func (c *commentController) renderError(ctx *gin.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if _, ok := err.(validator.ValidationErrors); ok {
+		ctx.JSON(config.ValidationErrorResponse.Code, config.ValidationErrorResponse.Json)
+		return true
+	}
+
+	if err == gorm.ErrRecordNotFound {
+		ctx.JSON(config.RecordNotFoundErrorResponse.Code, config.RecordNotFoundErrorResponse.Json)
+		return true
+	}
+
+	if err == config.ForbiddenError {
+		ctx.JSON(config.ForbiddenErrorResponse.Code, config.ForbiddenErrorResponse.Json)
+		return true
+	}
+
+	ctx.AbortWithStatus(500)
+	return true
+}
+
+// test用
+func TestNewCommentController(s service.CommentService) CommentController {
+	return &commentController{service: s}
+}
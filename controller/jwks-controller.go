@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/service"
+)
+
+type JWKSController interface {
+	Index(*gin.Context) // GET /.well-known/jwks.json
+}
+
+type jwksController struct {
+	service service.JWTService
+}
+
+func NewJWKSController() JWKSController {
+	return &jwksController{service: service.NewJWTService()}
+}
+
+// 鍵のローテーション後も検証側が新しい鍵を取得できるようにキャッシュは短めにする
+func (c *jwksController) Index(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=3600")
+	ctx.JSON(200, c.service.JWKS())
+}
+
+// test用
+func TestNewJWKSController(s service.JWTService) JWKSController {
+	return &jwksController{service: s}
+}
@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"gorm.io/gorm"
+)
+
+type LabelController interface {
+	Index(*gin.Context)   // GET /api/labels
+	Create(*gin.Context)  // POST /api/labels
+	Update(*gin.Context)  // PUT /api/labels/:id
+	Destroy(*gin.Context) // DELETE /api/labels/:id
+	Attach(*gin.Context)  // PUT /api/cards/:id/labels/:labelID
+	Detach(*gin.Context)  // DELETE /api/cards/:id/labels/:labelID
+}
+
+type labelController struct {
+	service service.LabelService
+}
+
+func NewLabelController() LabelController {
+	return &labelController{service: service.NewLabelService()}
+}
+
+func (c *labelController) Index(ctx *gin.Context) {
+	labels, err := c.service.Index(ctx)
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.JSON(200, model.ToJsonLabelSlice(labels))
+}
+
+func (c *labelController) Create(ctx *gin.Context) {
+	label, err := c.service.Create(ctx)
+	if _, ok := err.(validator.ValidationErrors); ok {
+		ctx.JSON(config.ValidationErrorResponse.Code, config.ValidationErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.JSON(200, label.ToJson())
+}
+
+func (c *labelController) Update(ctx *gin.Context) {
+	label, err := c.service.Update(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.JSON(200, label.ToJson())
+}
+
+func (c *labelController) Destroy(ctx *gin.Context) {
+	err := c.service.Destroy(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.Status(200)
+}
+
+func (c *labelController) Attach(ctx *gin.Context) {
+	card, err := c.service.Attach(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.JSON(200, card.ToJson())
+}
+
+func (c *labelController) Detach(ctx *gin.Context) {
+	card, err := c.service.Detach(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.JSON(200, card.ToJson())
+}
+
+// エラーがあればレスポンスを返してtrueを返す
+func (c *labelController) renderError(ctx *gin.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if _, ok := err.(validator.ValidationErrors); ok {
+		ctx.JSON(config.ValidationErrorResponse.Code, config.ValidationErrorResponse.Json)
+		return true
+	}
+
+	if err == gorm.ErrRecordNotFound {
+		ctx.JSON(config.RecordNotFoundErrorResponse.Code, config.RecordNotFoundErrorResponse.Json)
+		return true
+	}
+
+	if err == config.ForbiddenError {
+		ctx.JSON(config.ForbiddenErrorResponse.Code, config.ForbiddenErrorResponse.Json)
+		return true
+	}
+
+	ctx.AbortWithStatus(500)
+	return true
+}
+
+// test用
+func TestNewLabelController(s service.LabelService) LabelController {
+	return &labelController{service: s}
+}
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"strconv"
+
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"github.com/kuritaeiji/todo-gin-back/config"
@@ -13,11 +15,12 @@ type listController struct {
 }
 
 type ListController interface {
-	Index(*gin.Context)   // GET /api/lists
-	Create(*gin.Context)  // POST /api/lists
+	Index(*gin.Context)   // GET /api/boards/:id/lists
+	Create(*gin.Context)  // POST /api/boards/:id/lists
 	Update(*gin.Context)  // PUT /api/lists/:id
 	Destroy(*gin.Context) // DELETE /api/lists/:id
 	Move(*gin.Context)    // PUT /api/lists/:id/move
+	Archive(*gin.Context) // PUT /api/lists/:id/archive
 }
 
 func NewListController() ListController {
@@ -26,6 +29,13 @@ func NewListController() ListController {
 
 func (c *listController) Index(ctx *gin.Context) {
 	lists, err := c.service.Index(ctx)
+	// labelが数値でない場合もバリデーションエラーにする
+	_, isNumError := err.(*strconv.NumError)
+	if _, ok := err.(validator.ValidationErrors); ok || isNumError {
+		ctx.AbortWithStatusJSON(config.ValidationErrorResponse.Code, config.ValidationErrorResponse.Json)
+		return
+	}
+
 	if err != nil {
 		ctx.AbortWithStatus(500)
 		return
@@ -88,6 +98,17 @@ func (c *listController) Move(ctx *gin.Context) {
 	ctx.Status(200)
 }
 
+func (c *listController) Archive(ctx *gin.Context) {
+	err := c.service.Archive(ctx)
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.Status(200)
+}
+
 // test用
 func TestNewListController(listService service.ListService) ListController {
 	return &listController{service: listService}
@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+)
+
+type LoginAttemptController interface {
+	Index(*gin.Context) // GET /api/admin/login-attempts
+}
+
+type loginAttemptController struct {
+	service service.LoginAttemptService
+}
+
+func NewLoginAttemptController() LoginAttemptController {
+	return &loginAttemptController{service: service.NewLoginAttemptService()}
+}
+
+func (c *loginAttemptController) Index(ctx *gin.Context) {
+	attempts, err := c.service.Index()
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.JSON(200, model.ToJsonLoginAttemptSlice(attempts))
+}
+
+// test用
+func TestNewLoginAttemptController(s service.LoginAttemptService) LoginAttemptController {
+	return &loginAttemptController{service: s}
+}
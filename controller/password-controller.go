@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/service"
+)
+
+type PasswordController interface {
+	Forgot(*gin.Context) // POST /api/password/forgot
+	Reset(*gin.Context)  // PUT /api/password/reset
+}
+
+type passwordController struct {
+	service service.PasswordService
+}
+
+func NewPasswordController() PasswordController {
+	return &passwordController{service: service.NewPasswordService()}
+}
+
+func (c *passwordController) Forgot(ctx *gin.Context) {
+	err := c.service.Forgot(ctx)
+	if _, ok := err.(validator.ValidationErrors); ok {
+		ctx.JSON(config.ValidationErrorResponse.Code, config.ValidationErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.Status(200)
+}
+
+func (c *passwordController) Reset(ctx *gin.Context) {
+	err := c.service.Reset(ctx)
+	if _, ok := err.(validator.ValidationErrors); ok {
+		ctx.JSON(config.ValidationErrorResponse.Code, config.ValidationErrorResponse.Json)
+		return
+	}
+
+	if err == config.InvalidPasswordResetTokenError {
+		ctx.JSON(config.InvalidPasswordResetTokenErrorResponse.Code, config.InvalidPasswordResetTokenErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.Status(200)
+}
+
+// test
+func TestNewPasswordController(passwordService service.PasswordService) PasswordController {
+	return &passwordController{service: passwordService}
+}
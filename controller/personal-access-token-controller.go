@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"gorm.io/gorm"
+)
+
+type PersonalAccessTokenController interface {
+	Index(*gin.Context)   // GET /api/users/tokens
+	Create(*gin.Context)  // POST /api/users/tokens
+	Destroy(*gin.Context) // DELETE /api/users/tokens/:id
+}
+
+type personalAccessTokenController struct {
+	service service.PersonalAccessTokenService
+}
+
+func NewPersonalAccessTokenController() PersonalAccessTokenController {
+	return &personalAccessTokenController{service: service.NewPersonalAccessTokenService()}
+}
+
+func (c *personalAccessTokenController) Index(ctx *gin.Context) {
+	tokens, err := c.service.Index(ctx)
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.JSON(200, model.ToJsonPersonalAccessTokenSlice(tokens))
+}
+
+// トークンは作成時のみ返すのでクライアントで保存してもらう
+func (c *personalAccessTokenController) Create(ctx *gin.Context) {
+	token, tokenString, err := c.service.Create(ctx)
+	if _, ok := err.(validator.ValidationErrors); ok {
+		ctx.JSON(config.ValidationErrorResponse.Code, config.ValidationErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	json := token.ToJson()
+	json["token"] = tokenString
+	ctx.JSON(200, json)
+}
+
+func (c *personalAccessTokenController) Destroy(ctx *gin.Context) {
+	err := c.service.Destroy(ctx)
+	if err == gorm.ErrRecordNotFound {
+		ctx.JSON(config.RecordNotFoundErrorResponse.Code, config.RecordNotFoundErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.Status(200)
+}
+
+// test用
+func TestNewPersonalAccessTokenController(s service.PersonalAccessTokenService) PersonalAccessTokenController {
+	return &personalAccessTokenController{service: s}
+}
@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"gorm.io/gorm"
+)
+
+type SessionController interface {
+	Index(*gin.Context)   // GET /api/sessions
+	Destroy(*gin.Context) // DELETE /api/sessions/:id
+}
+
+type sessionController struct {
+	service service.SessionService
+}
+
+func NewSessionController() SessionController {
+	return &sessionController{service: service.NewSessionService()}
+}
+
+// リクエストした端末のセッションにはcurrentをtrueにして返す
+func (c *sessionController) Index(ctx *gin.Context) {
+	sessions, err := c.service.Index(ctx)
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	claim := ctx.MustGet(config.ClaimKey).(*service.UserClaim)
+	ctx.JSON(200, model.ToJsonSessionSlice(sessions, claim.SessionID))
+}
+
+func (c *sessionController) Destroy(ctx *gin.Context) {
+	err := c.service.Destroy(ctx)
+	if err == gorm.ErrRecordNotFound {
+		ctx.JSON(config.RecordNotFoundErrorResponse.Code, config.RecordNotFoundErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.Status(200)
+}
+
+// test用
+func TestNewSessionController(s service.SessionService) SessionController {
+	return &sessionController{service: s}
+}
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/service"
+)
+
+type TotpController interface {
+	Enroll(*gin.Context)  // POST /api/users/totp
+	Enable(*gin.Context)  // PUT /api/users/totp
+	Disable(*gin.Context) // DELETE /api/users/totp
+}
+
+type totpController struct {
+	service service.TotpService
+}
+
+func NewTotpController() TotpController {
+	return &totpController{service: service.NewTotpService()}
+}
+
+func (c *totpController) Enroll(ctx *gin.Context) {
+	secret, uri, err := c.service.Enroll(ctx)
+	if err == config.TotpAlreadyEnabledError {
+		ctx.JSON(config.TotpAlreadyEnabledErrorResponse.Code, config.TotpAlreadyEnabledErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"secret": secret,
+		"uri":    uri,
+	})
+}
+
+func (c *totpController) Enable(ctx *gin.Context) {
+	recoveryCodes, err := c.service.Enable(ctx)
+	if _, ok := err.(validator.ValidationErrors); ok {
+		ctx.JSON(config.ValidationErrorResponse.Code, config.ValidationErrorResponse.Json)
+		return
+	}
+
+	if err == config.TotpAlreadyEnabledError {
+		ctx.JSON(config.TotpAlreadyEnabledErrorResponse.Code, config.TotpAlreadyEnabledErrorResponse.Json)
+		return
+	}
+
+	if err == config.TotpNotEnrolledError {
+		ctx.JSON(config.TotpNotEnrolledErrorResponse.Code, config.TotpNotEnrolledErrorResponse.Json)
+		return
+	}
+
+	if err == config.InvalidTotpCodeError {
+		ctx.JSON(config.InvalidTotpCodeErrorResponse.Code, config.InvalidTotpCodeErrorResponse.Json)
+		return
+	}
+
+	if err == config.AccountLockedError {
+		ctx.JSON(config.AccountLockedErrorResponse.Code, config.AccountLockedErrorResponse.Json)
+		return
+	}
+
+	if err == config.TooManyLoginAttemptsError {
+		ctx.JSON(config.TooManyLoginAttemptsErrorResponse.Code, config.TooManyLoginAttemptsErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"recoveryCodes": recoveryCodes})
+}
+
+func (c *totpController) Disable(ctx *gin.Context) {
+	err := c.service.Disable(ctx)
+	if _, ok := err.(validator.ValidationErrors); ok {
+		ctx.JSON(config.ValidationErrorResponse.Code, config.ValidationErrorResponse.Json)
+		return
+	}
+
+	if err == config.TotpNotEnrolledError {
+		ctx.JSON(config.TotpNotEnrolledErrorResponse.Code, config.TotpNotEnrolledErrorResponse.Json)
+		return
+	}
+
+	if err == config.InvalidTotpCodeError {
+		ctx.JSON(config.InvalidTotpCodeErrorResponse.Code, config.InvalidTotpCodeErrorResponse.Json)
+		return
+	}
+
+	if err == config.PasswordAuthenticationError {
+		ctx.JSON(config.PasswordAuthenticationErrorResponse.Code, config.PasswordAuthenticationErrorResponse.Json)
+		return
+	}
+
+	if err == config.AccountLockedError {
+		ctx.JSON(config.AccountLockedErrorResponse.Code, config.AccountLockedErrorResponse.Json)
+		return
+	}
+
+	if err == config.TooManyLoginAttemptsError {
+		ctx.JSON(config.TooManyLoginAttemptsErrorResponse.Code, config.TooManyLoginAttemptsErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.Status(200)
+}
+
+// test用
+func TestNewTotpController(s service.TotpService) TotpController {
+	return &totpController{service: s}
+}
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"gorm.io/gorm"
+)
+
+type TrashController interface {
+	Index(*gin.Context)       // GET /api/trash
+	RestoreList(*gin.Context) // PUT /api/trash/lists/:id/restore
+	RestoreCard(*gin.Context) // PUT /api/trash/cards/:id/restore
+	DestroyList(*gin.Context) // DELETE /api/trash/lists/:id
+	DestroyCard(*gin.Context) // DELETE /api/trash/cards/:id
+}
+
+type trashController struct {
+	service service.TrashService
+}
+
+func NewTrashController() TrashController {
+	return &trashController{service: service.NewTrashService()}
+}
+
+func (c *trashController) Index(ctx *gin.Context) {
+	lists, cards, err := c.service.Index(ctx)
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"lists": model.ToTrashJsonListSlice(lists),
+		"cards": model.ToTrashJsonCardSlice(cards),
+	})
+}
+
+func (c *trashController) RestoreList(ctx *gin.Context) {
+	list, err := c.service.RestoreList(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.JSON(200, list.ToJson())
+}
+
+func (c *trashController) RestoreCard(ctx *gin.Context) {
+	card, err := c.service.RestoreCard(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.JSON(200, card.ToJson())
+}
+
+func (c *trashController) DestroyList(ctx *gin.Context) {
+	err := c.service.DestroyList(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.Status(200)
+}
+
+func (c *trashController) DestroyCard(ctx *gin.Context) {
+	err := c.service.DestroyCard(ctx)
+	if c.renderError(ctx, err) {
+		return
+	}
+
+	ctx.Status(200)
+}
+
+// エラーがあればレスポンスを返してtrueを返す
+func (c *trashController) renderError(ctx *gin.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if err == gorm.ErrRecordNotFound {
+		ctx.JSON(config.RecordNotFoundErrorResponse.Code, config.RecordNotFoundErrorResponse.Json)
+		return true
+	}
+
+	if err == config.ForbiddenError {
+		ctx.JSON(config.ForbiddenErrorResponse.Code, config.ForbiddenErrorResponse.Json)
+		return true
+	}
+
+	if err == config.ListInTrashError {
+		ctx.JSON(config.ListInTrashErrorResponse.Code, config.ListInTrashErrorResponse.Json)
+		return true
+	}
+
+	ctx.AbortWithStatus(500)
+	return true
+}
+
+// test用
+func TestNewTrashController(s service.TrashService) TrashController {
+	return &trashController{service: s}
+}
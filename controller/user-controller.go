@@ -10,10 +10,14 @@ import (
 )
 
 type UserController interface {
-	Create(ctx *gin.Context)   // POST /users
-	IsUnique(ctx *gin.Context) // GET /users/unique?email="email"
-	Activate(ctx *gin.Context) // PUT /users/activate?token="token"
-	Destroy(ctx *gin.Context)  // DELETE /users/:id
+	Create(ctx *gin.Context)           // POST /users
+	IsUnique(ctx *gin.Context)         // GET /users/unique?email="email"
+	Activate(ctx *gin.Context)         // PUT /users/activate?token="token"
+	ResendActivation(ctx *gin.Context) // POST /users/activation/resend
+	Destroy(ctx *gin.Context)          // DELETE /users/:id
+	ChangePassword(ctx *gin.Context)   // PUT /users/password
+	ChangeEmail(ctx *gin.Context)      // PUT /users/email
+	ConfirmEmail(ctx *gin.Context)     // PUT /users/email/confirm?token="token"
 }
 
 type userController struct {
@@ -98,6 +102,22 @@ func (c *userController) Activate(ctx *gin.Context) {
 	ctx.Status(200)
 }
 
+func (c *userController) ResendActivation(ctx *gin.Context) {
+	err := c.service.ResendActivation(ctx)
+	if _, ok := err.(validator.ValidationErrors); ok {
+		ctx.JSON(config.ValidationErrorResponse.Code, config.ValidationErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		gin.DefaultWriter.Write([]byte(err.Error()))
+		return
+	}
+
+	ctx.Status(200)
+}
+
 func (c *userController) Destroy(ctx *gin.Context) {
 	err := c.service.Destroy(ctx)
 
@@ -110,6 +130,79 @@ func (c *userController) Destroy(ctx *gin.Context) {
 	ctx.Status(200)
 }
 
+func (c *userController) ChangePassword(ctx *gin.Context) {
+	tokenPair, err := c.service.ChangePassword(ctx)
+	if _, ok := err.(validator.ValidationErrors); ok {
+		ctx.JSON(config.ValidationErrorResponse.Code, config.ValidationErrorResponse.Json)
+		return
+	}
+
+	if err == config.PasswordAuthenticationError {
+		ctx.JSON(config.PasswordAuthenticationErrorResponse.Code, config.PasswordAuthenticationErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		gin.DefaultWriter.Write([]byte(err.Error()))
+		return
+	}
+
+	ctx.JSON(200, tokenPair.ToJson())
+}
+
+func (c *userController) ChangeEmail(ctx *gin.Context) {
+	err := c.service.ChangeEmail(ctx)
+	if _, ok := err.(validator.ValidationErrors); ok {
+		ctx.JSON(config.ValidationErrorResponse.Code, config.ValidationErrorResponse.Json)
+		return
+	}
+
+	if err == config.PasswordAuthenticationError {
+		ctx.JSON(config.PasswordAuthenticationErrorResponse.Code, config.PasswordAuthenticationErrorResponse.Json)
+		return
+	}
+
+	if err == config.UniqueUserError {
+		ctx.JSON(config.UniqueUserErrorResponse.Code, config.UniqueUserErrorResponse.Json)
+		return
+	}
+
+	if err == config.EmailClientError {
+		ctx.JSON(config.EmailClientErrorResponse.Code, config.EmailClientErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		gin.DefaultWriter.Write([]byte(err.Error()))
+		return
+	}
+
+	ctx.Status(200)
+}
+
+func (c *userController) ConfirmEmail(ctx *gin.Context) {
+	err := c.service.ConfirmEmail(ctx)
+	if err == config.InvalidEmailChangeTokenError {
+		ctx.JSON(config.InvalidEmailChangeTokenErrorResponse.Code, config.InvalidEmailChangeTokenErrorResponse.Json)
+		return
+	}
+
+	if err == config.UniqueUserError {
+		ctx.JSON(config.UniqueUserErrorResponse.Code, config.UniqueUserErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		gin.DefaultWriter.Write([]byte(err.Error()))
+		return
+	}
+
+	ctx.Status(200)
+}
+
 // test用
 func TestNewUserController(us service.UserService, es service.EmailService) UserController {
 	return &userController{us, es}
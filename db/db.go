@@ -59,13 +59,127 @@ func CloseDB() {
 
 func migrate() {
 	db.AutoMigrate(model.User{})
+	db.AutoMigrate(model.Board{})
+	db.AutoMigrate(model.BoardMember{})
 	db.AutoMigrate(model.List{})
+	db.AutoMigrate(model.Label{})
 	db.AutoMigrate(model.Card{})
+	db.AutoMigrate(model.RefreshToken{})
+	db.AutoMigrate(model.RevokedToken{})
+	db.AutoMigrate(model.PasswordReset{})
+	db.AutoMigrate(model.EmailChange{})
+	db.AutoMigrate(model.LoginAttempt{})
+	db.AutoMigrate(model.RecoveryCode{})
+	db.AutoMigrate(model.Identity{})
+	db.AutoMigrate(model.PersonalAccessToken{})
+	db.AutoMigrate(model.Session{})
+	db.AutoMigrate(model.AuditLog{})
+	db.AutoMigrate(model.CardReminder{})
+	db.AutoMigrate(model.Checklist{})
+	db.AutoMigrate(model.ChecklistItem{})
+	db.AutoMigrate(model.Comment{})
+	db.AutoMigrate(model.Attachment{})
+	migrateOpenID()
+	migrateBoards()
+	migrateBoardOwners()
+}
+
+// 以前users.open_idに保存していたgoogleのアカウントをidentitiesに移す
+// MySQLではDDLの実行時に暗黙的にコミットされるのでトランザクションは使わず、途中で失敗しても再実行できるようにする
+func migrateOpenID() {
+	if !db.Migrator().HasColumn(&model.User{}, "open_id") {
+		return
+	}
+
+	err := db.Exec(
+		"INSERT INTO identities (created_at, updated_at, provider, subject, user_id) SELECT NOW(), NOW(), 'google', open_id, id FROM users WHERE open_id <> '' AND deleted_at IS NULL AND NOT EXISTS (SELECT 1 FROM identities WHERE identities.provider = 'google' AND identities.subject = users.open_id)",
+	).Error
+	if err == nil {
+		err = db.Migrator().DropColumn(&model.User{}, "open_id")
+	}
+	if err != nil {
+		panic(fmt.Sprintf("Failed to migrate open_id\n%v", err.Error()))
+	}
+}
+
+// 以前はリストをユーザーに直接紐付けていたので、リストを持つユーザーごとにボードを作成して移す
+// migrateOpenIDと同じくトランザクションは使わず、途中で失敗して再実行してもボードが重複しないようにする
+func migrateBoards() {
+	if !db.Migrator().HasColumn(&model.List{}, "user_id") {
+		return
+	}
+
+	if err := migrateListsToBoards(); err != nil {
+		panic(fmt.Sprintf("Failed to migrate boards\n%v", err.Error()))
+	}
+}
+
+func migrateListsToBoards() error {
+	err := db.Exec(
+		"INSERT INTO boards (created_at, updated_at, title, `index`, user_id) SELECT NOW(), NOW(), 'My board', 0, id FROM users WHERE id IN (SELECT DISTINCT user_id FROM lists) AND NOT EXISTS (SELECT 1 FROM boards WHERE boards.user_id = users.id)",
+	).Error
+	if err != nil {
+		return err
+	}
+
+	// 以前の実行でボードが重複している場合も最初に作成したボードに移す
+	err = db.Exec("UPDATE lists SET board_id = (SELECT MIN(boards.id) FROM boards WHERE boards.user_id = lists.user_id) WHERE EXISTS (SELECT 1 FROM boards WHERE boards.user_id = lists.user_id)").Error
+	if err != nil {
+		return err
+	}
+
+	// user_idの外部キー制約を先に削除しないとカラムを削除できない
+	var constraints []string
+	err = db.Raw(
+		"SELECT CONSTRAINT_NAME FROM information_schema.KEY_COLUMN_USAGE WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'lists' AND COLUMN_NAME = 'user_id' AND REFERENCED_TABLE_NAME IS NOT NULL",
+	).Scan(&constraints).Error
+	if err != nil {
+		return err
+	}
+
+	for _, constraint := range constraints {
+		err = db.Exec("ALTER TABLE lists DROP FOREIGN KEY " + constraint).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return db.Migrator().DropColumn(&model.List{}, "user_id")
+}
+
+// メンバーがいないボードは作成したユーザーをオーナーにする
+func migrateBoardOwners() {
+	err := db.Exec(
+		"INSERT INTO board_members (created_at, updated_at, board_id, user_id, role, accepted_at) SELECT NOW(), NOW(), id, user_id, ?, NOW() FROM boards WHERE deleted_at IS NULL AND NOT EXISTS (SELECT 1 FROM board_members WHERE board_members.board_id = boards.id)",
+		model.BoardRoleOwner,
+	).Error
+	if err != nil {
+		panic(fmt.Sprintf("Failed to migrate board owners\n%v", err.Error()))
+	}
 }
 
 // test
 func DeleteAll() {
+	db.Exec("DELETE FROM audit_logs")
+	db.Exec("DELETE FROM sessions")
+	db.Exec("DELETE FROM personal_access_tokens")
+	db.Exec("DELETE FROM identities")
+	db.Exec("DELETE FROM recovery_codes")
+	db.Exec("DELETE FROM login_attempts")
+	db.Exec("DELETE FROM email_changes")
+	db.Exec("DELETE FROM password_resets")
+	db.Exec("DELETE FROM revoked_tokens")
+	db.Exec("DELETE FROM refresh_tokens")
+	db.Exec("DELETE FROM attachments")
+	db.Exec("DELETE FROM comments")
+	db.Exec("DELETE FROM checklist_items")
+	db.Exec("DELETE FROM checklists")
+	db.Exec("DELETE FROM card_reminders")
+	db.Exec("DELETE FROM card_labels")
+	db.Exec("DELETE FROM labels")
 	db.Exec("DELETE FROM cards")
 	db.Exec("DELETE FROM lists")
+	db.Exec("DELETE FROM board_members")
+	db.Exec("DELETE FROM boards")
 	db.Exec("DELETE FROM users")
 }
@@ -0,0 +1,8 @@
+package dto
+
+// 監査ログの検索条件 beforeには前のページの最後のログのidを指定する
+type AuditLogQuery struct {
+	UserID int    `form:"userId" binding:"gte=0"`
+	Event  string `form:"event" binding:"max=64"`
+	Before int    `form:"before" binding:"gte=0"`
+}
@@ -9,3 +9,23 @@ type Oauth struct {
 	Code  string `json:"code"`
 	State string `json:"state"`
 }
+
+type RefreshToken struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+type TotpLogin struct {
+	ChallengeToken string `json:"challengeToken" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+// 認証アプリのコードもしくはリカバリーコード
+type TotpCode struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// 2段階認証を無効にする場合は現在のパスワードも要求する
+type TotpDisable struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "github.com/kuritaeiji/todo-gin-back/model"
+
+// POST /api/boards/:id/members 登録済みのユーザーをメールアドレスで招待する
+type BoardMember struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required,oneof=owner editor viewer"`
+}
+
+func (dtoMember BoardMember) Transfer(member *model.BoardMember) {
+	member.Role = dtoMember.Role
+}
+
+type BoardMemberRole struct {
+	Role string `json:"role" binding:"required,oneof=owner editor viewer"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "github.com/kuritaeiji/todo-gin-back/model"
+
+type Board struct {
+	Title string `json:"title" binding:"required,max=50"`
+	Index int    `json:"index" binding:"gte=0"`
+}
+
+func (dtoBoard Board) Transfer(board *model.Board) {
+	board.Title = dtoBoard.Title
+	board.Index = dtoBoard.Index
+}
+
+type MoveBoard struct {
+	Index int `json:"index" binding:"gte=0"`
+}
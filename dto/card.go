@@ -1,14 +1,28 @@
 package dto
 
-import "github.com/kuritaeiji/todo-gin-back/model"
+import (
+	"time"
+
+	"github.com/kuritaeiji/todo-gin-back/model"
+)
 
 type Card struct {
 	Title string `json:"title" binding:"required,max=100"`
-	Index int    `json:"index" binding:"gte=0"`
+	// Markdown 文字数で制限する
+	Description string `json:"description" binding:"max=10000"`
+	// RFC3339のオフセット付きの日時 省略した場合は未設定にする
+	StartAt  *time.Time `json:"startAt"`
+	DueAt    *time.Time `json:"dueAt" binding:"omitempty,afterfield=StartAt"`
+	Timezone string     `json:"timezone" binding:"omitempty,max=64,timezone"`
+	Index    int        `json:"index" binding:"gte=0"`
 }
 
 func (dtoCard Card) Transfer(card *model.Card) {
 	card.Title = dtoCard.Title
+	card.Description = dtoCard.Description
+	card.StartAt = utc(dtoCard.StartAt)
+	card.DueAt = utc(dtoCard.DueAt)
+	card.Timezone = dtoCard.Timezone
 	card.Index = dtoCard.Index
 }
 
@@ -16,3 +30,12 @@ type MoveCard struct {
 	ToIndex  int `json:"toIndex" binding:"gte=0"`
 	ToListID int `json:"toListID" binding:"gte=0"`
 }
+
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	u := t.UTC()
+	return &u
+}
@@ -0,0 +1,31 @@
+package dto
+
+import "github.com/kuritaeiji/todo-gin-back/model"
+
+type Checklist struct {
+	Title string `json:"title" binding:"required,max=100"`
+	Index int    `json:"index" binding:"gte=0"`
+}
+
+// 更新時はIndexを使わない 並び替えはMoveChecklistで行う
+func (dtoChecklist Checklist) Transfer(checklist *model.Checklist) {
+	checklist.Title = dtoChecklist.Title
+	checklist.Index = dtoChecklist.Index
+}
+
+type ChecklistItem struct {
+	Title string `json:"title" binding:"required,max=255"`
+	Done  bool   `json:"done"`
+	Index int    `json:"index" binding:"gte=0"`
+}
+
+func (dtoItem ChecklistItem) Transfer(item *model.ChecklistItem) {
+	item.Title = dtoItem.Title
+	item.Done = dtoItem.Done
+	item.Index = dtoItem.Index
+}
+
+// チェックリストと項目の並び替えに使う
+type MoveChecklist struct {
+	ToIndex int `json:"toIndex" binding:"gte=0"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+// Markdown 文字数で制限する
+type Comment struct {
+	Body string `json:"body" binding:"required,max=10000"`
+}
+
+// beforeには前のページの最後のコメントのidを指定する
+type CommentQuery struct {
+	Before int `form:"before" binding:"gte=0"`
+}
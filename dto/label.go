@@ -0,0 +1,14 @@
+package dto
+
+import "github.com/kuritaeiji/todo-gin-back/model"
+
+// Colorは#rrggbbもしくは#rgb
+type Label struct {
+	Name  string `json:"name" binding:"required,max=50"`
+	Color string `json:"color" binding:"required,hexcolor"`
+}
+
+func (dtoLabel Label) Transfer(label *model.Label) {
+	label.Name = dtoLabel.Name
+	label.Color = dtoLabel.Color
+}
@@ -14,6 +14,11 @@ func (dtoList List) Transfer(list *model.List) {
 	list.Index = dtoList.Index
 }
 
+// GET /api/boards/:id/lists?label=1&label=2 いずれかのラベルが付いたカードのみを返す
+type ListQuery struct {
+	LabelIDs []int `form:"label" binding:"max=20,dive,gt=0"`
+}
+
 type MoveList struct {
 	Index int `json:"index" binding:"gte=0"`
 }
@@ -0,0 +1,8 @@
+package dto
+
+type PersonalAccessToken struct {
+	Name   string   `json:"name" binding:"required,max=100"`
+	Scopes []string `json:"scopes" binding:"required,min=1,dive,oneof=lists:read lists:write cards:read cards:write"`
+	// 省略した場合は無期限
+	ExpiresInDays int `json:"expiresInDays" binding:"gte=0,lte=365"`
+}
@@ -15,3 +15,36 @@ func (dtoUser User) Transfer(user *model.User) {
 	digestByte, _ := bcrypt.GenerateFromPassword([]byte(dtoUser.Password), bcrypt.DefaultCost)
 	user.PasswordDigest = string(digestByte)
 }
+
+type ForgotPassword struct {
+	Email string `json:"email" binding:"required,max=100,email"`
+}
+
+type ResetPassword struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8,max=50,password"`
+}
+
+func (dtoResetPassword ResetPassword) Transfer(user *model.User) {
+	digestByte, _ := bcrypt.GenerateFromPassword([]byte(dtoResetPassword.Password), bcrypt.DefaultCost)
+	user.PasswordDigest = string(digestByte)
+}
+
+type ChangePassword struct {
+	CurrentPassword string `json:"currentPassword" binding:"required"`
+	Password        string `json:"password" binding:"required,min=8,max=50,password"`
+}
+
+func (dtoChangePassword ChangePassword) Transfer(user *model.User) {
+	digestByte, _ := bcrypt.GenerateFromPassword([]byte(dtoChangePassword.Password), bcrypt.DefaultCost)
+	user.PasswordDigest = string(digestByte)
+}
+
+type ChangeEmail struct {
+	Email    string `json:"email" binding:"required,max=100,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+type ResendActivation struct {
+	Email string `json:"email" binding:"required,max=100,email"`
+}
@@ -0,0 +1,77 @@
+package factory
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/dto"
+	"github.com/kuritaeiji/todo-gin-back/model"
+)
+
+type BoardConfig struct {
+	Title              string
+	Index              int
+	NotUseDefaultValue bool
+}
+
+func (config *BoardConfig) setDefaultValue() {
+	if config.NotUseDefaultValue {
+		return
+	}
+
+	if config.Title == "" {
+		config.Title = "board title"
+	}
+}
+
+func NewDtoBoard(config *BoardConfig) dto.Board {
+	config.setDefaultValue()
+	return dto.Board{Title: config.Title, Index: config.Index}
+}
+
+func NewBoard(config *BoardConfig) model.Board {
+	dtoBoard := NewDtoBoard(config)
+	var board model.Board
+	dtoBoard.Transfer(&board)
+	return board
+}
+
+// userをオーナーとしてメンバーに追加する
+func CreateBoard(config *BoardConfig, user model.User) model.Board {
+	board := NewBoard(config)
+	board.UserID = user.ID
+	db.GetDB().Create(&board)
+	CreateBoardMember(board, user, model.BoardRoleOwner)
+	return board
+}
+
+// 招待を承諾済みのメンバーを作成する
+func CreateBoardMember(board model.Board, user model.User, role string) model.BoardMember {
+	now := time.Now()
+	member := model.BoardMember{BoardID: board.ID, UserID: user.ID, Role: role, AcceptedAt: &now}
+	db.GetDB().Create(&member)
+	return member
+}
+
+// userの最初のボードを返す ボードが無い場合は作成する
+func UserBoard(user model.User) model.Board {
+	var board model.Board
+	err := db.GetDB().Where("user_id = ?", user.ID).Order("id").First(&board).Error
+	if err != nil {
+		return CreateBoard(&BoardConfig{}, user)
+	}
+	return board
+}
+
+func CreateBoardRequestBody(config *BoardConfig) io.Reader {
+	config.setDefaultValue()
+	body := map[string]interface{}{
+		"title": config.Title,
+		"index": config.Index,
+	}
+	bodyBytes, _ := json.Marshal(body)
+	return strings.NewReader(string(bodyBytes))
+}
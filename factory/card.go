@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/kuritaeiji/todo-gin-back/dto"
@@ -13,6 +14,10 @@ import (
 
 type CardConfig struct {
 	Title              string
+	Description        string
+	StartAt            *time.Time
+	DueAt              *time.Time
+	Timezone           string
 	Index              int
 	NotUseDefaultValue bool
 }
@@ -32,8 +37,12 @@ func (cardConfig *CardConfig) setDefaultValue() {
 func NewDtoCard(cardConfig *CardConfig) dto.Card {
 	cardConfig.setDefaultValue()
 	return dto.Card{
-		Title: cardConfig.Title,
-		Index: cardConfig.Index,
+		Title:       cardConfig.Title,
+		Description: cardConfig.Description,
+		StartAt:     cardConfig.StartAt,
+		DueAt:       cardConfig.DueAt,
+		Timezone:    cardConfig.Timezone,
+		Index:       cardConfig.Index,
 	}
 }
 
@@ -53,8 +62,12 @@ func CreateCard(cardConfig *CardConfig, list model.List) model.Card {
 func CreateCardRequestBody(cardConfig *CardConfig) io.Reader {
 	cardConfig.setDefaultValue()
 	body := gin.H{
-		"title": cardConfig.Title,
-		"index": cardConfig.Index,
+		"title":       cardConfig.Title,
+		"description": cardConfig.Description,
+		"startAt":     cardConfig.StartAt,
+		"dueAt":       cardConfig.DueAt,
+		"timezone":    cardConfig.Timezone,
+		"index":       cardConfig.Index,
 	}
 	json, _ := json.Marshal(body)
 	return strings.NewReader(string(json))
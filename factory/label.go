@@ -0,0 +1,59 @@
+package factory
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/dto"
+	"github.com/kuritaeiji/todo-gin-back/model"
+)
+
+type LabelConfig struct {
+	Name               string
+	Color              string
+	NotUseDefaultValue bool
+}
+
+func (config *LabelConfig) setDefaultValue() {
+	if config.NotUseDefaultValue {
+		return
+	}
+
+	if config.Name == "" {
+		config.Name = "label name"
+	}
+	if config.Color == "" {
+		config.Color = "#ff0000"
+	}
+}
+
+func NewDtoLabel(config *LabelConfig) dto.Label {
+	config.setDefaultValue()
+	return dto.Label{Name: config.Name, Color: config.Color}
+}
+
+func NewLabel(config *LabelConfig) model.Label {
+	dtoLabel := NewDtoLabel(config)
+	var label model.Label
+	dtoLabel.Transfer(&label)
+	return label
+}
+
+func CreateLabel(config *LabelConfig, user model.User) model.Label {
+	label := NewLabel(config)
+	label.UserID = user.ID
+	db.GetDB().Create(&label)
+	return label
+}
+
+func CreateLabelRequestBody(config *LabelConfig) io.Reader {
+	config.setDefaultValue()
+	body := map[string]interface{}{
+		"name":  config.Name,
+		"color": config.Color,
+	}
+	bodyBytes, _ := json.Marshal(body)
+	return strings.NewReader(string(bodyBytes))
+}
@@ -40,10 +40,16 @@ func NewList(config *ListConfig) model.List {
 	return list
 }
 
+// userの最初のボードに作成する
 func CreateList(config *ListConfig, user model.User) model.List {
+	return CreateBoardList(config, UserBoard(user))
+}
+
+func CreateBoardList(config *ListConfig, board model.Board) model.List {
 	list := NewList(config)
-	list.UserID = user.ID
+	list.BoardID = board.ID
 	db.GetDB().Create(&list)
+	list.Board = board
 	return list
 }
 
@@ -1,10 +1,13 @@
 package factory
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/kuritaeiji/todo-gin-back/db"
@@ -18,7 +21,6 @@ type UserConfig struct {
 	Email              string
 	Password           string
 	Activated          bool
-	OpenID             string
 	NotUseDefaultValue bool
 }
 
@@ -54,7 +56,6 @@ func NewUser(config *UserConfig) model.User {
 	dtoUser.Transfer(&user)
 	user.ID = config.ID
 	user.Activated = config.Activated
-	user.OpenID = config.OpenID
 
 	return user
 }
@@ -65,8 +66,22 @@ func CreateUser(config *UserConfig) model.User {
 	return user
 }
 
+func CreateIdentity(user model.User, provider, subject string) model.Identity {
+	identity := model.Identity{Provider: provider, Subject: subject, UserID: user.ID}
+	db.GetDB().Create(&identity)
+	return identity
+}
+
+// 認証ミドルウェアがセッションを確認するのでセッションも作成する
 func CreateAccessToken(user model.User) string {
-	return service.NewJWTService().CreateJWT(user, service.DayFromNowAccessToken)
+	session := CreateSession(user, fmt.Sprintf("session-%v-%v", user.ID, time.Now().UnixNano()))
+	return service.NewJWTService().CreateAccessJWT(user, session.SessionID)
+}
+
+func CreateSession(user model.User, sessionID string) model.Session {
+	session := model.Session{SessionID: sessionID, UserAgent: "test", IP: "127.0.0.1", LastSeenAt: time.Now(), UserID: user.ID}
+	db.GetDB().Create(&session)
+	return session
 }
 
 func CreateUserClaim(user model.User) service.UserClaim {
@@ -85,3 +100,34 @@ func CreateUserRequestBody(config *UserConfig) io.Reader {
 	bodyBytes, _ := json.Marshal(body)
 	return strings.NewReader(string(bodyBytes))
 }
+
+func CreateRefreshTokenRequestBody(refreshToken string) io.Reader {
+	body := map[string]string{
+		"refreshToken": refreshToken,
+	}
+	bodyBytes, _ := json.Marshal(body)
+	return strings.NewReader(string(bodyBytes))
+}
+
+func CreateForgotPasswordRequestBody(email string) io.Reader {
+	body := map[string]string{
+		"email": email,
+	}
+	bodyBytes, _ := json.Marshal(body)
+	return strings.NewReader(string(bodyBytes))
+}
+
+func CreateResetPasswordRequestBody(token, password string) io.Reader {
+	body := map[string]string{
+		"token":    token,
+		"password": password,
+	}
+	bodyBytes, _ := json.Marshal(body)
+	return strings.NewReader(string(bodyBytes))
+}
+
+// serviceでDBに保存するトークンのハッシュ値と同じ
+func Digest(tokenString string) string {
+	sum := sha256.Sum256([]byte(tokenString))
+	return hex.EncodeToString(sum[:])
+}
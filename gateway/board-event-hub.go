@@ -0,0 +1,222 @@
+package gateway
+
+// mockgen -source=gateway/board-event-hub.go -destination=mock_gateway/board-event-hub.go
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// ボードのリストとカードの変更を通知するイベント idは再接続時の再開位置に使う
+type BoardEvent struct {
+	ID      int64
+	BoardID int
+	Type    string
+	Data    interface{}
+}
+
+type BoardEventSubscription struct {
+	// 再接続した場合にlastEventIDより後に発行されたイベント
+	Missed []BoardEvent
+	// 保持しているイベントでは再開できず取りこぼしがある場合はtrue
+	Gap bool
+	// 受信が遅れて溜まりすぎた場合は閉じるので、クライアントは再接続する
+	Events <-chan BoardEvent
+	Close  func()
+}
+
+// 複数インスタンスで配信する場合はpub/subのバックエンドを使う実装に差し替える
+// 実装はボードごとにidが増加するようにイベントを発行する必要がある
+type BoardEventHub interface {
+	Publish(boardID int, eventType string, data interface{}) error
+	Subscribe(boardID int, userID int, lastEventID int64) (BoardEventSubscription, error)
+	// ボードから外されたユーザーやロールが変わったユーザーの購読を閉じる
+	CloseUser(boardID int, userID int)
+}
+
+const (
+	// 再接続で再開できるようにボードごとに保持するイベントの数
+	boardEventBufferSize = 100
+	// 購読者ごとに送信待ちにできるイベントの数
+	boardEventSubscriberBufferSize = 16
+	// 購読者がいないボードは最後のイベントからこの時間が経てば保持しているイベントを捨てる
+	boardEventLogTTL = 10 * time.Minute
+	// 全てのボードで保持するイベントのデータの合計 超えた場合は古いイベントから捨てる
+	boardEventMaxRetainedBytes = 32 << 20
+)
+
+type boardEventLog struct {
+	events []BoardEvent
+	sizes  []int
+	// 保持しきれずに捨てたイベントの中で最も新しいもののid
+	evictedID int64
+	// 購読者とそのユーザーのid
+	subscribers map[chan BoardEvent]int
+	updatedAt   time.Time
+}
+
+type memoryBoardEventHub struct {
+	mu            sync.Mutex
+	lastID        int64
+	boards        map[int]*boardEventLog
+	retainedBytes int
+	prunedAt      time.Time
+	ttl           time.Duration
+	maxBytes      int
+}
+
+var boardEventHub = NewMemoryBoardEventHub()
+
+// 1プロセス内でのみ配信する
+func NewMemoryBoardEventHub() BoardEventHub {
+	return TestNewMemoryBoardEventHub(boardEventLogTTL, boardEventMaxRetainedBytes)
+}
+
+// サービス間で同じハブを使う
+func GetBoardEventHub() BoardEventHub {
+	return boardEventHub
+}
+
+// 送信待ちが溢れた購読者は待たずに切断する
+func (h *memoryBoardEventHub) Publish(boardID int, eventType string, data interface{}) error {
+	size := 0
+	if encoded, err := json.Marshal(data); err == nil {
+		size = len(encoded)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	h.prune(now)
+	log := h.boardLog(boardID, now)
+	h.lastID++
+	event := BoardEvent{ID: h.lastID, BoardID: boardID, Type: eventType, Data: data}
+	log.events = append(log.events, event)
+	log.sizes = append(log.sizes, size)
+	log.updatedAt = now
+	h.retainedBytes += size
+	if len(log.events) > boardEventBufferSize {
+		h.evictOldest(log)
+	}
+	for h.retainedBytes > h.maxBytes {
+		oldest := h.oldestLog()
+		if oldest == nil {
+			break
+		}
+		h.evictOldest(oldest)
+	}
+
+	for ch := range log.subscribers {
+		select {
+		case ch <- event:
+		default:
+			delete(log.subscribers, ch)
+			close(ch)
+		}
+	}
+	return nil
+}
+
+// lastEventIDが0の場合は新しいイベントのみ受け取る
+func (h *memoryBoardEventHub) Subscribe(boardID int, userID int, lastEventID int64) (BoardEventSubscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	h.prune(now)
+	log := h.boardLog(boardID, now)
+	var subscription BoardEventSubscription
+	if lastEventID > 0 {
+		for _, event := range log.events {
+			if event.ID > lastEventID {
+				subscription.Missed = append(subscription.Missed, event)
+			}
+		}
+		// プロセスが再起動した場合はidが巻き戻る
+		subscription.Gap = lastEventID < log.evictedID || lastEventID > h.lastID
+	}
+
+	ch := make(chan BoardEvent, boardEventSubscriberBufferSize)
+	log.subscribers[ch] = userID
+	subscription.Events = ch
+	subscription.Close = func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		if _, ok := log.subscribers[ch]; ok {
+			delete(log.subscribers, ch)
+			close(ch)
+			// 最後の購読者が切断した時点からTTLを数える
+			log.updatedAt = time.Now()
+		}
+	}
+	return subscription, nil
+}
+
+func (h *memoryBoardEventHub) CloseUser(boardID int, userID int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	log, ok := h.boards[boardID]
+	if !ok {
+		return
+	}
+	for ch, subscriberID := range log.subscribers {
+		if subscriberID == userID {
+			delete(log.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// 新しく作るボードのログはそれまでのイベントを持たないので、再接続した場合は取りこぼしとして扱う
+func (h *memoryBoardEventHub) boardLog(boardID int, now time.Time) *boardEventLog {
+	log, ok := h.boards[boardID]
+	if !ok {
+		log = &boardEventLog{evictedID: h.lastID, subscribers: map[chan BoardEvent]int{}, updatedAt: now}
+		h.boards[boardID] = log
+	}
+	return log
+}
+
+// 購読者がいないボードのログをTTLごとにまとめて捨てる
+func (h *memoryBoardEventHub) prune(now time.Time) {
+	if now.Sub(h.prunedAt) < h.ttl {
+		return
+	}
+	h.prunedAt = now
+
+	for boardID, log := range h.boards {
+		if len(log.subscribers) == 0 && now.Sub(log.updatedAt) >= h.ttl {
+			for _, size := range log.sizes {
+				h.retainedBytes -= size
+			}
+			delete(h.boards, boardID)
+		}
+	}
+}
+
+// idは全てのボードで増加するので先頭のイベントのidが最も小さいボードが最も古いイベントを持つ
+func (h *memoryBoardEventHub) oldestLog() *boardEventLog {
+	var oldest *boardEventLog
+	for _, log := range h.boards {
+		if len(log.events) > 0 && (oldest == nil || log.events[0].ID < oldest.events[0].ID) {
+			oldest = log
+		}
+	}
+	return oldest
+}
+
+func (h *memoryBoardEventHub) evictOldest(log *boardEventLog) {
+	log.evictedID = log.events[0].ID
+	h.retainedBytes -= log.sizes[0]
+	log.events = append([]BoardEvent(nil), log.events[1:]...)
+	log.sizes = append([]int(nil), log.sizes[1:]...)
+}
+
+// test
+func TestNewMemoryBoardEventHub(ttl time.Duration, maxBytes int) BoardEventHub {
+	return &memoryBoardEventHub{boards: map[int]*boardEventLog{}, ttl: ttl, maxBytes: maxBytes}
+}
@@ -3,17 +3,25 @@ package gateway
 // mockgen -source=gateway/oauth-gateway.go -destination=mock_gateway/oauth-gateway.go
 
 import (
-	"os"
+	"crypto/subtle"
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
 	"golang.org/x/oauth2"
 )
 
 type OauthGateway interface {
-	SearchProvider(*gin.Context) (*oidc.Provider, error)
-	RequestTokenEndpoint(oauth2Config oauth2.Config, ctx *gin.Context, code string) (*oauth2.Token, error)
-	VerifyIDToken(ctx *gin.Context, provider *oidc.Provider, rawIDToken string) (*oidc.IDToken, error)
+	SearchProvider(ctx *gin.Context, issuer string) (*oidc.Provider, error)
+	RequestTokenEndpoint(oauth2Config oauth2.Config, ctx *gin.Context, code, codeVerifier string) (*oauth2.Token, error)
+	VerifyIDToken(ctx *gin.Context, provider *oidc.Provider, clientID, rawIDToken, nonce string) (IDTokenClaims, error)
+}
+
+// id_tokenから取り出すクレーム
+type IDTokenClaims struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
 }
 
 type oauthGateway struct{}
@@ -22,17 +30,32 @@ func NewOauthGateway() OauthGateway {
 	return &oauthGateway{}
 }
 
-// googleの認可エンドポイントのurlやtokenエンドポイントのurlやid_tokenの署名の公開鍵のurl等を取りに行ってくれる
-func (g *oauthGateway) SearchProvider(ctx *gin.Context) (*oidc.Provider, error) {
-	return oidc.NewProvider(ctx.Request.Context(), os.Getenv("GOOGLE_OAUTH_URL"))
+// issuerの認可エンドポイントのurlやtokenエンドポイントのurlやid_tokenの署名の公開鍵のurl等を取りに行ってくれる
+func (g *oauthGateway) SearchProvider(ctx *gin.Context, issuer string) (*oidc.Provider, error) {
+	return oidc.NewProvider(ctx.Request.Context(), issuer)
 }
 
-func (g *oauthGateway) RequestTokenEndpoint(oauth2Config oauth2.Config, ctx *gin.Context, code string) (*oauth2.Token, error) {
-	return oauth2Config.Exchange(ctx.Request.Context(), code)
+// PKCEのcode_verifierを送信して認可リクエストを行ったクライアントであることを証明する
+func (g *oauthGateway) RequestTokenEndpoint(oauth2Config oauth2.Config, ctx *gin.Context, code, codeVerifier string) (*oauth2.Token, error) {
+	return oauth2Config.Exchange(ctx.Request.Context(), code, oauth2.SetAuthURLParam("code_verifier", codeVerifier))
 }
 
 // id_tokenの検証 jwtの署名の公開鍵を取りに行く為gatewayに置く
-func (g *oauthGateway) VerifyIDToken(ctx *gin.Context, provider *oidc.Provider, rawIDToken string) (*oidc.IDToken, error) {
-	verifier := provider.Verifier(&oidc.Config{ClientID: os.Getenv("CLIENT_ID")})
-	return verifier.Verify(ctx, rawIDToken)
+// nonceは認可リクエスト時にcookieに保存した値と一致しなければならない
+func (g *oauthGateway) VerifyIDToken(ctx *gin.Context, provider *oidc.Provider, clientID, rawIDToken, nonce string) (IDTokenClaims, error) {
+	verifier := provider.Verifier(&oidc.Config{ClientID: clientID})
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return IDTokenClaims{}, err
+	}
+
+	if nonce == "" || subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(nonce)) != 1 {
+		return IDTokenClaims{}, config.InvalidNonceError
+	}
+
+	var claims IDTokenClaims
+	if err := idToken.Claims(&claims); err != nil {
+		return IDTokenClaims{}, err
+	}
+	return claims, nil
 }
@@ -0,0 +1,104 @@
+package gateway
+
+// mockgen -source=gateway/rate-limit-store.go -destination=mock_gateway/rate-limit-store.go
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/kuritaeiji/todo-gin-back/config"
+)
+
+// トークンバケットから1つ取り出した結果
+type RateLimitResult struct {
+	Allowed   bool
+	Remaining int
+	// バケットが満タンに戻るまでの時間
+	ResetAfter time.Duration
+	// 拒否された場合に次のトークンが補充されるまでの時間
+	RetryAfter time.Duration
+}
+
+// 複数インスタンスで制限を共有する場合は共有のバックエンドを使う実装に差し替える
+// 実装は取り出しの判定と更新をアトミックに行う必要がある
+type RateLimitStore interface {
+	Take(key string, limit int, period time.Duration) (RateLimitResult, error)
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	fullAt    time.Time
+}
+
+type memoryRateLimitStore struct {
+	mu          sync.Mutex
+	buckets     map[string]*bucket
+	lastSweepAt time.Time
+	now         func() time.Time
+}
+
+// 満タンになったバケットを削除する間隔
+const sweepRateLimitBucketsInterval = time.Minute
+
+// 1プロセス内でのみ制限を共有する
+func NewMemoryRateLimitStore() RateLimitStore {
+	return &memoryRateLimitStore{buckets: map[string]*bucket{}, now: time.Now}
+}
+
+// limit個のトークンがperiodで満タンになるように一定間隔で補充する
+func (s *memoryRateLimitStore) Take(key string, limit int, period time.Duration) (RateLimitResult, error) {
+	// 0で割らないように不正な設定はエラーにする
+	if limit <= 0 || period <= 0 {
+		return RateLimitResult{}, config.InvalidRateLimitError
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	interval := period / time.Duration(limit)
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit), updatedAt: now}
+		s.buckets[key] = b
+	}
+
+	b.tokens = math.Min(float64(limit), b.tokens+float64(now.Sub(b.updatedAt))/float64(interval))
+	b.updatedAt = now
+
+	result := RateLimitResult{}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration((1 - b.tokens) * float64(interval))
+	}
+
+	result.Remaining = int(b.tokens)
+	result.ResetAfter = time.Duration((float64(limit) - b.tokens) * float64(interval))
+	b.fullAt = now.Add(result.ResetAfter)
+	return result, nil
+}
+
+// 満タンのバケットは新しく作ったものと同じなので削除してメモリを解放する
+func (s *memoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweepAt) < sweepRateLimitBucketsInterval {
+		return
+	}
+
+	for key, b := range s.buckets {
+		if !now.Before(b.fullAt) {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweepAt = now
+}
+
+// test
+func TestNewMemoryRateLimitStore(now func() time.Time) RateLimitStore {
+	return &memoryRateLimitStore{buckets: map[string]*bucket{}, now: now}
+}
@@ -0,0 +1,112 @@
+package gateway
+
+// mockgen -source=gateway/storage-gateway.go -destination=mock_gateway/storage-gateway.go
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kuritaeiji/todo-gin-back/config"
+)
+
+// 添付ファイルの保存先 keyは"attachments/1/xxxx"のような/区切りのパス
+// S3互換のストレージを使う場合は同じインターフェースの実装を追加してNewStorageGatewayで切り替える
+type StorageGateway interface {
+	Put(key string, body io.Reader) error
+	// 存在しない場合はconfig.StorageObjectNotFoundErrorを返す
+	Get(key string) (io.ReadCloser, error)
+	// 存在しない場合もエラーにしない
+	Delete(key string) error
+}
+
+// STORAGE_BACKENDで保存先を選ぶ 未設定の場合はローカルのファイルシステムに保存する
+func NewStorageGateway() StorageGateway {
+	switch os.Getenv("STORAGE_BACKEND") {
+	case "", "local":
+		return NewLocalStorageGateway(localStorageDir())
+	default:
+		panic(fmt.Sprintf("Unknown storage backend: %v", os.Getenv("STORAGE_BACKEND")))
+	}
+}
+
+type localStorageGateway struct {
+	dir string
+}
+
+func NewLocalStorageGateway(dir string) StorageGateway {
+	return &localStorageGateway{dir: dir}
+}
+
+// STORAGE_LOCAL_DIRが未設定の場合は作業ディレクトリのstorageに保存する
+func localStorageDir() string {
+	if dir := os.Getenv("STORAGE_LOCAL_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(config.WorkDir, "storage")
+}
+
+// 書き込み途中のファイルが読まれないように一時ファイルに書き込んでから移動する
+func (g *localStorageGateway) Put(key string, body io.Reader) error {
+	path, err := g.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := io.Copy(file, body); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+func (g *localStorageGateway) Get(key string) (io.ReadCloser, error) {
+	path, err := g.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, config.StorageObjectNotFoundError
+	}
+	return file, err
+}
+
+func (g *localStorageGateway) Delete(key string) error {
+	path, err := g.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// 保存先のディレクトリの外を指すkeyは使えない
+func (g *localStorageGateway) path(key string) (string, error) {
+	path := filepath.Join(g.dir, filepath.FromSlash(key))
+	if key == "" || !strings.HasPrefix(path, filepath.Clean(g.dir)+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid storage key: %v", key)
+	}
+	return path, nil
+}
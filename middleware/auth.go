@@ -6,6 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt"
 	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/model"
 	"github.com/kuritaeiji/todo-gin-back/repository"
 	"github.com/kuritaeiji/todo-gin-back/service"
 )
@@ -13,42 +14,115 @@ import (
 type AuthMiddleware interface {
 	Auth(*gin.Context)
 	Guest(*gin.Context)
+	Admin(*gin.Context)
+	Scope(readScope, writeScope string) gin.HandlerFunc
 }
 
 type authMiddleware struct {
-	jwtService     service.JWTService
-	userRepository repository.UserRepository
+	jwtService                 service.JWTService
+	personalAccessTokenService service.PersonalAccessTokenService
+	sessionService             service.SessionService
+	userRepository             repository.UserRepository
 }
 
 func NewAuthMiddleware() AuthMiddleware {
 	return &authMiddleware{
-		jwtService:     service.NewJWTService(),
-		userRepository: repository.NewUserRepository(),
+		jwtService:                 service.NewJWTService(),
+		personalAccessTokenService: service.NewPersonalAccessTokenService(),
+		sessionService:             service.NewSessionService(),
+		userRepository:             repository.NewUserRepository(),
 	}
 }
 
+// ログインで発行したアクセストークンのみで認証する
+// パーソナルアクセストークンはアカウントの操作には使えない
 func (m *authMiddleware) Auth(ctx *gin.Context) {
-	claim, err := m.jwtService.VerifyJWT(m.tokenString(ctx))
+	tokenString := m.tokenString(ctx)
+	if strings.HasPrefix(tokenString, model.PersonalAccessTokenPrefix) {
+		ctx.AbortWithStatusJSON(config.InsufficientScopeErrorResponse.Code, config.InsufficientScopeErrorResponse.Json)
+		return
+	}
+
+	if m.authenticateJWT(ctx, tokenString) {
+		ctx.Next()
+	}
+}
+
+// アクセストークンもしくはパーソナルアクセストークンで認証する
+// パーソナルアクセストークンはGETとHEADの場合はreadScope、それ以外はwriteScopeを持っていなければならない
+func (m *authMiddleware) Scope(readScope, writeScope string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tokenString := m.tokenString(ctx)
+		if !strings.HasPrefix(tokenString, model.PersonalAccessTokenPrefix) {
+			if m.authenticateJWT(ctx, tokenString) {
+				ctx.Next()
+			}
+			return
+		}
+
+		token, err := m.personalAccessTokenService.Authenticate(tokenString)
+		if err != nil {
+			ctx.AbortWithStatusJSON(config.NotLoggedInErrorResponse.Code, config.NotLoggedInErrorResponse.Json)
+			return
+		}
+
+		scope := writeScope
+		if ctx.Request.Method == "GET" || ctx.Request.Method == "HEAD" {
+			scope = readScope
+		}
+		if !token.HasScope(scope) {
+			ctx.AbortWithStatusJSON(config.InsufficientScopeErrorResponse.Code, config.InsufficientScopeErrorResponse.Json)
+			return
+		}
+
+		ctx.Set(config.CurrentUserKey, token.User)
+		ctx.Set(config.PersonalAccessTokenKey, token)
+		ctx.Next()
+	}
+}
+
+// 認証に失敗した場合はレスポンスを返してfalseを返す
+func (m *authMiddleware) authenticateJWT(ctx *gin.Context, tokenString string) bool {
+	claim, err := m.jwtService.VerifyJWT(tokenString)
 	verr, ok := err.(*jwt.ValidationError)
 	if ok && verr.Errors == jwt.ValidationErrorExpired {
 		ctx.AbortWithStatusJSON(config.NotLoggedInWithJwtIsExpiredErrorResponse.Code, config.NotLoggedInWithJwtIsExpiredErrorResponse.Json)
-		return
+		return false
 	}
 
 	if err != nil {
 		ctx.AbortWithStatusJSON(config.NotLoggedInErrorResponse.Code, config.NotLoggedInErrorResponse.Json)
-		return
+		return false
+	}
+
+	// 2段階認証のチャレンジトークンや有効化用のトークンでは認証しない
+	if claim.Type != service.AccessTokenType {
+		ctx.AbortWithStatusJSON(config.NotLoggedInErrorResponse.Code, config.NotLoggedInErrorResponse.Json)
+		return false
 	}
 
 	currentUser, err := m.userRepository.Find(claim.ID)
 
 	if err != nil {
 		ctx.AbortWithStatusJSON(config.NotLoggedInErrorResponse.Code, config.NotLoggedInErrorResponse.Json)
-		return
+		return false
+	}
+
+	// 全ての端末からログアウトした後のトークン
+	if claim.Version != currentUser.TokenVersion {
+		ctx.AbortWithStatusJSON(config.NotLoggedInErrorResponse.Code, config.NotLoggedInErrorResponse.Json)
+		return false
+	}
+
+	// ログアウト済みのトークンと端末ごとにログアウトさせたセッションのトークン
+	if err := m.sessionService.Touch(claim.SessionID, claim.Id, currentUser); err != nil {
+		ctx.AbortWithStatusJSON(config.NotLoggedInErrorResponse.Code, config.NotLoggedInErrorResponse.Json)
+		return false
 	}
 
 	ctx.Set(config.CurrentUserKey, currentUser)
-	ctx.Next()
+	ctx.Set(config.ClaimKey, claim)
+	return true
 }
 
 func (m *authMiddleware) Guest(ctx *gin.Context) {
@@ -60,14 +134,27 @@ func (m *authMiddleware) Guest(ctx *gin.Context) {
 	ctx.Next()
 }
 
+// Authの後に使用する
+func (m *authMiddleware) Admin(ctx *gin.Context) {
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	if !currentUser.Admin {
+		ctx.AbortWithStatusJSON(config.ForbiddenErrorResponse.Code, config.ForbiddenErrorResponse.Json)
+		return
+	}
+
+	ctx.Next()
+}
+
 func (m *authMiddleware) tokenString(ctx *gin.Context) string {
 	return strings.Replace(ctx.GetHeader(config.TokenHeader), config.Bearer, "", 1)
 }
 
 // test
-func TestNewAuthMiddleware(jwtService service.JWTService, userRepository repository.UserRepository) AuthMiddleware {
+func TestNewAuthMiddleware(jwtService service.JWTService, personalAccessTokenService service.PersonalAccessTokenService, sessionService service.SessionService, userRepository repository.UserRepository) AuthMiddleware {
 	return &authMiddleware{
-		jwtService:     jwtService,
-		userRepository: userRepository,
+		jwtService:                 jwtService,
+		personalAccessTokenService: personalAccessTokenService,
+		sessionService:             sessionService,
+		userRepository:             userRepository,
 	}
 }
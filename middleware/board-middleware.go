@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"gorm.io/gorm"
+)
+
+type boardMiddleware struct {
+	service service.BoardMiddlewareService
+}
+
+type BoardMiddleware interface {
+	Authorize(*gin.Context)
+	AuthorizeOwner(*gin.Context)
+}
+
+func NewBoardMiddleware() BoardMiddleware {
+	return &boardMiddleware{service: service.NewBoardMiddlewareService()}
+}
+
+func (m *boardMiddleware) Authorize(ctx *gin.Context) {
+	board, err := m.service.Authorize(ctx)
+	m.setBoard(ctx, board, err)
+}
+
+func (m *boardMiddleware) AuthorizeOwner(ctx *gin.Context) {
+	board, err := m.service.AuthorizeOwner(ctx)
+	m.setBoard(ctx, board, err)
+}
+
+func (m *boardMiddleware) setBoard(ctx *gin.Context, board model.Board, err error) {
+	if err == gorm.ErrRecordNotFound {
+		ctx.AbortWithStatusJSON(config.RecordNotFoundErrorResponse.Code, config.RecordNotFoundErrorResponse.Json)
+		return
+	}
+
+	if err == config.ForbiddenError {
+		ctx.AbortWithStatusJSON(config.ForbiddenErrorResponse.Code, config.ForbiddenErrorResponse.Json)
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatus(500)
+		return
+	}
+
+	ctx.Set(config.BoardKey, board)
+	ctx.Next()
+}
+
+// test
+func TestNewBoardMiddleware(boardMiddlewareService service.BoardMiddlewareService) BoardMiddleware {
+	return &boardMiddleware{service: boardMiddlewareService}
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/gateway"
+	"github.com/kuritaeiji/todo-gin-back/model"
+)
+
+// Period の間に Limit 回までリクエストを許可する
+// Name はストアのキーの接頭辞になるのでルートグループごとに別の名前にする
+type RateLimitPolicy struct {
+	Name   string
+	Limit  int
+	Period time.Duration
+	Key    func(*gin.Context) string
+}
+
+var (
+	// ユーザー登録やログインは総当たりされやすいので厳しくする
+	GuestRateLimitPolicy = RateLimitPolicy{
+		Name:   "guest",
+		Limit:  20,
+		Period: time.Minute,
+		Key:    IPRateLimitKey,
+	}
+
+	AuthRateLimitPolicy = RateLimitPolicy{
+		Name:   "auth",
+		Limit:  300,
+		Period: time.Minute,
+		Key:    UserRateLimitKey,
+	}
+
+	// リフレッシュトークンやメールアドレスの確認トークンを使うルートはログインしていなくても呼ばれるのでIPアドレスで制限する
+	TokenRateLimitPolicy = RateLimitPolicy{
+		Name:   "token",
+		Limit:  60,
+		Period: time.Minute,
+		Key:    IPRateLimitKey,
+	}
+)
+
+func IPRateLimitKey(ctx *gin.Context) string {
+	return fmt.Sprintf("ip:%v", ctx.ClientIP())
+}
+
+// Authの後に使用する ログインしていない場合はIPアドレスで制限する
+func UserRateLimitKey(ctx *gin.Context) string {
+	value, _ := ctx.Get(config.CurrentUserKey)
+	currentUser, ok := value.(model.User)
+	if !ok {
+		return IPRateLimitKey(ctx)
+	}
+	return fmt.Sprintf("user:%v", currentUser.ID)
+}
+
+type RateLimitMiddleware interface {
+	Limit(*gin.Context)
+}
+
+type rateLimitMiddleware struct {
+	policy RateLimitPolicy
+	store  gateway.RateLimitStore
+}
+
+// RATE_LIMIT_<NAME> で Period あたりの回数を変更できる
+func NewRateLimitMiddleware(policy RateLimitPolicy, store gateway.RateLimitStore) RateLimitMiddleware {
+	policy.Limit = rateLimitFromEnv(policy)
+	return &rateLimitMiddleware{policy: policy, store: store}
+}
+
+// RateLimit-* ヘッダーで残りの回数を返し、超えた場合はRetry-Afterヘッダーと共に429を返す
+func (m *rateLimitMiddleware) Limit(ctx *gin.Context) {
+	key := fmt.Sprintf("%v:%v", m.policy.Name, m.policy.Key(ctx))
+	result, err := m.store.Take(key, m.policy.Limit, m.policy.Period)
+	// ストアに障害が発生してもリクエストは通す
+	if err != nil {
+		gin.DefaultWriter.Write([]byte(fmt.Sprintf("Failed to take rate limit token\n%v", err.Error())))
+		ctx.Next()
+		return
+	}
+
+	ctx.Header("RateLimit-Limit", strconv.Itoa(m.policy.Limit))
+	ctx.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	ctx.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+	if !result.Allowed {
+		ctx.Header("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+		ctx.AbortWithStatusJSON(config.RateLimitErrorResponse.Code, config.RateLimitErrorResponse.Json)
+		return
+	}
+
+	ctx.Next()
+}
+
+func ceilSeconds(duration time.Duration) int {
+	return int(math.Ceil(duration.Seconds()))
+}
+
+// 設定されていない場合や0以下の場合は既定の回数を使う
+func rateLimitFromEnv(policy RateLimitPolicy) int {
+	limit, err := strconv.Atoi(os.Getenv(fmt.Sprintf("RATE_LIMIT_%v", strings.ToUpper(policy.Name))))
+	if err != nil || limit <= 0 {
+		return policy.Limit
+	}
+	return limit
+}
@@ -0,0 +1,76 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: gateway/board-event-hub.go
+
+// Package mock_gateway is a generated GoMock package.
+package mock_gateway
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	gateway "github.com/kuritaeiji/todo-gin-back/gateway"
+)
+
+// MockBoardEventHub is a mock of BoardEventHub interface.
+type MockBoardEventHub struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoardEventHubMockRecorder
+}
+
+// MockBoardEventHubMockRecorder is the mock recorder for MockBoardEventHub.
+type MockBoardEventHubMockRecorder struct {
+	mock *MockBoardEventHub
+}
+
+// NewMockBoardEventHub creates a new mock instance.
+func NewMockBoardEventHub(ctrl *gomock.Controller) *MockBoardEventHub {
+	mock := &MockBoardEventHub{ctrl: ctrl}
+	mock.recorder = &MockBoardEventHubMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoardEventHub) EXPECT() *MockBoardEventHubMockRecorder {
+	return m.recorder
+}
+
+// CloseUser mocks base method.
+func (m *MockBoardEventHub) CloseUser(boardID, userID int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CloseUser", boardID, userID)
+}
+
+// CloseUser indicates an expected call of CloseUser.
+func (mr *MockBoardEventHubMockRecorder) CloseUser(boardID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseUser", reflect.TypeOf((*MockBoardEventHub)(nil).CloseUser), boardID, userID)
+}
+
+// Publish mocks base method.
+func (m *MockBoardEventHub) Publish(boardID int, eventType string, data interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", boardID, eventType, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockBoardEventHubMockRecorder) Publish(boardID, eventType, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockBoardEventHub)(nil).Publish), boardID, eventType, data)
+}
+
+// Subscribe mocks base method.
+func (m *MockBoardEventHub) Subscribe(boardID, userID int, lastEventID int64) (gateway.BoardEventSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", boardID, userID, lastEventID)
+	ret0, _ := ret[0].(gateway.BoardEventSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockBoardEventHubMockRecorder) Subscribe(boardID, userID, lastEventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockBoardEventHub)(nil).Subscribe), boardID, userID, lastEventID)
+}
@@ -10,6 +10,7 @@ import (
 	oidc "github.com/coreos/go-oidc/v3/oidc"
 	gin "github.com/gin-gonic/gin"
 	gomock "github.com/golang/mock/gomock"
+	gateway "github.com/kuritaeiji/todo-gin-back/gateway"
 	oauth2 "golang.org/x/oauth2"
 )
 
@@ -37,46 +38,46 @@ func (m *MockOauthGateway) EXPECT() *MockOauthGatewayMockRecorder {
 }
 
 // RequestTokenEndpoint mocks base method.
-func (m *MockOauthGateway) RequestTokenEndpoint(oauth2Config oauth2.Config, ctx *gin.Context, code string) (*oauth2.Token, error) {
+func (m *MockOauthGateway) RequestTokenEndpoint(oauth2Config oauth2.Config, ctx *gin.Context, code, codeVerifier string) (*oauth2.Token, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RequestTokenEndpoint", oauth2Config, ctx, code)
+	ret := m.ctrl.Call(m, "RequestTokenEndpoint", oauth2Config, ctx, code, codeVerifier)
 	ret0, _ := ret[0].(*oauth2.Token)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RequestTokenEndpoint indicates an expected call of RequestTokenEndpoint.
-func (mr *MockOauthGatewayMockRecorder) RequestTokenEndpoint(oauth2Config, ctx, code interface{}) *gomock.Call {
+func (mr *MockOauthGatewayMockRecorder) RequestTokenEndpoint(oauth2Config, ctx, code, codeVerifier interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestTokenEndpoint", reflect.TypeOf((*MockOauthGateway)(nil).RequestTokenEndpoint), oauth2Config, ctx, code)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestTokenEndpoint", reflect.TypeOf((*MockOauthGateway)(nil).RequestTokenEndpoint), oauth2Config, ctx, code, codeVerifier)
 }
 
 // SearchProvider mocks base method.
-func (m *MockOauthGateway) SearchProvider(arg0 *gin.Context) (*oidc.Provider, error) {
+func (m *MockOauthGateway) SearchProvider(ctx *gin.Context, issuer string) (*oidc.Provider, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchProvider", arg0)
+	ret := m.ctrl.Call(m, "SearchProvider", ctx, issuer)
 	ret0, _ := ret[0].(*oidc.Provider)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SearchProvider indicates an expected call of SearchProvider.
-func (mr *MockOauthGatewayMockRecorder) SearchProvider(arg0 interface{}) *gomock.Call {
+func (mr *MockOauthGatewayMockRecorder) SearchProvider(ctx, issuer interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchProvider", reflect.TypeOf((*MockOauthGateway)(nil).SearchProvider), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchProvider", reflect.TypeOf((*MockOauthGateway)(nil).SearchProvider), ctx, issuer)
 }
 
 // VerifyIDToken mocks base method.
-func (m *MockOauthGateway) VerifyIDToken(ctx *gin.Context, provider *oidc.Provider, rawIDToken string) (*oidc.IDToken, error) {
+func (m *MockOauthGateway) VerifyIDToken(ctx *gin.Context, provider *oidc.Provider, clientID, rawIDToken, nonce string) (gateway.IDTokenClaims, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "VerifyIDToken", ctx, provider, rawIDToken)
-	ret0, _ := ret[0].(*oidc.IDToken)
+	ret := m.ctrl.Call(m, "VerifyIDToken", ctx, provider, clientID, rawIDToken, nonce)
+	ret0, _ := ret[0].(gateway.IDTokenClaims)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // VerifyIDToken indicates an expected call of VerifyIDToken.
-func (mr *MockOauthGatewayMockRecorder) VerifyIDToken(ctx, provider, rawIDToken interface{}) *gomock.Call {
+func (mr *MockOauthGatewayMockRecorder) VerifyIDToken(ctx, provider, clientID, rawIDToken, nonce interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyIDToken", reflect.TypeOf((*MockOauthGateway)(nil).VerifyIDToken), ctx, provider, rawIDToken)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyIDToken", reflect.TypeOf((*MockOauthGateway)(nil).VerifyIDToken), ctx, provider, clientID, rawIDToken, nonce)
 }
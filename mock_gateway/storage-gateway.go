@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: gateway/storage-gateway.go
+
+// Package mock_gateway is a generated GoMock package.
+package mock_gateway
+
+import (
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStorageGateway is a mock of StorageGateway interface.
+type MockStorageGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageGatewayMockRecorder
+}
+
+// MockStorageGatewayMockRecorder is the mock recorder for MockStorageGateway.
+type MockStorageGatewayMockRecorder struct {
+	mock *MockStorageGateway
+}
+
+// NewMockStorageGateway creates a new mock instance.
+func NewMockStorageGateway(ctrl *gomock.Controller) *MockStorageGateway {
+	mock := &MockStorageGateway{ctrl: ctrl}
+	mock.recorder = &MockStorageGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorageGateway) EXPECT() *MockStorageGatewayMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockStorageGateway) Delete(key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockStorageGatewayMockRecorder) Delete(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStorageGateway)(nil).Delete), key)
+}
+
+// Get mocks base method.
+func (m *MockStorageGateway) Get(key string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockStorageGatewayMockRecorder) Get(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStorageGateway)(nil).Get), key)
+}
+
+// Put mocks base method.
+func (m *MockStorageGateway) Put(key string, body io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", key, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockStorageGatewayMockRecorder) Put(key, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockStorageGateway)(nil).Put), key, body)
+}
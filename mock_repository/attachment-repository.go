@@ -0,0 +1,168 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/attachment-repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/kuritaeiji/todo-gin-back/model"
+)
+
+// MockAttachmentRepository is a mock of AttachmentRepository interface.
+type MockAttachmentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentRepositoryMockRecorder
+}
+
+// MockAttachmentRepositoryMockRecorder is the mock recorder for MockAttachmentRepository.
+type MockAttachmentRepositoryMockRecorder struct {
+	mock *MockAttachmentRepository
+}
+
+// NewMockAttachmentRepository creates a new mock instance.
+func NewMockAttachmentRepository(ctrl *gomock.Controller) *MockAttachmentRepository {
+	mock := &MockAttachmentRepository{ctrl: ctrl}
+	mock.recorder = &MockAttachmentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentRepository) EXPECT() *MockAttachmentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAttachmentRepository) Create(attachment *model.Attachment, quota int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", attachment, quota)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAttachmentRepositoryMockRecorder) Create(attachment, quota interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAttachmentRepository)(nil).Create), attachment, quota)
+}
+
+// Destroy mocks base method.
+func (m *MockAttachmentRepository) Destroy(attachment *model.Attachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Destroy", attachment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Destroy indicates an expected call of Destroy.
+func (mr *MockAttachmentRepositoryMockRecorder) Destroy(attachment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Destroy", reflect.TypeOf((*MockAttachmentRepository)(nil).Destroy), attachment)
+}
+
+// DestroyAllByBoard mocks base method.
+func (m *MockAttachmentRepository) DestroyAllByBoard(board *model.Board) ([]model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyAllByBoard", board)
+	ret0, _ := ret[0].([]model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DestroyAllByBoard indicates an expected call of DestroyAllByBoard.
+func (mr *MockAttachmentRepositoryMockRecorder) DestroyAllByBoard(board interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyAllByBoard", reflect.TypeOf((*MockAttachmentRepository)(nil).DestroyAllByBoard), board)
+}
+
+// DestroyAllByCard mocks base method.
+func (m *MockAttachmentRepository) DestroyAllByCard(card *model.Card) ([]model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyAllByCard", card)
+	ret0, _ := ret[0].([]model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DestroyAllByCard indicates an expected call of DestroyAllByCard.
+func (mr *MockAttachmentRepositoryMockRecorder) DestroyAllByCard(card interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyAllByCard", reflect.TypeOf((*MockAttachmentRepository)(nil).DestroyAllByCard), card)
+}
+
+// DestroyAllByList mocks base method.
+func (m *MockAttachmentRepository) DestroyAllByList(list *model.List) ([]model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyAllByList", list)
+	ret0, _ := ret[0].([]model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DestroyAllByList indicates an expected call of DestroyAllByList.
+func (mr *MockAttachmentRepositoryMockRecorder) DestroyAllByList(list interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyAllByList", reflect.TypeOf((*MockAttachmentRepository)(nil).DestroyAllByList), list)
+}
+
+// DestroyAllByUser mocks base method.
+func (m *MockAttachmentRepository) DestroyAllByUser(user *model.User) ([]model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyAllByUser", user)
+	ret0, _ := ret[0].([]model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DestroyAllByUser indicates an expected call of DestroyAllByUser.
+func (mr *MockAttachmentRepositoryMockRecorder) DestroyAllByUser(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyAllByUser", reflect.TypeOf((*MockAttachmentRepository)(nil).DestroyAllByUser), user)
+}
+
+// Find mocks base method.
+func (m *MockAttachmentRepository) Find(card *model.Card, id int) (model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", card, id)
+	ret0, _ := ret[0].(model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockAttachmentRepositoryMockRecorder) Find(card, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockAttachmentRepository)(nil).Find), card, id)
+}
+
+// FindAll mocks base method.
+func (m *MockAttachmentRepository) FindAll(card *model.Card) ([]model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", card)
+	ret0, _ := ret[0].([]model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockAttachmentRepositoryMockRecorder) FindAll(card interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockAttachmentRepository)(nil).FindAll), card)
+}
+
+// TotalSize mocks base method.
+func (m *MockAttachmentRepository) TotalSize(user *model.User) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TotalSize", user)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TotalSize indicates an expected call of TotalSize.
+func (mr *MockAttachmentRepositoryMockRecorder) TotalSize(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TotalSize", reflect.TypeOf((*MockAttachmentRepository)(nil).TotalSize), user)
+}
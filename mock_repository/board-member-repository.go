@@ -0,0 +1,166 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/board-member-repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/kuritaeiji/todo-gin-back/model"
+)
+
+// MockBoardMemberRepository is a mock of BoardMemberRepository interface.
+type MockBoardMemberRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoardMemberRepositoryMockRecorder
+}
+
+// MockBoardMemberRepositoryMockRecorder is the mock recorder for MockBoardMemberRepository.
+type MockBoardMemberRepositoryMockRecorder struct {
+	mock *MockBoardMemberRepository
+}
+
+// NewMockBoardMemberRepository creates a new mock instance.
+func NewMockBoardMemberRepository(ctrl *gomock.Controller) *MockBoardMemberRepository {
+	mock := &MockBoardMemberRepository{ctrl: ctrl}
+	mock.recorder = &MockBoardMemberRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoardMemberRepository) EXPECT() *MockBoardMemberRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockBoardMemberRepository) Accept(member *model.BoardMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockBoardMemberRepositoryMockRecorder) Accept(member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockBoardMemberRepository)(nil).Accept), member)
+}
+
+// Create mocks base method.
+func (m *MockBoardMemberRepository) Create(member *model.BoardMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBoardMemberRepositoryMockRecorder) Create(member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBoardMemberRepository)(nil).Create), member)
+}
+
+// Destroy mocks base method.
+func (m *MockBoardMemberRepository) Destroy(member *model.BoardMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Destroy", member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Destroy indicates an expected call of Destroy.
+func (mr *MockBoardMemberRepositoryMockRecorder) Destroy(member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Destroy", reflect.TypeOf((*MockBoardMemberRepository)(nil).Destroy), member)
+}
+
+// Find mocks base method.
+func (m *MockBoardMemberRepository) Find(boardID, userID int) (model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", boardID, userID)
+	ret0, _ := ret[0].(model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockBoardMemberRepositoryMockRecorder) Find(boardID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockBoardMemberRepository)(nil).Find), boardID, userID)
+}
+
+// FindAll mocks base method.
+func (m *MockBoardMemberRepository) FindAll(boardID int) ([]model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", boardID)
+	ret0, _ := ret[0].([]model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockBoardMemberRepositoryMockRecorder) FindAll(boardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockBoardMemberRepository)(nil).FindAll), boardID)
+}
+
+// FindInBoard mocks base method.
+func (m *MockBoardMemberRepository) FindInBoard(boardID, id int) (model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInBoard", boardID, id)
+	ret0, _ := ret[0].(model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindInBoard indicates an expected call of FindInBoard.
+func (mr *MockBoardMemberRepositoryMockRecorder) FindInBoard(boardID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInBoard", reflect.TypeOf((*MockBoardMemberRepository)(nil).FindInBoard), boardID, id)
+}
+
+// FindInvitation mocks base method.
+func (m *MockBoardMemberRepository) FindInvitation(id, userID int) (model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInvitation", id, userID)
+	ret0, _ := ret[0].(model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindInvitation indicates an expected call of FindInvitation.
+func (mr *MockBoardMemberRepositoryMockRecorder) FindInvitation(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInvitation", reflect.TypeOf((*MockBoardMemberRepository)(nil).FindInvitation), id, userID)
+}
+
+// FindInvitations mocks base method.
+func (m *MockBoardMemberRepository) FindInvitations(userID int) ([]model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInvitations", userID)
+	ret0, _ := ret[0].([]model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindInvitations indicates an expected call of FindInvitations.
+func (mr *MockBoardMemberRepositoryMockRecorder) FindInvitations(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInvitations", reflect.TypeOf((*MockBoardMemberRepository)(nil).FindInvitations), userID)
+}
+
+// UpdateRole mocks base method.
+func (m *MockBoardMemberRepository) UpdateRole(member *model.BoardMember, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", member, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockBoardMemberRepositoryMockRecorder) UpdateRole(member, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockBoardMemberRepository)(nil).UpdateRole), member, role)
+}
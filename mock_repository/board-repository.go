@@ -0,0 +1,121 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/board-repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/kuritaeiji/todo-gin-back/model"
+)
+
+// MockBoardRepository is a mock of BoardRepository interface.
+type MockBoardRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoardRepositoryMockRecorder
+}
+
+// MockBoardRepositoryMockRecorder is the mock recorder for MockBoardRepository.
+type MockBoardRepositoryMockRecorder struct {
+	mock *MockBoardRepository
+}
+
+// NewMockBoardRepository creates a new mock instance.
+func NewMockBoardRepository(ctrl *gomock.Controller) *MockBoardRepository {
+	mock := &MockBoardRepository{ctrl: ctrl}
+	mock.recorder = &MockBoardRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoardRepository) EXPECT() *MockBoardRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBoardRepository) Create(user *model.User, board *model.Board) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", user, board)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBoardRepositoryMockRecorder) Create(user, board interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBoardRepository)(nil).Create), user, board)
+}
+
+// Destroy mocks base method.
+func (m *MockBoardRepository) Destroy(board *model.Board) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Destroy", board)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Destroy indicates an expected call of Destroy.
+func (mr *MockBoardRepositoryMockRecorder) Destroy(board interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Destroy", reflect.TypeOf((*MockBoardRepository)(nil).Destroy), board)
+}
+
+// Find mocks base method.
+func (m *MockBoardRepository) Find(id int) (model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", id)
+	ret0, _ := ret[0].(model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockBoardRepositoryMockRecorder) Find(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockBoardRepository)(nil).Find), id)
+}
+
+// FindAll mocks base method.
+func (m *MockBoardRepository) FindAll(user *model.User) ([]model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", user)
+	ret0, _ := ret[0].([]model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockBoardRepositoryMockRecorder) FindAll(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockBoardRepository)(nil).FindAll), user)
+}
+
+// Move mocks base method.
+func (m *MockBoardRepository) Move(board *model.Board, toIndex int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", board, toIndex)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockBoardRepositoryMockRecorder) Move(board, toIndex interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockBoardRepository)(nil).Move), board, toIndex)
+}
+
+// Update mocks base method.
+func (m *MockBoardRepository) Update(board *model.Board, updatingBoard model.Board) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", board, updatingBoard)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBoardRepositoryMockRecorder) Update(board, updatingBoard interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBoardRepository)(nil).Update), board, updatingBoard)
+}
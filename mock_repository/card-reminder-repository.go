@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/card-reminder-repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/kuritaeiji/todo-gin-back/model"
+)
+
+// MockCardReminderRepository is a mock of CardReminderRepository interface.
+type MockCardReminderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCardReminderRepositoryMockRecorder
+}
+
+// MockCardReminderRepositoryMockRecorder is the mock recorder for MockCardReminderRepository.
+type MockCardReminderRepositoryMockRecorder struct {
+	mock *MockCardReminderRepository
+}
+
+// NewMockCardReminderRepository creates a new mock instance.
+func NewMockCardReminderRepository(ctrl *gomock.Controller) *MockCardReminderRepository {
+	mock := &MockCardReminderRepository{ctrl: ctrl}
+	mock.recorder = &MockCardReminderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCardReminderRepository) EXPECT() *MockCardReminderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindDue mocks base method.
+func (m *MockCardReminderRepository) FindDue(now time.Time) ([]model.CardReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDue", now)
+	ret0, _ := ret[0].([]model.CardReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDue indicates an expected call of FindDue.
+func (mr *MockCardReminderRepositoryMockRecorder) FindDue(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDue", reflect.TypeOf((*MockCardReminderRepository)(nil).FindDue), now)
+}
+
+// MarkSent mocks base method.
+func (m *MockCardReminderRepository) MarkSent(reminder *model.CardReminder, sentAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSent", reminder, sentAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkSent indicates an expected call of MarkSent.
+func (mr *MockCardReminderRepositoryMockRecorder) MarkSent(reminder, sentAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSent", reflect.TypeOf((*MockCardReminderRepository)(nil).MarkSent), reminder, sentAt)
+}
+
+// Replace mocks base method.
+func (m *MockCardReminderRepository) Replace(card *model.Card, reminders []model.CardReminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", card, reminders)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockCardReminderRepositoryMockRecorder) Replace(card, reminders interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockCardReminderRepository)(nil).Replace), card, reminders)
+}
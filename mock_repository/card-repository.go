@@ -34,6 +34,20 @@ func (m *MockCardRepository) EXPECT() *MockCardRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Archive mocks base method.
+func (m *MockCardRepository) Archive(arg0 *model.Card) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockCardRepositoryMockRecorder) Archive(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockCardRepository)(nil).Archive), arg0)
+}
+
 // Create mocks base method.
 func (m *MockCardRepository) Create(arg0 *model.Card, arg1 *model.List) error {
 	m.ctrl.T.Helper()
@@ -62,6 +76,20 @@ func (mr *MockCardRepositoryMockRecorder) Destroy(card interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Destroy", reflect.TypeOf((*MockCardRepository)(nil).Destroy), card)
 }
 
+// DestroyPermanently mocks base method.
+func (m *MockCardRepository) DestroyPermanently(arg0 *model.Card) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyPermanently", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyPermanently indicates an expected call of DestroyPermanently.
+func (mr *MockCardRepositoryMockRecorder) DestroyPermanently(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyPermanently", reflect.TypeOf((*MockCardRepository)(nil).DestroyPermanently), arg0)
+}
+
 // Find mocks base method.
 func (m *MockCardRepository) Find(id int) (model.Card, error) {
 	m.ctrl.T.Helper()
@@ -77,6 +105,51 @@ func (mr *MockCardRepositoryMockRecorder) Find(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockCardRepository)(nil).Find), id)
 }
 
+// FindTrashed mocks base method.
+func (m *MockCardRepository) FindTrashed(arg0 *model.User) ([]model.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTrashed", arg0)
+	ret0, _ := ret[0].([]model.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTrashed indicates an expected call of FindTrashed.
+func (mr *MockCardRepositoryMockRecorder) FindTrashed(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTrashed", reflect.TypeOf((*MockCardRepository)(nil).FindTrashed), arg0)
+}
+
+// FindWithDueAtByList mocks base method.
+func (m *MockCardRepository) FindWithDueAtByList(arg0 *model.List) ([]model.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindWithDueAtByList", arg0)
+	ret0, _ := ret[0].([]model.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindWithDueAtByList indicates an expected call of FindWithDueAtByList.
+func (mr *MockCardRepositoryMockRecorder) FindWithDueAtByList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWithDueAtByList", reflect.TypeOf((*MockCardRepository)(nil).FindWithDueAtByList), arg0)
+}
+
+// FindWithTrashed mocks base method.
+func (m *MockCardRepository) FindWithTrashed(id int) (model.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindWithTrashed", id)
+	ret0, _ := ret[0].(model.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindWithTrashed indicates an expected call of FindWithTrashed.
+func (mr *MockCardRepositoryMockRecorder) FindWithTrashed(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWithTrashed", reflect.TypeOf((*MockCardRepository)(nil).FindWithTrashed), id)
+}
+
 // Move mocks base method.
 func (m *MockCardRepository) Move(card *model.Card, toListID, toIndex int) error {
 	m.ctrl.T.Helper()
@@ -91,6 +164,20 @@ func (mr *MockCardRepositoryMockRecorder) Move(card, toListID, toIndex interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockCardRepository)(nil).Move), card, toListID, toIndex)
 }
 
+// Restore mocks base method.
+func (m *MockCardRepository) Restore(arg0 *model.Card) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockCardRepositoryMockRecorder) Restore(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockCardRepository)(nil).Restore), arg0)
+}
+
 // Update mocks base method.
 func (m *MockCardRepository) Update(card, updatingCard *model.Card) error {
 	m.ctrl.T.Helper()
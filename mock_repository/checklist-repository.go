@@ -0,0 +1,192 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/checklist-repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/kuritaeiji/todo-gin-back/model"
+)
+
+// MockChecklistRepository is a mock of ChecklistRepository interface.
+type MockChecklistRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChecklistRepositoryMockRecorder
+}
+
+// MockChecklistRepositoryMockRecorder is the mock recorder for MockChecklistRepository.
+type MockChecklistRepositoryMockRecorder struct {
+	mock *MockChecklistRepository
+}
+
+// NewMockChecklistRepository creates a new mock instance.
+func NewMockChecklistRepository(ctrl *gomock.Controller) *MockChecklistRepository {
+	mock := &MockChecklistRepository{ctrl: ctrl}
+	mock.recorder = &MockChecklistRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChecklistRepository) EXPECT() *MockChecklistRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockChecklistRepository) Create(checklist *model.Checklist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", checklist)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockChecklistRepositoryMockRecorder) Create(checklist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockChecklistRepository)(nil).Create), checklist)
+}
+
+// CreateItem mocks base method.
+func (m *MockChecklistRepository) CreateItem(item *model.ChecklistItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItem", item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateItem indicates an expected call of CreateItem.
+func (mr *MockChecklistRepositoryMockRecorder) CreateItem(item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItem", reflect.TypeOf((*MockChecklistRepository)(nil).CreateItem), item)
+}
+
+// Destroy mocks base method.
+func (m *MockChecklistRepository) Destroy(checklist *model.Checklist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Destroy", checklist)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Destroy indicates an expected call of Destroy.
+func (mr *MockChecklistRepositoryMockRecorder) Destroy(checklist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Destroy", reflect.TypeOf((*MockChecklistRepository)(nil).Destroy), checklist)
+}
+
+// DestroyItem mocks base method.
+func (m *MockChecklistRepository) DestroyItem(item *model.ChecklistItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyItem", item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyItem indicates an expected call of DestroyItem.
+func (mr *MockChecklistRepositoryMockRecorder) DestroyItem(item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyItem", reflect.TypeOf((*MockChecklistRepository)(nil).DestroyItem), item)
+}
+
+// Find mocks base method.
+func (m *MockChecklistRepository) Find(card *model.Card, id int) (model.Checklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", card, id)
+	ret0, _ := ret[0].(model.Checklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockChecklistRepositoryMockRecorder) Find(card, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockChecklistRepository)(nil).Find), card, id)
+}
+
+// FindAll mocks base method.
+func (m *MockChecklistRepository) FindAll(card *model.Card) ([]model.Checklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", card)
+	ret0, _ := ret[0].([]model.Checklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockChecklistRepositoryMockRecorder) FindAll(card interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockChecklistRepository)(nil).FindAll), card)
+}
+
+// FindItem mocks base method.
+func (m *MockChecklistRepository) FindItem(checklist *model.Checklist, id int) (model.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindItem", checklist, id)
+	ret0, _ := ret[0].(model.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindItem indicates an expected call of FindItem.
+func (mr *MockChecklistRepositoryMockRecorder) FindItem(checklist, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindItem", reflect.TypeOf((*MockChecklistRepository)(nil).FindItem), checklist, id)
+}
+
+// Move mocks base method.
+func (m *MockChecklistRepository) Move(checklist *model.Checklist, toIndex int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", checklist, toIndex)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockChecklistRepositoryMockRecorder) Move(checklist, toIndex interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockChecklistRepository)(nil).Move), checklist, toIndex)
+}
+
+// MoveItem mocks base method.
+func (m *MockChecklistRepository) MoveItem(item *model.ChecklistItem, toIndex int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveItem", item, toIndex)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveItem indicates an expected call of MoveItem.
+func (mr *MockChecklistRepositoryMockRecorder) MoveItem(item, toIndex interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveItem", reflect.TypeOf((*MockChecklistRepository)(nil).MoveItem), item, toIndex)
+}
+
+// Update mocks base method.
+func (m *MockChecklistRepository) Update(checklist *model.Checklist, updatingChecklist model.Checklist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", checklist, updatingChecklist)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockChecklistRepositoryMockRecorder) Update(checklist, updatingChecklist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockChecklistRepository)(nil).Update), checklist, updatingChecklist)
+}
+
+// UpdateItem mocks base method.
+func (m *MockChecklistRepository) UpdateItem(item *model.ChecklistItem, updatingItem model.ChecklistItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", item, updatingItem)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateItem indicates an expected call of UpdateItem.
+func (mr *MockChecklistRepositoryMockRecorder) UpdateItem(item, updatingItem interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockChecklistRepository)(nil).UpdateItem), item, updatingItem)
+}
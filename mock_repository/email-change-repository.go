@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/email-change-repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/kuritaeiji/todo-gin-back/model"
+)
+
+// MockEmailChangeRepository is a mock of EmailChangeRepository interface.
+type MockEmailChangeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailChangeRepositoryMockRecorder
+}
+
+// MockEmailChangeRepositoryMockRecorder is the mock recorder for MockEmailChangeRepository.
+type MockEmailChangeRepositoryMockRecorder struct {
+	mock *MockEmailChangeRepository
+}
+
+// NewMockEmailChangeRepository creates a new mock instance.
+func NewMockEmailChangeRepository(ctrl *gomock.Controller) *MockEmailChangeRepository {
+	mock := &MockEmailChangeRepository{ctrl: ctrl}
+	mock.recorder = &MockEmailChangeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailChangeRepository) EXPECT() *MockEmailChangeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockEmailChangeRepository) Confirm(change *model.EmailChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockEmailChangeRepositoryMockRecorder) Confirm(change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockEmailChangeRepository)(nil).Confirm), change)
+}
+
+// Create mocks base method.
+func (m *MockEmailChangeRepository) Create(change *model.EmailChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEmailChangeRepositoryMockRecorder) Create(change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEmailChangeRepository)(nil).Create), change)
+}
+
+// FindByDigest mocks base method.
+func (m *MockEmailChangeRepository) FindByDigest(digest string) (model.EmailChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByDigest", digest)
+	ret0, _ := ret[0].(model.EmailChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByDigest indicates an expected call of FindByDigest.
+func (mr *MockEmailChangeRepositoryMockRecorder) FindByDigest(digest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByDigest", reflect.TypeOf((*MockEmailChangeRepository)(nil).FindByDigest), digest)
+}
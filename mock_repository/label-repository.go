@@ -0,0 +1,135 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/label-repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/kuritaeiji/todo-gin-back/model"
+)
+
+// MockLabelRepository is a mock of LabelRepository interface.
+type MockLabelRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLabelRepositoryMockRecorder
+}
+
+// MockLabelRepositoryMockRecorder is the mock recorder for MockLabelRepository.
+type MockLabelRepositoryMockRecorder struct {
+	mock *MockLabelRepository
+}
+
+// NewMockLabelRepository creates a new mock instance.
+func NewMockLabelRepository(ctrl *gomock.Controller) *MockLabelRepository {
+	mock := &MockLabelRepository{ctrl: ctrl}
+	mock.recorder = &MockLabelRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLabelRepository) EXPECT() *MockLabelRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Attach mocks base method.
+func (m *MockLabelRepository) Attach(card *model.Card, label *model.Label) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attach", card, label)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockLabelRepositoryMockRecorder) Attach(card, label interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockLabelRepository)(nil).Attach), card, label)
+}
+
+// Create mocks base method.
+func (m *MockLabelRepository) Create(label *model.Label) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", label)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLabelRepositoryMockRecorder) Create(label interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLabelRepository)(nil).Create), label)
+}
+
+// Destroy mocks base method.
+func (m *MockLabelRepository) Destroy(label *model.Label) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Destroy", label)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Destroy indicates an expected call of Destroy.
+func (mr *MockLabelRepositoryMockRecorder) Destroy(label interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Destroy", reflect.TypeOf((*MockLabelRepository)(nil).Destroy), label)
+}
+
+// Detach mocks base method.
+func (m *MockLabelRepository) Detach(card *model.Card, label *model.Label) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Detach", card, label)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Detach indicates an expected call of Detach.
+func (mr *MockLabelRepositoryMockRecorder) Detach(card, label interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detach", reflect.TypeOf((*MockLabelRepository)(nil).Detach), card, label)
+}
+
+// Find mocks base method.
+func (m *MockLabelRepository) Find(id int) (model.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", id)
+	ret0, _ := ret[0].(model.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockLabelRepositoryMockRecorder) Find(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockLabelRepository)(nil).Find), id)
+}
+
+// FindAll mocks base method.
+func (m *MockLabelRepository) FindAll(user *model.User) ([]model.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", user)
+	ret0, _ := ret[0].([]model.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockLabelRepositoryMockRecorder) FindAll(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockLabelRepository)(nil).FindAll), user)
+}
+
+// Update mocks base method.
+func (m *MockLabelRepository) Update(label *model.Label, updatingLabel model.Label) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", label, updatingLabel)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockLabelRepositoryMockRecorder) Update(label, updatingLabel interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLabelRepository)(nil).Update), label, updatingLabel)
+}
@@ -35,8 +35,22 @@ func (m *MockListRepository) EXPECT() *MockListRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Archive mocks base method.
+func (m *MockListRepository) Archive(arg0 *model.List) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockListRepositoryMockRecorder) Archive(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockListRepository)(nil).Archive), arg0)
+}
+
 // Create mocks base method.
-func (m *MockListRepository) Create(arg0 *model.User, arg1 *model.List) error {
+func (m *MockListRepository) Create(arg0 *model.Board, arg1 *model.List) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Create", arg0, arg1)
 	ret0, _ := ret[0].(error)
@@ -77,6 +91,20 @@ func (mr *MockListRepositoryMockRecorder) DestroyLists(lists, tx interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyLists", reflect.TypeOf((*MockListRepository)(nil).DestroyLists), lists, tx)
 }
 
+// DestroyPermanently mocks base method.
+func (m *MockListRepository) DestroyPermanently(arg0 *model.List) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyPermanently", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyPermanently indicates an expected call of DestroyPermanently.
+func (mr *MockListRepositoryMockRecorder) DestroyPermanently(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyPermanently", reflect.TypeOf((*MockListRepository)(nil).DestroyPermanently), arg0)
+}
+
 // Find mocks base method.
 func (m *MockListRepository) Find(id int) (model.List, error) {
 	m.ctrl.T.Helper()
@@ -93,7 +121,7 @@ func (mr *MockListRepositoryMockRecorder) Find(id interface{}) *gomock.Call {
 }
 
 // FindListsWithCards mocks base method.
-func (m *MockListRepository) FindListsWithCards(arg0 *model.User) error {
+func (m *MockListRepository) FindListsWithCards(arg0 *model.Board) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FindListsWithCards", arg0)
 	ret0, _ := ret[0].(error)
@@ -106,18 +134,76 @@ func (mr *MockListRepositoryMockRecorder) FindListsWithCards(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindListsWithCards", reflect.TypeOf((*MockListRepository)(nil).FindListsWithCards), arg0)
 }
 
+// FindListsWithCardsByLabels mocks base method.
+func (m *MockListRepository) FindListsWithCardsByLabels(board *model.Board, labelIDs []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindListsWithCardsByLabels", board, labelIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindListsWithCardsByLabels indicates an expected call of FindListsWithCardsByLabels.
+func (mr *MockListRepositoryMockRecorder) FindListsWithCardsByLabels(board, labelIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindListsWithCardsByLabels", reflect.TypeOf((*MockListRepository)(nil).FindListsWithCardsByLabels), board, labelIDs)
+}
+
+// FindTrashed mocks base method.
+func (m *MockListRepository) FindTrashed(arg0 *model.User) ([]model.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTrashed", arg0)
+	ret0, _ := ret[0].([]model.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTrashed indicates an expected call of FindTrashed.
+func (mr *MockListRepositoryMockRecorder) FindTrashed(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTrashed", reflect.TypeOf((*MockListRepository)(nil).FindTrashed), arg0)
+}
+
+// FindWithTrashed mocks base method.
+func (m *MockListRepository) FindWithTrashed(id int) (model.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindWithTrashed", id)
+	ret0, _ := ret[0].(model.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindWithTrashed indicates an expected call of FindWithTrashed.
+func (mr *MockListRepositoryMockRecorder) FindWithTrashed(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWithTrashed", reflect.TypeOf((*MockListRepository)(nil).FindWithTrashed), id)
+}
+
 // Move mocks base method.
-func (m *MockListRepository) Move(list *model.List, toIndex int, currentUser *model.User) error {
+func (m *MockListRepository) Move(list *model.List, toIndex int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Move", list, toIndex, currentUser)
+	ret := m.ctrl.Call(m, "Move", list, toIndex)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Move indicates an expected call of Move.
-func (mr *MockListRepositoryMockRecorder) Move(list, toIndex, currentUser interface{}) *gomock.Call {
+func (mr *MockListRepositoryMockRecorder) Move(list, toIndex interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockListRepository)(nil).Move), list, toIndex)
+}
+
+// Restore mocks base method.
+func (m *MockListRepository) Restore(arg0 *model.List) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockListRepositoryMockRecorder) Restore(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockListRepository)(nil).Move), list, toIndex, currentUser)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockListRepository)(nil).Restore), arg0)
 }
 
 // Update mocks base method.
@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/session-repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/kuritaeiji/todo-gin-back/model"
+)
+
+// MockSessionRepository is a mock of SessionRepository interface.
+type MockSessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionRepositoryMockRecorder
+}
+
+// MockSessionRepositoryMockRecorder is the mock recorder for MockSessionRepository.
+type MockSessionRepositoryMockRecorder struct {
+	mock *MockSessionRepository
+}
+
+// NewMockSessionRepository creates a new mock instance.
+func NewMockSessionRepository(ctrl *gomock.Controller) *MockSessionRepository {
+	mock := &MockSessionRepository{ctrl: ctrl}
+	mock.recorder = &MockSessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionRepository) EXPECT() *MockSessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSessionRepository) Create(session *model.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSessionRepositoryMockRecorder) Create(session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSessionRepository)(nil).Create), session)
+}
+
+// DestroyBySessionID mocks base method.
+func (m *MockSessionRepository) DestroyBySessionID(sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyBySessionID", sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyBySessionID indicates an expected call of DestroyBySessionID.
+func (mr *MockSessionRepositoryMockRecorder) DestroyBySessionID(sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyBySessionID", reflect.TypeOf((*MockSessionRepository)(nil).DestroyBySessionID), sessionID)
+}
+
+// Find mocks base method.
+func (m *MockSessionRepository) Find(user *model.User, id int) (model.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", user, id)
+	ret0, _ := ret[0].(model.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockSessionRepositoryMockRecorder) Find(user, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockSessionRepository)(nil).Find), user, id)
+}
+
+// FindActive mocks base method.
+func (m *MockSessionRepository) FindActive(sessionID string, userID int, jti string) (model.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActive", sessionID, userID, jti)
+	ret0, _ := ret[0].(model.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActive indicates an expected call of FindActive.
+func (mr *MockSessionRepositoryMockRecorder) FindActive(sessionID, userID, jti interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActive", reflect.TypeOf((*MockSessionRepository)(nil).FindActive), sessionID, userID, jti)
+}
+
+// FindAll mocks base method.
+func (m *MockSessionRepository) FindAll(user *model.User) ([]model.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", user)
+	ret0, _ := ret[0].([]model.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockSessionRepositoryMockRecorder) FindAll(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockSessionRepository)(nil).FindAll), user)
+}
+
+// FindBySessionID mocks base method.
+func (m *MockSessionRepository) FindBySessionID(sessionID string) (model.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySessionID", sessionID)
+	ret0, _ := ret[0].(model.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySessionID indicates an expected call of FindBySessionID.
+func (mr *MockSessionRepositoryMockRecorder) FindBySessionID(sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySessionID", reflect.TypeOf((*MockSessionRepository)(nil).FindBySessionID), sessionID)
+}
+
+// UpdateLastSeenAt mocks base method.
+func (m *MockSessionRepository) UpdateLastSeenAt(session *model.Session, seenAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastSeenAt", session, seenAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastSeenAt indicates an expected call of UpdateLastSeenAt.
+func (mr *MockSessionRepositoryMockRecorder) UpdateLastSeenAt(session, seenAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastSeenAt", reflect.TypeOf((*MockSessionRepository)(nil).UpdateLastSeenAt), session, seenAt)
+}
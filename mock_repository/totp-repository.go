@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/totp-repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/kuritaeiji/todo-gin-back/model"
+)
+
+// MockTotpRepository is a mock of TotpRepository interface.
+type MockTotpRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTotpRepositoryMockRecorder
+}
+
+// MockTotpRepositoryMockRecorder is the mock recorder for MockTotpRepository.
+type MockTotpRepositoryMockRecorder struct {
+	mock *MockTotpRepository
+}
+
+// NewMockTotpRepository creates a new mock instance.
+func NewMockTotpRepository(ctrl *gomock.Controller) *MockTotpRepository {
+	mock := &MockTotpRepository{ctrl: ctrl}
+	mock.recorder = &MockTotpRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTotpRepository) EXPECT() *MockTotpRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Disable mocks base method.
+func (m *MockTotpRepository) Disable(user *model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockTotpRepositoryMockRecorder) Disable(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockTotpRepository)(nil).Disable), user)
+}
+
+// Enable mocks base method.
+func (m *MockTotpRepository) Enable(user *model.User, recoveryCodeDigests []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enable", user, recoveryCodeDigests)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enable indicates an expected call of Enable.
+func (mr *MockTotpRepositoryMockRecorder) Enable(user, recoveryCodeDigests interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enable", reflect.TypeOf((*MockTotpRepository)(nil).Enable), user, recoveryCodeDigests)
+}
+
+// SaveSecret mocks base method.
+func (m *MockTotpRepository) SaveSecret(user *model.User, secret string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSecret", user, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSecret indicates an expected call of SaveSecret.
+func (mr *MockTotpRepositoryMockRecorder) SaveSecret(user, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSecret", reflect.TypeOf((*MockTotpRepository)(nil).SaveSecret), user, secret)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockTotpRepository) UseRecoveryCode(user *model.User, digest string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", user, digest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockTotpRepositoryMockRecorder) UseRecoveryCode(user, digest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockTotpRepository)(nil).UseRecoveryCode), user, digest)
+}
+
+// UseTimeStep mocks base method.
+func (m *MockTotpRepository) UseTimeStep(user *model.User, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTimeStep", user, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseTimeStep indicates an expected call of UseTimeStep.
+func (mr *MockTotpRepositoryMockRecorder) UseTimeStep(user, step interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTimeStep", reflect.TypeOf((*MockTotpRepository)(nil).UseTimeStep), user, step)
+}
@@ -6,6 +6,7 @@ package mock_repository
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	model "github.com/kuritaeiji/todo-gin-back/model"
@@ -106,34 +107,19 @@ func (mr *MockUserRepositoryMockRecorder) FindByEmail(email interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockUserRepository)(nil).FindByEmail), email)
 }
 
-// FindOrCreateByOpenID mocks base method.
-func (m *MockUserRepository) FindOrCreateByOpenID(openID string) (model.User, error) {
+// FindOrCreateByIdentity mocks base method.
+func (m *MockUserRepository) FindOrCreateByIdentity(provider, subject, verifiedEmail string, trustEmail bool) (model.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindOrCreateByOpenID", openID)
+	ret := m.ctrl.Call(m, "FindOrCreateByIdentity", provider, subject, verifiedEmail, trustEmail)
 	ret0, _ := ret[0].(model.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindOrCreateByOpenID indicates an expected call of FindOrCreateByOpenID.
-func (mr *MockUserRepositoryMockRecorder) FindOrCreateByOpenID(openID interface{}) *gomock.Call {
+// FindOrCreateByIdentity indicates an expected call of FindOrCreateByIdentity.
+func (mr *MockUserRepositoryMockRecorder) FindOrCreateByIdentity(provider, subject, verifiedEmail, trustEmail interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrCreateByOpenID", reflect.TypeOf((*MockUserRepository)(nil).FindOrCreateByOpenID), openID)
-}
-
-// HasCard mocks base method.
-func (m *MockUserRepository) HasCard(card model.Card, user model.User) (bool, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HasCard", card, user)
-	ret0, _ := ret[0].(bool)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// HasCard indicates an expected call of HasCard.
-func (mr *MockUserRepositoryMockRecorder) HasCard(card, user interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasCard", reflect.TypeOf((*MockUserRepository)(nil).HasCard), card, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrCreateByIdentity", reflect.TypeOf((*MockUserRepository)(nil).FindOrCreateByIdentity), provider, subject, verifiedEmail, trustEmail)
 }
 
 // IsUnique mocks base method.
@@ -150,3 +136,59 @@ func (mr *MockUserRepositoryMockRecorder) IsUnique(email interface{}) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUnique", reflect.TypeOf((*MockUserRepository)(nil).IsUnique), email)
 }
+
+// LinkIdentity mocks base method.
+func (m *MockUserRepository) LinkIdentity(user *model.User, provider, subject string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkIdentity", user, provider, subject)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkIdentity indicates an expected call of LinkIdentity.
+func (mr *MockUserRepositoryMockRecorder) LinkIdentity(user, provider, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkIdentity", reflect.TypeOf((*MockUserRepository)(nil).LinkIdentity), user, provider, subject)
+}
+
+// UnlinkIdentity mocks base method.
+func (m *MockUserRepository) UnlinkIdentity(user *model.User, provider string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkIdentity", user, provider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkIdentity indicates an expected call of UnlinkIdentity.
+func (mr *MockUserRepositoryMockRecorder) UnlinkIdentity(user, provider interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkIdentity", reflect.TypeOf((*MockUserRepository)(nil).UnlinkIdentity), user, provider)
+}
+
+// UpdateActivationSentAt mocks base method.
+func (m *MockUserRepository) UpdateActivationSentAt(user *model.User, sentAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateActivationSentAt", user, sentAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateActivationSentAt indicates an expected call of UpdateActivationSentAt.
+func (mr *MockUserRepositoryMockRecorder) UpdateActivationSentAt(user, sentAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActivationSentAt", reflect.TypeOf((*MockUserRepository)(nil).UpdateActivationSentAt), user, sentAt)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockUserRepository) UpdatePassword(user *model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserRepositoryMockRecorder) UpdatePassword(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserRepository)(nil).UpdatePassword), user)
+}
@@ -0,0 +1,153 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/attachment-service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	io "io"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/kuritaeiji/todo-gin-back/model"
+)
+
+// MockAttachmentService is a mock of AttachmentService interface.
+type MockAttachmentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentServiceMockRecorder
+}
+
+// MockAttachmentServiceMockRecorder is the mock recorder for MockAttachmentService.
+type MockAttachmentServiceMockRecorder struct {
+	mock *MockAttachmentService
+}
+
+// NewMockAttachmentService creates a new mock instance.
+func NewMockAttachmentService(ctrl *gomock.Controller) *MockAttachmentService {
+	mock := &MockAttachmentService{ctrl: ctrl}
+	mock.recorder = &MockAttachmentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentService) EXPECT() *MockAttachmentServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAttachmentService) Create(arg0 *gin.Context) (model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAttachmentServiceMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAttachmentService)(nil).Create), arg0)
+}
+
+// Destroy mocks base method.
+func (m *MockAttachmentService) Destroy(arg0 *gin.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Destroy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Destroy indicates an expected call of Destroy.
+func (mr *MockAttachmentServiceMockRecorder) Destroy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Destroy", reflect.TypeOf((*MockAttachmentService)(nil).Destroy), arg0)
+}
+
+// DestroyByBoard mocks base method.
+func (m *MockAttachmentService) DestroyByBoard(board model.Board) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyByBoard", board)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyByBoard indicates an expected call of DestroyByBoard.
+func (mr *MockAttachmentServiceMockRecorder) DestroyByBoard(board interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyByBoard", reflect.TypeOf((*MockAttachmentService)(nil).DestroyByBoard), board)
+}
+
+// DestroyByCard mocks base method.
+func (m *MockAttachmentService) DestroyByCard(card model.Card) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyByCard", card)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyByCard indicates an expected call of DestroyByCard.
+func (mr *MockAttachmentServiceMockRecorder) DestroyByCard(card interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyByCard", reflect.TypeOf((*MockAttachmentService)(nil).DestroyByCard), card)
+}
+
+// DestroyByList mocks base method.
+func (m *MockAttachmentService) DestroyByList(list model.List) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyByList", list)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyByList indicates an expected call of DestroyByList.
+func (mr *MockAttachmentServiceMockRecorder) DestroyByList(list interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyByList", reflect.TypeOf((*MockAttachmentService)(nil).DestroyByList), list)
+}
+
+// DestroyByUser mocks base method.
+func (m *MockAttachmentService) DestroyByUser(user model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyByUser", user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyByUser indicates an expected call of DestroyByUser.
+func (mr *MockAttachmentServiceMockRecorder) DestroyByUser(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyByUser", reflect.TypeOf((*MockAttachmentService)(nil).DestroyByUser), user)
+}
+
+// Download mocks base method.
+func (m *MockAttachmentService) Download(arg0 *gin.Context) (model.Attachment, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Download", arg0)
+	ret0, _ := ret[0].(model.Attachment)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Download indicates an expected call of Download.
+func (mr *MockAttachmentServiceMockRecorder) Download(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockAttachmentService)(nil).Download), arg0)
+}
+
+// Index mocks base method.
+func (m *MockAttachmentService) Index(arg0 *gin.Context) ([]model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Index", arg0)
+	ret0, _ := ret[0].([]model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Index indicates an expected call of Index.
+func (mr *MockAttachmentServiceMockRecorder) Index(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockAttachmentService)(nil).Index), arg0)
+}
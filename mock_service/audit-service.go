@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/audit-service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/kuritaeiji/todo-gin-back/model"
+)
+
+// MockAuditService is a mock of AuditService interface.
+type MockAuditService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditServiceMockRecorder
+}
+
+// MockAuditServiceMockRecorder is the mock recorder for MockAuditService.
+type MockAuditServiceMockRecorder struct {
+	mock *MockAuditService
+}
+
+// NewMockAuditService creates a new mock instance.
+func NewMockAuditService(ctrl *gomock.Controller) *MockAuditService {
+	mock := &MockAuditService{ctrl: ctrl}
+	mock.recorder = &MockAuditServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditService) EXPECT() *MockAuditServiceMockRecorder {
+	return m.recorder
+}
+
+// AdminIndex mocks base method.
+func (m *MockAuditService) AdminIndex(arg0 *gin.Context) ([]model.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminIndex", arg0)
+	ret0, _ := ret[0].([]model.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminIndex indicates an expected call of AdminIndex.
+func (mr *MockAuditServiceMockRecorder) AdminIndex(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminIndex", reflect.TypeOf((*MockAuditService)(nil).AdminIndex), arg0)
+}
+
+// DestroyExpired mocks base method.
+func (m *MockAuditService) DestroyExpired() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyExpired")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyExpired indicates an expected call of DestroyExpired.
+func (mr *MockAuditServiceMockRecorder) DestroyExpired() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyExpired", reflect.TypeOf((*MockAuditService)(nil).DestroyExpired))
+}
+
+// Index mocks base method.
+func (m *MockAuditService) Index(arg0 *gin.Context) ([]model.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Index", arg0)
+	ret0, _ := ret[0].([]model.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Index indicates an expected call of Index.
+func (mr *MockAuditServiceMockRecorder) Index(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockAuditService)(nil).Index), arg0)
+}
+
+// Record mocks base method.
+func (m *MockAuditService) Record(ctx *gin.Context, event string, user *model.User, detail string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", ctx, event, user, detail)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuditServiceMockRecorder) Record(ctx, event, user, detail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuditService)(nil).Record), ctx, event, user, detail)
+}
+
+// Run mocks base method.
+func (m *MockAuditService) Run(stop <-chan struct{}) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", stop)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockAuditServiceMockRecorder) Run(stop interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockAuditService)(nil).Run), stop)
+}
@@ -9,6 +9,7 @@ import (
 
 	gin "github.com/gin-gonic/gin"
 	gomock "github.com/golang/mock/gomock"
+	service "github.com/kuritaeiji/todo-gin-back/service"
 )
 
 // MockAuthService is a mock of AuthService interface.
@@ -34,48 +35,135 @@ func (m *MockAuthService) EXPECT() *MockAuthServiceMockRecorder {
 	return m.recorder
 }
 
-// Google mocks base method.
-func (m *MockAuthService) Google(arg0 *gin.Context) (string, string, error) {
+// LinkOauth mocks base method.
+func (m *MockAuthService) LinkOauth(arg0 *gin.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Google", arg0)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "LinkOauth", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkOauth indicates an expected call of LinkOauth.
+func (mr *MockAuthServiceMockRecorder) LinkOauth(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkOauth", reflect.TypeOf((*MockAuthService)(nil).LinkOauth), arg0)
+}
+
+// Login mocks base method.
+func (m *MockAuthService) Login(arg0 *gin.Context) (service.TokenPair, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", arg0)
+	ret0, _ := ret[0].(service.TokenPair)
 	ret1, _ := ret[1].(string)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// Google indicates an expected call of Google.
-func (mr *MockAuthServiceMockRecorder) Google(arg0 interface{}) *gomock.Call {
+// Login indicates an expected call of Login.
+func (mr *MockAuthServiceMockRecorder) Login(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuthService)(nil).Login), arg0)
+}
+
+// Logout mocks base method.
+func (m *MockAuthService) Logout(arg0 *gin.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockAuthServiceMockRecorder) Logout(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Google", reflect.TypeOf((*MockAuthService)(nil).Google), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthService)(nil).Logout), arg0)
 }
 
-// GoogleLogin mocks base method.
-func (m *MockAuthService) GoogleLogin(arg0 *gin.Context) (string, error) {
+// LogoutAll mocks base method.
+func (m *MockAuthService) LogoutAll(arg0 *gin.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GoogleLogin", arg0)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "LogoutAll", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutAll indicates an expected call of LogoutAll.
+func (mr *MockAuthServiceMockRecorder) LogoutAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAll", reflect.TypeOf((*MockAuthService)(nil).LogoutAll), arg0)
+}
+
+// Oauth mocks base method.
+func (m *MockAuthService) Oauth(arg0 *gin.Context) (service.OauthAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Oauth", arg0)
+	ret0, _ := ret[0].(service.OauthAuthorization)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GoogleLogin indicates an expected call of GoogleLogin.
-func (mr *MockAuthServiceMockRecorder) GoogleLogin(arg0 interface{}) *gomock.Call {
+// Oauth indicates an expected call of Oauth.
+func (mr *MockAuthServiceMockRecorder) Oauth(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GoogleLogin", reflect.TypeOf((*MockAuthService)(nil).GoogleLogin), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Oauth", reflect.TypeOf((*MockAuthService)(nil).Oauth), arg0)
 }
 
-// Login mocks base method.
-func (m *MockAuthService) Login(arg0 *gin.Context) (string, error) {
+// OauthLogin mocks base method.
+func (m *MockAuthService) OauthLogin(arg0 *gin.Context) (service.TokenPair, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", arg0)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "OauthLogin", arg0)
+	ret0, _ := ret[0].(service.TokenPair)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OauthLogin indicates an expected call of OauthLogin.
+func (mr *MockAuthServiceMockRecorder) OauthLogin(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OauthLogin", reflect.TypeOf((*MockAuthService)(nil).OauthLogin), arg0)
+}
+
+// Refresh mocks base method.
+func (m *MockAuthService) Refresh(arg0 *gin.Context) (service.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", arg0)
+	ret0, _ := ret[0].(service.TokenPair)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Login indicates an expected call of Login.
-func (mr *MockAuthServiceMockRecorder) Login(arg0 interface{}) *gomock.Call {
+// Refresh indicates an expected call of Refresh.
+func (mr *MockAuthServiceMockRecorder) Refresh(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuthService)(nil).Login), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockAuthService)(nil).Refresh), arg0)
+}
+
+// TotpLogin mocks base method.
+func (m *MockAuthService) TotpLogin(arg0 *gin.Context) (service.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TotpLogin", arg0)
+	ret0, _ := ret[0].(service.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TotpLogin indicates an expected call of TotpLogin.
+func (mr *MockAuthServiceMockRecorder) TotpLogin(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TotpLogin", reflect.TypeOf((*MockAuthService)(nil).TotpLogin), arg0)
+}
+
+// UnlinkOauth mocks base method.
+func (m *MockAuthService) UnlinkOauth(arg0 *gin.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkOauth", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkOauth indicates an expected call of UnlinkOauth.
+func (mr *MockAuthServiceMockRecorder) UnlinkOauth(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkOauth", reflect.TypeOf((*MockAuthService)(nil).UnlinkOauth), arg0)
 }
@@ -0,0 +1,107 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/board-event-service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	gateway "github.com/kuritaeiji/todo-gin-back/gateway"
+	model "github.com/kuritaeiji/todo-gin-back/model"
+)
+
+// MockBoardEventService is a mock of BoardEventService interface.
+type MockBoardEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoardEventServiceMockRecorder
+}
+
+// MockBoardEventServiceMockRecorder is the mock recorder for MockBoardEventService.
+type MockBoardEventServiceMockRecorder struct {
+	mock *MockBoardEventService
+}
+
+// NewMockBoardEventService creates a new mock instance.
+func NewMockBoardEventService(ctrl *gomock.Controller) *MockBoardEventService {
+	mock := &MockBoardEventService{ctrl: ctrl}
+	mock.recorder = &MockBoardEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoardEventService) EXPECT() *MockBoardEventServiceMockRecorder {
+	return m.recorder
+}
+
+// Authorize mocks base method.
+func (m *MockBoardEventService) Authorize(arg0 *gin.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockBoardEventServiceMockRecorder) Authorize(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockBoardEventService)(nil).Authorize), arg0)
+}
+
+// PublishCard mocks base method.
+func (m *MockBoardEventService) PublishCard(eventType string, card model.Card, listIDs ...int) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{eventType, card}
+	for _, a := range listIDs {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "PublishCard", varargs...)
+}
+
+// PublishCard indicates an expected call of PublishCard.
+func (mr *MockBoardEventServiceMockRecorder) PublishCard(eventType, card interface{}, listIDs ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{eventType, card}, listIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishCard", reflect.TypeOf((*MockBoardEventService)(nil).PublishCard), varargs...)
+}
+
+// PublishCardChanged mocks base method.
+func (m *MockBoardEventService) PublishCardChanged(cardID int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PublishCardChanged", cardID)
+}
+
+// PublishCardChanged indicates an expected call of PublishCardChanged.
+func (mr *MockBoardEventServiceMockRecorder) PublishCardChanged(cardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishCardChanged", reflect.TypeOf((*MockBoardEventService)(nil).PublishCardChanged), cardID)
+}
+
+// PublishList mocks base method.
+func (m *MockBoardEventService) PublishList(eventType string, list model.List) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PublishList", eventType, list)
+}
+
+// PublishList indicates an expected call of PublishList.
+func (mr *MockBoardEventServiceMockRecorder) PublishList(eventType, list interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishList", reflect.TypeOf((*MockBoardEventService)(nil).PublishList), eventType, list)
+}
+
+// Subscribe mocks base method.
+func (m *MockBoardEventService) Subscribe(arg0 *gin.Context) (gateway.BoardEventSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", arg0)
+	ret0, _ := ret[0].(gateway.BoardEventSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockBoardEventServiceMockRecorder) Subscribe(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockBoardEventService)(nil).Subscribe), arg0)
+}
@@ -0,0 +1,153 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/board-member-service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/kuritaeiji/todo-gin-back/model"
+)
+
+// MockBoardMemberService is a mock of BoardMemberService interface.
+type MockBoardMemberService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoardMemberServiceMockRecorder
+}
+
+// MockBoardMemberServiceMockRecorder is the mock recorder for MockBoardMemberService.
+type MockBoardMemberServiceMockRecorder struct {
+	mock *MockBoardMemberService
+}
+
+// NewMockBoardMemberService creates a new mock instance.
+func NewMockBoardMemberService(ctrl *gomock.Controller) *MockBoardMemberService {
+	mock := &MockBoardMemberService{ctrl: ctrl}
+	mock.recorder = &MockBoardMemberServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoardMemberService) EXPECT() *MockBoardMemberServiceMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockBoardMemberService) Accept(arg0 *gin.Context) (model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", arg0)
+	ret0, _ := ret[0].(model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockBoardMemberServiceMockRecorder) Accept(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockBoardMemberService)(nil).Accept), arg0)
+}
+
+// Authorize mocks base method.
+func (m *MockBoardMemberService) Authorize(boardID int, user model.User, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", boardID, user, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockBoardMemberServiceMockRecorder) Authorize(boardID, user, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockBoardMemberService)(nil).Authorize), boardID, user, role)
+}
+
+// Decline mocks base method.
+func (m *MockBoardMemberService) Decline(arg0 *gin.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decline", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Decline indicates an expected call of Decline.
+func (mr *MockBoardMemberServiceMockRecorder) Decline(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decline", reflect.TypeOf((*MockBoardMemberService)(nil).Decline), arg0)
+}
+
+// Destroy mocks base method.
+func (m *MockBoardMemberService) Destroy(arg0 *gin.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Destroy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Destroy indicates an expected call of Destroy.
+func (mr *MockBoardMemberServiceMockRecorder) Destroy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Destroy", reflect.TypeOf((*MockBoardMemberService)(nil).Destroy), arg0)
+}
+
+// Index mocks base method.
+func (m *MockBoardMemberService) Index(arg0 *gin.Context) ([]model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Index", arg0)
+	ret0, _ := ret[0].([]model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Index indicates an expected call of Index.
+func (mr *MockBoardMemberServiceMockRecorder) Index(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockBoardMemberService)(nil).Index), arg0)
+}
+
+// Invitations mocks base method.
+func (m *MockBoardMemberService) Invitations(arg0 *gin.Context) ([]model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invitations", arg0)
+	ret0, _ := ret[0].([]model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invitations indicates an expected call of Invitations.
+func (mr *MockBoardMemberServiceMockRecorder) Invitations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invitations", reflect.TypeOf((*MockBoardMemberService)(nil).Invitations), arg0)
+}
+
+// Invite mocks base method.
+func (m *MockBoardMemberService) Invite(arg0 *gin.Context) (model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite", arg0)
+	ret0, _ := ret[0].(model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockBoardMemberServiceMockRecorder) Invite(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockBoardMemberService)(nil).Invite), arg0)
+}
+
+// Update mocks base method.
+func (m *MockBoardMemberService) Update(arg0 *gin.Context) (model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBoardMemberServiceMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBoardMemberService)(nil).Update), arg0)
+}
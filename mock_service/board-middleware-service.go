@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/board-middleware-service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/kuritaeiji/todo-gin-back/model"
+)
+
+// MockBoardMiddlewareService is a mock of BoardMiddlewareService interface.
+type MockBoardMiddlewareService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoardMiddlewareServiceMockRecorder
+}
+
+// MockBoardMiddlewareServiceMockRecorder is the mock recorder for MockBoardMiddlewareService.
+type MockBoardMiddlewareServiceMockRecorder struct {
+	mock *MockBoardMiddlewareService
+}
+
+// NewMockBoardMiddlewareService creates a new mock instance.
+func NewMockBoardMiddlewareService(ctrl *gomock.Controller) *MockBoardMiddlewareService {
+	mock := &MockBoardMiddlewareService{ctrl: ctrl}
+	mock.recorder = &MockBoardMiddlewareServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoardMiddlewareService) EXPECT() *MockBoardMiddlewareServiceMockRecorder {
+	return m.recorder
+}
+
+// Authorize mocks base method.
+func (m *MockBoardMiddlewareService) Authorize(arg0 *gin.Context) (model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", arg0)
+	ret0, _ := ret[0].(model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockBoardMiddlewareServiceMockRecorder) Authorize(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockBoardMiddlewareService)(nil).Authorize), arg0)
+}
+
+// AuthorizeOwner mocks base method.
+func (m *MockBoardMiddlewareService) AuthorizeOwner(arg0 *gin.Context) (model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeOwner", arg0)
+	ret0, _ := ret[0].(model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorizeOwner indicates an expected call of AuthorizeOwner.
+func (mr *MockBoardMiddlewareServiceMockRecorder) AuthorizeOwner(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeOwner", reflect.TypeOf((*MockBoardMiddlewareService)(nil).AuthorizeOwner), arg0)
+}
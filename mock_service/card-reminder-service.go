@@ -0,0 +1,75 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/card-reminder-service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/kuritaeiji/todo-gin-back/model"
+)
+
+// MockCardReminderService is a mock of CardReminderService interface.
+type MockCardReminderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCardReminderServiceMockRecorder
+}
+
+// MockCardReminderServiceMockRecorder is the mock recorder for MockCardReminderService.
+type MockCardReminderServiceMockRecorder struct {
+	mock *MockCardReminderService
+}
+
+// NewMockCardReminderService creates a new mock instance.
+func NewMockCardReminderService(ctrl *gomock.Controller) *MockCardReminderService {
+	mock := &MockCardReminderService{ctrl: ctrl}
+	mock.recorder = &MockCardReminderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCardReminderService) EXPECT() *MockCardReminderServiceMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockCardReminderService) Run(stop <-chan struct{}) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", stop)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockCardReminderServiceMockRecorder) Run(stop interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockCardReminderService)(nil).Run), stop)
+}
+
+// Schedule mocks base method.
+func (m *MockCardReminderService) Schedule(card model.Card) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schedule", card)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Schedule indicates an expected call of Schedule.
+func (mr *MockCardReminderServiceMockRecorder) Schedule(card interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schedule", reflect.TypeOf((*MockCardReminderService)(nil).Schedule), card)
+}
+
+// SendDue mocks base method.
+func (m *MockCardReminderService) SendDue() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDue")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendDue indicates an expected call of SendDue.
+func (mr *MockCardReminderServiceMockRecorder) SendDue() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDue", reflect.TypeOf((*MockCardReminderService)(nil).SendDue))
+}
@@ -0,0 +1,167 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/checklist-service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/kuritaeiji/todo-gin-back/model"
+)
+
+// MockChecklistService is a mock of ChecklistService interface.
+type MockChecklistService struct {
+	ctrl     *gomock.Controller
+	recorder *MockChecklistServiceMockRecorder
+}
+
+// MockChecklistServiceMockRecorder is the mock recorder for MockChecklistService.
+type MockChecklistServiceMockRecorder struct {
+	mock *MockChecklistService
+}
+
+// NewMockChecklistService creates a new mock instance.
+func NewMockChecklistService(ctrl *gomock.Controller) *MockChecklistService {
+	mock := &MockChecklistService{ctrl: ctrl}
+	mock.recorder = &MockChecklistServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChecklistService) EXPECT() *MockChecklistServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockChecklistService) Create(arg0 *gin.Context) (model.Checklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(model.Checklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockChecklistServiceMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockChecklistService)(nil).Create), arg0)
+}
+
+// CreateItem mocks base method.
+func (m *MockChecklistService) CreateItem(arg0 *gin.Context) (model.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItem", arg0)
+	ret0, _ := ret[0].(model.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateItem indicates an expected call of CreateItem.
+func (mr *MockChecklistServiceMockRecorder) CreateItem(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItem", reflect.TypeOf((*MockChecklistService)(nil).CreateItem), arg0)
+}
+
+// Destroy mocks base method.
+func (m *MockChecklistService) Destroy(arg0 *gin.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Destroy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Destroy indicates an expected call of Destroy.
+func (mr *MockChecklistServiceMockRecorder) Destroy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Destroy", reflect.TypeOf((*MockChecklistService)(nil).Destroy), arg0)
+}
+
+// DestroyItem mocks base method.
+func (m *MockChecklistService) DestroyItem(arg0 *gin.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyItem", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyItem indicates an expected call of DestroyItem.
+func (mr *MockChecklistServiceMockRecorder) DestroyItem(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyItem", reflect.TypeOf((*MockChecklistService)(nil).DestroyItem), arg0)
+}
+
+// Index mocks base method.
+func (m *MockChecklistService) Index(arg0 *gin.Context) ([]model.Checklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Index", arg0)
+	ret0, _ := ret[0].([]model.Checklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Index indicates an expected call of Index.
+func (mr *MockChecklistServiceMockRecorder) Index(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockChecklistService)(nil).Index), arg0)
+}
+
+// Move mocks base method.
+func (m *MockChecklistService) Move(arg0 *gin.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockChecklistServiceMockRecorder) Move(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockChecklistService)(nil).Move), arg0)
+}
+
+// MoveItem mocks base method.
+func (m *MockChecklistService) MoveItem(arg0 *gin.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveItem", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveItem indicates an expected call of MoveItem.
+func (mr *MockChecklistServiceMockRecorder) MoveItem(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveItem", reflect.TypeOf((*MockChecklistService)(nil).MoveItem), arg0)
+}
+
+// Update mocks base method.
+func (m *MockChecklistService) Update(arg0 *gin.Context) (model.Checklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(model.Checklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockChecklistServiceMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockChecklistService)(nil).Update), arg0)
+}
+
+// UpdateItem mocks base method.
+func (m *MockChecklistService) UpdateItem(arg0 *gin.Context) (model.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", arg0)
+	ret0, _ := ret[0].(model.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateItem indicates an expected call of UpdateItem.
+func (mr *MockChecklistServiceMockRecorder) UpdateItem(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockChecklistService)(nil).UpdateItem), arg0)
+}
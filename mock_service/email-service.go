@@ -6,6 +6,7 @@ package mock_service
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	model "github.com/kuritaeiji/todo-gin-back/model"
@@ -34,6 +35,20 @@ func (m *MockEmailService) EXPECT() *MockEmailServiceMockRecorder {
 	return m.recorder
 }
 
+// AccountLockedEmail mocks base method.
+func (m *MockEmailService) AccountLockedEmail(user model.User, lockedUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccountLockedEmail", user, lockedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AccountLockedEmail indicates an expected call of AccountLockedEmail.
+func (mr *MockEmailServiceMockRecorder) AccountLockedEmail(user, lockedUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountLockedEmail", reflect.TypeOf((*MockEmailService)(nil).AccountLockedEmail), user, lockedUntil)
+}
+
 // ActivationUserEmail mocks base method.
 func (m *MockEmailService) ActivationUserEmail(arg0 model.User) error {
 	m.ctrl.T.Helper()
@@ -47,3 +62,59 @@ func (mr *MockEmailServiceMockRecorder) ActivationUserEmail(arg0 interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivationUserEmail", reflect.TypeOf((*MockEmailService)(nil).ActivationUserEmail), arg0)
 }
+
+// BoardInvitationEmail mocks base method.
+func (m *MockEmailService) BoardInvitationEmail(user model.User, board model.Board, inviter model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BoardInvitationEmail", user, board, inviter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BoardInvitationEmail indicates an expected call of BoardInvitationEmail.
+func (mr *MockEmailServiceMockRecorder) BoardInvitationEmail(user, board, inviter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BoardInvitationEmail", reflect.TypeOf((*MockEmailService)(nil).BoardInvitationEmail), user, board, inviter)
+}
+
+// CardReminderEmail mocks base method.
+func (m *MockEmailService) CardReminderEmail(user model.User, card model.Card) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CardReminderEmail", user, card)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CardReminderEmail indicates an expected call of CardReminderEmail.
+func (mr *MockEmailServiceMockRecorder) CardReminderEmail(user, card interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CardReminderEmail", reflect.TypeOf((*MockEmailService)(nil).CardReminderEmail), user, card)
+}
+
+// EmailChangeEmail mocks base method.
+func (m *MockEmailService) EmailChangeEmail(email, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EmailChangeEmail", email, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EmailChangeEmail indicates an expected call of EmailChangeEmail.
+func (mr *MockEmailServiceMockRecorder) EmailChangeEmail(email, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmailChangeEmail", reflect.TypeOf((*MockEmailService)(nil).EmailChangeEmail), email, token)
+}
+
+// PasswordResetEmail mocks base method.
+func (m *MockEmailService) PasswordResetEmail(user model.User, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PasswordResetEmail", user, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PasswordResetEmail indicates an expected call of PasswordResetEmail.
+func (mr *MockEmailServiceMockRecorder) PasswordResetEmail(user, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordResetEmail", reflect.TypeOf((*MockEmailService)(nil).PasswordResetEmail), user, token)
+}
@@ -35,6 +35,34 @@ func (m *MockJWTService) EXPECT() *MockJWTServiceMockRecorder {
 	return m.recorder
 }
 
+// CreateAccessJWT mocks base method.
+func (m *MockJWTService) CreateAccessJWT(user model.User, sessionID string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccessJWT", user, sessionID)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// CreateAccessJWT indicates an expected call of CreateAccessJWT.
+func (mr *MockJWTServiceMockRecorder) CreateAccessJWT(user, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccessJWT", reflect.TypeOf((*MockJWTService)(nil).CreateAccessJWT), user, sessionID)
+}
+
+// CreateChallengeJWT mocks base method.
+func (m *MockJWTService) CreateChallengeJWT(user model.User) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChallengeJWT", user)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// CreateChallengeJWT indicates an expected call of CreateChallengeJWT.
+func (mr *MockJWTServiceMockRecorder) CreateChallengeJWT(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChallengeJWT", reflect.TypeOf((*MockJWTService)(nil).CreateChallengeJWT), user)
+}
+
 // CreateJWT mocks base method.
 func (m *MockJWTService) CreateJWT(user model.User, dayFromNow int) string {
 	m.ctrl.T.Helper()
@@ -49,6 +77,20 @@ func (mr *MockJWTServiceMockRecorder) CreateJWT(user, dayFromNow interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJWT", reflect.TypeOf((*MockJWTService)(nil).CreateJWT), user, dayFromNow)
 }
 
+// JWKS mocks base method.
+func (m *MockJWTService) JWKS() service.JWKSet {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JWKS")
+	ret0, _ := ret[0].(service.JWKSet)
+	return ret0
+}
+
+// JWKS indicates an expected call of JWKS.
+func (mr *MockJWTServiceMockRecorder) JWKS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JWKS", reflect.TypeOf((*MockJWTService)(nil).JWKS))
+}
+
 // VerifyJWT mocks base method.
 func (m *MockJWTService) VerifyJWT(tokdnString string) (*service.UserClaim, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/label-service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/kuritaeiji/todo-gin-back/model"
+)
+
+// MockLabelService is a mock of LabelService interface.
+type MockLabelService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLabelServiceMockRecorder
+}
+
+// MockLabelServiceMockRecorder is the mock recorder for MockLabelService.
+type MockLabelServiceMockRecorder struct {
+	mock *MockLabelService
+}
+
+// NewMockLabelService creates a new mock instance.
+func NewMockLabelService(ctrl *gomock.Controller) *MockLabelService {
+	mock := &MockLabelService{ctrl: ctrl}
+	mock.recorder = &MockLabelServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLabelService) EXPECT() *MockLabelServiceMockRecorder {
+	return m.recorder
+}
+
+// Attach mocks base method.
+func (m *MockLabelService) Attach(arg0 *gin.Context) (model.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attach", arg0)
+	ret0, _ := ret[0].(model.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockLabelServiceMockRecorder) Attach(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockLabelService)(nil).Attach), arg0)
+}
+
+// Create mocks base method.
+func (m *MockLabelService) Create(arg0 *gin.Context) (model.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(model.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLabelServiceMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLabelService)(nil).Create), arg0)
+}
+
+// Destroy mocks base method.
+func (m *MockLabelService) Destroy(arg0 *gin.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Destroy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Destroy indicates an expected call of Destroy.
+func (mr *MockLabelServiceMockRecorder) Destroy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Destroy", reflect.TypeOf((*MockLabelService)(nil).Destroy), arg0)
+}
+
+// Detach mocks base method.
+func (m *MockLabelService) Detach(arg0 *gin.Context) (model.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Detach", arg0)
+	ret0, _ := ret[0].(model.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Detach indicates an expected call of Detach.
+func (mr *MockLabelServiceMockRecorder) Detach(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detach", reflect.TypeOf((*MockLabelService)(nil).Detach), arg0)
+}
+
+// Index mocks base method.
+func (m *MockLabelService) Index(arg0 *gin.Context) ([]model.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Index", arg0)
+	ret0, _ := ret[0].([]model.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Index indicates an expected call of Index.
+func (mr *MockLabelServiceMockRecorder) Index(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockLabelService)(nil).Index), arg0)
+}
+
+// Update mocks base method.
+func (m *MockLabelService) Update(arg0 *gin.Context) (model.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(model.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockLabelServiceMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLabelService)(nil).Update), arg0)
+}
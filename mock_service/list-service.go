@@ -35,6 +35,20 @@ func (m *MockListService) EXPECT() *MockListServiceMockRecorder {
 	return m.recorder
 }
 
+// Archive mocks base method.
+func (m *MockListService) Archive(arg0 *gin.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockListServiceMockRecorder) Archive(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockListService)(nil).Archive), arg0)
+}
+
 // Create mocks base method.
 func (m *MockListService) Create(arg0 *gin.Context) (model.List, error) {
 	m.ctrl.T.Helper()
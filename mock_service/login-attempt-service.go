@@ -0,0 +1,106 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/login-attempt-service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/kuritaeiji/todo-gin-back/model"
+)
+
+// MockLoginAttemptService is a mock of LoginAttemptService interface.
+type MockLoginAttemptService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginAttemptServiceMockRecorder
+}
+
+// MockLoginAttemptServiceMockRecorder is the mock recorder for MockLoginAttemptService.
+type MockLoginAttemptServiceMockRecorder struct {
+	mock *MockLoginAttemptService
+}
+
+// NewMockLoginAttemptService creates a new mock instance.
+func NewMockLoginAttemptService(ctrl *gomock.Controller) *MockLoginAttemptService {
+	mock := &MockLoginAttemptService{ctrl: ctrl}
+	mock.recorder = &MockLoginAttemptServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginAttemptService) EXPECT() *MockLoginAttemptServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckIP mocks base method.
+func (m *MockLoginAttemptService) CheckIP(ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckIP", ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckIP indicates an expected call of CheckIP.
+func (mr *MockLoginAttemptServiceMockRecorder) CheckIP(ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckIP", reflect.TypeOf((*MockLoginAttemptService)(nil).CheckIP), ip)
+}
+
+// CheckUser mocks base method.
+func (m *MockLoginAttemptService) CheckUser(user model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckUser", user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckUser indicates an expected call of CheckUser.
+func (mr *MockLoginAttemptServiceMockRecorder) CheckUser(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUser", reflect.TypeOf((*MockLoginAttemptService)(nil).CheckUser), user)
+}
+
+// Fail mocks base method.
+func (m *MockLoginAttemptService) Fail(ip string, user *model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", ip, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockLoginAttemptServiceMockRecorder) Fail(ip, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockLoginAttemptService)(nil).Fail), ip, user)
+}
+
+// Index mocks base method.
+func (m *MockLoginAttemptService) Index() ([]model.LoginAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Index")
+	ret0, _ := ret[0].([]model.LoginAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Index indicates an expected call of Index.
+func (mr *MockLoginAttemptServiceMockRecorder) Index() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockLoginAttemptService)(nil).Index))
+}
+
+// Succeed mocks base method.
+func (m *MockLoginAttemptService) Succeed(user model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Succeed", user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Succeed indicates an expected call of Succeed.
+func (mr *MockLoginAttemptServiceMockRecorder) Succeed(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Succeed", reflect.TypeOf((*MockLoginAttemptService)(nil).Succeed), user)
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/refresh-token-service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/kuritaeiji/todo-gin-back/model"
+)
+
+// MockRefreshTokenService is a mock of RefreshTokenService interface.
+type MockRefreshTokenService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefreshTokenServiceMockRecorder
+}
+
+// MockRefreshTokenServiceMockRecorder is the mock recorder for MockRefreshTokenService.
+type MockRefreshTokenServiceMockRecorder struct {
+	mock *MockRefreshTokenService
+}
+
+// NewMockRefreshTokenService creates a new mock instance.
+func NewMockRefreshTokenService(ctrl *gomock.Controller) *MockRefreshTokenService {
+	mock := &MockRefreshTokenService{ctrl: ctrl}
+	mock.recorder = &MockRefreshTokenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefreshTokenService) EXPECT() *MockRefreshTokenServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRefreshTokenService) Create(user model.User, family string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", user, family)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRefreshTokenServiceMockRecorder) Create(user, family interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRefreshTokenService)(nil).Create), user, family)
+}
+
+// Revoke mocks base method.
+func (m *MockRefreshTokenService) Revoke(family string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", family)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockRefreshTokenServiceMockRecorder) Revoke(family interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockRefreshTokenService)(nil).Revoke), family)
+}
+
+// Rotate mocks base method.
+func (m *MockRefreshTokenService) Rotate(tokenString string) (model.RefreshToken, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", tokenString)
+	ret0, _ := ret[0].(model.RefreshToken)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockRefreshTokenServiceMockRecorder) Rotate(tokenString interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockRefreshTokenService)(nil).Rotate), tokenString)
+}
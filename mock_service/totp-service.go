@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/totp-service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/kuritaeiji/todo-gin-back/model"
+)
+
+// MockTotpService is a mock of TotpService interface.
+type MockTotpService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTotpServiceMockRecorder
+}
+
+// MockTotpServiceMockRecorder is the mock recorder for MockTotpService.
+type MockTotpServiceMockRecorder struct {
+	mock *MockTotpService
+}
+
+// NewMockTotpService creates a new mock instance.
+func NewMockTotpService(ctrl *gomock.Controller) *MockTotpService {
+	mock := &MockTotpService{ctrl: ctrl}
+	mock.recorder = &MockTotpServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTotpService) EXPECT() *MockTotpServiceMockRecorder {
+	return m.recorder
+}
+
+// Disable mocks base method.
+func (m *MockTotpService) Disable(arg0 *gin.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockTotpServiceMockRecorder) Disable(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockTotpService)(nil).Disable), arg0)
+}
+
+// Enable mocks base method.
+func (m *MockTotpService) Enable(arg0 *gin.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enable", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enable indicates an expected call of Enable.
+func (mr *MockTotpServiceMockRecorder) Enable(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enable", reflect.TypeOf((*MockTotpService)(nil).Enable), arg0)
+}
+
+// Enroll mocks base method.
+func (m *MockTotpService) Enroll(arg0 *gin.Context) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enroll", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Enroll indicates an expected call of Enroll.
+func (mr *MockTotpServiceMockRecorder) Enroll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enroll", reflect.TypeOf((*MockTotpService)(nil).Enroll), arg0)
+}
+
+// Verify mocks base method.
+func (m *MockTotpService) Verify(user model.User, code string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", user, code)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockTotpServiceMockRecorder) Verify(user, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockTotpService)(nil).Verify), user, code)
+}
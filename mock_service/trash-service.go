@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/trash-service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/kuritaeiji/todo-gin-back/model"
+)
+
+// MockTrashService is a mock of TrashService interface.
+type MockTrashService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashServiceMockRecorder
+}
+
+// MockTrashServiceMockRecorder is the mock recorder for MockTrashService.
+type MockTrashServiceMockRecorder struct {
+	mock *MockTrashService
+}
+
+// NewMockTrashService creates a new mock instance.
+func NewMockTrashService(ctrl *gomock.Controller) *MockTrashService {
+	mock := &MockTrashService{ctrl: ctrl}
+	mock.recorder = &MockTrashServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrashService) EXPECT() *MockTrashServiceMockRecorder {
+	return m.recorder
+}
+
+// DestroyCard mocks base method.
+func (m *MockTrashService) DestroyCard(arg0 *gin.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyCard", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyCard indicates an expected call of DestroyCard.
+func (mr *MockTrashServiceMockRecorder) DestroyCard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyCard", reflect.TypeOf((*MockTrashService)(nil).DestroyCard), arg0)
+}
+
+// DestroyList mocks base method.
+func (m *MockTrashService) DestroyList(arg0 *gin.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyList", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyList indicates an expected call of DestroyList.
+func (mr *MockTrashServiceMockRecorder) DestroyList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyList", reflect.TypeOf((*MockTrashService)(nil).DestroyList), arg0)
+}
+
+// Index mocks base method.
+func (m *MockTrashService) Index(arg0 *gin.Context) ([]model.List, []model.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Index", arg0)
+	ret0, _ := ret[0].([]model.List)
+	ret1, _ := ret[1].([]model.Card)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Index indicates an expected call of Index.
+func (mr *MockTrashServiceMockRecorder) Index(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockTrashService)(nil).Index), arg0)
+}
+
+// RestoreCard mocks base method.
+func (m *MockTrashService) RestoreCard(arg0 *gin.Context) (model.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCard", arg0)
+	ret0, _ := ret[0].(model.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreCard indicates an expected call of RestoreCard.
+func (mr *MockTrashServiceMockRecorder) RestoreCard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCard", reflect.TypeOf((*MockTrashService)(nil).RestoreCard), arg0)
+}
+
+// RestoreList mocks base method.
+func (m *MockTrashService) RestoreList(arg0 *gin.Context) (model.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreList", arg0)
+	ret0, _ := ret[0].(model.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreList indicates an expected call of RestoreList.
+func (mr *MockTrashServiceMockRecorder) RestoreList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreList", reflect.TypeOf((*MockTrashService)(nil).RestoreList), arg0)
+}
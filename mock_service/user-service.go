@@ -10,6 +10,7 @@ import (
 	gin "github.com/gin-gonic/gin"
 	gomock "github.com/golang/mock/gomock"
 	model "github.com/kuritaeiji/todo-gin-back/model"
+	service "github.com/kuritaeiji/todo-gin-back/service"
 )
 
 // MockUserService is a mock of UserService interface.
@@ -49,6 +50,49 @@ func (mr *MockUserServiceMockRecorder) Activate(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Activate", reflect.TypeOf((*MockUserService)(nil).Activate), arg0)
 }
 
+// ChangeEmail mocks base method.
+func (m *MockUserService) ChangeEmail(arg0 *gin.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeEmail", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeEmail indicates an expected call of ChangeEmail.
+func (mr *MockUserServiceMockRecorder) ChangeEmail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeEmail", reflect.TypeOf((*MockUserService)(nil).ChangeEmail), arg0)
+}
+
+// ChangePassword mocks base method.
+func (m *MockUserService) ChangePassword(arg0 *gin.Context) (service.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", arg0)
+	ret0, _ := ret[0].(service.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockUserServiceMockRecorder) ChangePassword(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockUserService)(nil).ChangePassword), arg0)
+}
+
+// ConfirmEmail mocks base method.
+func (m *MockUserService) ConfirmEmail(arg0 *gin.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEmail", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmEmail indicates an expected call of ConfirmEmail.
+func (mr *MockUserServiceMockRecorder) ConfirmEmail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEmail", reflect.TypeOf((*MockUserService)(nil).ConfirmEmail), arg0)
+}
+
 // Create mocks base method.
 func (m *MockUserService) Create(arg0 *gin.Context) (model.User, error) {
 	m.ctrl.T.Helper()
@@ -92,3 +136,17 @@ func (mr *MockUserServiceMockRecorder) IsUnique(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUnique", reflect.TypeOf((*MockUserService)(nil).IsUnique), arg0)
 }
+
+// ResendActivation mocks base method.
+func (m *MockUserService) ResendActivation(arg0 *gin.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendActivation", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendActivation indicates an expected call of ResendActivation.
+func (mr *MockUserServiceMockRecorder) ResendActivation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendActivation", reflect.TypeOf((*MockUserService)(nil).ResendActivation), arg0)
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// カードの添付ファイル 本体はStorageKeyでストレージに保存する
+// ContentTypeはアップロード時にファイルの中身から判定した値 Sizeはバイト数
+type Attachment struct {
+	gorm.Model
+	ID          int    `gorm:"primaryKey;autoIncrement;not null"`
+	FileName    string `gorm:"type:varchar(255)"`
+	ContentType string `gorm:"type:varchar(255)"`
+	Size        int64
+	StorageKey  string `gorm:"type:varchar(255);unique"`
+	CardID      int    `gorm:"index"`
+	Card        Card   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	// 容量の制限はアップロードしたユーザーごとに数える
+	UserID int  `gorm:"index"`
+	User   User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (attachment *Attachment) ToJson() gin.H {
+	return gin.H{
+		"id":          attachment.ID,
+		"fileName":    attachment.FileName,
+		"contentType": attachment.ContentType,
+		"size":        attachment.Size,
+		"createdAt":   attachment.CreatedAt,
+	}
+}
+
+func ToJsonAttachmentSlice(attachments []Attachment) []gin.H {
+	jsonAttachments := make([]gin.H, 0, len(attachments))
+	for _, attachment := range attachments {
+		jsonAttachments = append(jsonAttachments, attachment.ToJson())
+	}
+	return jsonAttachments
+}
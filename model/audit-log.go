@@ -0,0 +1,64 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// 監査ログに記録する認証関連のイベント
+const (
+	AuditEventLoginSucceeded  = "login.succeeded"
+	AuditEventLoginFailed     = "login.failed"
+	AuditEventOauthLogin      = "oauth.login"
+	AuditEventActivated       = "user.activated"
+	AuditEventPasswordChanged = "password.changed"
+	AuditEventPasswordReset   = "password.reset"
+	AuditEventUserDeleted     = "user.deleted"
+	AuditEventLogout          = "logout"
+	AuditEventLogoutAll       = "logout.all"
+	AuditEventSessionRevoked  = "session.revoked"
+	AuditEventEmailChanged    = "email.changed"
+	AuditEventTotpEnabled     = "totp.enabled"
+	AuditEventTotpDisabled    = "totp.disabled"
+	// Detailには失敗した操作を保存する
+	AuditEventTotpFailed = "totp.failed"
+	// Detailにはトークンのidを保存する
+	AuditEventPersonalAccessTokenCreated = "personal_access_token.created"
+	AuditEventPersonalAccessTokenRevoked = "personal_access_token.revoked"
+)
+
+// 認証に関するイベントの記録
+// ユーザーの削除後も残すのでusersへの外部キーは持たない 存在しないメールアドレスでのログイン失敗はUserIDがnilになる
+// DetailにはOAuthのプロバイダー名やログインに失敗したメールアドレス等を保存する
+type AuditLog struct {
+	gorm.Model
+	ID        int       `gorm:"primaryKey;autoIncrement;not null"`
+	CreatedAt time.Time `gorm:"index"`
+	Event     string    `gorm:"type:varchar(64);index;not null"`
+	UserID    *int      `gorm:"index"`
+	Detail    string    `gorm:"type:varchar(255)"`
+	IP        string    `gorm:"type:varchar(64)"`
+	UserAgent string    `gorm:"type:varchar(255)"`
+}
+
+func (log *AuditLog) ToJson() gin.H {
+	return gin.H{
+		"id":        log.ID,
+		"event":     log.Event,
+		"userId":    log.UserID,
+		"detail":    log.Detail,
+		"ip":        log.IP,
+		"userAgent": log.UserAgent,
+		"createdAt": log.CreatedAt,
+	}
+}
+
+func ToJsonAuditLogSlice(logs []AuditLog) []gin.H {
+	jsonLogs := make([]gin.H, 0, len(logs))
+	for _, log := range logs {
+		jsonLogs = append(jsonLogs, log.ToJson())
+	}
+	return jsonLogs
+}
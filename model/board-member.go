@@ -0,0 +1,84 @@
+package model
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	BoardRoleOwner  = "owner"
+	BoardRoleEditor = "editor"
+	BoardRoleViewer = "viewer"
+)
+
+// 数字が大きいほど多くの操作ができる
+var boardRoleLevels = map[string]int{
+	BoardRoleViewer: 1,
+	BoardRoleEditor: 2,
+	BoardRoleOwner:  3,
+}
+
+// ボードを共有するユーザー 招待を承諾するまでAcceptedAtはnil
+type BoardMember struct {
+	gorm.Model
+	ID         int    `gorm:"primaryKey;autoIncrement;not null"`
+	BoardID    int    `gorm:"uniqueIndex:idx_board_members_board_id_user_id"`
+	Board      Board  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	UserID     int    `gorm:"uniqueIndex:idx_board_members_board_id_user_id;index"`
+	User       User   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Role       string `gorm:"type:varchar(10);not null"`
+	AcceptedAt *time.Time
+}
+
+// 招待を承諾していないメンバーは何もできない
+func (member *BoardMember) Can(role string) bool {
+	return member.AcceptedAt != nil && boardRoleLevels[member.Role] >= boardRoleLevels[role]
+}
+
+// 参照はviewer、それ以外はeditorの権限が必要
+func RequiredBoardRole(method string) string {
+	if method == http.MethodGet || method == http.MethodHead {
+		return BoardRoleViewer
+	}
+	return BoardRoleEditor
+}
+
+// Userをpreloadしておく
+func (member *BoardMember) ToJson() gin.H {
+	return gin.H{
+		"id":       member.ID,
+		"userId":   member.UserID,
+		"email":    member.User.Email,
+		"role":     member.Role,
+		"accepted": member.AcceptedAt != nil,
+	}
+}
+
+func ToJsonBoardMemberSlice(members []BoardMember) []gin.H {
+	jsonMemberSlice := make([]gin.H, 0, len(members))
+	for _, member := range members {
+		jsonMemberSlice = append(jsonMemberSlice, member.ToJson())
+	}
+	return jsonMemberSlice
+}
+
+// Boardをpreloadしておく
+func (member *BoardMember) ToInvitationJson() gin.H {
+	return gin.H{
+		"id":         member.ID,
+		"boardId":    member.BoardID,
+		"boardTitle": member.Board.Title,
+		"role":       member.Role,
+	}
+}
+
+func ToInvitationJsonSlice(members []BoardMember) []gin.H {
+	jsonInvitationSlice := make([]gin.H, 0, len(members))
+	for _, member := range members {
+		jsonInvitationSlice = append(jsonInvitationSlice, member.ToInvitationJson())
+	}
+	return jsonInvitationSlice
+}
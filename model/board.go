@@ -0,0 +1,35 @@
+package model
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ユーザーは複数のボードを持ち、リストはいずれかのボードに属する
+// UserIDは作成したユーザー 操作の権限はMembersのロールで確認する
+type Board struct {
+	gorm.Model
+	ID      int    `gorm:"primaryKey;autoIncrement;not null"`
+	Title   string `gorm:"type:varchar(50);not null"`
+	Index   int
+	UserID  int  `gorm:"index"`
+	User    User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Lists   []List
+	Members []BoardMember
+}
+
+func (board *Board) ToJson() gin.H {
+	return gin.H{
+		"id":    board.ID,
+		"title": board.Title,
+		"index": board.Index,
+	}
+}
+
+func ToJsonBoardSlice(boards []Board) []gin.H {
+	jsonBoardSlice := make([]gin.H, 0, len(boards))
+	for _, board := range boards {
+		jsonBoardSlice = append(jsonBoardSlice, board.ToJson())
+	}
+	return jsonBoardSlice
+}
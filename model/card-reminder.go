@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// カードの期限の前に送るリマインダー 期限を変更すると未送信のものは作り直す
+// OffsetMinutesは期限の何分前に送るか
+type CardReminder struct {
+	gorm.Model
+	ID            int       `gorm:"primaryKey;autoIncrement;not null"`
+	RemindAt      time.Time `gorm:"index"`
+	OffsetMinutes int
+	SentAt        *time.Time
+	CardID        int  `gorm:"index"`
+	Card          Card `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
@@ -1,24 +1,94 @@
 package model
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/markdown"
 	"gorm.io/gorm"
 )
 
 type Card struct {
 	gorm.Model
-	ID     int    `gorm:"primaryKey;autoIncrement;not null"`
-	Title  string `gorm:"type:varchar(100)"`
-	Index  int
-	ListID int
-	List   List `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ID    int    `gorm:"primaryKey;autoIncrement;not null"`
+	Title string `gorm:"type:varchar(100)"`
+	// Markdown
+	Description string `gorm:"type:text"`
+	// UTCで保存する Timezoneはリマインダーメールで日時を表示する際に使うIANAのタイムゾーン名 空の場合はUTC
+	StartAt  *time.Time
+	DueAt    *time.Time `gorm:"index"`
+	Timezone string     `gorm:"type:varchar(64)"`
+	Index    int
+	ListID   int
+	List     List    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Labels   []Label `gorm:"many2many:card_labels;"`
+	// 一覧では項目の完了数のみを返す
+	Checklists []Checklist
+	// 削除されていないコメントの数 カードを取得する際にまとめて数える
+	CommentCount int `gorm:"-"`
+	// アーカイブ中のカードは一覧に表示しない ゴミ箱から元に戻せる
+	ArchivedAt *time.Time `gorm:"index"`
 }
 
 func (card *Card) ToJson() gin.H {
 	return gin.H{
-		"id":    card.ID,
-		"title": card.Title,
+		"id":              card.ID,
+		"title":           card.Title,
+		"description":     card.Description,
+		"descriptionHtml": card.DescriptionHTML(),
+		"startAt":         card.StartAt,
+		"dueAt":           card.DueAt,
+		"timezone":        card.Timezone,
+		"labels":          ToJsonLabelSlice(card.Labels),
+		"checklistItems":  card.ChecklistProgress(),
+		"commentCount":    card.CommentCount,
+	}
+}
+
+// ゴミ箱の一覧で使う indexは元に戻す際の位置
+func (card *Card) ToTrashJson() gin.H {
+	json := card.ToJson()
+	json["listId"] = card.ListID
+	json["index"] = card.Index
+	json["archivedAt"] = card.ArchivedAt
+	json["deletedAt"] = deletedAt(card.DeletedAt)
+	return json
+}
+
+// 全チェックリストの項目数と完了した項目数 例: {"done": 3, "total": 5}
+func (card *Card) ChecklistProgress() gin.H {
+	done, total := 0, 0
+	for _, checklist := range card.Checklists {
+		for _, item := range checklist.Items {
+			total++
+			if item.Done {
+				done++
+			}
+		}
+	}
+	return gin.H{"done": done, "total": total}
+}
+
+// Timezoneが不正な場合もUTCにする
+func (card *Card) Location() *time.Location {
+	location, err := time.LoadLocation(card.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// 説明をサニタイズ済みのHTMLに変換する クライアントはそのまま表示できる
+func (card *Card) DescriptionHTML() string {
+	if card.Description == "" {
+		return ""
 	}
+
+	html, err := markdown.Render(card.Description)
+	if err != nil {
+		return ""
+	}
+	return html
 }
 
 func ToJsonCardSlice(cards []Card) []gin.H {
@@ -28,3 +98,11 @@ func ToJsonCardSlice(cards []Card) []gin.H {
 	}
 	return jsonCardSlice
 }
+
+func ToTrashJsonCardSlice(cards []Card) []gin.H {
+	jsonCardSlice := make([]gin.H, 0, len(cards))
+	for _, card := range cards {
+		jsonCardSlice = append(jsonCardSlice, card.ToTrashJson())
+	}
+	return jsonCardSlice
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// カードごとのチェックリスト Indexはカード内での並び順
+type Checklist struct {
+	gorm.Model
+	ID     int    `gorm:"primaryKey;autoIncrement;not null"`
+	Title  string `gorm:"type:varchar(100)"`
+	Index  int
+	CardID int             `gorm:"index"`
+	Card   Card            `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Items  []ChecklistItem `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// Indexはチェックリスト内での並び順
+type ChecklistItem struct {
+	gorm.Model
+	ID          int    `gorm:"primaryKey;autoIncrement;not null"`
+	Title       string `gorm:"type:varchar(255)"`
+	Done        bool
+	Index       int
+	ChecklistID int `gorm:"index"`
+}
+
+func (checklist *Checklist) ToJson() gin.H {
+	return gin.H{
+		"id":    checklist.ID,
+		"title": checklist.Title,
+		"index": checklist.Index,
+		"items": ToJsonChecklistItemSlice(checklist.Items),
+	}
+}
+
+func ToJsonChecklistSlice(checklists []Checklist) []gin.H {
+	jsonChecklists := make([]gin.H, 0, len(checklists))
+	for _, checklist := range checklists {
+		jsonChecklists = append(jsonChecklists, checklist.ToJson())
+	}
+	return jsonChecklists
+}
+
+func (item *ChecklistItem) ToJson() gin.H {
+	return gin.H{
+		"id":    item.ID,
+		"title": item.Title,
+		"done":  item.Done,
+		"index": item.Index,
+	}
+}
+
+func ToJsonChecklistItemSlice(items []ChecklistItem) []gin.H {
+	jsonItems := make([]gin.H, 0, len(items))
+	for _, item := range items {
+		jsonItems = append(jsonItems, item.ToJson())
+	}
+	return jsonItems
+}
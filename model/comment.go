@@ -0,0 +1,50 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/markdown"
+	"gorm.io/gorm"
+)
+
+// カードへのコメント BodyはMarkdown EditedAtは作成者が最後に編集した日時
+type Comment struct {
+	gorm.Model
+	ID        int       `gorm:"primaryKey;autoIncrement;not null"`
+	CreatedAt time.Time `gorm:"index"`
+	Body      string    `gorm:"type:text"`
+	EditedAt  *time.Time
+	CardID    int  `gorm:"index"`
+	Card      Card `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	UserID    int  `gorm:"index"`
+	User      User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (comment *Comment) ToJson() gin.H {
+	return gin.H{
+		"id":        comment.ID,
+		"body":      comment.Body,
+		"bodyHtml":  comment.BodyHTML(),
+		"author":    gin.H{"id": comment.User.ID, "email": comment.User.Email},
+		"createdAt": comment.CreatedAt,
+		"editedAt":  comment.EditedAt,
+	}
+}
+
+// 本文をサニタイズ済みのHTMLに変換する
+func (comment *Comment) BodyHTML() string {
+	html, err := markdown.Render(comment.Body)
+	if err != nil {
+		return ""
+	}
+	return html
+}
+
+func ToJsonCommentSlice(comments []Comment) []gin.H {
+	jsonComments := make([]gin.H, 0, len(comments))
+	for _, comment := range comments {
+		jsonComments = append(jsonComments, comment.ToJson())
+	}
+	return jsonComments
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// メールアドレス変更の確認用トークン 確認されるまでは古いメールアドレスを使う
+type EmailChange struct {
+	gorm.Model
+	ID        int    `gorm:"primaryKey;autoIncrement;not null"`
+	Digest    string `gorm:"type:varchar(64);uniqueIndex;not null"`
+	Email     string `gorm:"type:varchar(100);not null"`
+	ExpiresAt time.Time
+	UserID    int
+	User      User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (change *EmailChange) IsExpired() bool {
+	return time.Now().After(change.ExpiresAt)
+}
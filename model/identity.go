@@ -0,0 +1,16 @@
+package model
+
+import (
+	"gorm.io/gorm"
+)
+
+// 外部のIDプロバイダーのアカウント (provider, subject)の組でユーザーを特定する
+// 1人のユーザーは1つのプロバイダーにつき1つのアカウントのみ紐付けられる
+type Identity struct {
+	gorm.Model
+	ID       int    `gorm:"primaryKey;autoIncrement;not null" json:"id"`
+	Provider string `gorm:"type:varchar(50);uniqueIndex:idx_identities_provider_subject;uniqueIndex:idx_identities_user_provider;not null" json:"provider"`
+	Subject  string `gorm:"type:varchar(256);uniqueIndex:idx_identities_provider_subject;not null" json:"-"`
+	UserID   int    `gorm:"uniqueIndex:idx_identities_user_provider" json:"-"`
+	User     User   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
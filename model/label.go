@@ -0,0 +1,32 @@
+package model
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ユーザーごとのラベル Colorは#rrggbbもしくは#rgb
+type Label struct {
+	gorm.Model
+	ID     int    `gorm:"primaryKey;autoIncrement;not null"`
+	Name   string `gorm:"type:varchar(50);not null"`
+	Color  string `gorm:"type:varchar(7);not null"`
+	UserID int    `gorm:"index"`
+	User   User   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (label *Label) ToJson() gin.H {
+	return gin.H{
+		"id":    label.ID,
+		"name":  label.Name,
+		"color": label.Color,
+	}
+}
+
+func ToJsonLabelSlice(labels []Label) []gin.H {
+	jsonLabels := make([]gin.H, 0, len(labels))
+	for _, label := range labels {
+		jsonLabels = append(jsonLabels, label.ToJson())
+	}
+	return jsonLabels
+}
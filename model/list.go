@@ -1,18 +1,22 @@
 package model
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
 type List struct {
 	gorm.Model
-	ID     int    `gorm:"primaryKey;autoIncrement;not null" json:"id"`
-	Title  string `gorm:"type:varchar(50);not null" json:"title"`
-	Index  int    `json:"index"`
-	UserID int    `json:"userID"`
-	User   User   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	Cards  []Card
+	ID      int    `gorm:"primaryKey;autoIncrement;not null" json:"id"`
+	Title   string `gorm:"type:varchar(50);not null" json:"title"`
+	Index   int    `json:"index"`
+	BoardID int    `gorm:"index" json:"boardID"`
+	Board   Board  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Cards   []Card
+	// アーカイブ中のリストは一覧に表示しない ゴミ箱から元に戻せる
+	ArchivedAt *time.Time `gorm:"index"`
 }
 
 func (list *List) ToJson() gin.H {
@@ -23,6 +27,18 @@ func (list *List) ToJson() gin.H {
 	}
 }
 
+// ゴミ箱の一覧で使う indexは元に戻す際の位置
+func (list *List) ToTrashJson() gin.H {
+	return gin.H{
+		"id":         list.ID,
+		"title":      list.Title,
+		"index":      list.Index,
+		"boardId":    list.BoardID,
+		"archivedAt": list.ArchivedAt,
+		"deletedAt":  deletedAt(list.DeletedAt),
+	}
+}
+
 func ToJsonListSlice(listSlice []List) []gin.H {
 	jsonListSlice := make([]gin.H, 0, len(listSlice))
 	for _, list := range listSlice {
@@ -30,3 +46,19 @@ func ToJsonListSlice(listSlice []List) []gin.H {
 	}
 	return jsonListSlice
 }
+
+func ToTrashJsonListSlice(listSlice []List) []gin.H {
+	jsonListSlice := make([]gin.H, 0, len(listSlice))
+	for _, list := range listSlice {
+		jsonListSlice = append(jsonListSlice, list.ToTrashJson())
+	}
+	return jsonListSlice
+}
+
+// 削除されていない場合はnullを返す
+func deletedAt(value gorm.DeletedAt) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Time
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ログインの失敗回数をアカウントごととIPアドレスごとに記録する
+// Keyは "user:<id>" もしくは "ip:<address>"
+type LoginAttempt struct {
+	gorm.Model
+	ID           int    `gorm:"primaryKey;autoIncrement;not null"`
+	Key          string `gorm:"type:varchar(128);uniqueIndex;not null"`
+	Failures     int    `gorm:"default:0"`
+	LastFailedAt time.Time
+	LockedUntil  *time.Time
+}
+
+func (attempt *LoginAttempt) IsLocked() bool {
+	return attempt.LockedUntil != nil && time.Now().Before(*attempt.LockedUntil)
+}
+
+func (attempt *LoginAttempt) ToJson() gin.H {
+	return gin.H{
+		"key":          attempt.Key,
+		"failures":     attempt.Failures,
+		"lastFailedAt": attempt.LastFailedAt,
+		"lockedUntil":  attempt.LockedUntil,
+	}
+}
+
+func ToJsonLoginAttemptSlice(attempts []LoginAttempt) []gin.H {
+	jsonAttempts := make([]gin.H, 0, len(attempts))
+	for _, attempt := range attempts {
+		jsonAttempts = append(jsonAttempts, attempt.ToJson())
+	}
+	return jsonAttempts
+}
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// パスワード再設定用のトークン リフレッシュトークンと同じくハッシュ値のみを保存する
+type PasswordReset struct {
+	gorm.Model
+	ID        int    `gorm:"primaryKey;autoIncrement;not null"`
+	Digest    string `gorm:"type:varchar(64);uniqueIndex;not null"`
+	ExpiresAt time.Time
+	UserID    int
+	User      User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (reset *PasswordReset) IsExpired() bool {
+	return time.Now().After(reset.ExpiresAt)
+}
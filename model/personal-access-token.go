@@ -0,0 +1,70 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// スクリプト等から使うトークンの権限
+const (
+	ScopeListsRead  = "lists:read"
+	ScopeListsWrite = "lists:write"
+	ScopeCardsRead  = "cards:read"
+	ScopeCardsWrite = "cards:write"
+)
+
+// jwtと区別するためにトークンの先頭に付ける
+const PersonalAccessTokenPrefix = "tgp_"
+
+// パーソナルアクセストークン ハッシュ値のみを保存する
+// Scopesはスペース区切り ExpiresAtがnilの場合は無期限
+type PersonalAccessToken struct {
+	gorm.Model
+	ID         int    `gorm:"primaryKey;autoIncrement;not null"`
+	Name       string `gorm:"type:varchar(100);not null"`
+	Digest     string `gorm:"type:varchar(64);uniqueIndex;not null"`
+	Scopes     string `gorm:"type:varchar(255);not null"`
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	UserID     int  `gorm:"index"`
+	User       User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (token *PersonalAccessToken) IsExpired() bool {
+	return token.ExpiresAt != nil && time.Now().After(*token.ExpiresAt)
+}
+
+func (token *PersonalAccessToken) ScopeList() []string {
+	return strings.Fields(token.Scopes)
+}
+
+func (token *PersonalAccessToken) HasScope(scope string) bool {
+	for _, s := range token.ScopeList() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (token *PersonalAccessToken) ToJson() gin.H {
+	return gin.H{
+		"id":         token.ID,
+		"name":       token.Name,
+		"scopes":     token.ScopeList(),
+		"expiresAt":  token.ExpiresAt,
+		"lastUsedAt": token.LastUsedAt,
+		"createdAt":  token.CreatedAt,
+	}
+}
+
+func ToJsonPersonalAccessTokenSlice(tokens []PersonalAccessToken) []gin.H {
+	jsonTokens := make([]gin.H, 0, len(tokens))
+	for _, token := range tokens {
+		jsonTokens = append(jsonTokens, token.ToJson())
+	}
+	return jsonTokens
+}
@@ -0,0 +1,14 @@
+package model
+
+import (
+	"gorm.io/gorm"
+)
+
+// 2段階認証のリカバリーコード ハッシュ値のみを保存し使用したら削除する
+type RecoveryCode struct {
+	gorm.Model
+	ID     int    `gorm:"primaryKey;autoIncrement;not null"`
+	Digest string `gorm:"type:varchar(64);index;not null"`
+	UserID int
+	User   User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// リフレッシュトークンはハッシュ値のみを保存する
+// 同じログインから発行されたトークンは同じFamilyを持つ
+// SessionTrackedはセッションの記録を始めた後に発行されたトークンの場合にtrueになる
+type RefreshToken struct {
+	gorm.Model
+	ID             int    `gorm:"primaryKey;autoIncrement;not null"`
+	Digest         string `gorm:"type:varchar(64);uniqueIndex;not null"`
+	Family         string `gorm:"type:varchar(64);index;not null"`
+	Used           bool   `gorm:"default:false"`
+	SessionTracked bool   `gorm:"default:false"`
+	ExpiresAt      time.Time
+	UserID         int
+	User           User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (token *RefreshToken) IsExpired() bool {
+	return time.Now().After(token.ExpiresAt)
+}
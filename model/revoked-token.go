@@ -0,0 +1,15 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ログアウトしたアクセストークンのjti 有効期限が切れるまで保持する
+type RevokedToken struct {
+	gorm.Model
+	ID        int    `gorm:"primaryKey;autoIncrement;not null"`
+	JTI       string `gorm:"type:varchar(64);uniqueIndex;not null"`
+	ExpiresAt time.Time
+}
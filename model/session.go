@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ログインごとのセッション アクセストークンのsidとリフレッシュトークンのFamilyがSessionIDと一致する
+// セッションを削除するとそのセッションのトークンは使えなくなる
+type Session struct {
+	gorm.Model
+	ID         int    `gorm:"primaryKey;autoIncrement;not null"`
+	SessionID  string `gorm:"type:varchar(64);uniqueIndex;not null"`
+	UserAgent  string `gorm:"type:varchar(255)"`
+	IP         string `gorm:"type:varchar(64)"`
+	LastSeenAt time.Time
+	UserID     int  `gorm:"index"`
+	User       User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// currentSessionIDはリクエストしたトークンのsid
+func (session *Session) ToJson(currentSessionID string) gin.H {
+	return gin.H{
+		"id":         session.ID,
+		"userAgent":  session.UserAgent,
+		"ip":         session.IP,
+		"createdAt":  session.CreatedAt,
+		"lastSeenAt": session.LastSeenAt,
+		"current":    session.SessionID == currentSessionID,
+	}
+}
+
+func ToJsonSessionSlice(sessions []Session, currentSessionID string) []gin.H {
+	jsonSessions := make([]gin.H, 0, len(sessions))
+	for _, session := range sessions {
+		jsonSessions = append(jsonSessions, session.ToJson(currentSessionID))
+	}
+	return jsonSessions
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
@@ -11,9 +13,18 @@ type User struct {
 	Email          string `gorm:"type:varchar(100);index" json:"email"`
 	PasswordDigest string `gorm:"type:varchar(256)" json:"passwordDigest"`
 	Activated      bool   `gorm:"default:false" json:"activatedAt"`
-	OpenID         string `gorm:"type:varchar(256);index" json:"openID"`
+	TokenVersion   int    `gorm:"default:0" json:"-"`
+	// 有効化メールの再送を制限するために最後に送信した日時を保存する
+	ActivationSentAt *time.Time `json:"-"`
+	Admin            bool       `gorm:"default:false" json:"-"`
+	// 2段階認証 TotpSecretは登録開始時に保存し、最初のコードを検証してからTotpEnabledにする
+	TotpSecret  string `gorm:"type:varchar(64)" json:"-"`
+	TotpEnabled bool   `gorm:"default:false" json:"-"`
+	// 同じ認証コードを有効期間内に再利用させないために最後に受け付けた時間ステップを保存する
+	TotpLastStep int64 `gorm:"default:0" json:"-"`
 
-	Lists []List
+	Boards     []Board
+	Identities []Identity
 }
 
 func (user *User) Authenticate(password string) bool {
@@ -25,6 +36,10 @@ func (user *User) Authenticate(password string) bool {
 	return false
 }
 
-func (user *User) HasList(list List) bool {
-	return user.ID == list.UserID
+func (user *User) HasLabel(label Label) bool {
+	return user.ID == label.UserID
+}
+
+func (user *User) HasComment(comment Comment) bool {
+	return user.ID == comment.UserID
 }
@@ -0,0 +1,115 @@
+package repository
+
+// mockgen -source=repository/attachment-repository.go -destination=mock_repository/attachment-repository.go
+
+import (
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ストレージのファイルも削除するので添付ファイルは物理削除する
+type AttachmentRepository interface {
+	// アップロードしたユーザーの合計サイズがquotaを超える場合はAttachmentQuotaExceededErrorを返す
+	Create(attachment *model.Attachment, quota int64) error
+	FindAll(card *model.Card) ([]model.Attachment, error)
+	// 他のカードの添付ファイルはErrRecordNotFoundにする
+	Find(card *model.Card, id int) (model.Attachment, error)
+	Destroy(attachment *model.Attachment) error
+	TotalSize(user *model.User) (int64, error)
+	// 削除した添付ファイルを返すので呼び出し側でストレージのファイルを削除する
+	DestroyAllByCard(card *model.Card) ([]model.Attachment, error)
+	// 論理削除済みのものも含めたリストの全カードの添付ファイルを削除する
+	DestroyAllByList(list *model.List) ([]model.Attachment, error)
+	// ボードの全リストの全カードの添付ファイルを削除する
+	DestroyAllByBoard(board *model.Board) ([]model.Attachment, error)
+	// ユーザーがアップロードしたものとユーザーのカードに添付されたものを削除する
+	DestroyAllByUser(user *model.User) ([]model.Attachment, error)
+}
+
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository() AttachmentRepository {
+	return &attachmentRepository{db: db.GetDB()}
+}
+
+// 同時にアップロードされても上限を超えないようにユーザーの行をロックしてから合計サイズを確認する
+func (r *attachmentRepository) Create(attachment *model.Attachment, quota int64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var user model.User
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&user, attachment.UserID).Error
+		if err != nil {
+			return err
+		}
+
+		var total int64
+		err = tx.Model(model.Attachment{}).Where("user_id = ?", attachment.UserID).Select("COALESCE(SUM(size), 0)").Scan(&total).Error
+		if err != nil {
+			return err
+		}
+		if total+attachment.Size > quota {
+			return config.AttachmentQuotaExceededError
+		}
+
+		return tx.Omit("Card", "User").Create(attachment).Error
+	})
+}
+
+func (r *attachmentRepository) FindAll(card *model.Card) ([]model.Attachment, error) {
+	var attachments []model.Attachment
+	err := r.db.Where("card_id = ?", card.ID).Order("id asc").Find(&attachments).Error
+	return attachments, err
+}
+
+func (r *attachmentRepository) Find(card *model.Card, id int) (model.Attachment, error) {
+	var attachment model.Attachment
+	err := r.db.Where("card_id = ?", card.ID).First(&attachment, id).Error
+	return attachment, err
+}
+
+func (r *attachmentRepository) Destroy(attachment *model.Attachment) error {
+	return r.db.Unscoped().Delete(attachment).Error
+}
+
+func (r *attachmentRepository) TotalSize(user *model.User) (int64, error) {
+	var total int64
+	err := r.db.Model(model.Attachment{}).Where("user_id = ?", user.ID).Select("COALESCE(SUM(size), 0)").Scan(&total).Error
+	return total, err
+}
+
+func (r *attachmentRepository) DestroyAllByCard(card *model.Card) ([]model.Attachment, error) {
+	return r.destroyAll("card_id = ?", card.ID)
+}
+
+func (r *attachmentRepository) DestroyAllByList(list *model.List) ([]model.Attachment, error) {
+	listCardIDs := r.db.Unscoped().Table("cards").Select("cards.id").Where("cards.list_id = ?", list.ID)
+	return r.destroyAll("card_id IN (?)", listCardIDs)
+}
+
+func (r *attachmentRepository) DestroyAllByBoard(board *model.Board) ([]model.Attachment, error) {
+	boardCardIDs := r.db.Unscoped().Table("cards").Select("cards.id").Joins("JOIN lists ON lists.id = cards.list_id").Where("lists.board_id = ?", board.ID)
+	return r.destroyAll("card_id IN (?)", boardCardIDs)
+}
+
+func (r *attachmentRepository) DestroyAllByUser(user *model.User) ([]model.Attachment, error) {
+	// ユーザーの削除後に呼ぶのでリストとカードは論理削除済みでも対象にする
+	userCardIDs := r.db.Unscoped().Table("cards").Select("cards.id").Joins("JOIN lists ON lists.id = cards.list_id").Joins("JOIN boards ON boards.id = lists.board_id").Where("boards.user_id = ?", user.ID)
+	return r.destroyAll("user_id = ? OR card_id IN (?)", user.ID, userCardIDs)
+}
+
+func (r *attachmentRepository) destroyAll(query string, args ...interface{}) ([]model.Attachment, error) {
+	var attachments []model.Attachment
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where(query, args...).Find(&attachments).Error
+		if err != nil || len(attachments) == 0 {
+			return err
+		}
+
+		return tx.Unscoped().Delete(&attachments).Error
+	})
+	return attachments, err
+}
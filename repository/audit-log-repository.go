@@ -0,0 +1,60 @@
+package repository
+
+// mockgen -source=repository/audit-log-repository.go -destination=mock_repository/audit-log-repository.go
+
+import (
+	"time"
+
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"gorm.io/gorm"
+)
+
+// 監査ログの検索条件 ゼロ値の条件は使わない
+// BeforeIDより古いログをLimit件まで新しい順に返す
+type AuditLogQuery struct {
+	UserID   int
+	Event    string
+	BeforeID int
+	Limit    int
+}
+
+type AuditLogRepository interface {
+	Create(log *model.AuditLog) error
+	FindAll(query AuditLogQuery) ([]model.AuditLog, error)
+	DestroyExpired(expiredBefore time.Time) error
+}
+
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepository() AuditLogRepository {
+	return &auditLogRepository{db: db.GetDB()}
+}
+
+func (r *auditLogRepository) Create(log *model.AuditLog) error {
+	return r.db.Create(log).Error
+}
+
+func (r *auditLogRepository) FindAll(query AuditLogQuery) ([]model.AuditLog, error) {
+	tx := r.db.Order("id desc").Limit(query.Limit)
+	if query.UserID != 0 {
+		tx = tx.Where("user_id = ?", query.UserID)
+	}
+	if query.Event != "" {
+		tx = tx.Where("event = ?", query.Event)
+	}
+	if query.BeforeID != 0 {
+		tx = tx.Where("id < ?", query.BeforeID)
+	}
+
+	var logs []model.AuditLog
+	err := tx.Find(&logs).Error
+	return logs, err
+}
+
+// 保存期間を過ぎたログを削除する
+func (r *auditLogRepository) DestroyExpired(expiredBefore time.Time) error {
+	return r.db.Unscoped().Where("created_at < ?", expiredBefore).Delete(&model.AuditLog{}).Error
+}
@@ -0,0 +1,119 @@
+package repository
+
+// mockgen -source=repository/board-member-repository.go -destination=mock_repository/board-member-repository.go
+
+import (
+	"time"
+
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BoardMemberRepository interface {
+	Create(member *model.BoardMember) error
+	// 招待中のメンバーも含む
+	Find(boardID, userID int) (model.BoardMember, error)
+	FindInBoard(boardID, id int) (model.BoardMember, error)
+	FindAll(boardID int) ([]model.BoardMember, error)
+	// 最後のオーナーを他のロールに変更したり削除したりする場合はLastBoardOwnerErrorを返す
+	UpdateRole(member *model.BoardMember, role string) error
+	Destroy(member *model.BoardMember) error
+	FindInvitations(userID int) ([]model.BoardMember, error)
+	FindInvitation(id, userID int) (model.BoardMember, error)
+	Accept(member *model.BoardMember) error
+}
+
+type boardMemberRepository struct {
+	db *gorm.DB
+}
+
+func NewBoardMemberRepository() BoardMemberRepository {
+	return &boardMemberRepository{db: db.GetDB()}
+}
+
+// ゴミ箱を操作できるボードのidを返すサブクエリ
+func editableBoardIDs(db *gorm.DB, userID int) *gorm.DB {
+	return db.Model(model.BoardMember{}).Select("board_id").Where(
+		"user_id = ? AND accepted_at IS NOT NULL AND role IN ?", userID, []string{model.BoardRoleOwner, model.BoardRoleEditor},
+	)
+}
+
+func (r *boardMemberRepository) Create(member *model.BoardMember) error {
+	return r.db.Create(member).Error
+}
+
+func (r *boardMemberRepository) Find(boardID, userID int) (model.BoardMember, error) {
+	var member model.BoardMember
+	err := r.db.Where("board_id = ? AND user_id = ?", boardID, userID).First(&member).Error
+	return member, err
+}
+
+func (r *boardMemberRepository) FindInBoard(boardID, id int) (model.BoardMember, error) {
+	var member model.BoardMember
+	err := r.db.Preload("User").Where("board_id = ?", boardID).First(&member, id).Error
+	return member, err
+}
+
+func (r *boardMemberRepository) FindAll(boardID int) ([]model.BoardMember, error) {
+	var members []model.BoardMember
+	err := r.db.Preload("User").Where("board_id = ?", boardID).Order("id ASC").Find(&members).Error
+	return members, err
+}
+
+func (r *boardMemberRepository) UpdateRole(member *model.BoardMember, role string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if role != model.BoardRoleOwner {
+			if err := validateLastOwner(tx, member); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(member).Update("role", role).Error
+	})
+}
+
+// 再度招待できるように物理削除する
+func (r *boardMemberRepository) Destroy(member *model.BoardMember) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := validateLastOwner(tx, member); err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Delete(member).Error
+	})
+}
+
+func (r *boardMemberRepository) FindInvitations(userID int) ([]model.BoardMember, error) {
+	var members []model.BoardMember
+	err := r.db.Joins("Board").Where("board_members.user_id = ? AND board_members.accepted_at IS NULL", userID).Order("board_members.id DESC").Find(&members).Error
+	return members, err
+}
+
+func (r *boardMemberRepository) FindInvitation(id, userID int) (model.BoardMember, error) {
+	var member model.BoardMember
+	err := r.db.Joins("Board").Where("board_members.user_id = ? AND board_members.accepted_at IS NULL", userID).First(&member, id).Error
+	return member, err
+}
+
+func (r *boardMemberRepository) Accept(member *model.BoardMember) error {
+	return r.db.Model(member).Update("accepted_at", time.Now()).Error
+}
+
+// オーナー同士が同時にお互いを外してもオーナーが残るように、ボードのオーナーの行をロックしてから確認する
+func validateLastOwner(tx *gorm.DB, member *model.BoardMember) error {
+	var ownerIDs []int
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Model(model.BoardMember{}).Where(
+		"board_id = ? AND role = ? AND accepted_at IS NOT NULL", member.BoardID, model.BoardRoleOwner,
+	).Pluck("id", &ownerIDs).Error
+	if err != nil {
+		return err
+	}
+
+	if len(ownerIDs) == 1 && ownerIDs[0] == member.ID {
+		return config.LastBoardOwnerError
+	}
+	return nil
+}
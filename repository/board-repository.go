@@ -0,0 +1,98 @@
+package repository
+
+// mockgen -source=repository/board-repository.go -destination=mock_repository/board-repository.go
+
+import (
+	"time"
+
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"gorm.io/gorm"
+)
+
+type BoardRepository interface {
+	// 作成したユーザーをオーナーとしてメンバーに追加する
+	Create(user *model.User, board *model.Board) error
+	// 招待を承諾したボードも含む
+	FindAll(user *model.User) ([]model.Board, error)
+	Find(id int) (model.Board, error)
+	Update(board *model.Board, updatingBoard model.Board) error
+	Move(board *model.Board, toIndex int) error
+	// ゴミ箱のものも含めてリストとカードを物理削除する 添付ファイルは事前に削除しておく
+	Destroy(board *model.Board) error
+}
+
+type boardRepository struct {
+	db *gorm.DB
+}
+
+func NewBoardRepository() BoardRepository {
+	return &boardRepository{db: db.GetDB()}
+}
+
+func (r *boardRepository) Create(user *model.User, board *model.Board) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(user).Association("Boards").Append(board)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		return tx.Create(&model.BoardMember{BoardID: board.ID, UserID: user.ID, Role: model.BoardRoleOwner, AcceptedAt: &now}).Error
+	})
+}
+
+func (r *boardRepository) FindAll(user *model.User) ([]model.Board, error) {
+	var boards []model.Board
+	err := r.db.Joins("JOIN board_members ON board_members.board_id = boards.id AND board_members.deleted_at IS NULL").Where(
+		"board_members.user_id = ? AND board_members.accepted_at IS NOT NULL", user.ID,
+	).Order("boards.index ASC").Order("boards.id ASC").Find(&boards).Error
+	return boards, err
+}
+
+func (r *boardRepository) Find(id int) (model.Board, error) {
+	var board model.Board
+	err := r.db.First(&board, id).Error
+	return board, err
+}
+
+func (r *boardRepository) Update(board *model.Board, updatingBoard model.Board) error {
+	return r.db.Model(board).Select("title").Updates(updatingBoard).Error
+}
+
+func (r *boardRepository) Move(board *model.Board, toIndex int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := shiftIndexesForMove(tx, model.Board{}, "boards", "boards.user_id = ?", board.UserID, board.Index, toIndex)
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(board).Update("index", toIndex).Error
+	})
+}
+
+func (r *boardRepository) Destroy(board *model.Board) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := destroyCardsPermanently(tx, "list_id IN (SELECT id FROM lists WHERE board_id = ?)", board.ID)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Unscoped().Where("board_id = ?", board.ID).Delete(&model.List{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Unscoped().Where("board_id = ?", board.ID).Delete(&model.BoardMember{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Unscoped().Delete(board).Error
+		if err != nil {
+			return err
+		}
+
+		return shiftIndexesForDestroy(tx, model.Board{}, "boards", "boards.user_id = ?", board.UserID, board.Index)
+	})
+}
@@ -0,0 +1,66 @@
+package repository
+
+// mockgen -source=repository/card-reminder-repository.go -destination=mock_repository/card-reminder-repository.go
+
+import (
+	"time"
+
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"gorm.io/gorm"
+)
+
+type CardReminderRepository interface {
+	Replace(card *model.Card, reminders []model.CardReminder) error
+	FindDue(now time.Time) ([]model.CardReminder, error)
+	MarkSent(reminder *model.CardReminder, sentAt time.Time) (bool, error)
+}
+
+type cardReminderRepository struct {
+	db *gorm.DB
+}
+
+func NewCardReminderRepository() CardReminderRepository {
+	return &cardReminderRepository{db: db.GetDB()}
+}
+
+// 未送信のリマインダーを削除して作り直す
+func (r *cardReminderRepository) Replace(card *model.Card, reminders []model.CardReminder) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Where("card_id = ? AND sent_at IS NULL", card.ID).Delete(&model.CardReminder{}).Error
+		if err != nil {
+			return err
+		}
+
+		if len(reminders) == 0 {
+			return nil
+		}
+		return tx.Create(&reminders).Error
+	})
+}
+
+// 送信時刻を過ぎた未送信のリマインダーをカードとボードの承諾済みのメンバーと一緒に返す
+// リストの削除で論理削除されたカードとアーカイブ中のリスト・カードのリマインダーは除く
+func (r *cardReminderRepository) FindDue(now time.Time) ([]model.CardReminder, error) {
+	var reminders []model.CardReminder
+	err := r.db.Joins("Card").Preload("Card.List.Board.Members", "accepted_at IS NOT NULL").Preload("Card.List.Board.Members.User").
+		Where("card_reminders.remind_at <= ? AND card_reminders.sent_at IS NULL AND Card.deleted_at IS NULL AND Card.archived_at IS NULL", now).
+		Where("Card.list_id IN (?)", r.db.Model(model.List{}).Select("id").Where("archived_at IS NULL")).
+		Order("card_reminders.remind_at").
+		Find(&reminders).Error
+	return reminders, err
+}
+
+// 複数のインスタンスで同じリマインダーを送らないように未送信の場合のみ更新する
+// 他のインスタンスが先に更新した場合はfalseを返す
+func (r *cardReminderRepository) MarkSent(reminder *model.CardReminder, sentAt time.Time) (bool, error) {
+	result := r.db.Model(&model.CardReminder{}).
+		Where("id = ? AND sent_at IS NULL", reminder.ID).
+		Update("sent_at", sentAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	reminder.SentAt = &sentAt
+	return result.RowsAffected == 1, nil
+}
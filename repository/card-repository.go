@@ -3,6 +3,9 @@ package repository
 // mockgen -source=repository/card-repository.go -destination=./mock_repository/card-repository.go
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/kuritaeiji/todo-gin-back/db"
 	"github.com/kuritaeiji/todo-gin-back/model"
 	"gorm.io/gorm"
@@ -19,44 +22,57 @@ type CardRepository interface {
 	Destroy(card *model.Card) error
 	Move(card *model.Card, toListID int, toIndex int) error
 	Find(id int) (model.Card, error)
+	Archive(*model.Card) error
+	FindTrashed(*model.User) ([]model.Card, error)
+	FindWithTrashed(id int) (model.Card, error)
+	Restore(*model.Card) error
+	FindWithDueAtByList(*model.List) ([]model.Card, error)
+	DestroyPermanently(*model.Card) error
 }
 
 func NewCardRepository() CardRepository {
 	return &cardRepository{db: db.GetDB(), listRepository: NewListRepository()}
 }
 
-var (
-	plusIndexExpr  = map[string]interface{}{"index": gorm.Expr("cards.index + ?", 1)}
-	minusIndexExpr = map[string]interface{}{"index": gorm.Expr("cards.index - ?", 1)}
-)
-
 func (r *cardRepository) Create(card *model.Card, list *model.List) error {
 	return r.db.Model(list).Association("Cards").Append(card)
 }
 
 func (r *cardRepository) Update(card *model.Card, updatingCard *model.Card) error {
-	return r.db.Model(&card).Select("title").Updates(updatingCard).Error
+	return r.db.Model(&card).Select("title", "description", "start_at", "due_at", "timezone").Updates(updatingCard).Error
 }
 
+// アーカイブされていないカードのindexはリストごとに0から連続させる
+const activeCardScope = "cards.list_id = ? AND cards.archived_at IS NULL"
+
+// カードは論理削除なので外部キーで削除されないリマインダーも削除する ラベルの紐付けは元に戻す際のために残す
 func (r *cardRepository) Destroy(card *model.Card) error {
-	return r.db.Delete(&card).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Where("card_id = ?", card.ID).Delete(&model.CardReminder{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Delete(&card).Error
+		if err != nil || card.ArchivedAt != nil {
+			return err
+		}
+
+		return shiftIndexesForDestroy(tx, model.Card{}, "cards", activeCardScope, card.ListID, card.Index)
+	})
 }
 
 func (r *cardRepository) Move(card *model.Card, toListID int, toIndex int) error {
 	if card.ListID == toListID {
-		if toIndex > card.Index {
-			return r.moveWhenIncreaseIndex(card, toIndex)
-		}
-
-		return r.moveWhenDecreaseIndex(card, toIndex)
+		return r.moveInList(card, toIndex)
 	}
 
 	return r.moveWhenChangeList(card, toListID, toIndex)
 }
 
-func (r *cardRepository) moveWhenIncreaseIndex(card *model.Card, toIndex int) error {
+func (r *cardRepository) moveInList(card *model.Card, toIndex int) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		err := tx.Model(model.Card{}).Where("cards.index > ? AND cards.index <= ? AND cards.list_id = ?", card.Index, toIndex, card.ListID).Updates(minusIndexExpr).Error
+		err := shiftIndexesForMove(tx, model.Card{}, "cards", activeCardScope, card.ListID, card.Index, toIndex)
 		if err != nil {
 			return err
 		}
@@ -65,37 +81,130 @@ func (r *cardRepository) moveWhenIncreaseIndex(card *model.Card, toIndex int) er
 	})
 }
 
-func (r *cardRepository) moveWhenDecreaseIndex(card *model.Card, toIndex int) error {
+func (r *cardRepository) moveWhenChangeList(card *model.Card, toListID int, toIndex int) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		err := tx.Model(model.Card{}).Where("cards.index < ? AND cards.index >= ? AND cards.list_id = ?", card.Index, toIndex, card.ListID).Updates(plusIndexExpr).Error
+		err := shiftIndexesForDestroy(tx, model.Card{}, "cards", activeCardScope, card.ListID, card.Index)
 		if err != nil {
 			return err
 		}
 
-		return tx.Model(card).Update("index", toIndex).Error
+		err = shiftIndexesForInsert(tx, model.Card{}, "cards", activeCardScope, toListID, toIndex)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(card).Select("Index", "ListID").Updates(model.Card{Index: toIndex, ListID: toListID}).Error
+		return err
 	})
 }
 
-func (r *cardRepository) moveWhenChangeList(card *model.Card, toListID int, toIndex int) error {
+func (r *cardRepository) Find(id int) (model.Card, error) {
+	var card model.Card
+	err := r.db.Model(model.Card{}).Preload("Labels").Preload("Checklists.Items").First(&card, id).Error
+	if err != nil {
+		return card, err
+	}
+
+	err = setCommentCounts(r.db, []*model.Card{&card})
+	return card, err
+}
+
+// 未送信のリマインダーは元に戻す際に作り直す
+func (r *cardRepository) Archive(card *model.Card) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		err := tx.Model(model.Card{}).Where("cards.index > ? AND cards.list_id = ?", card.Index, card.ListID).Updates(minusIndexExpr).Error
+		err := tx.Unscoped().Where("card_id = ? AND sent_at IS NULL", card.ID).Delete(&model.CardReminder{}).Error
 		if err != nil {
 			return err
 		}
 
-		err = tx.Model(model.Card{}).Where("cards.index >= ? AND cards.list_id = ?", toIndex, toListID).Updates(plusIndexExpr).Error
+		err = tx.Model(card).Update("archived_at", time.Now()).Error
 		if err != nil {
 			return err
 		}
 
-		err = tx.Model(card).Select("Index", "ListID").Updates(model.Card{Index: toIndex, ListID: toListID}).Error
-		return err
+		return shiftIndexesForDestroy(tx, model.Card{}, "cards", activeCardScope, card.ListID, card.Index)
 	})
 }
 
-func (r *cardRepository) Find(id int) (model.Card, error) {
-	var card model.Card
-	err := r.db.Model(model.Card{}).First(&card, id).Error
+// 編集できるボードのアーカイブ中または削除済みのカードを新しい順に返す 削除済みのリストのカードはリストと一緒に戻すので含めない
+func (r *cardRepository) FindTrashed(user *model.User) ([]model.Card, error) {
+	var cards []model.Card
+	err := r.db.Unscoped().Joins("JOIN lists ON lists.id = cards.list_id AND lists.deleted_at IS NULL").Where("lists.board_id IN (?) AND (cards.archived_at IS NOT NULL OR cards.deleted_at IS NOT NULL)", editableBoardIDs(r.db, user.ID)).Order("COALESCE(cards.deleted_at, cards.archived_at) DESC").Preload("Labels").Preload("Checklists.Items").Find(&cards).Error
+	if err != nil {
+		return cards, err
+	}
 
+	pointers := make([]*model.Card, 0, len(cards))
+	for i := range cards {
+		pointers = append(pointers, &cards[i])
+	}
+	return cards, setCommentCounts(r.db, pointers)
+}
+
+// 所有者を確認できるように削除済みのリストもpreloadする
+func (r *cardRepository) FindWithTrashed(id int) (model.Card, error) {
+	var card model.Card
+	err := r.db.Unscoped().Preload("List", func(tx *gorm.DB) *gorm.DB {
+		return tx.Unscoped()
+	}).Preload("List.Board").First(&card, id).Error
 	return card, err
 }
+
+// 元の位置(アーカイブされていないカードの数を超える場合は末尾)に戻し、後ろのカードをずらす
+func (r *cardRepository) Restore(card *model.Card) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		toIndex, err := restoringIndex(tx, model.Card{}, activeCardScope, card.ListID, card.Index)
+		if err != nil {
+			return err
+		}
+
+		err = shiftIndexesForInsert(tx, model.Card{}, "cards", activeCardScope, card.ListID, toIndex)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Unscoped().Model(card).Updates(map[string]interface{}{"archived_at": nil, "deleted_at": nil, "index": toIndex}).Error
+		if err != nil {
+			return err
+		}
+
+		card.ArchivedAt = nil
+		card.DeletedAt = gorm.DeletedAt{}
+		card.Index = toIndex
+		return nil
+	})
+}
+
+// リストを元に戻した際にリマインダーを作り直すために期限のあるアーカイブされていないカードを返す
+func (r *cardRepository) FindWithDueAtByList(list *model.List) ([]model.Card, error) {
+	var cards []model.Card
+	err := r.db.Where("list_id = ? AND archived_at IS NULL AND due_at IS NOT NULL", list.ID).Find(&cards).Error
+	return cards, err
+}
+
+// 添付ファイルは事前に削除しておく
+func (r *cardRepository) DestroyPermanently(card *model.Card) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return destroyCardsPermanently(tx, "id = ?", card.ID)
+	})
+}
+
+// conditionに一致するカードと外部キーで削除されない関連レコードを物理削除する
+// MySQLは削除するテーブル自体をサブクエリで参照できないのでcardsは条件で直接削除する
+func destroyCardsPermanently(tx *gorm.DB, condition string, args ...interface{}) error {
+	cardIDs := "SELECT id FROM cards WHERE " + condition
+	for _, query := range []string{
+		"DELETE FROM checklist_items WHERE checklist_id IN (SELECT id FROM checklists WHERE card_id IN (%s))",
+		"DELETE FROM checklists WHERE card_id IN (%s)",
+		"DELETE FROM comments WHERE card_id IN (%s)",
+		"DELETE FROM card_reminders WHERE card_id IN (%s)",
+		"DELETE FROM card_labels WHERE card_id IN (%s)",
+	} {
+		err := tx.Exec(fmt.Sprintf(query, cardIDs), args...).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Exec("DELETE FROM cards WHERE "+condition, args...).Error
+}
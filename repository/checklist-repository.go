@@ -0,0 +1,120 @@
+package repository
+
+// mockgen -source=repository/checklist-repository.go -destination=mock_repository/checklist-repository.go
+
+import (
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"gorm.io/gorm"
+)
+
+type ChecklistRepository interface {
+	FindAll(card *model.Card) ([]model.Checklist, error)
+	Find(card *model.Card, id int) (model.Checklist, error)
+	Create(checklist *model.Checklist) error
+	Update(checklist *model.Checklist, updatingChecklist model.Checklist) error
+	Destroy(checklist *model.Checklist) error
+	Move(checklist *model.Checklist, toIndex int) error
+	FindItem(checklist *model.Checklist, id int) (model.ChecklistItem, error)
+	CreateItem(item *model.ChecklistItem) error
+	UpdateItem(item *model.ChecklistItem, updatingItem model.ChecklistItem) error
+	DestroyItem(item *model.ChecklistItem) error
+	MoveItem(item *model.ChecklistItem, toIndex int) error
+}
+
+type checklistRepository struct {
+	db *gorm.DB
+}
+
+func NewChecklistRepository() ChecklistRepository {
+	return &checklistRepository{db: db.GetDB()}
+}
+
+func (r *checklistRepository) FindAll(card *model.Card) ([]model.Checklist, error) {
+	var checklists []model.Checklist
+	err := r.db.Where("card_id = ?", card.ID).Order("checklists.index ASC").Preload("Items", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("checklist_items.index ASC")
+	}).Find(&checklists).Error
+	return checklists, err
+}
+
+// 他のカードのチェックリストはErrRecordNotFoundにする
+func (r *checklistRepository) Find(card *model.Card, id int) (model.Checklist, error) {
+	var checklist model.Checklist
+	err := r.db.Where("card_id = ?", card.ID).Preload("Items", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("checklist_items.index ASC")
+	}).First(&checklist, id).Error
+	return checklist, err
+}
+
+func (r *checklistRepository) Create(checklist *model.Checklist) error {
+	return r.db.Create(checklist).Error
+}
+
+func (r *checklistRepository) Update(checklist *model.Checklist, updatingChecklist model.Checklist) error {
+	return r.db.Model(checklist).Select("title").Updates(updatingChecklist).Error
+}
+
+func (r *checklistRepository) Destroy(checklist *model.Checklist) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("checklist_id = ?", checklist.ID).Delete(&model.ChecklistItem{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Delete(checklist).Error
+		if err != nil {
+			return err
+		}
+
+		return shiftIndexesForDestroy(tx, model.Checklist{}, "checklists", "checklists.card_id = ?", checklist.CardID, checklist.Index)
+	})
+}
+
+func (r *checklistRepository) Move(checklist *model.Checklist, toIndex int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := shiftIndexesForMove(tx, model.Checklist{}, "checklists", "checklists.card_id = ?", checklist.CardID, checklist.Index, toIndex)
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(checklist).Update("index", toIndex).Error
+	})
+}
+
+// 他のチェックリストの項目はErrRecordNotFoundにする
+func (r *checklistRepository) FindItem(checklist *model.Checklist, id int) (model.ChecklistItem, error) {
+	var item model.ChecklistItem
+	err := r.db.Where("checklist_id = ?", checklist.ID).First(&item, id).Error
+	return item, err
+}
+
+func (r *checklistRepository) CreateItem(item *model.ChecklistItem) error {
+	return r.db.Create(item).Error
+}
+
+func (r *checklistRepository) UpdateItem(item *model.ChecklistItem, updatingItem model.ChecklistItem) error {
+	return r.db.Model(item).Select("title", "done").Updates(updatingItem).Error
+}
+
+func (r *checklistRepository) DestroyItem(item *model.ChecklistItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Delete(item).Error
+		if err != nil {
+			return err
+		}
+
+		return shiftIndexesForDestroy(tx, model.ChecklistItem{}, "checklist_items", "checklist_items.checklist_id = ?", item.ChecklistID, item.Index)
+	})
+}
+
+func (r *checklistRepository) MoveItem(item *model.ChecklistItem, toIndex int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := shiftIndexesForMove(tx, model.ChecklistItem{}, "checklist_items", "checklist_items.checklist_id = ?", item.ChecklistID, item.Index, toIndex)
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(item).Update("index", toIndex).Error
+	})
+}
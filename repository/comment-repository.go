@@ -0,0 +1,90 @@
+package repository
+
+// mockgen -source=repository/comment-repository.go -destination=mock_repository/comment-repository.go
+
+import (
+	"time"
+
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"gorm.io/gorm"
+)
+
+type CommentRepository interface {
+	// beforeIDより古いコメントをlimit件まで新しい順に返す beforeIDが0の場合は最新から返す
+	FindAll(card *model.Card, beforeID int, limit int) ([]model.Comment, error)
+	Find(id int) (model.Comment, error)
+	Create(comment *model.Comment) error
+	Update(comment *model.Comment, body string, editedAt time.Time) error
+	Destroy(comment *model.Comment) error
+}
+
+type commentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository() CommentRepository {
+	return &commentRepository{db: db.GetDB()}
+}
+
+func (r *commentRepository) FindAll(card *model.Card, beforeID int, limit int) ([]model.Comment, error) {
+	tx := r.db.Joins("User").Where("comments.card_id = ?", card.ID).Order("comments.id desc").Limit(limit)
+	if beforeID != 0 {
+		tx = tx.Where("comments.id < ?", beforeID)
+	}
+
+	var comments []model.Comment
+	err := tx.Find(&comments).Error
+	return comments, err
+}
+
+// 削除されたカードのコメントはErrRecordNotFoundにする ボードの権限を確認できるようにカードもpreloadする
+func (r *commentRepository) Find(id int) (model.Comment, error) {
+	var comment model.Comment
+	err := r.db.Joins("User").Joins("JOIN cards ON cards.id = comments.card_id AND cards.deleted_at IS NULL").Preload("Card").First(&comment, id).Error
+	return comment, err
+}
+
+func (r *commentRepository) Create(comment *model.Comment) error {
+	return r.db.Omit("Card", "User").Create(comment).Error
+}
+
+func (r *commentRepository) Update(comment *model.Comment, body string, editedAt time.Time) error {
+	comment.Body = body
+	comment.EditedAt = &editedAt
+	return r.db.Model(comment).Select("body", "edited_at").Updates(comment).Error
+}
+
+func (r *commentRepository) Destroy(comment *model.Comment) error {
+	return r.db.Delete(comment).Error
+}
+
+// cardsに削除されていないコメントの数をsetする
+func setCommentCounts(db *gorm.DB, cards []*model.Card) error {
+	if len(cards) == 0 {
+		return nil
+	}
+
+	cardIDs := make([]int, 0, len(cards))
+	for _, card := range cards {
+		cardIDs = append(cardIDs, card.ID)
+	}
+
+	var counts []struct {
+		CardID int
+		Count  int
+	}
+	err := db.Model(model.Comment{}).Select("card_id, COUNT(*) AS count").Where("card_id IN ?", cardIDs).Group("card_id").Scan(&counts).Error
+	if err != nil {
+		return err
+	}
+
+	countByCardID := make(map[int]int, len(counts))
+	for _, count := range counts {
+		countByCardID[count.CardID] = count.Count
+	}
+	for _, card := range cards {
+		card.CommentCount = countByCardID[card.ID]
+	}
+	return nil
+}
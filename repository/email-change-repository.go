@@ -0,0 +1,73 @@
+package repository
+
+// mockgen -source=repository/email-change-repository.go -destination=mock_repository/email-change-repository.go
+
+import (
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"gorm.io/gorm"
+)
+
+type EmailChangeRepository interface {
+	Create(change *model.EmailChange) error
+	FindByDigest(digest string) (model.EmailChange, error)
+	Confirm(change *model.EmailChange) error
+}
+
+type emailChangeRepository struct {
+	db                        *gorm.DB
+	tokenRevocationRepository TokenRevocationRepository
+}
+
+func NewEmailChangeRepository() EmailChangeRepository {
+	return &emailChangeRepository{
+		db:                        db.GetDB(),
+		tokenRevocationRepository: NewTokenRevocationRepository(),
+	}
+}
+
+// 同じユーザーの未確認の変更は新しい変更で置き換える
+func (r *emailChangeRepository) Create(change *model.EmailChange) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Where("user_id = ?", change.UserID).Delete(&model.EmailChange{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(change).Error
+	})
+}
+
+func (r *emailChangeRepository) FindByDigest(digest string) (model.EmailChange, error) {
+	var change model.EmailChange
+	err := r.db.Joins("User").Where("email_changes.digest = ?", digest).First(&change).Error
+	return change, err
+}
+
+// メールアドレスを更新してログイン中のトークンを全て無効にする
+func (r *emailChangeRepository) Confirm(change *model.EmailChange) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// 確認メールを送ってから確認されるまでの間に他のユーザーが登録している場合がある
+		var count int64
+		err := tx.Model(model.User{}).Where("email = ?", change.Email).Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return config.UniqueUserError
+		}
+
+		err = tx.Model(&change.User).Update("email", change.Email).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Unscoped().Where("user_id = ?", change.UserID).Delete(&model.EmailChange{}).Error
+		if err != nil {
+			return err
+		}
+
+		return r.tokenRevocationRepository.RevokeAllWithTx(&change.User, tx)
+	})
+}
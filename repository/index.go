@@ -0,0 +1,36 @@
+package repository
+
+import "gorm.io/gorm"
+
+// 同じ並び順を共有するレコード(scope)の中でfromの位置にあるレコードをtoに移動する際に、間にあるレコードのindexを1つずつずらす
+// tableはindexを持つテーブル名 scopeは例えば"cards.list_id = ?"
+func shiftIndexesForMove(tx *gorm.DB, value interface{}, table string, scope string, scopeID int, from int, to int) error {
+	column := table + ".index"
+	if to > from {
+		return tx.Model(value).Where(column+" > ? AND "+column+" <= ? AND "+scope, from, to, scopeID).Updates(map[string]interface{}{"index": gorm.Expr(column+" - ?", 1)}).Error
+	}
+
+	return tx.Model(value).Where(column+" < ? AND "+column+" >= ? AND "+scope, from, to, scopeID).Updates(map[string]interface{}{"index": gorm.Expr(column+" + ?", 1)}).Error
+}
+
+// scopeの中でindexより後ろにあるレコードのindexを1つずつ詰める 削除時に使う
+func shiftIndexesForDestroy(tx *gorm.DB, value interface{}, table string, scope string, scopeID int, index int) error {
+	column := table + ".index"
+	return tx.Model(value).Where(column+" > ? AND "+scope, index, scopeID).Updates(map[string]interface{}{"index": gorm.Expr(column+" - ?", 1)}).Error
+}
+
+// scopeの中でindex以降にあるレコードのindexを1つずつ後ろにずらす 元に戻したレコードを挿入する際に使う
+func shiftIndexesForInsert(tx *gorm.DB, value interface{}, table string, scope string, scopeID int, index int) error {
+	column := table + ".index"
+	return tx.Model(value).Where(column+" >= ? AND "+scope, index, scopeID).Updates(map[string]interface{}{"index": gorm.Expr(column+" + ?", 1)}).Error
+}
+
+// 元に戻すレコードの位置 元の位置がscopeのレコード数を超える場合は末尾にする
+func restoringIndex(tx *gorm.DB, value interface{}, scope string, scopeID int, index int) (int, error) {
+	var count int64
+	err := tx.Model(value).Where(scope, scopeID).Count(&count).Error
+	if int64(index) > count {
+		return int(count), err
+	}
+	return index, err
+}
@@ -0,0 +1,67 @@
+package repository
+
+// mockgen -source=repository/label-repository.go -destination=mock_repository/label-repository.go
+
+import (
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"gorm.io/gorm"
+)
+
+type LabelRepository interface {
+	Create(label *model.Label) error
+	FindAll(user *model.User) ([]model.Label, error)
+	Find(id int) (model.Label, error)
+	Update(label *model.Label, updatingLabel model.Label) error
+	Destroy(label *model.Label) error
+	Attach(card *model.Card, label *model.Label) error
+	Detach(card *model.Card, label *model.Label) error
+}
+
+type labelRepository struct {
+	db *gorm.DB
+}
+
+func NewLabelRepository() LabelRepository {
+	return &labelRepository{db: db.GetDB()}
+}
+
+func (r *labelRepository) Create(label *model.Label) error {
+	return r.db.Create(label).Error
+}
+
+func (r *labelRepository) FindAll(user *model.User) ([]model.Label, error) {
+	var labels []model.Label
+	err := r.db.Where("user_id = ?", user.ID).Order("id asc").Find(&labels).Error
+	return labels, err
+}
+
+func (r *labelRepository) Find(id int) (model.Label, error) {
+	var label model.Label
+	err := r.db.First(&label, id).Error
+	return label, err
+}
+
+func (r *labelRepository) Update(label *model.Label, updatingLabel model.Label) error {
+	return r.db.Model(label).Select("name", "color").Updates(updatingLabel).Error
+}
+
+// ラベルは論理削除なのでカードとの紐付けも削除する
+func (r *labelRepository) Destroy(label *model.Label) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("DELETE FROM card_labels WHERE label_id = ?", label.ID).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Delete(label).Error
+	})
+}
+
+func (r *labelRepository) Attach(card *model.Card, label *model.Label) error {
+	return r.db.Model(card).Association("Labels").Append(label)
+}
+
+func (r *labelRepository) Detach(card *model.Card, label *model.Label) error {
+	return r.db.Model(card).Association("Labels").Delete(label)
+}
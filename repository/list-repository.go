@@ -3,6 +3,8 @@ package repository
 // mockgen -source=repository/list-repository.go -destination=./mock_repository/list-repository.go
 
 import (
+	"time"
+
 	"github.com/kuritaeiji/todo-gin-back/db"
 	"github.com/kuritaeiji/todo-gin-back/model"
 	"gorm.io/gorm"
@@ -14,40 +16,59 @@ type listRepository struct {
 }
 
 type ListRepository interface {
-	Create(*model.User, *model.List) error
+	Create(*model.Board, *model.List) error
 	Update(list *model.List, updatingList model.List) error
 	Destroy(*model.List) error
 	DestroyLists(lists *[]model.List, tx *gorm.DB) error
-	Move(list *model.List, toIndex int, currentUser *model.User) error
+	Move(list *model.List, toIndex int) error
 	Find(id int) (model.List, error)
-	FindListsWithCards(*model.User) error
+	FindListsWithCards(*model.Board) error
+	FindListsWithCardsByLabels(board *model.Board, labelIDs []int) error
+	Archive(*model.List) error
+	FindTrashed(*model.User) ([]model.List, error)
+	FindWithTrashed(id int) (model.List, error)
+	Restore(*model.List) error
+	DestroyPermanently(*model.List) error
 }
 
 func NewListRepository() ListRepository {
 	return &listRepository{db: db.GetDB()}
 }
 
-func (r *listRepository) Create(user *model.User, list *model.List) error {
-	return r.db.Model(user).Association("Lists").Append(list)
+func (r *listRepository) Create(board *model.Board, list *model.List) error {
+	return r.db.Model(board).Association("Lists").Append(list)
 }
 
 func (r *listRepository) Update(list *model.List, updatingList model.List) error {
 	return r.db.Model(&list).Select("title").Updates(updatingList).Error
 }
 
+// アーカイブされていないリストのindexは0から連続させる
+const activeListScope = "lists.board_id = ? AND lists.archived_at IS NULL"
+
+// カードにはリストと同じ削除日時を入れ、リストを元に戻す際に一緒に削除したカードだけを戻せるようにする
 func (r *listRepository) Destroy(list *model.List) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		err := tx.Select(clause.Associations).Delete(list).Error
+		err := destroyUnsentListReminders(tx, list.ID)
 		if err != nil {
 			return err
 		}
 
-		err = tx.Model(model.List{}).Where("lists.index > ? AND lists.user_id = ?", list.Index, list.UserID).Updates(map[string]interface{}{"index": gorm.Expr("lists.index - ?", 1)}).Error
+		now := time.Now()
+		err = tx.Model(model.Card{}).Where("list_id = ?", list.ID).Update("deleted_at", now).Error
 		if err != nil {
 			return err
 		}
 
-		return nil
+		err = tx.Model(list).Update("deleted_at", now).Error
+		if err != nil {
+			return err
+		}
+
+		if list.ArchivedAt != nil {
+			return nil
+		}
+		return shiftIndexesForDestroy(tx, model.List{}, "lists", activeListScope, list.BoardID, list.Index)
 	})
 }
 
@@ -56,49 +77,134 @@ func (r *listRepository) DestroyLists(lists *[]model.List, tx *gorm.DB) error {
 	return tx.Select(clause.Associations).Delete(lists).Error
 }
 
-func (r *listRepository) Move(list *model.List, toIndex int, currentUser *model.User) error {
-	if toIndex > list.Index {
-		return r.db.Transaction(func(tx *gorm.DB) error {
-			// 現在のリストの位置と移動後のリストの位置の間にあるリスト群を取り出し、indexを−1する
-			err := tx.Model(&model.List{}).Where("lists.index <= ? AND lists.index > ? AND lists.user_id = ?", toIndex, list.Index, currentUser.ID).Updates(map[string]interface{}{"index": gorm.Expr("lists.index - ?", 1)}).Error
-			if err != nil {
-				return err
-			}
+func (r *listRepository) Move(list *model.List, toIndex int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := shiftIndexesForMove(tx, model.List{}, "lists", activeListScope, list.BoardID, list.Index, toIndex)
+		if err != nil {
+			return err
+		}
 
-			err = tx.Model(list).Update("index", toIndex).Error
-			if err != nil {
-				return err
-			}
+		return tx.Model(list).Update("index", toIndex).Error
+	})
+}
 
-			return nil
-		})
+// 所有者を確認できるようにボードもpreloadする
+func (r *listRepository) Find(id int) (model.List, error) {
+	var list model.List
+	err := r.db.Preload("Board").First(&list, id).Error
+	return list, err
+}
+
+func (r *listRepository) FindListsWithCards(board *model.Board) error {
+	// board.listsにlistsをsetする(cardもpreloadした状態で) アーカイブ中のリストとカードは含めない
+	err := r.db.Where(model.List{BoardID: board.ID}).Where("lists.archived_at IS NULL").Order("lists.index ASC").Preload("Cards", func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("cards.archived_at IS NULL").Order("cards.index ASC")
+	}).Preload("Cards.Labels").Preload("Cards.Checklists.Items").Find(&board.Lists).Error
+	if err != nil {
+		return err
 	}
 
-	// toIndexがlistのindexよりも小さい時
+	return setCommentCounts(r.db, cardsInLists(board.Lists))
+}
+
+// labelIDsのいずれかのラベルが付いたカードのみをpreloadする カードが無いリストも返す
+func (r *listRepository) FindListsWithCardsByLabels(board *model.Board, labelIDs []int) error {
+	err := r.db.Where(model.List{BoardID: board.ID}).Where("lists.archived_at IS NULL").Order("lists.index ASC").Preload("Cards", func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("cards.archived_at IS NULL AND cards.id IN (?)", r.db.Table("card_labels").Select("card_id").Where("label_id IN ?", labelIDs)).Order("cards.index ASC")
+	}).Preload("Cards.Labels").Preload("Cards.Checklists.Items").Find(&board.Lists).Error
+	if err != nil {
+		return err
+	}
+
+	return setCommentCounts(r.db, cardsInLists(board.Lists))
+}
+
+func (r *listRepository) Archive(list *model.List) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		// 現在のリストの位置と移動後のリストの位置の間にあるリスト群を取り出し、indexを+1する
-		err := tx.Model(model.List{}).Where("lists.index >= ? AND lists.index < ? AND lists.user_id = ?", toIndex, list.ID, list.UserID).Updates(map[string]interface{}{"index": gorm.Expr("lists.index + ?", 1)}).Error
+		err := destroyUnsentListReminders(tx, list.ID)
 		if err != nil {
 			return err
 		}
 
-		err = tx.Model(list).Update("index", toIndex).Error
+		err = tx.Model(list).Update("archived_at", time.Now()).Error
 		if err != nil {
 			return err
 		}
-		return nil
+
+		return shiftIndexesForDestroy(tx, model.List{}, "lists", activeListScope, list.BoardID, list.Index)
 	})
 }
 
-func (r *listRepository) Find(id int) (model.List, error) {
+// 編集できるボードのアーカイブ中または削除済みのリストを新しい順に返す
+func (r *listRepository) FindTrashed(user *model.User) ([]model.List, error) {
+	var lists []model.List
+	err := r.db.Unscoped().Where("board_id IN (?)", editableBoardIDs(r.db, user.ID)).Where("archived_at IS NOT NULL OR deleted_at IS NOT NULL").Order("COALESCE(deleted_at, archived_at) DESC").Find(&lists).Error
+	return lists, err
+}
+
+func (r *listRepository) FindWithTrashed(id int) (model.List, error) {
 	var list model.List
-	err := r.db.First(&list, id).Error
+	err := r.db.Unscoped().Preload("Board").First(&list, id).Error
 	return list, err
 }
 
-func (r *listRepository) FindListsWithCards(user *model.User) error {
-	// user.listsにlistsをsetする(cardもpreloadした状態で)
-	return r.db.Where(model.List{UserID: user.ID}).Order("lists.index ASC").Preload("Cards", func(tx *gorm.DB) *gorm.DB {
-		return tx.Order("cards.index ASC")
-	}).Find(&user.Lists).Error
+// 元の位置(アーカイブされていないリストの数を超える場合は末尾)に戻し、後ろのリストをずらす
+// 削除済みの場合はリストと一緒に削除したカードも戻す
+func (r *listRepository) Restore(list *model.List) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		toIndex, err := restoringIndex(tx, model.List{}, activeListScope, list.BoardID, list.Index)
+		if err != nil {
+			return err
+		}
+
+		err = shiftIndexesForInsert(tx, model.List{}, "lists", activeListScope, list.BoardID, toIndex)
+		if err != nil {
+			return err
+		}
+
+		if list.DeletedAt.Valid {
+			err = tx.Unscoped().Model(model.Card{}).Where("list_id = ? AND deleted_at = ?", list.ID, list.DeletedAt.Time).Update("deleted_at", nil).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		err = tx.Unscoped().Model(list).Updates(map[string]interface{}{"archived_at": nil, "deleted_at": nil, "index": toIndex}).Error
+		if err != nil {
+			return err
+		}
+
+		list.ArchivedAt = nil
+		list.DeletedAt = gorm.DeletedAt{}
+		list.Index = toIndex
+		return nil
+	})
+}
+
+// リストと削除済みのものも含めた全カードを物理削除する 添付ファイルは事前に削除しておく
+func (r *listRepository) DestroyPermanently(list *model.List) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := destroyCardsPermanently(tx, "list_id = ?", list.ID)
+		if err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Delete(list).Error
+	})
+}
+
+// カードの削除やアーカイブと同じく未送信のリマインダーは元に戻す際に作り直す
+func destroyUnsentListReminders(tx *gorm.DB, listID int) error {
+	cardIDs := tx.Unscoped().Model(model.Card{}).Select("id").Where("list_id = ?", listID)
+	return tx.Unscoped().Where("sent_at IS NULL AND card_id IN (?)", cardIDs).Delete(&model.CardReminder{}).Error
+}
+
+func cardsInLists(lists []model.List) []*model.Card {
+	cards := make([]*model.Card, 0)
+	for i := range lists {
+		for j := range lists[i].Cards {
+			cards = append(cards, &lists[i].Cards[j])
+		}
+	}
+	return cards
 }
@@ -0,0 +1,79 @@
+package repository
+
+// mockgen -source=repository/login-attempt-repository.go -destination=mock_repository/login-attempt-repository.go
+
+import (
+	"time"
+
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"gorm.io/gorm"
+)
+
+type LoginAttemptRepository interface {
+	FindAll() ([]model.LoginAttempt, error)
+	FindByKey(key string) (model.LoginAttempt, error)
+	Increment(key string, failedAt time.Time, resetBefore time.Time) (model.LoginAttempt, error)
+	Lock(attempt *model.LoginAttempt, lockedUntil time.Time) error
+	DestroyByKey(key string) error
+}
+
+type loginAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptRepository() LoginAttemptRepository {
+	return &loginAttemptRepository{db: db.GetDB()}
+}
+
+func (r *loginAttemptRepository) FindAll() ([]model.LoginAttempt, error) {
+	var attempts []model.LoginAttempt
+	err := r.db.Order("failures desc").Find(&attempts).Error
+	return attempts, err
+}
+
+func (r *loginAttemptRepository) FindByKey(key string) (model.LoginAttempt, error) {
+	var attempt model.LoginAttempt
+	err := r.db.Where("`key` = ?", key).First(&attempt).Error
+	return attempt, err
+}
+
+// 同時に失敗したリクエストで回数が失われないように1つのクエリで失敗回数を増やし、更新後の行を返す
+// 最後の失敗がresetBeforeより前の場合は失敗回数とロックをリセットしてから数え直す
+// 更新した行はトランザクションが終わるまでロックされるので、読み直した失敗回数は自分の更新の結果になる
+func (r *loginAttemptRepository) Increment(key string, failedAt time.Time, resetBefore time.Time) (model.LoginAttempt, error) {
+	var attempt model.LoginAttempt
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// ON DUPLICATE KEY UPDATEの代入は左から順に評価されるのでlast_failed_atは最後に更新する
+		err := tx.Exec(
+			"INSERT INTO login_attempts (`key`, failures, last_failed_at, created_at, updated_at) VALUES (?, 1, ?, ?, ?) "+
+				"ON DUPLICATE KEY UPDATE failures = IF(last_failed_at < ?, 1, failures + 1), "+
+				"locked_until = IF(last_failed_at < ?, NULL, locked_until), "+
+				"last_failed_at = VALUES(last_failed_at), updated_at = VALUES(updated_at)",
+			key, failedAt, failedAt, failedAt, resetBefore, resetBefore,
+		).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Where("`key` = ?", key).First(&attempt).Error
+	})
+	return attempt, err
+}
+
+// 同時に失敗したリクエストで先に設定された長いロックを短くしないようにする
+func (r *loginAttemptRepository) Lock(attempt *model.LoginAttempt, lockedUntil time.Time) error {
+	err := r.db.Model(model.LoginAttempt{}).Where(
+		"id = ? AND (locked_until IS NULL OR locked_until < ?)", attempt.ID, lockedUntil,
+	).Update("locked_until", lockedUntil).Error
+	if err != nil {
+		return err
+	}
+
+	attempt.LockedUntil = &lockedUntil
+	return nil
+}
+
+func (r *loginAttemptRepository) DestroyByKey(key string) error {
+	return r.db.Unscoped().Where("`key` = ?", key).Delete(&model.LoginAttempt{}).Error
+}
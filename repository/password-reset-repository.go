@@ -0,0 +1,32 @@
+package repository
+
+// mockgen -source=repository/password-reset-repository.go -destination=mock_repository/password-reset-repository.go
+
+import (
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"gorm.io/gorm"
+)
+
+type PasswordResetRepository interface {
+	Create(reset *model.PasswordReset) error
+	FindByDigest(digest string) (model.PasswordReset, error)
+}
+
+type passwordResetRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetRepository() PasswordResetRepository {
+	return &passwordResetRepository{db: db.GetDB()}
+}
+
+func (r *passwordResetRepository) Create(reset *model.PasswordReset) error {
+	return r.db.Create(reset).Error
+}
+
+func (r *passwordResetRepository) FindByDigest(digest string) (model.PasswordReset, error) {
+	var reset model.PasswordReset
+	err := r.db.Joins("User").Where("password_resets.digest = ?", digest).First(&reset).Error
+	return reset, err
+}
@@ -0,0 +1,61 @@
+package repository
+
+// mockgen -source=repository/personal-access-token-repository.go -destination=mock_repository/personal-access-token-repository.go
+
+import (
+	"time"
+
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"gorm.io/gorm"
+)
+
+type PersonalAccessTokenRepository interface {
+	Create(token *model.PersonalAccessToken) error
+	FindAll(user *model.User) ([]model.PersonalAccessToken, error)
+	FindByDigest(digest string) (model.PersonalAccessToken, error)
+	UpdateLastUsedAt(token *model.PersonalAccessToken, usedAt time.Time) error
+	Destroy(user *model.User, id int) error
+}
+
+type personalAccessTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPersonalAccessTokenRepository() PersonalAccessTokenRepository {
+	return &personalAccessTokenRepository{db: db.GetDB()}
+}
+
+func (r *personalAccessTokenRepository) Create(token *model.PersonalAccessToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *personalAccessTokenRepository) FindAll(user *model.User) ([]model.PersonalAccessToken, error) {
+	var tokens []model.PersonalAccessToken
+	err := r.db.Where("user_id = ?", user.ID).Order("id desc").Find(&tokens).Error
+	return tokens, err
+}
+
+func (r *personalAccessTokenRepository) FindByDigest(digest string) (model.PersonalAccessToken, error) {
+	var token model.PersonalAccessToken
+	err := r.db.Joins("User").Where("personal_access_tokens.digest = ?", digest).First(&token).Error
+	return token, err
+}
+
+func (r *personalAccessTokenRepository) UpdateLastUsedAt(token *model.PersonalAccessToken, usedAt time.Time) error {
+	token.LastUsedAt = &usedAt
+	return r.db.Model(token).Update("last_used_at", usedAt).Error
+}
+
+// 他のユーザーのトークンは削除できない
+func (r *personalAccessTokenRepository) Destroy(user *model.User, id int) error {
+	result := r.db.Unscoped().Where("id = ? AND user_id = ?", id, user.ID).Delete(&model.PersonalAccessToken{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package repository
+
+// mockgen -source=repository/refresh-token-repository.go -destination=mock_repository/refresh-token-repository.go
+
+import (
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepository interface {
+	Create(token *model.RefreshToken) error
+	FindByDigest(digest string) (model.RefreshToken, error)
+	Use(token *model.RefreshToken) error
+	DestroyFamily(family string) error
+}
+
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository() RefreshTokenRepository {
+	return &refreshTokenRepository{db: db.GetDB()}
+}
+
+func (r *refreshTokenRepository) Create(token *model.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *refreshTokenRepository) FindByDigest(digest string) (model.RefreshToken, error) {
+	var token model.RefreshToken
+	err := r.db.Joins("User").Where("refresh_tokens.digest = ?", digest).First(&token).Error
+	return token, err
+}
+
+// 未使用の場合のみ使用済みにする 同時に同じトークンが使われた場合は片方がReusedRefreshTokenErrorになる
+func (r *refreshTokenRepository) Use(token *model.RefreshToken) error {
+	result := r.db.Model(model.RefreshToken{}).Where("id = ? AND used = ?", token.ID, false).Update("used", true)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return config.ReusedRefreshTokenError
+	}
+
+	token.Used = true
+	return nil
+}
+
+func (r *refreshTokenRepository) DestroyFamily(family string) error {
+	return r.db.Unscoped().Where("family = ?", family).Delete(&model.RefreshToken{}).Error
+}
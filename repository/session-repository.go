@@ -0,0 +1,70 @@
+package repository
+
+// mockgen -source=repository/session-repository.go -destination=mock_repository/session-repository.go
+
+import (
+	"time"
+
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"gorm.io/gorm"
+)
+
+type SessionRepository interface {
+	Create(session *model.Session) error
+	FindAll(user *model.User) ([]model.Session, error)
+	Find(user *model.User, id int) (model.Session, error)
+	FindBySessionID(sessionID string) (model.Session, error)
+	FindActive(sessionID string, userID int, jti string) (model.Session, error)
+	UpdateLastSeenAt(session *model.Session, seenAt time.Time) error
+	DestroyBySessionID(sessionID string) error
+}
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository() SessionRepository {
+	return &sessionRepository{db: db.GetDB()}
+}
+
+func (r *sessionRepository) Create(session *model.Session) error {
+	return r.db.Create(session).Error
+}
+
+func (r *sessionRepository) FindAll(user *model.User) ([]model.Session, error) {
+	var sessions []model.Session
+	err := r.db.Where("user_id = ?", user.ID).Order("last_seen_at desc").Find(&sessions).Error
+	return sessions, err
+}
+
+// 他のユーザーのセッションは見つからない
+func (r *sessionRepository) Find(user *model.User, id int) (model.Session, error) {
+	var session model.Session
+	err := r.db.Where("id = ? AND user_id = ?", id, user.ID).First(&session).Error
+	return session, err
+}
+
+func (r *sessionRepository) FindBySessionID(sessionID string) (model.Session, error) {
+	var session model.Session
+	err := r.db.Where("session_id = ?", sessionID).First(&session).Error
+	return session, err
+}
+
+// アクセストークンのセッションを探す 他のユーザーのセッションやログアウト済みのjtiの場合は見つからない
+// リクエストごとに実行するのでjtiの失効確認も同じクエリで行う
+func (r *sessionRepository) FindActive(sessionID string, userID int, jti string) (model.Session, error) {
+	var session model.Session
+	revoked := r.db.Model(&model.RevokedToken{}).Select("1").Where("jti = ?", jti)
+	err := r.db.Where("session_id = ? AND user_id = ?", sessionID, userID).Where("NOT EXISTS (?)", revoked).First(&session).Error
+	return session, err
+}
+
+func (r *sessionRepository) UpdateLastSeenAt(session *model.Session, seenAt time.Time) error {
+	session.LastSeenAt = seenAt
+	return r.db.Model(session).Update("last_seen_at", seenAt).Error
+}
+
+func (r *sessionRepository) DestroyBySessionID(sessionID string) error {
+	return r.db.Unscoped().Where("session_id = ?", sessionID).Delete(&model.Session{}).Error
+}
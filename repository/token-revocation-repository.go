@@ -0,0 +1,89 @@
+package repository
+
+// mockgen -source=repository/token-revocation-repository.go -destination=mock_repository/token-revocation-repository.go
+
+import (
+	"time"
+
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TokenRevocationRepository interface {
+	Revoke(jti string, expiresAt time.Time) error
+	RevokeOnce(jti string, expiresAt time.Time) (bool, error)
+	IsRevoked(jti string) (bool, error)
+	RevokeAll(user *model.User) error
+	RevokeAllWithTx(user *model.User, tx *gorm.DB) error
+}
+
+type tokenRevocationRepository struct {
+	db *gorm.DB
+}
+
+func NewTokenRevocationRepository() TokenRevocationRepository {
+	return &tokenRevocationRepository{db: db.GetDB()}
+}
+
+func (r *tokenRevocationRepository) Revoke(jti string, expiresAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// 有効期限が切れたjtiは保持する必要がないので削除する
+		err := tx.Unscoped().Where("expires_at < ?", time.Now()).Delete(&model.RevokedToken{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(&model.RevokedToken{JTI: jti, ExpiresAt: expiresAt}).Error
+	})
+}
+
+// 1回しか使えないトークンを使用済みにする 既に使用済みの場合はfalseを返す
+// 同時に使われた場合も1つしか成功しないように一意制約で判定する
+func (r *tokenRevocationRepository) RevokeOnce(jti string, expiresAt time.Time) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.RevokedToken{JTI: jti, ExpiresAt: expiresAt})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *tokenRevocationRepository) IsRevoked(jti string) (bool, error) {
+	var count int64
+	err := r.db.Model(model.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *tokenRevocationRepository) RevokeAll(user *model.User) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return r.RevokeAllWithTx(user, tx)
+	})
+}
+
+// token_versionを上げてアクセストークンを無効にし、リフレッシュトークンとセッションとパーソナルアクセストークンを全て削除する
+// userを削除する際にトランザクション内で使う
+func (r *tokenRevocationRepository) RevokeAllWithTx(user *model.User, tx *gorm.DB) error {
+	err := tx.Unscoped().Where("user_id = ?", user.ID).Delete(&model.RefreshToken{}).Error
+	if err != nil {
+		return err
+	}
+
+	err = tx.Unscoped().Where("user_id = ?", user.ID).Delete(&model.Session{}).Error
+	if err != nil {
+		return err
+	}
+
+	err = tx.Unscoped().Where("user_id = ?", user.ID).Delete(&model.PersonalAccessToken{}).Error
+	if err != nil {
+		return err
+	}
+
+	err = tx.Model(user).Update("token_version", gorm.Expr("token_version + ?", 1)).Error
+	if err != nil {
+		return err
+	}
+
+	user.TokenVersion++
+	return nil
+}
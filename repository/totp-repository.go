@@ -0,0 +1,94 @@
+package repository
+
+// mockgen -source=repository/totp-repository.go -destination=mock_repository/totp-repository.go
+
+import (
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"gorm.io/gorm"
+)
+
+type TotpRepository interface {
+	SaveSecret(user *model.User, secret string) error
+	Enable(user *model.User, recoveryCodeDigests []string) error
+	Disable(user *model.User) error
+	UseRecoveryCode(user *model.User, digest string) error
+	UseTimeStep(user *model.User, step int64) error
+}
+
+type totpRepository struct {
+	db *gorm.DB
+}
+
+func NewTotpRepository() TotpRepository {
+	return &totpRepository{db: db.GetDB()}
+}
+
+func (r *totpRepository) SaveSecret(user *model.User, secret string) error {
+	user.TotpSecret = secret
+	return r.db.Model(user).Update("totp_secret", secret).Error
+}
+
+// 2段階認証を有効にしてリカバリーコードを作り直す
+func (r *totpRepository) Enable(user *model.User, recoveryCodeDigests []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(user).Update("totp_enabled", true).Error
+		if err != nil {
+			return err
+		}
+
+		err = r.destroyRecoveryCodesWithTx(user, tx)
+		if err != nil {
+			return err
+		}
+
+		codes := make([]model.RecoveryCode, 0, len(recoveryCodeDigests))
+		for _, digest := range recoveryCodeDigests {
+			codes = append(codes, model.RecoveryCode{Digest: digest, UserID: user.ID})
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+func (r *totpRepository) Disable(user *model.User) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(user).Updates(map[string]interface{}{"totp_enabled": false, "totp_secret": ""}).Error
+		if err != nil {
+			return err
+		}
+
+		return r.destroyRecoveryCodesWithTx(user, tx)
+	})
+}
+
+// リカバリーコードは1回しか使えないので削除する 見つからない場合はgorm.ErrRecordNotFoundを返す
+func (r *totpRepository) UseRecoveryCode(user *model.User, digest string) error {
+	result := r.db.Unscoped().Where("user_id = ? AND digest = ?", user.ID, digest).Delete(&model.RecoveryCode{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// 受け付けた時間ステップ以前のコードは使えないようにする 既に使われている場合はgorm.ErrRecordNotFoundを返す
+// 同時に同じコードで認証された場合も1つしか受け付けないように条件付きで更新する
+func (r *totpRepository) UseTimeStep(user *model.User, step int64) error {
+	result := r.db.Model(model.User{}).Where("id = ? AND totp_last_step < ?", user.ID, step).Update("totp_last_step", step)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	user.TotpLastStep = step
+	return nil
+}
+
+func (r *totpRepository) destroyRecoveryCodesWithTx(user *model.User, tx *gorm.DB) error {
+	return tx.Unscoped().Where("user_id = ?", user.ID).Delete(&model.RecoveryCode{}).Error
+}
@@ -3,6 +3,8 @@ package repository
 // mockgen -source=repository/user-repository.go -destination=mock_repository/user-repository.go
 
 import (
+	"time"
+
 	"github.com/kuritaeiji/todo-gin-back/config"
 	"github.com/kuritaeiji/todo-gin-back/db"
 	"github.com/kuritaeiji/todo-gin-back/model"
@@ -12,23 +14,28 @@ import (
 type UserRepository interface {
 	Create(user *model.User) error
 	Activate(user *model.User) error
+	UpdateActivationSentAt(user *model.User, sentAt time.Time) error
+	UpdatePassword(user *model.User) error
 	Destroy(user *model.User) error
-	FindOrCreateByOpenID(openID string) (model.User, error)
+	FindOrCreateByIdentity(provider, subject, verifiedEmail string, trustEmail bool) (model.User, error)
+	LinkIdentity(user *model.User, provider, subject string) error
+	UnlinkIdentity(user *model.User, provider string) error
 	IsUnique(email string) (bool, error)
 	Find(id int) (model.User, error)
 	FindByEmail(email string) (model.User, error)
-	HasCard(card model.Card, user model.User) (bool, error)
 }
 
 type userRepository struct {
-	db             *gorm.DB
-	listRepository ListRepository
+	db                        *gorm.DB
+	listRepository            ListRepository
+	tokenRevocationRepository TokenRevocationRepository
 }
 
 func NewUserRepository() UserRepository {
 	return &userRepository{
-		db:             db.GetDB(),
-		listRepository: NewListRepository(),
+		db:                        db.GetDB(),
+		listRepository:            NewListRepository(),
+		tokenRevocationRepository: NewTokenRevocationRepository(),
 	}
 }
 
@@ -44,41 +51,201 @@ func (r *userRepository) Activate(user *model.User) error {
 	return r.db.Save(user).Error
 }
 
-func (r *userRepository) Destroy(user *model.User) error {
-	err := r.listRepository.FindListsWithCards(user)
-	if err != nil {
-		return err
-	}
+func (r *userRepository) UpdateActivationSentAt(user *model.User, sentAt time.Time) error {
+	user.ActivationSentAt = &sentAt
+	return r.db.Model(user).Update("activation_sent_at", sentAt).Error
+}
 
+// パスワードを更新して未使用の再設定用トークンとログイン中のトークンを全て無効にする
+// パスワード再設定とパスワード変更の両方で使う
+func (r *userRepository) UpdatePassword(user *model.User) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		err := r.listRepository.DestroyLists(&user.Lists, tx)
+		err := tx.Model(user).Update("password_digest", user.PasswordDigest).Error
 		if err != nil {
 			return err
 		}
 
-		return r.db.Delete(user).Error
+		err = tx.Unscoped().Where("user_id = ?", user.ID).Delete(&model.PasswordReset{}).Error
+		if err != nil {
+			return err
+		}
+
+		return r.tokenRevocationRepository.RevokeAllWithTx(user, tx)
 	})
 }
 
-func (r *userRepository) FindOrCreateByOpenID(openID string) (model.User, error) {
+// 他にオーナーがいる共有ボードは残してメンバーから外すだけにする
+func (r *userRepository) Destroy(user *model.User) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		boardIDs, err := soleOwnedBoardIDs(tx, user)
+		if err != nil {
+			return err
+		}
+
+		// アーカイブ中のリストも削除する
+		var lists []model.List
+		err = tx.Where("board_id IN ?", boardIDs).Find(&lists).Error
+		if err != nil {
+			return err
+		}
+
+		if len(lists) > 0 {
+			err := r.listRepository.DestroyLists(&lists, tx)
+			if err != nil {
+				return err
+			}
+		}
+
+		// 参加しているボードのメンバーと削除するボードのメンバーを削除する
+		err = tx.Unscoped().Where("user_id = ? OR board_id IN ?", user.ID, boardIDs).Delete(&model.BoardMember{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Where("id IN ?", boardIDs).Delete(&model.Board{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = transferCreatedBoards(tx, user)
+		if err != nil {
+			return err
+		}
+
+		// 発行済みのトークンを全て無効にする
+		err = r.tokenRevocationRepository.RevokeAllWithTx(user, tx)
+		if err != nil {
+			return err
+		}
+
+		// 紐付けたアカウントで再度ログインした場合は新しいユーザーを作成する
+		err = tx.Unscoped().Where("user_id = ?", user.ID).Delete(&model.Identity{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Unscoped().Where("user_id = ?", user.ID).Delete(&model.PersonalAccessToken{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Where("user_id = ?", user.ID).Delete(&model.Label{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Delete(user).Error
+	})
+}
+
+// (provider, subject)の組でユーザーを探し、見つからなければ作成する
+// verifiedEmailはIDプロバイダーが確認済みのメールアドレス 確認されていない場合は空文字を渡す
+// 同じメールアドレスで有効化済みのユーザーがいる場合、trustEmailのプロバイダーであればそのユーザーにアカウントを紐付ける
+func (r *userRepository) FindOrCreateByIdentity(provider, subject, verifiedEmail string, trustEmail bool) (model.User, error) {
 	var user model.User
-	err := r.db.Model(model.User{}).Where("open_id = ?", openID).First(&user).Error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var identity model.Identity
+		err := tx.Joins("User").Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error
+		if err == nil {
+			user = identity.User
+			return nil
+		}
 
-	// ユーザーが見つかった場合とエラーが発生した場合
-	if err != nil && err != gorm.ErrRecordNotFound {
-		return user, err
-	}
+		if err != gorm.ErrRecordNotFound {
+			return err
+		}
 
-	// ユーザーが見つからなかった場合
-	if err == gorm.ErrRecordNotFound {
-		user.OpenID = openID
-		user.Activated = true
-		err = r.db.Create(&user).Error
-	}
+		if verifiedEmail != "" {
+			err = tx.Where("email = ?", verifiedEmail).First(&user).Error
+			if err != nil && err != gorm.ErrRecordNotFound {
+				return err
+			}
+
+			if err == nil {
+				// 信頼しないプロバイダーは確認済みと偽ってアカウントを乗っ取れるので、ログイン中に紐付けてもらう
+				// 有効化されていないユーザーは第三者がメールアドレスを先に登録した可能性があるので紐付けない
+				if !trustEmail || !user.Activated {
+					return config.AccountLinkConflictError
+				}
+
+				// 同じプロバイダーの別のアカウントが既に紐付いている場合
+				linked, err := r.hasIdentity(tx, &user, provider)
+				if err != nil {
+					return err
+				}
+				if linked {
+					return config.AccountLinkConflictError
+				}
+
+				return tx.Create(&model.Identity{Provider: provider, Subject: subject, UserID: user.ID}).Error
+			}
+		}
+
+		// ユーザーが見つからなかった場合
+		user = model.User{Email: verifiedEmail, Activated: true}
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model.Identity{Provider: provider, Subject: subject, UserID: user.ID}).Error
+	})
 
 	return user, err
 }
 
+// 既に紐付いている同じプロバイダーのアカウントは置き換える
+func (r *userRepository) LinkIdentity(user *model.User, provider, subject string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(model.Identity{}).Where("provider = ? AND subject = ? AND user_id <> ?", provider, subject, user.ID).Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return config.IdentityAlreadyLinkedError
+		}
+
+		err = tx.Unscoped().Where("user_id = ? AND provider = ?", user.ID, provider).Delete(&model.Identity{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(&model.Identity{Provider: provider, Subject: subject, UserID: user.ID}).Error
+	})
+}
+
+// パスワードを持たないユーザーは最後のアカウントの紐付けを解除するとログインできなくなるので解除させない
+func (r *userRepository) UnlinkIdentity(user *model.User, provider string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var identities []model.Identity
+		err := tx.Where("user_id = ?", user.ID).Find(&identities).Error
+		if err != nil {
+			return err
+		}
+
+		linked := false
+		for _, identity := range identities {
+			if identity.Provider == provider {
+				linked = true
+			}
+		}
+		if !linked {
+			return gorm.ErrRecordNotFound
+		}
+
+		if user.PasswordDigest == "" && len(identities) == 1 {
+			return config.UnlinkLastLoginMethodError
+		}
+
+		return tx.Unscoped().Where("user_id = ? AND provider = ?", user.ID, provider).Delete(&model.Identity{}).Error
+	})
+}
+
+func (r *userRepository) hasIdentity(tx *gorm.DB, user *model.User, provider string) (bool, error) {
+	var count int64
+	err := tx.Model(model.Identity{}).Where("user_id = ? AND provider = ?", user.ID, provider).Count(&count).Error
+	return count > 0, err
+}
+
 func (r *userRepository) IsUnique(email string) (bool, error) {
 	var count int64
 	err := r.db.Model(model.User{}).Where("email = ?", email).Count(&count).Error
@@ -108,11 +275,45 @@ func (r *userRepository) FindByEmail(email string) (model.User, error) {
 	return user, nil
 }
 
-func (r *userRepository) HasCard(card model.Card, user model.User) (bool, error) {
-	err := r.db.Joins("List").First(&card).Error
+// 作成したボードと自分がオーナーのボードのうち、他に承諾済みのオーナーがいないボードのidを返す
+func soleOwnedBoardIDs(tx *gorm.DB, user *model.User) ([]int, error) {
+	ownedBoardIDs := tx.Model(model.BoardMember{}).Select("board_id").Where(
+		"user_id = ? AND role = ? AND accepted_at IS NOT NULL", user.ID, model.BoardRoleOwner,
+	)
+	otherOwners := tx.Model(model.BoardMember{}).Select("1").Where(
+		"board_members.board_id = boards.id AND board_members.user_id <> ? AND board_members.role = ? AND board_members.accepted_at IS NOT NULL", user.ID, model.BoardRoleOwner,
+	)
+
+	var boardIDs []int
+	err := tx.Model(model.Board{}).Where("boards.user_id = ? OR boards.id IN (?)", user.ID, ownedBoardIDs).Where("NOT EXISTS (?)", otherOwners).Pluck("boards.id", &boardIDs).Error
+	return boardIDs, err
+}
+
+// 残した作成済みのボードは最初にオーナーになったメンバーに引き継ぎ、そのユーザーのボードの末尾に並べる
+func transferCreatedBoards(tx *gorm.DB, user *model.User) error {
+	var boards []model.Board
+	err := tx.Where("user_id = ?", user.ID).Find(&boards).Error
 	if err != nil {
-		return false, err
+		return err
 	}
 
-	return card.List.UserID == user.ID, nil
+	for _, board := range boards {
+		var owner model.BoardMember
+		err := tx.Where("board_id = ? AND role = ? AND accepted_at IS NOT NULL", board.ID, model.BoardRoleOwner).Order("accepted_at ASC").Order("id ASC").First(&owner).Error
+		if err != nil {
+			return err
+		}
+
+		var index int64
+		err = tx.Model(model.Board{}).Where("user_id = ?", owner.UserID).Count(&index).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&board).Updates(map[string]interface{}{"user_id": owner.UserID, "index": index}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
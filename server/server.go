@@ -9,14 +9,24 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/kuritaeiji/todo-gin-back/config"
 	"github.com/kuritaeiji/todo-gin-back/controller"
+	"github.com/kuritaeiji/todo-gin-back/gateway"
 	"github.com/kuritaeiji/todo-gin-back/middleware"
 	"github.com/kuritaeiji/todo-gin-back/mock_gateway"
 	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
 	"github.com/kuritaeiji/todo-gin-back/service"
 )
 
 func Init() {
-	router := RouterSetup(controller.NewUserController())
+	// 複数インスタンスで動かす場合は共有のストアに差し替える
+	router := RouterSetup(controller.NewUserController(), gateway.NewMemoryRateLimitStore())
+
+	// カードの期限のリマインダーの送信と保存期間を過ぎた監査ログの削除をバックグラウンドで行う
+	stop := make(chan struct{})
+	defer close(stop)
+	go service.NewCardReminderService().Run(stop)
+	go service.NewAuditService().Run(stop)
+
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
@@ -24,71 +34,241 @@ func Init() {
 	router.Run(":" + port)
 }
 
-func RouterSetup(userController controller.UserController) *gin.Engine {
+func RouterSetup(userController controller.UserController, rateLimitStore gateway.RateLimitStore) *gin.Engine {
 	r := router()
 	r.Use(middleware.NewCorsMiddleware())
+	// 他のサービスがアクセストークンを検証するための公開鍵 ブラウザ以外からも取得されるのでcsrf対策の前に登録する
+	r.GET("/.well-known/jwks.json", controller.NewJWKSController().Index)
 	if gin.Mode() != gin.TestMode {
 		r.Use(middleware.NewCsrfMiddleware().ConfirmRequestHeader)
 	}
 
 	api := r.Group("/api")
 
+	authCon := controller.NewAuthController()
+	tokenRateLimit := middleware.NewRateLimitMiddleware(middleware.TokenRateLimitPolicy, rateLimitStore)
+	// アクセストークンの有効期限が切れている状態で呼ばれるのでguestにもauthにも含めない
+	api.POST("/token/refresh", tokenRateLimit.Limit, authCon.Refresh)
+	// 確認リンクは別の端末で開かれる場合もある
+	api.PUT("/users/email/confirm", tokenRateLimit.Limit, userController.ConfirmEmail)
+
 	authMiddleware := middleware.NewAuthMiddleware()
 	guest := api.Group("")
 	{
+		guest.Use(middleware.NewRateLimitMiddleware(middleware.GuestRateLimitPolicy, rateLimitStore).Limit)
 		guest.Use(authMiddleware.Guest)
 
-		authCon := controller.NewAuthController()
 		guest.POST("/login", authCon.Login)
-		guest.GET("/google", authCon.Google)
-		guest.POST("/google/login", authCon.GoogleLogin)
+		guest.POST("/login/totp", authCon.TotpLogin)
+		guest.GET("/oauth/:provider", authCon.Oauth)
+		guest.POST("/oauth/:provider/login", authCon.OauthLogin)
+
+		passwordCon := controller.NewPasswordController()
+		guest.POST("/password/forgot", passwordCon.Forgot)
+		guest.PUT("/password/reset", passwordCon.Reset)
 
 		user := guest.Group("/users")
 		{
 			user.POST("", userController.Create)
 			user.GET("/unique", userController.IsUnique)
 			user.PUT("/activate", userController.Activate)
+			user.POST("/activation/resend", userController.ResendActivation)
 		}
 	}
 
+	// ログイン中のユーザーごとに制限するので認証の後に使用する
+	authRateLimit := middleware.NewRateLimitMiddleware(middleware.AuthRateLimitPolicy, rateLimitStore)
+	useAuthRateLimit := func(group *gin.RouterGroup) {
+		group.Use(authRateLimit.Limit)
+	}
+
+	// アカウントの操作はログインで発行したアクセストークンでのみ行える
 	auth := api.Group("")
 	{
 		auth.Use(authMiddleware.Auth)
+		useAuthRateLimit(auth)
 		auth.DELETE("/users", userController.Destroy)
+		auth.PUT("/users/password", userController.ChangePassword)
+		auth.PUT("/users/email", userController.ChangeEmail)
+
+		auth.DELETE("/logout", authCon.Logout)
+		auth.DELETE("/sessions", authCon.LogoutAll)
+		sessionCon := controller.NewSessionController()
+		auth.GET("/sessions", sessionCon.Index)
+		auth.DELETE("/sessions/:id", sessionCon.Destroy)
+
+		// ログイン中に認可エンドポイントのurlを取得してプロバイダーのアカウントを紐付ける
+		auth.GET("/users/oauth/:provider", authCon.Oauth)
+		auth.POST("/users/oauth/:provider", authCon.LinkOauth)
+		auth.DELETE("/users/oauth/:provider", authCon.UnlinkOauth)
+
+		totpCon := controller.NewTotpController()
+		auth.POST("/users/totp", totpCon.Enroll)
+		auth.PUT("/users/totp", totpCon.Enable)
+		auth.DELETE("/users/totp", totpCon.Disable)
+
+		tokenCon := controller.NewPersonalAccessTokenController()
+		auth.GET("/users/tokens", tokenCon.Index)
+		auth.POST("/users/tokens", tokenCon.Create)
+		auth.DELETE("/users/tokens/:id", tokenCon.Destroy)
+		auditCon := controller.NewAuditController()
+		auth.GET("/users/audit", auditCon.Index)
+
+		admin := auth.Group("/admin")
+		{
+			admin.Use(authMiddleware.Admin)
+			admin.GET("/login-attempts", controller.NewLoginAttemptController().Index)
+			admin.GET("/audit-logs", auditCon.AdminIndex)
+		}
+	}
 
-		listMiddleware := middleware.NewListMiddleware()
-		list := auth.Group("/lists")
+	// ボードとリストとカードはスコープを持つパーソナルアクセストークンでも操作できる
+	// ボードはリストの入れ物なのでリストのスコープで操作できる
+	// 共有したボードはメンバーのロールで操作を制限する viewerは参照のみできる
+	listCon := controller.NewListController()
+	board := api.Group("/boards")
+	{
+		board.Use(authMiddleware.Scope(model.ScopeListsRead, model.ScopeListsWrite))
+		useAuthRateLimit(board)
+		boardCon := controller.NewBoardController()
+		board.GET("", boardCon.Index)
+		board.POST("", boardCon.Create)
+
+		boardMiddleware := middleware.NewBoardMiddleware()
+		boardMemberCon := controller.NewBoardMemberController()
+		boardAuth := board.Group("")
 		{
-			listCon := controller.NewListController()
-			list.GET("", listCon.Index)
-			list.POST("", listCon.Create)
-
-			listAuth := list.Group("")
-			{
-				listAuth.Use(listMiddleware.Authorize)
-				listAuth.PUT("/:id", listCon.Update)
-				listAuth.DELETE("/:id", listCon.Destroy)
-				listAuth.PUT("/:id/move", listCon.Move)
-			}
+			boardAuth.Use(boardMiddleware.Authorize)
+			boardAuth.GET("/:id/lists", listCon.Index)
+			boardAuth.POST("/:id/lists", listCon.Create)
+			boardAuth.GET("/:id/members", boardMemberCon.Index)
+			// リストとカードの変更を配信する 接続中はレスポンスを返し続ける
+			boardAuth.GET("/:id/events", controller.NewBoardEventController().Stream)
 		}
 
-		cardCon := controller.NewCardController()
-		cardWithListAuth := auth.Group("")
+		boardOwner := board.Group("")
 		{
-			cardWithListAuth.Use(listMiddleware.Authorize)
-			cardWithListAuth.POST("/lists/:listID/cards", cardCon.Create)
+			boardOwner.Use(boardMiddleware.AuthorizeOwner)
+			boardOwner.PUT("/:id", boardCon.Update)
+			boardOwner.DELETE("/:id", boardCon.Destroy)
+			boardOwner.PUT("/:id/move", boardCon.Move)
+			boardOwner.POST("/:id/members", boardMemberCon.Invite)
+			boardOwner.PUT("/:id/members/:memberID", boardMemberCon.Update)
+			boardOwner.DELETE("/:id/members/:memberID", boardMemberCon.Destroy)
 		}
+	}
+
+	// 自分宛ての招待はボードの認可を通さずに承諾または拒否する
+	invitation := api.Group("/invitations")
+	{
+		invitation.Use(authMiddleware.Scope(model.ScopeListsRead, model.ScopeListsWrite))
+		useAuthRateLimit(invitation)
+		boardMemberCon := controller.NewBoardMemberController()
+		invitation.GET("", boardMemberCon.Invitations)
+		invitation.PUT("/:id", boardMemberCon.Accept)
+		invitation.DELETE("/:id", boardMemberCon.Decline)
+	}
+
+	listMiddleware := middleware.NewListMiddleware()
+	list := api.Group("/lists")
+	{
+		list.Use(authMiddleware.Scope(model.ScopeListsRead, model.ScopeListsWrite))
+		useAuthRateLimit(list)
 
-		card := auth.Group("/cards")
+		listAuth := list.Group("")
 		{
-			cardMiddleware := middleware.NewCardMiddleware()
-			card.Use(cardMiddleware.Authorize)
-			card.PUT("/:id", cardCon.Update)
-			card.DELETE("/:id", cardCon.Destroy)
-			card.PUT("/:id/move", cardCon.Move)
+			listAuth.Use(listMiddleware.Authorize)
+			listAuth.PUT("/:id", listCon.Update)
+			listAuth.DELETE("/:id", listCon.Destroy)
+			listAuth.PUT("/:id/move", listCon.Move)
+			listAuth.PUT("/:id/archive", listCon.Archive)
 		}
 	}
 
+	// ラベルはカードに付けるのでカードのスコープで操作できる
+	labelCon := controller.NewLabelController()
+	label := api.Group("/labels")
+	{
+		label.Use(authMiddleware.Scope(model.ScopeCardsRead, model.ScopeCardsWrite))
+		useAuthRateLimit(label)
+		label.GET("", labelCon.Index)
+		label.POST("", labelCon.Create)
+		label.PUT("/:id", labelCon.Update)
+		label.DELETE("/:id", labelCon.Destroy)
+	}
+
+	// コメントは作成者のみ編集と削除ができる
+	commentCon := controller.NewCommentController()
+	comment := api.Group("/comments")
+	{
+		comment.Use(authMiddleware.Scope(model.ScopeCardsRead, model.ScopeCardsWrite))
+		useAuthRateLimit(comment)
+		comment.PUT("/:id", commentCon.Update)
+		comment.DELETE("/:id", commentCon.Destroy)
+	}
+
+	cardCon := controller.NewCardController()
+	cardWithListAuth := api.Group("")
+	{
+		cardWithListAuth.Use(authMiddleware.Scope(model.ScopeCardsRead, model.ScopeCardsWrite))
+		useAuthRateLimit(cardWithListAuth)
+		cardWithListAuth.Use(listMiddleware.Authorize)
+		cardWithListAuth.POST("/lists/:listID/cards", cardCon.Create)
+	}
+
+	card := api.Group("/cards")
+	{
+		card.Use(authMiddleware.Scope(model.ScopeCardsRead, model.ScopeCardsWrite))
+		useAuthRateLimit(card)
+		cardMiddleware := middleware.NewCardMiddleware()
+		card.Use(cardMiddleware.Authorize)
+		card.PUT("/:id", cardCon.Update)
+		card.DELETE("/:id", cardCon.Destroy)
+		card.PUT("/:id/move", cardCon.Move)
+		card.PUT("/:id/archive", cardCon.Archive)
+		card.PUT("/:id/labels/:labelID", labelCon.Attach)
+		card.DELETE("/:id/labels/:labelID", labelCon.Detach)
+
+		card.GET("/:id/comments", commentCon.Index)
+		card.POST("/:id/comments", commentCon.Create)
+
+		attachmentCon := controller.NewAttachmentController()
+		card.GET("/:id/attachments", attachmentCon.Index)
+		card.POST("/:id/attachments", attachmentCon.Create)
+		card.GET("/:id/attachments/:attachmentID", attachmentCon.Download)
+		card.DELETE("/:id/attachments/:attachmentID", attachmentCon.Destroy)
+
+		checklistCon := controller.NewChecklistController()
+		card.GET("/:id/checklists", checklistCon.Index)
+		card.POST("/:id/checklists", checklistCon.Create)
+		card.PUT("/:id/checklists/:checklistID", checklistCon.Update)
+		card.DELETE("/:id/checklists/:checklistID", checklistCon.Destroy)
+		card.PUT("/:id/checklists/:checklistID/move", checklistCon.Move)
+		card.POST("/:id/checklists/:checklistID/items", checklistCon.CreateItem)
+		card.PUT("/:id/checklists/:checklistID/items/:itemID", checklistCon.UpdateItem)
+		card.DELETE("/:id/checklists/:checklistID/items/:itemID", checklistCon.DestroyItem)
+		card.PUT("/:id/checklists/:checklistID/items/:itemID/move", checklistCon.MoveItem)
+	}
+
+	// ゴミ箱のリストとカードはそれぞれのスコープで操作できる ロールの確認はサービスで行う
+	trashCon := controller.NewTrashController()
+	trashList := api.Group("/trash")
+	{
+		trashList.Use(authMiddleware.Scope(model.ScopeListsRead, model.ScopeListsWrite))
+		useAuthRateLimit(trashList)
+		trashList.GET("", trashCon.Index)
+		trashList.PUT("/lists/:id/restore", trashCon.RestoreList)
+		trashList.DELETE("/lists/:id", trashCon.DestroyList)
+	}
+
+	trashCard := api.Group("/trash/cards")
+	{
+		trashCard.Use(authMiddleware.Scope(model.ScopeCardsRead, model.ScopeCardsWrite))
+		useAuthRateLimit(trashCard)
+		trashCard.PUT("/:id/restore", trashCon.RestoreCard)
+		trashCard.DELETE("/:id", trashCon.DestroyCard)
+	}
+
 	return r
 }
 
@@ -119,8 +299,17 @@ func router() *gin.Engine {
 
 // test用 sendgridのmailclientをモック化
 func TestRouterSetup(emailClientMock *mock_gateway.MockEmailGateway) *gin.Engine {
-	con := controller.TestNewUserController(service.NewUserService(), service.TestNewEmailService(
-		emailClientMock, service.NewJWTService(),
-	))
-	return RouterSetup(con)
+	emailService := service.TestNewEmailService(emailClientMock, service.NewJWTService())
+	userService := service.TestNewUserService(
+		service.NewJWTService(),
+		service.NewRefreshTokenService(),
+		service.NewSessionService(),
+		service.NewAuditService(),
+		emailService,
+		service.NewAttachmentService(),
+		repository.NewUserRepository(),
+		repository.NewEmailChangeRepository(),
+	)
+	con := controller.TestNewUserController(userService, emailService)
+	return RouterSetup(con, gateway.NewMemoryRateLimitStore())
 }
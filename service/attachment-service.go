@@ -0,0 +1,254 @@
+package service
+
+// mockgen -source=service/attachment-service.go -destination=mock_service/attachment-service.go
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/gateway"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	// ATTACHMENT_MAX_SIZE_MBとATTACHMENT_USER_QUOTA_MBが設定されていない場合の上限
+	MBDefaultAttachmentMaxSize   = 10
+	MBDefaultAttachmentUserQuota = 100
+	attachmentKeyByteLength      = 16
+	attachmentFileNameMaxLength  = 255
+	// http.DetectContentTypeが判定に使うバイト数
+	contentTypeSniffLength = 512
+	// multipartの境界やヘッダーの分としてファイルサイズの上限に加えて受け付けるバイト数
+	multipartOverhead = 1 << 20
+)
+
+type AttachmentService interface {
+	Index(*gin.Context) ([]model.Attachment, error)
+	Create(*gin.Context) (model.Attachment, error)
+	// 呼び出し側でio.ReadCloserを閉じる
+	Download(*gin.Context) (model.Attachment, io.ReadCloser, error)
+	Destroy(*gin.Context) error
+	// カードやリスト、ボードを完全に削除する前、ユーザーを削除した後に添付ファイルとストレージのファイルを削除する
+	DestroyByCard(card model.Card) error
+	DestroyByList(list model.List) error
+	DestroyByBoard(board model.Board) error
+	DestroyByUser(user model.User) error
+}
+
+type attachmentService struct {
+	repository repository.AttachmentRepository
+	storage    gateway.StorageGateway
+}
+
+func NewAttachmentService() AttachmentService {
+	return &attachmentService{repository: repository.NewAttachmentRepository(), storage: gateway.NewStorageGateway()}
+}
+
+// カードはcardMiddlewareで認可済み
+func (s *attachmentService) Index(ctx *gin.Context) ([]model.Attachment, error) {
+	card := ctx.MustGet(config.CardKey).(model.Card)
+	return s.repository.FindAll(&card)
+}
+
+// multipartのfileフィールドのファイルを保存する ContentTypeはクライアントの申告ではなく中身から判定する
+func (s *attachmentService) Create(ctx *gin.Context) (model.Attachment, error) {
+	maxSize := AttachmentMaxSize()
+	if ctx.Request.ContentLength > maxSize+multipartOverhead {
+		return model.Attachment{}, config.AttachmentTooLargeError
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxSize+multipartOverhead)
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		// Content-Lengthが無いリクエストは上限を超えた時点で読み込みを打ち切る
+		if strings.Contains(err.Error(), "request body too large") {
+			return model.Attachment{}, config.AttachmentTooLargeError
+		}
+		return model.Attachment{}, http.ErrMissingFile
+	}
+
+	if fileHeader.Size > maxSize {
+		return model.Attachment{}, config.AttachmentTooLargeError
+	}
+
+	// ストレージに保存する前に確認する 同時にアップロードされた場合はrepository.Createで弾く
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	totalSize, err := s.repository.TotalSize(&currentUser)
+	if err != nil {
+		return model.Attachment{}, err
+	}
+	if totalSize+fileHeader.Size > AttachmentUserQuota() {
+		return model.Attachment{}, config.AttachmentQuotaExceededError
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return model.Attachment{}, err
+	}
+	defer file.Close()
+
+	head := make([]byte, contentTypeSniffLength)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return model.Attachment{}, err
+	}
+	head = head[:n]
+
+	card := ctx.MustGet(config.CardKey).(model.Card)
+	key := fmt.Sprintf("attachments/%d/%s", card.ID, config.MakeRandomToken(attachmentKeyByteLength))
+	if err := s.storage.Put(key, io.MultiReader(bytes.NewReader(head), file)); err != nil {
+		return model.Attachment{}, err
+	}
+
+	attachment := model.Attachment{
+		FileName:    attachmentFileName(fileHeader.Filename),
+		ContentType: http.DetectContentType(head),
+		Size:        fileHeader.Size,
+		StorageKey:  key,
+		CardID:      card.ID,
+		UserID:      currentUser.ID,
+	}
+	if err := s.repository.Create(&attachment, AttachmentUserQuota()); err != nil {
+		s.storage.Delete(key)
+		return model.Attachment{}, err
+	}
+
+	return attachment, nil
+}
+
+func (s *attachmentService) Download(ctx *gin.Context) (model.Attachment, io.ReadCloser, error) {
+	attachment, err := s.findAttachment(ctx)
+	if err != nil {
+		return model.Attachment{}, nil, err
+	}
+
+	body, err := s.storage.Get(attachment.StorageKey)
+	if err == config.StorageObjectNotFoundError {
+		return model.Attachment{}, nil, gorm.ErrRecordNotFound
+	}
+	if err != nil {
+		return model.Attachment{}, nil, err
+	}
+
+	return attachment, body, nil
+}
+
+// ストレージのファイルの削除に失敗してもginのエラーとしてログに出力するのみにする
+func (s *attachmentService) Destroy(ctx *gin.Context) error {
+	attachment, err := s.findAttachment(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repository.Destroy(&attachment); err != nil {
+		return err
+	}
+
+	if err := s.storage.Delete(attachment.StorageKey); err != nil {
+		ctx.Error(err)
+	}
+	return nil
+}
+
+func (s *attachmentService) DestroyByCard(card model.Card) error {
+	attachments, err := s.repository.DestroyAllByCard(&card)
+	if err != nil {
+		return err
+	}
+
+	return s.deleteObjects(attachments)
+}
+
+func (s *attachmentService) DestroyByList(list model.List) error {
+	attachments, err := s.repository.DestroyAllByList(&list)
+	if err != nil {
+		return err
+	}
+
+	return s.deleteObjects(attachments)
+}
+
+func (s *attachmentService) DestroyByBoard(board model.Board) error {
+	attachments, err := s.repository.DestroyAllByBoard(&board)
+	if err != nil {
+		return err
+	}
+
+	return s.deleteObjects(attachments)
+}
+
+func (s *attachmentService) DestroyByUser(user model.User) error {
+	attachments, err := s.repository.DestroyAllByUser(&user)
+	if err != nil {
+		return err
+	}
+
+	return s.deleteObjects(attachments)
+}
+
+// 1つ失敗しても残りのファイルは削除する
+func (s *attachmentService) deleteObjects(attachments []model.Attachment) error {
+	var firstErr error
+	for _, attachment := range attachments {
+		if err := s.storage.Delete(attachment.StorageKey); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (s *attachmentService) findAttachment(ctx *gin.Context) (model.Attachment, error) {
+	id, err := strconv.Atoi(ctx.Param("attachmentID"))
+	if err != nil {
+		return model.Attachment{}, gorm.ErrRecordNotFound
+	}
+
+	card := ctx.MustGet(config.CardKey).(model.Card)
+	return s.repository.Find(&card, id)
+}
+
+// ファイル名はvarchar(255)に収まるように文字の途中で切らずに切り詰める
+func attachmentFileName(name string) string {
+	if name == "" {
+		return "file"
+	}
+
+	for len(name) > attachmentFileNameMaxLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
+
+// ATTACHMENT_MAX_SIZE_MB: 1ファイルの上限
+func AttachmentMaxSize() int64 {
+	return megabytesFromEnv("ATTACHMENT_MAX_SIZE_MB", MBDefaultAttachmentMaxSize)
+}
+
+// ATTACHMENT_USER_QUOTA_MB: ユーザーごとのアップロードした添付ファイルの合計の上限
+func AttachmentUserQuota() int64 {
+	return megabytesFromEnv("ATTACHMENT_USER_QUOTA_MB", MBDefaultAttachmentUserQuota)
+}
+
+func megabytesFromEnv(key string, defaultValue int64) int64 {
+	value, err := strconv.ParseInt(os.Getenv(key), 10, 64)
+	if err != nil || value <= 0 {
+		value = defaultValue
+	}
+	return value << 20
+}
+
+// test
+func TestNewAttachmentService(repository repository.AttachmentRepository, storage gateway.StorageGateway) AttachmentService {
+	return &attachmentService{repository: repository, storage: storage}
+}
@@ -0,0 +1,129 @@
+package service
+
+// mockgen -source=service/audit-service.go -destination=mock_service/audit-service.go
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/dto"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+)
+
+const (
+	AuditLogPageSize = 50
+	// AUDIT_LOG_RETENTION_DAYSが設定されていない場合の保存期間
+	DayDefaultAuditLogRetention = 365
+	// 保存期間を過ぎたログを削除する間隔
+	HourAuditLogRetentionInterval = 1
+)
+
+type AuditService interface {
+	Record(ctx *gin.Context, event string, user *model.User, detail string)
+	Index(*gin.Context) ([]model.AuditLog, error)
+	AdminIndex(*gin.Context) ([]model.AuditLog, error)
+	DestroyExpired() error
+	Run(stop <-chan struct{})
+}
+
+type auditService struct {
+	repository repository.AuditLogRepository
+}
+
+func NewAuditService() AuditService {
+	return &auditService{repository: repository.NewAuditLogRepository()}
+}
+
+// 記録に失敗しても認証の処理は続けるのでエラーはginのエラーとしてログに出力するのみにする
+func (s *auditService) Record(ctx *gin.Context, event string, user *model.User, detail string) {
+	userAgent := ctx.Request.UserAgent()
+	if len(userAgent) > userAgentMaxLength {
+		userAgent = userAgent[:userAgentMaxLength]
+	}
+
+	log := model.AuditLog{
+		Event:     event,
+		Detail:    detail,
+		IP:        ctx.ClientIP(),
+		UserAgent: userAgent,
+	}
+	if user != nil {
+		userID := user.ID
+		log.UserID = &userID
+	}
+
+	if err := s.repository.Create(&log); err != nil {
+		ctx.Error(err)
+	}
+}
+
+// ログイン中のユーザー自身のログ
+func (s *auditService) Index(ctx *gin.Context) ([]model.AuditLog, error) {
+	var dtoQuery dto.AuditLogQuery
+	if err := ctx.ShouldBindQuery(&dtoQuery); err != nil {
+		return nil, err
+	}
+
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	return s.repository.FindAll(repository.AuditLogQuery{
+		UserID:   currentUser.ID,
+		Event:    dtoQuery.Event,
+		BeforeID: dtoQuery.Before,
+		Limit:    AuditLogPageSize,
+	})
+}
+
+// 管理者用 全てのユーザーのログをユーザーとイベントで絞り込める
+func (s *auditService) AdminIndex(ctx *gin.Context) ([]model.AuditLog, error) {
+	var dtoQuery dto.AuditLogQuery
+	if err := ctx.ShouldBindQuery(&dtoQuery); err != nil {
+		return nil, err
+	}
+
+	return s.repository.FindAll(repository.AuditLogQuery{
+		UserID:   dtoQuery.UserID,
+		Event:    dtoQuery.Event,
+		BeforeID: dtoQuery.Before,
+		Limit:    AuditLogPageSize,
+	})
+}
+
+// 記録のたびに削除するとログイン失敗の回数に応じて削除が走るので定期的にまとめて削除する
+func (s *auditService) DestroyExpired() error {
+	return s.repository.DestroyExpired(time.Now().AddDate(0, 0, -auditLogRetentionDays()))
+}
+
+// stopが閉じられるまで定期的に保存期間を過ぎたログを削除する
+func (s *auditService) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(HourAuditLogRetentionInterval * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := s.DestroyExpired(); err != nil {
+				gin.DefaultWriter.Write([]byte(fmt.Sprintf("Failed to destroy expired audit logs\n%v\n", err.Error())))
+			}
+		}
+	}
+}
+
+func auditLogRetentionDays() int {
+	days, err := strconv.Atoi(os.Getenv("AUDIT_LOG_RETENTION_DAYS"))
+	if err != nil || days <= 0 {
+		return DayDefaultAuditLogRetention
+	}
+	return days
+}
+
+// test
+func TestNewAuditService(repository repository.AuditLogRepository) AuditService {
+	return &auditService{repository: repository}
+}
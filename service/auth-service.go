@@ -3,131 +3,456 @@ package service
 // mockgen -source=service/auth-service.go -destination=mock_service/auth-service.go
 
 import (
-	"os"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/gin-gonic/gin"
 	"github.com/kuritaeiji/todo-gin-back/config"
 	"github.com/kuritaeiji/todo-gin-back/dto"
 	"github.com/kuritaeiji/todo-gin-back/gateway"
+	"github.com/kuritaeiji/todo-gin-back/model"
 	"github.com/kuritaeiji/todo-gin-back/repository"
 	"golang.org/x/oauth2"
+	"gorm.io/gorm"
 )
 
 type AuthService interface {
-	Login(*gin.Context) (string, error)
-	Google(*gin.Context) (string, string, error)
-	GoogleLogin(*gin.Context) (string, error)
+	Login(*gin.Context) (TokenPair, string, error)
+	Oauth(*gin.Context) (OauthAuthorization, error)
+	OauthLogin(*gin.Context) (TokenPair, string, error)
+	TotpLogin(*gin.Context) (TokenPair, error)
+	LinkOauth(*gin.Context) error
+	UnlinkOauth(*gin.Context) error
+	Refresh(*gin.Context) (TokenPair, error)
+	Logout(*gin.Context) error
+	LogoutAll(*gin.Context) error
 }
 
 type authService struct {
-	dto            dto.Auth
-	dtoOauth       dto.Oauth
-	userRepository repository.UserRepository
-	jwtService     JWTService
-	oauthGateway   gateway.OauthGateway
+	dto                       dto.Auth
+	userRepository            repository.UserRepository
+	tokenRevocationRepository repository.TokenRevocationRepository
+	jwtService                JWTService
+	refreshTokenService       RefreshTokenService
+	sessionService            SessionService
+	auditService              AuditService
+	loginAttemptService       LoginAttemptService
+	totpService               TotpService
+	oauthGateway              gateway.OauthGateway
+}
+
+const (
+	// state, PKCEのcode_verifier, nonceのバイト数 code_verifierは43文字以上でなければならない
+	oauthRandomByteLength = 32
+)
+
+// ログインやトークン再発行時にクライアントに返すトークン
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+func (pair TokenPair) ToJson() gin.H {
+	return gin.H{
+		"token":        pair.AccessToken,
+		"refreshToken": pair.RefreshToken,
+		"expiresIn":    MinuteFromNowAccessToken * 60,
+	}
 }
 
 func NewAuthService() AuthService {
 	return &authService{
-		dto:            dto.Auth{},
-		userRepository: repository.NewUserRepository(),
-		jwtService:     NewJWTService(),
-		oauthGateway:   gateway.NewOauthGateway(),
+		dto:                       dto.Auth{},
+		userRepository:            repository.NewUserRepository(),
+		tokenRevocationRepository: repository.NewTokenRevocationRepository(),
+		jwtService:                NewJWTService(),
+		refreshTokenService:       NewRefreshTokenService(),
+		sessionService:            NewSessionService(),
+		auditService:              NewAuditService(),
+		loginAttemptService:       NewLoginAttemptService(),
+		totpService:               NewTotpService(),
+		oauthGateway:              gateway.NewOauthGateway(),
 	}
 }
 
-func (s *authService) Login(ctx *gin.Context) (string, error) {
+// 2段階認証が有効な場合はトークンの代わりにチャレンジトークンを返す
+func (s *authService) Login(ctx *gin.Context) (TokenPair, string, error) {
 	err := ctx.ShouldBindJSON(&s.dto)
 	if err != nil {
-		return "", err
+		return TokenPair{}, "", err
+	}
+
+	ip := ctx.ClientIP()
+	if err := s.loginAttemptService.CheckIP(ip); err != nil {
+		return TokenPair{}, "", err
 	}
 
 	user, err := s.userRepository.FindByEmail(s.dto.Email)
+	if err == gorm.ErrRecordNotFound {
+		s.auditService.Record(ctx, model.AuditEventLoginFailed, nil, s.dto.Email)
+		if ferr := s.loginAttemptService.Fail(ip, nil); ferr != nil {
+			return TokenPair{}, "", ferr
+		}
+	}
 	if err != nil {
-		return "", err
+		return TokenPair{}, "", err
+	}
+
+	// ロック中は正しいパスワードでもログインできない
+	if err := s.loginAttemptService.CheckUser(user); err != nil {
+		return TokenPair{}, "", err
 	}
 
 	if !user.Authenticate(s.dto.Password) {
-		return "", config.PasswordAuthenticationError
+		s.auditService.Record(ctx, model.AuditEventLoginFailed, &user, "")
+		if err := s.loginAttemptService.Fail(ip, &user); err != nil {
+			return TokenPair{}, "", err
+		}
+		return TokenPair{}, "", config.PasswordAuthenticationError
+	}
+
+	// パスワードが正しい場合のみ有効化されていないことを伝える
+	if !user.Activated {
+		return TokenPair{}, "", config.NotActivatedUserError
+	}
+
+	// 失敗回数は認証コードの検証が終わるまでリセットしない
+	if user.TotpEnabled {
+		return TokenPair{}, s.jwtService.CreateChallengeJWT(user), nil
 	}
 
-	return s.jwtService.CreateJWT(user, DayFromNowAccessToken), nil
+	if err := s.loginAttemptService.Succeed(user); err != nil {
+		return TokenPair{}, "", err
+	}
+
+	tokenPair, err := s.createTokenPair(ctx, user)
+	if err != nil {
+		return TokenPair{}, "", err
+	}
+
+	s.auditService.Record(ctx, model.AuditEventLoginSucceeded, &user, "")
+	return tokenPair, "", nil
+}
+
+// 認可リクエストの内容 State, CodeVerifier, Nonceはcookieに保存してコールバック時に検証する
+type OauthAuthorization struct {
+	URL          string
+	State        string
+	CodeVerifier string
+	Nonce        string
+}
+
+// パスパラメーターのプロバイダーの認可エンドポイントのurlを返す
+func (s *authService) Oauth(ctx *gin.Context) (OauthAuthorization, error) {
+	oauthProvider, err := config.FindOauthProvider(ctx.Param("provider"))
+	if err != nil {
+		return OauthAuthorization{}, err
+	}
+
+	provider, err := s.oauthGateway.SearchProvider(ctx, oauthProvider.Issuer)
+	if err != nil {
+		return OauthAuthorization{}, err
+	}
+
+	authorization := OauthAuthorization{
+		State:        config.MakeRandomToken(oauthRandomByteLength),
+		CodeVerifier: config.MakeRandomToken(oauthRandomByteLength),
+		Nonce:        config.MakeRandomToken(oauthRandomByteLength),
+	}
+	oauth2Config := CreateOauth2Config(oauthProvider, provider)
+	authorization.URL = oauth2Config.AuthCodeURL(
+		authorization.State,
+		oauth2.SetAuthURLParam("code_challenge", codeChallenge(authorization.CodeVerifier)),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+		oidc.Nonce(authorization.Nonce),
+	)
+	return authorization, nil
 }
 
-func (s *authService) Google(ctx *gin.Context) (string, string, error) {
-	state := config.MakeRandomStr(20)
+func (s *authService) OauthLogin(ctx *gin.Context) (TokenPair, string, error) {
+	oauthProvider, claims, err := s.verifyIDToken(ctx)
+	if err != nil {
+		return TokenPair{}, "", err
+	}
+
+	// プロバイダーが確認済みのメールアドレスのみを既存のユーザーとの紐付けに使う
+	var verifiedEmail string
+	if claims.EmailVerified {
+		verifiedEmail = claims.Email
+	}
+
+	// (provider, subject)によるユーザーの作成もしくは探索
+	user, err := s.userRepository.FindOrCreateByIdentity(oauthProvider.Name, claims.Subject, verifiedEmail, oauthProvider.TrustEmail)
+	if err != nil {
+		return TokenPair{}, "", err
+	}
+
+	if user.TotpEnabled {
+		return TokenPair{}, s.jwtService.CreateChallengeJWT(user), nil
+	}
 
-	provider, err := s.oauthGateway.SearchProvider(ctx)
+	// jwtを作成
+	tokenPair, err := s.createTokenPair(ctx, user)
 	if err != nil {
-		return "", "", err
+		return TokenPair{}, "", err
 	}
 
-	oauth2Config := CreateOauth2Config(provider)
-	return oauth2Config.AuthCodeURL(state), state, nil
+	s.auditService.Record(ctx, model.AuditEventOauthLogin, &user, oauthProvider.Name)
+	return tokenPair, "", nil
 }
 
-func (s *authService) GoogleLogin(ctx *gin.Context) (string, error) {
+// チャレンジトークンと認証アプリのコードもしくはリカバリーコードを検証してトークンを発行する
+func (s *authService) TotpLogin(ctx *gin.Context) (TokenPair, error) {
+	var dtoTotpLogin dto.TotpLogin
+	if err := ctx.ShouldBindJSON(&dtoTotpLogin); err != nil {
+		return TokenPair{}, err
+	}
+
+	claim, err := s.jwtService.VerifyJWT(dtoTotpLogin.ChallengeToken)
+	if err != nil || claim.Type != ChallengeTokenType {
+		return TokenPair{}, config.InvalidChallengeTokenError
+	}
+
+	ip := ctx.ClientIP()
+	if err := s.loginAttemptService.CheckIP(ip); err != nil {
+		return TokenPair{}, err
+	}
+
+	user, err := s.userRepository.Find(claim.ID)
+	if err == gorm.ErrRecordNotFound {
+		return TokenPair{}, config.InvalidChallengeTokenError
+	}
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	// チャレンジトークン発行後に全ての端末からログアウトした場合や2段階認証を無効にした場合
+	if claim.Version != user.TokenVersion || !user.TotpEnabled {
+		return TokenPair{}, config.InvalidChallengeTokenError
+	}
+
+	if err := s.loginAttemptService.CheckUser(user); err != nil {
+		return TokenPair{}, err
+	}
+
+	ok, err := s.totpService.Verify(user, dtoTotpLogin.Code)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	if !ok {
+		s.auditService.Record(ctx, model.AuditEventLoginFailed, &user, "totp")
+		if err := s.loginAttemptService.Fail(ip, &user); err != nil {
+			return TokenPair{}, err
+		}
+		return TokenPair{}, config.InvalidTotpCodeError
+	}
+
+	// チャレンジトークンは認証コードの検証に成功した時に使用済みにして再利用させない
+	ok, err = s.tokenRevocationRepository.RevokeOnce(claim.Id, time.Unix(claim.ExpiresAt, 0))
+	if err != nil {
+		return TokenPair{}, err
+	}
+	if !ok {
+		return TokenPair{}, config.InvalidChallengeTokenError
+	}
+
+	if err := s.loginAttemptService.Succeed(user); err != nil {
+		return TokenPair{}, err
+	}
+
+	tokenPair, err := s.createTokenPair(ctx, user)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	s.auditService.Record(ctx, model.AuditEventLoginSucceeded, &user, "totp")
+	return tokenPair, nil
+}
+
+// ログイン中のユーザーにプロバイダーのアカウントを紐付ける
+func (s *authService) LinkOauth(ctx *gin.Context) error {
+	oauthProvider, claims, err := s.verifyIDToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	return s.userRepository.LinkIdentity(&currentUser, oauthProvider.Name, claims.Subject)
+}
+
+func (s *authService) UnlinkOauth(ctx *gin.Context) error {
+	oauthProvider, err := config.FindOauthProvider(ctx.Param("provider"))
+	if err != nil {
+		return err
+	}
+
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	return s.userRepository.UnlinkIdentity(&currentUser, oauthProvider.Name)
+}
+
+// リフレッシュトークンをローテーションしてアクセストークンを再発行する
+func (s *authService) Refresh(ctx *gin.Context) (TokenPair, error) {
+	var dtoRefreshToken dto.RefreshToken
+	if err := ctx.ShouldBindJSON(&dtoRefreshToken); err != nil {
+		return TokenPair{}, err
+	}
+
+	usedToken, refreshToken, err := s.refreshTokenService.Rotate(dtoRefreshToken.RefreshToken)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	if err := s.sessionService.Resume(ctx, usedToken); err != nil {
+		return TokenPair{}, err
+	}
+
+	return TokenPair{
+		AccessToken:  s.jwtService.CreateAccessJWT(usedToken.User, usedToken.Family),
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// 現在のアクセストークンとそのログインのセッションを無効にする
+func (s *authService) Logout(ctx *gin.Context) error {
+	claim := ctx.MustGet(config.ClaimKey).(*UserClaim)
+	err := s.tokenRevocationRepository.Revoke(claim.Id, time.Unix(claim.ExpiresAt, 0))
+	if err != nil {
+		return err
+	}
+
+	if err := s.sessionService.Revoke(claim.SessionID); err != nil {
+		return err
+	}
+
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	s.auditService.Record(ctx, model.AuditEventLogout, &currentUser, "")
+	return nil
+}
+
+// 全ての端末からログアウトする パーソナルアクセストークンも削除する
+func (s *authService) LogoutAll(ctx *gin.Context) error {
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	if err := s.tokenRevocationRepository.RevokeAll(&currentUser); err != nil {
+		return err
+	}
+
+	s.auditService.Record(ctx, model.AuditEventLogoutAll, &currentUser, "")
+	return nil
+}
+
+// ログインごとにセッションを作成してアクセストークンとリフレッシュトークンを紐付ける
+func (s *authService) createTokenPair(ctx *gin.Context, user model.User) (TokenPair, error) {
+	session, err := s.sessionService.Create(ctx, user)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	return issueTokenPair(s.jwtService, s.refreshTokenService, user, session.SessionID)
+}
+
+// パスワード変更等で他の端末のトークンを無効にした後、現在の端末にはトークンを再発行する
+func issueTokenPair(jwtService JWTService, refreshTokenService RefreshTokenService, user model.User, sessionID string) (TokenPair, error) {
+	refreshToken, err := refreshTokenService.Create(user, sessionID)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	return TokenPair{
+		AccessToken:  jwtService.CreateAccessJWT(user, sessionID),
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// stateを検証して認可コードをトークンエンドポイントでid_tokenと交換し、検証したクレームを返す
+func (s *authService) verifyIDToken(ctx *gin.Context) (config.OauthProvider, gateway.IDTokenClaims, error) {
+	oauthProvider, err := config.FindOauthProvider(ctx.Param("provider"))
+	if err != nil {
+		return config.OauthProvider{}, gateway.IDTokenClaims{}, err
+	}
+
 	// stateの検証
 	cookieState, err := ctx.Cookie(config.StateCookieKey)
 	if err != nil {
-		return "", err
+		return config.OauthProvider{}, gateway.IDTokenClaims{}, err
 	}
 
-	ctx.ShouldBindJSON(&s.dtoOauth)
-	if cookieState != s.dtoOauth.State {
-		return "", config.CsrfError
+	codeVerifier, err := ctx.Cookie(config.CodeVerifierCookieKey)
+	if err != nil {
+		return config.OauthProvider{}, gateway.IDTokenClaims{}, err
 	}
 
-	provider, err := s.oauthGateway.SearchProvider(ctx)
+	nonce, err := ctx.Cookie(config.NonceCookieKey)
 	if err != nil {
-		return "", err
+		return config.OauthProvider{}, gateway.IDTokenClaims{}, err
+	}
+
+	var dtoOauth dto.Oauth
+	ctx.ShouldBindJSON(&dtoOauth)
+	if cookieState == "" || subtle.ConstantTimeCompare([]byte(cookieState), []byte(dtoOauth.State)) != 1 {
+		return config.OauthProvider{}, gateway.IDTokenClaims{}, config.CsrfError
+	}
+
+	provider, err := s.oauthGateway.SearchProvider(ctx, oauthProvider.Issuer)
+	if err != nil {
+		return config.OauthProvider{}, gateway.IDTokenClaims{}, err
 	}
 
 	// トークンエンドポイントにリクエスト
-	oauth2Config := CreateOauth2Config(provider)
-	oauth2Token, err := s.oauthGateway.RequestTokenEndpoint(oauth2Config, ctx, s.dtoOauth.Code)
+	oauth2Config := CreateOauth2Config(oauthProvider, provider)
+	oauth2Token, err := s.oauthGateway.RequestTokenEndpoint(oauth2Config, ctx, dtoOauth.Code, codeVerifier)
 	if err != nil {
-		return "", err
+		return config.OauthProvider{}, gateway.IDTokenClaims{}, err
 	}
 
 	// id_tokenの取り出し
 	rawIDToken, ok := oauth2Token.Extra("id_token").(string)
 	if !ok {
-		return "", config.StandardError
+		return config.OauthProvider{}, gateway.IDTokenClaims{}, config.StandardError
 	}
 
 	// id_tokenの検証
-	idToken, err := s.oauthGateway.VerifyIDToken(ctx, provider, rawIDToken)
-	if err != nil {
-		return "", config.StandardError
+	// nonceが一致しない場合はid_tokenが再利用された可能性があるので他の検証エラーと区別する
+	claims, err := s.oauthGateway.VerifyIDToken(ctx, provider, oauthProvider.ClientID, rawIDToken, nonce)
+	if err == config.InvalidNonceError {
+		return config.OauthProvider{}, gateway.IDTokenClaims{}, err
 	}
-
-	// open_idによるユーザーの作成もしくは探索
-	user, err := s.userRepository.FindOrCreateByOpenID(idToken.Subject)
 	if err != nil {
-		return "", err
+		return config.OauthProvider{}, gateway.IDTokenClaims{}, config.StandardError
 	}
 
-	// jwtを作成
-	return s.jwtService.CreateJWT(user, DayFromNowAccessToken), nil
+	return oauthProvider, claims, nil
+}
+
+// PKCEのS256方式のcode_challenge
+func codeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
 }
 
-func CreateOauth2Config(provider *oidc.Provider) oauth2.Config {
+func CreateOauth2Config(oauthProvider config.OauthProvider, provider *oidc.Provider) oauth2.Config {
 	return oauth2.Config{
-		ClientID:     os.Getenv("CLIENT_ID"),
-		ClientSecret: os.Getenv("CLIENT_SECRET"),
-		RedirectURL:  os.Getenv("REDIRECT_URL"),
+		ClientID:     oauthProvider.ClientID,
+		ClientSecret: oauthProvider.ClientSecret,
+		RedirectURL:  oauthProvider.RedirectURL,
 		Endpoint:     provider.Endpoint(),
-		Scopes:       []string{oidc.ScopeOpenID},
+		Scopes:       oauthProvider.Scopes,
 	}
 }
 
 // test
-func TestNewAuthService(userRepository repository.UserRepository, jwtService JWTService, oauthGateway gateway.OauthGateway) AuthService {
+func TestNewAuthService(userRepository repository.UserRepository, tokenRevocationRepository repository.TokenRevocationRepository, jwtService JWTService, refreshTokenService RefreshTokenService, sessionService SessionService, auditService AuditService, loginAttemptService LoginAttemptService, totpService TotpService, oauthGateway gateway.OauthGateway) AuthService {
 	return &authService{
-		userRepository: userRepository,
-		jwtService:     jwtService,
-		oauthGateway:   oauthGateway,
+		userRepository:            userRepository,
+		tokenRevocationRepository: tokenRevocationRepository,
+		jwtService:                jwtService,
+		refreshTokenService:       refreshTokenService,
+		sessionService:            sessionService,
+		auditService:              auditService,
+		loginAttemptService:       loginAttemptService,
+		totpService:               totpService,
+		oauthGateway:              oauthGateway,
 	}
 }
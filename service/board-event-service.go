@@ -0,0 +1,158 @@
+package service
+
+// mockgen -source=service/board-event-service.go -destination=mock_service/board-event-service.go
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/gateway"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	ListCreatedEvent  = "list.created"
+	ListUpdatedEvent  = "list.updated"
+	ListMovedEvent    = "list.moved"
+	ListDeletedEvent  = "list.deleted"
+	ListArchivedEvent = "list.archived"
+	ListRestoredEvent = "list.restored"
+	CardCreatedEvent  = "card.created"
+	CardUpdatedEvent  = "card.updated"
+	CardMovedEvent    = "card.moved"
+	CardDeletedEvent  = "card.deleted"
+	CardArchivedEvent = "card.archived"
+	CardRestoredEvent = "card.restored"
+	// ゴミ箱から物理削除した
+	ListDestroyedEvent = "list.destroyed"
+	CardDestroyedEvent = "card.destroyed"
+)
+
+type BoardEventService interface {
+	// boardはboardMiddlewareで認可済み Last-Event-IDヘッダーがある場合はその続きから受け取る
+	Subscribe(*gin.Context) (gateway.BoardEventSubscription, error)
+	// 接続中に定期的に呼び、接続後にトークンが期限切れや無効になった場合やボードのメンバーでなくなった場合はエラーを返す
+	Authorize(*gin.Context) error
+	PublishList(eventType string, list model.List)
+	// カードを別のボードのリストに移動した場合は両方のボードに通知する
+	PublishCard(eventType string, card model.Card, listIDs ...int)
+	// ラベルやチェックリストを変更した場合にカードを読み込み直してcard.updatedを通知する
+	PublishCardChanged(cardID int)
+}
+
+type boardEventService struct {
+	hub                           gateway.BoardEventHub
+	listRepository                repository.ListRepository
+	cardRepository                repository.CardRepository
+	personalAccessTokenRepository repository.PersonalAccessTokenRepository
+	sessionService                SessionService
+	boardMemberService            BoardMemberService
+}
+
+func NewBoardEventService() BoardEventService {
+	return &boardEventService{
+		hub:                           gateway.GetBoardEventHub(),
+		listRepository:                repository.NewListRepository(),
+		cardRepository:                repository.NewCardRepository(),
+		personalAccessTokenRepository: repository.NewPersonalAccessTokenRepository(),
+		sessionService:                NewSessionService(),
+		boardMemberService:            NewBoardMemberService(),
+	}
+}
+
+func (s *boardEventService) Subscribe(ctx *gin.Context) (gateway.BoardEventSubscription, error) {
+	board := ctx.MustGet(config.BoardKey).(model.Board)
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	lastEventID, _ := strconv.ParseInt(ctx.GetHeader("Last-Event-ID"), 10, 64)
+	return s.hub.Subscribe(board.ID, currentUser.ID, lastEventID)
+}
+
+// 認証時と同じくアクセストークンはセッションとjtiを、パーソナルアクセストークンは削除されていないことを確認する
+func (s *boardEventService) Authorize(ctx *gin.Context) error {
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	if value, ok := ctx.Get(config.PersonalAccessTokenKey); ok {
+		token := value.(model.PersonalAccessToken)
+		rToken, err := s.personalAccessTokenRepository.FindByDigest(token.Digest)
+		if err == gorm.ErrRecordNotFound || (err == nil && rToken.IsExpired()) {
+			return config.InvalidPersonalAccessTokenError
+		}
+		if err != nil {
+			return err
+		}
+	} else {
+		claim := ctx.MustGet(config.ClaimKey).(*UserClaim)
+		if time.Now().Unix() > claim.ExpiresAt {
+			return config.AccessTokenExpiredError
+		}
+		if err := s.sessionService.Touch(claim.SessionID, claim.Id, currentUser); err != nil {
+			return err
+		}
+	}
+
+	board := ctx.MustGet(config.BoardKey).(model.Board)
+	return s.boardMemberService.Authorize(board.ID, currentUser, model.BoardRoleViewer)
+}
+
+// 通知に失敗しても変更は取り消さない
+func (s *boardEventService) PublishList(eventType string, list model.List) {
+	data := gin.H{"id": list.ID, "title": list.Title, "index": list.Index}
+	s.publish(list.BoardID, eventType, data)
+}
+
+// listIDsを省略した場合はカードのリストのボードに通知する
+func (s *boardEventService) PublishCard(eventType string, card model.Card, listIDs ...int) {
+	if len(listIDs) == 0 {
+		listIDs = []int{card.ListID}
+	}
+
+	data := card.ToJson()
+	data["listId"] = card.ListID
+	data["index"] = card.Index
+	published := map[int]bool{}
+	for _, listID := range listIDs {
+		list, err := s.listRepository.FindWithTrashed(listID)
+		if err != nil {
+			gin.DefaultWriter.Write([]byte(fmt.Sprintf("Failed to publish board event\n%v\n", err.Error())))
+			continue
+		}
+
+		if !published[list.BoardID] {
+			published[list.BoardID] = true
+			s.publish(list.BoardID, eventType, data)
+		}
+	}
+}
+
+// ラベルとチェックリストの進捗を含めて通知する
+func (s *boardEventService) PublishCardChanged(cardID int) {
+	card, err := s.cardRepository.Find(cardID)
+	if err != nil {
+		gin.DefaultWriter.Write([]byte(fmt.Sprintf("Failed to publish board event\n%v\n", err.Error())))
+		return
+	}
+
+	s.PublishCard(CardUpdatedEvent, card)
+}
+
+func (s *boardEventService) publish(boardID int, eventType string, data gin.H) {
+	if err := s.hub.Publish(boardID, eventType, data); err != nil {
+		gin.DefaultWriter.Write([]byte(fmt.Sprintf("Failed to publish board event\n%v\n", err.Error())))
+	}
+}
+
+// test
+func TestNewBoardEventService(hub gateway.BoardEventHub, listRepository repository.ListRepository, cardRepository repository.CardRepository, personalAccessTokenRepository repository.PersonalAccessTokenRepository, sessionService SessionService, boardMemberService BoardMemberService) BoardEventService {
+	return &boardEventService{
+		hub:                           hub,
+		listRepository:                listRepository,
+		cardRepository:                cardRepository,
+		personalAccessTokenRepository: personalAccessTokenRepository,
+		sessionService:                sessionService,
+		boardMemberService:            boardMemberService,
+	}
+}
@@ -0,0 +1,182 @@
+package service
+
+// mockgen -source=service/board-member-service.go -destination=mock_service/board-member-service.go
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/dto"
+	"github.com/kuritaeiji/todo-gin-back/gateway"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"gorm.io/gorm"
+)
+
+type BoardMemberService interface {
+	// ボードのメンバーでないかroleの権限を持たない場合はForbiddenErrorを返す
+	Authorize(boardID int, user model.User, role string) error
+	Index(*gin.Context) ([]model.BoardMember, error)
+	Invite(*gin.Context) (model.BoardMember, error)
+	Update(*gin.Context) (model.BoardMember, error)
+	Destroy(*gin.Context) error
+	Invitations(*gin.Context) ([]model.BoardMember, error)
+	Accept(*gin.Context) (model.BoardMember, error)
+	Decline(*gin.Context) error
+}
+
+type boardMemberService struct {
+	repository     repository.BoardMemberRepository
+	userRepository repository.UserRepository
+	emailService   EmailService
+	hub            gateway.BoardEventHub
+}
+
+func NewBoardMemberService() BoardMemberService {
+	return &boardMemberService{
+		repository:     repository.NewBoardMemberRepository(),
+		userRepository: repository.NewUserRepository(),
+		emailService:   NewEmailService(),
+		hub:            gateway.GetBoardEventHub(),
+	}
+}
+
+func (s *boardMemberService) Authorize(boardID int, user model.User, role string) error {
+	member, err := s.repository.Find(boardID, user.ID)
+	if err == gorm.ErrRecordNotFound {
+		return config.ForbiddenError
+	}
+	if err != nil {
+		return err
+	}
+
+	if !member.Can(role) {
+		return config.ForbiddenError
+	}
+
+	return nil
+}
+
+// boardはboardMiddlewareで認可済み
+func (s *boardMemberService) Index(ctx *gin.Context) ([]model.BoardMember, error) {
+	board := ctx.MustGet(config.BoardKey).(model.Board)
+	return s.repository.FindAll(board.ID)
+}
+
+// 登録済みのユーザーのみ招待できる 招待中のユーザーを再度招待した場合もAlreadyBoardMemberErrorを返す
+func (s *boardMemberService) Invite(ctx *gin.Context) (model.BoardMember, error) {
+	var dtoMember dto.BoardMember
+	if err := ctx.ShouldBindJSON(&dtoMember); err != nil {
+		return model.BoardMember{}, err
+	}
+
+	user, err := s.userRepository.FindByEmail(dtoMember.Email)
+	if err != nil {
+		return model.BoardMember{}, err
+	}
+
+	board := ctx.MustGet(config.BoardKey).(model.Board)
+	_, err = s.repository.Find(board.ID, user.ID)
+	if err == nil {
+		return model.BoardMember{}, config.AlreadyBoardMemberError
+	}
+	if err != gorm.ErrRecordNotFound {
+		return model.BoardMember{}, err
+	}
+
+	member := model.BoardMember{BoardID: board.ID, UserID: user.ID, User: user}
+	dtoMember.Transfer(&member)
+	if err := s.repository.Create(&member); err != nil {
+		return model.BoardMember{}, err
+	}
+
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	err = s.emailService.BoardInvitationEmail(user, board, currentUser)
+	return member, err
+}
+
+func (s *boardMemberService) Update(ctx *gin.Context) (model.BoardMember, error) {
+	var dtoRole dto.BoardMemberRole
+	if err := ctx.ShouldBindJSON(&dtoRole); err != nil {
+		return model.BoardMember{}, err
+	}
+
+	member, err := s.findMember(ctx)
+	if err != nil {
+		return model.BoardMember{}, err
+	}
+
+	if err := s.repository.UpdateRole(&member, dtoRole.Role); err != nil {
+		return model.BoardMember{}, err
+	}
+
+	// 変更後のロールで認可し直すように接続中の配信を切断する
+	s.hub.CloseUser(member.BoardID, member.UserID)
+	return member, nil
+}
+
+func (s *boardMemberService) Destroy(ctx *gin.Context) error {
+	member, err := s.findMember(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repository.Destroy(&member); err != nil {
+		return err
+	}
+
+	s.hub.CloseUser(member.BoardID, member.UserID)
+	return nil
+}
+
+func (s *boardMemberService) Invitations(ctx *gin.Context) ([]model.BoardMember, error) {
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	return s.repository.FindInvitations(currentUser.ID)
+}
+
+func (s *boardMemberService) Accept(ctx *gin.Context) (model.BoardMember, error) {
+	member, err := s.findInvitation(ctx)
+	if err != nil {
+		return model.BoardMember{}, err
+	}
+
+	err = s.repository.Accept(&member)
+	return member, err
+}
+
+func (s *boardMemberService) Decline(ctx *gin.Context) error {
+	member, err := s.findInvitation(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.Destroy(&member)
+}
+
+// 他のボードのメンバーはErrRecordNotFoundにする
+func (s *boardMemberService) findMember(ctx *gin.Context) (model.BoardMember, error) {
+	id, err := strconv.Atoi(ctx.Param("memberID"))
+	if err != nil {
+		return model.BoardMember{}, gorm.ErrRecordNotFound
+	}
+
+	board := ctx.MustGet(config.BoardKey).(model.Board)
+	return s.repository.FindInBoard(board.ID, id)
+}
+
+// 他のユーザーへの招待はErrRecordNotFoundにする
+func (s *boardMemberService) findInvitation(ctx *gin.Context) (model.BoardMember, error) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return model.BoardMember{}, gorm.ErrRecordNotFound
+	}
+
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	return s.repository.FindInvitation(id, currentUser.ID)
+}
+
+// test
+func TestNewBoardMemberService(boardMemberRepository repository.BoardMemberRepository, userRepository repository.UserRepository, emailService EmailService, hub gateway.BoardEventHub) BoardMemberService {
+	return &boardMemberService{repository: boardMemberRepository, userRepository: userRepository, emailService: emailService, hub: hub}
+}
@@ -0,0 +1,62 @@
+package service
+
+// mockgen -source=service/board-middleware-service.go -destination=mock_service/board-middleware-service.go
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"gorm.io/gorm"
+)
+
+type boardMiddlewareService struct {
+	repository         repository.BoardRepository
+	boardMemberService BoardMemberService
+}
+
+type BoardMiddlewareService interface {
+	// 参照はviewer、それ以外はeditorのロールが必要
+	Authorize(*gin.Context) (model.Board, error)
+	// ボードの編集と削除、メンバーの管理はownerのロールが必要
+	AuthorizeOwner(*gin.Context) (model.Board, error)
+}
+
+func NewBoardMiddlewareService() BoardMiddlewareService {
+	return &boardMiddlewareService{repository: repository.NewBoardRepository(), boardMemberService: NewBoardMemberService()}
+}
+
+func (s *boardMiddlewareService) Authorize(ctx *gin.Context) (model.Board, error) {
+	return s.authorize(ctx, model.RequiredBoardRole(ctx.Request.Method))
+}
+
+func (s *boardMiddlewareService) AuthorizeOwner(ctx *gin.Context) (model.Board, error) {
+	return s.authorize(ctx, model.BoardRoleOwner)
+}
+
+// 不正なidはErrRecordNotFoundにする
+func (s *boardMiddlewareService) authorize(ctx *gin.Context, role string) (model.Board, error) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return model.Board{}, gorm.ErrRecordNotFound
+	}
+
+	board, err := s.repository.Find(id)
+	if err != nil {
+		return model.Board{}, err
+	}
+
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	if err := s.boardMemberService.Authorize(board.ID, currentUser, role); err != nil {
+		return model.Board{}, err
+	}
+
+	return board, nil
+}
+
+// test
+func TestNewBoardMiddlewareService(boardRepository repository.BoardRepository, boardMemberService BoardMemberService) BoardMiddlewareService {
+	return &boardMiddlewareService{repository: boardRepository, boardMemberService: boardMemberService}
+}
@@ -0,0 +1,88 @@
+package service
+
+// mockgen -source=service/board-service.go -destination=mock_service/board-service.go
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/dto"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+)
+
+type BoardService interface {
+	Index(*gin.Context) ([]model.Board, error)
+	Create(*gin.Context) (model.Board, error)
+	Update(*gin.Context) (model.Board, error)
+	Destroy(*gin.Context) error
+	Move(*gin.Context) error
+}
+
+type boardService struct {
+	repository        repository.BoardRepository
+	attachmentService AttachmentService
+}
+
+func NewBoardService() BoardService {
+	return &boardService{repository: repository.NewBoardRepository(), attachmentService: NewAttachmentService()}
+}
+
+func (s *boardService) Index(ctx *gin.Context) ([]model.Board, error) {
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	return s.repository.FindAll(&currentUser)
+}
+
+func (s *boardService) Create(ctx *gin.Context) (model.Board, error) {
+	var dtoBoard dto.Board
+	if err := ctx.ShouldBindJSON(&dtoBoard); err != nil {
+		return model.Board{}, err
+	}
+
+	var board model.Board
+	dtoBoard.Transfer(&board)
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	if err := s.repository.Create(&currentUser, &board); err != nil {
+		return model.Board{}, err
+	}
+
+	return board, nil
+}
+
+// boardはboardMiddlewareで認可済み
+func (s *boardService) Update(ctx *gin.Context) (model.Board, error) {
+	var dtoBoard dto.Board
+	if err := ctx.ShouldBindJSON(&dtoBoard); err != nil {
+		return model.Board{}, err
+	}
+
+	var updatingBoard model.Board
+	dtoBoard.Transfer(&updatingBoard)
+	board := ctx.MustGet(config.BoardKey).(model.Board)
+	err := s.repository.Update(&board, updatingBoard)
+	return board, err
+}
+
+// ボードはゴミ箱に入れずにリストとカードごと物理削除する
+func (s *boardService) Destroy(ctx *gin.Context) error {
+	board := ctx.MustGet(config.BoardKey).(model.Board)
+	if err := s.attachmentService.DestroyByBoard(board); err != nil {
+		return err
+	}
+
+	return s.repository.Destroy(&board)
+}
+
+func (s *boardService) Move(ctx *gin.Context) error {
+	var moveBoard dto.MoveBoard
+	if err := ctx.ShouldBindJSON(&moveBoard); err != nil {
+		return err
+	}
+
+	board := ctx.MustGet(config.BoardKey).(model.Board)
+	return s.repository.Move(&board, moveBoard.Index)
+}
+
+// test
+func TestNewBoardService(boardRepository repository.BoardRepository, attachmentService AttachmentService) BoardService {
+	return &boardService{repository: boardRepository, attachmentService: attachmentService}
+}
@@ -12,16 +12,17 @@ import (
 )
 
 type cardMiddlewareService struct {
-	repository     repository.CardRepository
-	userRepository repository.UserRepository
+	repository            repository.CardRepository
+	listMiddlewareService ListMiddlewareServive
 }
 
 type CardMiddlewareService interface {
+	// 参照はviewer、それ以外はeditorのロールが必要
 	Authorize(*gin.Context) (model.Card, error)
 }
 
 func NewCardMiddlewareService() CardMiddlewareService {
-	return &cardMiddlewareService{repository: repository.NewCardRepository(), userRepository: repository.NewUserRepository()}
+	return &cardMiddlewareService{repository: repository.NewCardRepository(), listMiddlewareService: NewListMiddlewareService()}
 }
 
 func (s *cardMiddlewareService) Authorize(ctx *gin.Context) (model.Card, error) {
@@ -36,18 +37,15 @@ func (s *cardMiddlewareService) Authorize(ctx *gin.Context) (model.Card, error)
 	}
 
 	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
-	hasCard, err := s.userRepository.HasCard(card, currentUser)
+	_, err = s.listMiddlewareService.FindAndAuthorizeList(card.ListID, currentUser, model.RequiredBoardRole(ctx.Request.Method))
 	if err != nil {
 		return card, err
 	}
-	if !hasCard {
-		return card, config.ForbiddenError
-	}
 
 	return card, nil
 }
 
 // test
-func TestNewCardMiddlewareService(cardRepository repository.CardRepository, userRepository repository.UserRepository) CardMiddlewareService {
-	return &cardMiddlewareService{repository: cardRepository, userRepository: userRepository}
+func TestNewCardMiddlewareService(cardRepository repository.CardRepository, listMiddlewareService ListMiddlewareServive) CardMiddlewareService {
+	return &cardMiddlewareService{repository: cardRepository, listMiddlewareService: listMiddlewareService}
 }
@@ -0,0 +1,136 @@
+package service
+
+// mockgen -source=service/card-reminder-service.go -destination=mock_service/card-reminder-service.go
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+)
+
+const (
+	// 送信時刻を過ぎたリマインダーを確認する間隔
+	MinuteCardReminderInterval = 1
+	// CARD_REMINDER_OFFSETSが設定されていない場合は期限の1日前と1時間前に送る
+	defaultCardReminderOffsets = "24h,1h"
+)
+
+type CardReminderService interface {
+	Schedule(card model.Card) error
+	SendDue() error
+	Run(stop <-chan struct{})
+}
+
+type cardReminderService struct {
+	repository   repository.CardReminderRepository
+	emailService EmailService
+}
+
+func NewCardReminderService() CardReminderService {
+	return &cardReminderService{
+		repository:   repository.NewCardReminderRepository(),
+		emailService: NewEmailService(),
+	}
+}
+
+// 期限の変更時に未送信のリマインダーを作り直す 期限がない場合は削除のみ行う
+// 既に送信時刻を過ぎたリマインダーは作らない
+func (s *cardReminderService) Schedule(card model.Card) error {
+	var reminders []model.CardReminder
+	if card.DueAt != nil {
+		now := time.Now()
+		for _, offset := range CardReminderOffsets() {
+			remindAt := card.DueAt.Add(-offset)
+			if remindAt.Before(now) {
+				continue
+			}
+			reminders = append(reminders, model.CardReminder{
+				RemindAt:      remindAt,
+				OffsetMinutes: int(offset / time.Minute),
+				CardID:        card.ID,
+			})
+		}
+	}
+
+	return s.repository.Replace(&card, reminders)
+}
+
+// 送信時刻を過ぎたリマインダーを送る 送信に失敗したメールは再送しない
+func (s *cardReminderService) SendDue() error {
+	now := time.Now()
+	reminders, err := s.repository.FindDue(now)
+	if err != nil {
+		return err
+	}
+
+	for _, reminder := range reminders {
+		claimed, err := s.repository.MarkSent(&reminder, now)
+		if err != nil {
+			return err
+		}
+		// 他のインスタンスが送信済み
+		if !claimed {
+			continue
+		}
+
+		// 期限を過ぎている場合
+		if reminder.Card.DueAt == nil || now.After(*reminder.Card.DueAt) {
+			continue
+		}
+
+		// ボードから外されたユーザーには送らない リストが削除されている場合はメンバーがいない
+		for _, member := range reminder.Card.List.Board.Members {
+			// 送信失敗のログはemailServiceが出力する
+			s.emailService.CardReminderEmail(member.User, reminder.Card)
+		}
+	}
+	return nil
+}
+
+// stopが閉じられるまで定期的にリマインダーを送る
+func (s *cardReminderService) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(MinuteCardReminderInterval * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := s.SendDue(); err != nil {
+				gin.DefaultWriter.Write([]byte(fmt.Sprintf("Failed to send card reminders\n%v\n", err.Error())))
+			}
+		}
+	}
+}
+
+// CARD_REMINDER_OFFSETS: 期限の何時間前に送るかをカンマ区切りのtime.Durationで指定する (例: 24h,1h,30m)
+func CardReminderOffsets() []time.Duration {
+	value := os.Getenv("CARD_REMINDER_OFFSETS")
+	if value == "" {
+		value = defaultCardReminderOffsets
+	}
+
+	var offsets []time.Duration
+	for _, s := range strings.Split(value, ",") {
+		offset, err := time.ParseDuration(strings.TrimSpace(s))
+		if err != nil || offset <= 0 {
+			continue
+		}
+		offsets = append(offsets, offset)
+	}
+	return offsets
+}
+
+// test
+func TestNewCardReminderService(repository repository.CardReminderRepository, emailService EmailService) CardReminderService {
+	return &cardReminderService{
+		repository:   repository,
+		emailService: emailService,
+	}
+}
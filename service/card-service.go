@@ -3,6 +3,8 @@ package service
 // mockgen -source=service/card-service.go -destination=./mock_service/card-service.go
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/kuritaeiji/todo-gin-back/config"
 	"github.com/kuritaeiji/todo-gin-back/dto"
@@ -13,6 +15,8 @@ import (
 type cardService struct {
 	repository            repository.CardRepository
 	listMiddlewareService ListMiddlewareServive
+	cardReminderService   CardReminderService
+	boardEventService     BoardEventService
 }
 
 type CardService interface {
@@ -20,10 +24,11 @@ type CardService interface {
 	Update(*gin.Context) (model.Card, error)
 	Destroy(*gin.Context) error
 	Move(*gin.Context) error
+	Archive(*gin.Context) error
 }
 
 func NewCardService() CardService {
-	return &cardService{repository: repository.NewCardRepository(), listMiddlewareService: NewListMiddlewareService()}
+	return &cardService{repository: repository.NewCardRepository(), listMiddlewareService: NewListMiddlewareService(), cardReminderService: NewCardReminderService(), boardEventService: NewBoardEventService()}
 }
 
 func (s *cardService) Create(ctx *gin.Context) (model.Card, error) {
@@ -37,7 +42,14 @@ func (s *cardService) Create(ctx *gin.Context) (model.Card, error) {
 	cardDto.Transfer(&card)
 
 	list := ctx.MustGet(config.ListKey).(model.List)
-	err = s.repository.Create(&card, &list)
+	if err := s.repository.Create(&card, &list); err != nil {
+		return model.Card{}, err
+	}
+
+	s.boardEventService.PublishCard(CardCreatedEvent, card)
+	if card.DueAt != nil {
+		err = s.cardReminderService.Schedule(card)
+	}
 	return card, err
 }
 
@@ -51,14 +63,44 @@ func (s *cardService) Update(ctx *gin.Context) (model.Card, error) {
 	var updatingCard model.Card
 	dtoCard.Transfer(&updatingCard)
 	card := ctx.MustGet(config.CardKey).(model.Card)
-	err = s.repository.Update(&card, &updatingCard)
+	previousDueAt := card.DueAt
+	if err := s.repository.Update(&card, &updatingCard); err != nil {
+		return card, err
+	}
 
+	s.boardEventService.PublishCard(CardUpdatedEvent, card)
+	// 期限が変わった場合のみリマインダーを作り直す
+	if !equalTime(previousDueAt, updatingCard.DueAt) {
+		card.DueAt = updatingCard.DueAt
+		err = s.cardReminderService.Schedule(card)
+	}
 	return card, err
 }
 
+// 論理削除なのでゴミ箱から元に戻せる 添付ファイルは完全に削除する際に削除する
 func (s *cardService) Destroy(ctx *gin.Context) error {
 	card := ctx.MustGet(config.CardKey).(model.Card)
-	return s.repository.Destroy(&card)
+	if err := s.repository.Destroy(&card); err != nil {
+		return err
+	}
+
+	s.boardEventService.PublishCard(CardDeletedEvent, card)
+	return nil
+}
+
+// アーカイブ済みの場合は何もしない
+func (s *cardService) Archive(ctx *gin.Context) error {
+	card := ctx.MustGet(config.CardKey).(model.Card)
+	if card.ArchivedAt != nil {
+		return nil
+	}
+
+	if err := s.repository.Archive(&card); err != nil {
+		return err
+	}
+
+	s.boardEventService.PublishCard(CardArchivedEvent, card)
+	return nil
 }
 
 func (s *cardService) Move(ctx *gin.Context) error {
@@ -69,18 +111,33 @@ func (s *cardService) Move(ctx *gin.Context) error {
 		return err
 	}
 
-	// カレントユーザーが移動した先のリストを所有しているか確認する
+	// カレントユーザーが移動した先のリストを編集できるか確認する
 	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
-	_, err = s.listMiddlewareService.FindAndAuthorizeList(dtoMoveCard.ToListID, currentUser)
+	_, err = s.listMiddlewareService.FindAndAuthorizeList(dtoMoveCard.ToListID, currentUser, model.BoardRoleEditor)
 	if err != nil {
 		return err
 	}
 
 	card := ctx.MustGet(config.CardKey).(model.Card)
-	return s.repository.Move(&card, dtoMoveCard.ToListID, dtoMoveCard.ToIndex)
+	fromListID := card.ListID
+	if err := s.repository.Move(&card, dtoMoveCard.ToListID, dtoMoveCard.ToIndex); err != nil {
+		return err
+	}
+
+	card.ListID = dtoMoveCard.ToListID
+	card.Index = dtoMoveCard.ToIndex
+	s.boardEventService.PublishCard(CardMovedEvent, card, dtoMoveCard.ToListID, fromListID)
+	return nil
+}
+
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
 }
 
 // test
-func TestNewCardService(cardRepository repository.CardRepository, listMiddlewareService ListMiddlewareServive) CardService {
-	return &cardService{repository: cardRepository, listMiddlewareService: listMiddlewareService}
+func TestNewCardService(cardRepository repository.CardRepository, listMiddlewareService ListMiddlewareServive, cardReminderService CardReminderService, boardEventService BoardEventService) CardService {
+	return &cardService{repository: cardRepository, listMiddlewareService: listMiddlewareService, cardReminderService: cardReminderService, boardEventService: boardEventService}
 }
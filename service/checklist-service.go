@@ -0,0 +1,222 @@
+package service
+
+// mockgen -source=service/checklist-service.go -destination=mock_service/checklist-service.go
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/dto"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"gorm.io/gorm"
+)
+
+// カードはcardMiddlewareで認可済みなのでカードに属するチェックリストのみを操作する
+// 変更した場合はカードの進捗が変わるのでボードに通知する
+type ChecklistService interface {
+	Index(*gin.Context) ([]model.Checklist, error)
+	Create(*gin.Context) (model.Checklist, error)
+	Update(*gin.Context) (model.Checklist, error)
+	Destroy(*gin.Context) error
+	Move(*gin.Context) error
+	CreateItem(*gin.Context) (model.ChecklistItem, error)
+	UpdateItem(*gin.Context) (model.ChecklistItem, error)
+	DestroyItem(*gin.Context) error
+	MoveItem(*gin.Context) error
+}
+
+type checklistService struct {
+	repository        repository.ChecklistRepository
+	boardEventService BoardEventService
+}
+
+func NewChecklistService() ChecklistService {
+	return &checklistService{repository: repository.NewChecklistRepository(), boardEventService: NewBoardEventService()}
+}
+
+func (s *checklistService) Index(ctx *gin.Context) ([]model.Checklist, error) {
+	card := ctx.MustGet(config.CardKey).(model.Card)
+	return s.repository.FindAll(&card)
+}
+
+func (s *checklistService) Create(ctx *gin.Context) (model.Checklist, error) {
+	var dtoChecklist dto.Checklist
+	if err := ctx.ShouldBindJSON(&dtoChecklist); err != nil {
+		return model.Checklist{}, err
+	}
+
+	card := ctx.MustGet(config.CardKey).(model.Card)
+	checklist := model.Checklist{CardID: card.ID}
+	dtoChecklist.Transfer(&checklist)
+	if err := s.repository.Create(&checklist); err != nil {
+		return model.Checklist{}, err
+	}
+
+	s.publishCardChanged(ctx)
+	return checklist, nil
+}
+
+func (s *checklistService) Update(ctx *gin.Context) (model.Checklist, error) {
+	checklist, err := s.findChecklist(ctx)
+	if err != nil {
+		return model.Checklist{}, err
+	}
+
+	var dtoChecklist dto.Checklist
+	if err := ctx.ShouldBindJSON(&dtoChecklist); err != nil {
+		return model.Checklist{}, err
+	}
+
+	var updatingChecklist model.Checklist
+	dtoChecklist.Transfer(&updatingChecklist)
+	if err := s.repository.Update(&checklist, updatingChecklist); err != nil {
+		return model.Checklist{}, err
+	}
+
+	s.publishCardChanged(ctx)
+	return checklist, nil
+}
+
+func (s *checklistService) Destroy(ctx *gin.Context) error {
+	checklist, err := s.findChecklist(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repository.Destroy(&checklist); err != nil {
+		return err
+	}
+
+	s.publishCardChanged(ctx)
+	return nil
+}
+
+func (s *checklistService) Move(ctx *gin.Context) error {
+	checklist, err := s.findChecklist(ctx)
+	if err != nil {
+		return err
+	}
+
+	var dtoMove dto.MoveChecklist
+	if err := ctx.ShouldBindJSON(&dtoMove); err != nil {
+		return err
+	}
+
+	if err := s.repository.Move(&checklist, dtoMove.ToIndex); err != nil {
+		return err
+	}
+
+	s.publishCardChanged(ctx)
+	return nil
+}
+
+func (s *checklistService) CreateItem(ctx *gin.Context) (model.ChecklistItem, error) {
+	checklist, err := s.findChecklist(ctx)
+	if err != nil {
+		return model.ChecklistItem{}, err
+	}
+
+	var dtoItem dto.ChecklistItem
+	if err := ctx.ShouldBindJSON(&dtoItem); err != nil {
+		return model.ChecklistItem{}, err
+	}
+
+	item := model.ChecklistItem{ChecklistID: checklist.ID}
+	dtoItem.Transfer(&item)
+	if err := s.repository.CreateItem(&item); err != nil {
+		return model.ChecklistItem{}, err
+	}
+
+	s.publishCardChanged(ctx)
+	return item, nil
+}
+
+func (s *checklistService) UpdateItem(ctx *gin.Context) (model.ChecklistItem, error) {
+	item, err := s.findItem(ctx)
+	if err != nil {
+		return model.ChecklistItem{}, err
+	}
+
+	var dtoItem dto.ChecklistItem
+	if err := ctx.ShouldBindJSON(&dtoItem); err != nil {
+		return model.ChecklistItem{}, err
+	}
+
+	var updatingItem model.ChecklistItem
+	dtoItem.Transfer(&updatingItem)
+	if err := s.repository.UpdateItem(&item, updatingItem); err != nil {
+		return model.ChecklistItem{}, err
+	}
+
+	s.publishCardChanged(ctx)
+	return item, nil
+}
+
+func (s *checklistService) DestroyItem(ctx *gin.Context) error {
+	item, err := s.findItem(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repository.DestroyItem(&item); err != nil {
+		return err
+	}
+
+	s.publishCardChanged(ctx)
+	return nil
+}
+
+func (s *checklistService) MoveItem(ctx *gin.Context) error {
+	item, err := s.findItem(ctx)
+	if err != nil {
+		return err
+	}
+
+	var dtoMove dto.MoveChecklist
+	if err := ctx.ShouldBindJSON(&dtoMove); err != nil {
+		return err
+	}
+
+	if err := s.repository.MoveItem(&item, dtoMove.ToIndex); err != nil {
+		return err
+	}
+
+	s.publishCardChanged(ctx)
+	return nil
+}
+
+func (s *checklistService) publishCardChanged(ctx *gin.Context) {
+	card := ctx.MustGet(config.CardKey).(model.Card)
+	s.boardEventService.PublishCardChanged(card.ID)
+}
+
+func (s *checklistService) findChecklist(ctx *gin.Context) (model.Checklist, error) {
+	id, err := strconv.Atoi(ctx.Param("checklistID"))
+	if err != nil {
+		return model.Checklist{}, gorm.ErrRecordNotFound
+	}
+
+	card := ctx.MustGet(config.CardKey).(model.Card)
+	return s.repository.Find(&card, id)
+}
+
+func (s *checklistService) findItem(ctx *gin.Context) (model.ChecklistItem, error) {
+	checklist, err := s.findChecklist(ctx)
+	if err != nil {
+		return model.ChecklistItem{}, err
+	}
+
+	id, err := strconv.Atoi(ctx.Param("itemID"))
+	if err != nil {
+		return model.ChecklistItem{}, gorm.ErrRecordNotFound
+	}
+
+	return s.repository.FindItem(&checklist, id)
+}
+
+// test
+func TestNewChecklistService(repository repository.ChecklistRepository, boardEventService BoardEventService) ChecklistService {
+	return &checklistService{repository: repository, boardEventService: boardEventService}
+}
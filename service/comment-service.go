@@ -0,0 +1,120 @@
+package service
+
+// mockgen -source=service/comment-service.go -destination=mock_service/comment-service.go
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/dto"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"gorm.io/gorm"
+)
+
+const CommentPageSize = 50
+
+type CommentService interface {
+	Index(*gin.Context) ([]model.Comment, error)
+	Create(*gin.Context) (model.Comment, error)
+	Update(*gin.Context) (model.Comment, error)
+	Destroy(*gin.Context) error
+}
+
+type commentService struct {
+	repository            repository.CommentRepository
+	listMiddlewareService ListMiddlewareServive
+}
+
+func NewCommentService() CommentService {
+	return &commentService{repository: repository.NewCommentRepository(), listMiddlewareService: NewListMiddlewareService()}
+}
+
+// カードはcardMiddlewareで認可済み
+func (s *commentService) Index(ctx *gin.Context) ([]model.Comment, error) {
+	var dtoQuery dto.CommentQuery
+	if err := ctx.ShouldBindQuery(&dtoQuery); err != nil {
+		return nil, err
+	}
+
+	card := ctx.MustGet(config.CardKey).(model.Card)
+	return s.repository.FindAll(&card, dtoQuery.Before, CommentPageSize)
+}
+
+func (s *commentService) Create(ctx *gin.Context) (model.Comment, error) {
+	var dtoComment dto.Comment
+	if err := ctx.ShouldBindJSON(&dtoComment); err != nil {
+		return model.Comment{}, err
+	}
+
+	card := ctx.MustGet(config.CardKey).(model.Card)
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	comment := model.Comment{Body: dtoComment.Body, CardID: card.ID, UserID: currentUser.ID}
+	if err := s.repository.Create(&comment); err != nil {
+		return model.Comment{}, err
+	}
+
+	comment.User = currentUser
+	return comment, nil
+}
+
+// ボードのeditor以上のロールを持つ作成者のみ編集できる
+func (s *commentService) Update(ctx *gin.Context) (model.Comment, error) {
+	comment, err := s.findOwnComment(ctx)
+	if err != nil {
+		return model.Comment{}, err
+	}
+
+	var dtoComment dto.Comment
+	if err := ctx.ShouldBindJSON(&dtoComment); err != nil {
+		return model.Comment{}, err
+	}
+
+	if err := s.repository.Update(&comment, dtoComment.Body, time.Now()); err != nil {
+		return model.Comment{}, err
+	}
+
+	return comment, nil
+}
+
+// ボードのeditor以上のロールを持つ作成者のみ削除できる
+func (s *commentService) Destroy(ctx *gin.Context) error {
+	comment, err := s.findOwnComment(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.Destroy(&comment)
+}
+
+func (s *commentService) findOwnComment(ctx *gin.Context) (model.Comment, error) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return model.Comment{}, gorm.ErrRecordNotFound
+	}
+
+	comment, err := s.repository.Find(id)
+	if err != nil {
+		return model.Comment{}, err
+	}
+
+	// ボードから外されたり閲覧者に変更されたりした作成者は編集できない
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	_, err = s.listMiddlewareService.FindAndAuthorizeList(comment.Card.ListID, currentUser, model.BoardRoleEditor)
+	if err != nil {
+		return model.Comment{}, err
+	}
+
+	if !currentUser.HasComment(comment) {
+		return model.Comment{}, config.ForbiddenError
+	}
+
+	return comment, nil
+}
+
+// test
+func TestNewCommentService(repository repository.CommentRepository, listMiddlewareService ListMiddlewareServive) CommentService {
+	return &commentService{repository: repository, listMiddlewareService: listMiddlewareService}
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/kuritaeiji/todo-gin-back/config"
@@ -17,6 +18,11 @@ import (
 
 type EmailService interface {
 	ActivationUserEmail(model.User) error
+	PasswordResetEmail(user model.User, token string) error
+	EmailChangeEmail(email, token string) error
+	AccountLockedEmail(user model.User, lockedUntil time.Time) error
+	CardReminderEmail(user model.User, card model.Card) error
+	BoardInvitationEmail(user model.User, board model.Board, inviter model.User) error
 }
 
 type emailService struct {
@@ -42,10 +48,74 @@ func (s *emailService) ActivationUserEmail(user model.User) error {
 
 func (s *emailService) activationHTML(user model.User) string {
 	token := s.jwtService.CreateJWT(user, DayFromNowActivateUserToken)
-	html := template.Must(template.ParseFiles(fmt.Sprintf("%v/template/activation-user.html", config.WorkDir)))
+	return s.html("activation-user.html", fmt.Sprintf("%v/activate?token=%v", os.Getenv("FRONT_ORIGIN"), token))
+}
+
+func (s *emailService) PasswordResetEmail(user model.User, token string) error {
+	html := s.html("password-reset.html", fmt.Sprintf("%v/password/reset?token=%v", os.Getenv("FRONT_ORIGIN"), token))
+	err := s.gateway.Send(user.Email, "パスワード再設定リンク", html)
+	if err != nil {
+		gin.DefaultWriter.Write([]byte(fmt.Sprintf("Failed to send password reset email\n%v", err.Error())))
+		return config.EmailClientError
+	}
+	return nil
+}
+
+// 新しいメールアドレスに確認メールを送る
+func (s *emailService) EmailChangeEmail(email, token string) error {
+	html := s.html("email-change.html", fmt.Sprintf("%v/email/confirm?token=%v", os.Getenv("FRONT_ORIGIN"), token))
+	err := s.gateway.Send(email, "メールアドレス確認リンク", html)
+	if err != nil {
+		gin.DefaultWriter.Write([]byte(fmt.Sprintf("Failed to send email change email\n%v", err.Error())))
+		return config.EmailClientError
+	}
+	return nil
+}
+
+func (s *emailService) AccountLockedEmail(user model.User, lockedUntil time.Time) error {
+	html := s.html("account-locked.html", lockedUntil.Format("2006/01/02 15:04"))
+	err := s.gateway.Send(user.Email, "アカウントロックのお知らせ", html)
+	if err != nil {
+		gin.DefaultWriter.Write([]byte(fmt.Sprintf("Failed to send account locked email\n%v", err.Error())))
+		return config.EmailClientError
+	}
+	return nil
+}
+
+// 期限はカードのタイムゾーンで表示する
+func (s *emailService) CardReminderEmail(user model.User, card model.Card) error {
+	html := s.html("card-reminder.html", map[string]string{
+		"Title": card.Title,
+		"DueAt": card.DueAt.In(card.Location()).Format("2006/01/02 15:04 MST"),
+		"URL":   os.Getenv("FRONT_ORIGIN"),
+	})
+	err := s.gateway.Send(user.Email, fmt.Sprintf("期限が近づいています: %v", card.Title), html)
+	if err != nil {
+		gin.DefaultWriter.Write([]byte(fmt.Sprintf("Failed to send card reminder email\n%v", err.Error())))
+		return config.EmailClientError
+	}
+	return nil
+}
+
+func (s *emailService) BoardInvitationEmail(user model.User, board model.Board, inviter model.User) error {
+	html := s.html("board-invitation.html", map[string]string{
+		"Title":   board.Title,
+		"Inviter": inviter.Email,
+		"URL":     fmt.Sprintf("%v/invitations", os.Getenv("FRONT_ORIGIN")),
+	})
+	err := s.gateway.Send(user.Email, fmt.Sprintf("ボードに招待されました: %v", board.Title), html)
+	if err != nil {
+		gin.DefaultWriter.Write([]byte(fmt.Sprintf("Failed to send board invitation email\n%v", err.Error())))
+		return config.EmailClientError
+	}
+	return nil
+}
+
+func (s *emailService) html(templateName string, data interface{}) string {
+	html := template.Must(template.ParseFiles(fmt.Sprintf("%v/template/%v", config.WorkDir, templateName)))
 	pr, pw := io.Pipe()
 	go func() {
-		html.Execute(pw, fmt.Sprintf("%v/activate?token=%v", os.Getenv("FRONT_ORIGIN"), token))
+		html.Execute(pw, data)
 		pw.Close()
 	}()
 	byteSlice, _ := ioutil.ReadAll(pr)
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	crand "crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+)
+
+const minRSAKeyBits = 2048
+
+// jwtの署名鍵 Kidはjwtのヘッダーに入れて検証時にどの鍵で署名したかを判別する
+// 以前の鍵は検証にのみ使うのでPrivateKeyを持たない
+type SigningKey struct {
+	Kid        string
+	Method     jwt.SigningMethod
+	PrivateKey crypto.PrivateKey
+	PublicKey  crypto.PublicKey
+}
+
+// Currentで署名し、CurrentとPreviousで検証する
+// 鍵をローテーションする場合は古い鍵の公開鍵をPreviousに移し、発行済みのトークンの有効期限が切れてから削除する
+// LegacySecretはHS256からの移行期間中にkidを持たないトークンを検証するためのもの
+type KeySet struct {
+	Current      SigningKey
+	Previous     []SigningKey
+	LegacySecret []byte
+}
+
+// JWKSエンドポイントで公開する公開鍵
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Kid string `json:"kid,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+var (
+	envKeySet     KeySet
+	envKeySetOnce sync.Once
+)
+
+// 秘密鍵(RSAもしくはEd25519)から署名鍵を作成する kidは公開鍵のJWK Thumbprint
+func NewSigningKey(privateKey crypto.PrivateKey) (SigningKey, error) {
+	var publicKey crypto.PublicKey
+	switch key := privateKey.(type) {
+	case *rsa.PrivateKey:
+		publicKey = &key.PublicKey
+	case ed25519.PrivateKey:
+		publicKey = key.Public()
+	default:
+		return SigningKey{}, errors.New("unsupported private key type")
+	}
+
+	signingKey, err := NewVerificationKey(publicKey)
+	if err != nil {
+		return SigningKey{}, err
+	}
+
+	signingKey.PrivateKey = privateKey
+	return signingKey, nil
+}
+
+// 検証にのみ使う公開鍵から署名鍵を作成する
+func NewVerificationKey(publicKey crypto.PublicKey) (SigningKey, error) {
+	var method jwt.SigningMethod
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if key.N.BitLen() < minRSAKeyBits {
+			return SigningKey{}, fmt.Errorf("rsa key must be at least %v bits", minRSAKeyBits)
+		}
+		method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		method = jwt.SigningMethodEdDSA
+	default:
+		return SigningKey{}, errors.New("unsupported public key type")
+	}
+
+	signingKey := SigningKey{Method: method, PublicKey: publicKey}
+	signingKey.Kid = thumbprint(signingKey.jwk())
+	return signingKey, nil
+}
+
+func (k SigningKey) jwk() JWK {
+	switch key := k.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		return JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(key),
+		}
+	}
+
+	return JWK{}
+}
+
+// RFC7638 必須のメンバーのみを辞書順に並べたjsonのsha256
+func thumbprint(jwk JWK) string {
+	var members map[string]string
+	if jwk.Kty == "RSA" {
+		members = map[string]string{"e": jwk.E, "kty": jwk.Kty, "n": jwk.N}
+	} else {
+		members = map[string]string{"crv": jwk.Crv, "kty": jwk.Kty, "x": jwk.X}
+	}
+
+	// encoding/jsonはmapのキーを辞書順に並べる
+	bytes, _ := json.Marshal(members)
+	sum := sha256.Sum256(bytes)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func (ks KeySet) find(kid string) (SigningKey, bool) {
+	if ks.Current.Kid == kid {
+		return ks.Current, true
+	}
+
+	for _, key := range ks.Previous {
+		if key.Kid == kid {
+			return key, true
+		}
+	}
+
+	return SigningKey{}, false
+}
+
+func (ks KeySet) JWKS() JWKSet {
+	keys := []JWK{}
+	for _, key := range append([]SigningKey{ks.Current}, ks.Previous...) {
+		jwk := key.jwk()
+		jwk.Use = "sig"
+		jwk.Alg = key.Method.Alg()
+		jwk.Kid = key.Kid
+		keys = append(keys, jwk)
+	}
+
+	return JWKSet{Keys: keys}
+}
+
+// 環境変数から一度だけ鍵を読み込み、全てのjwtServiceで同じ鍵を使う
+// JWT_PRIVATE_KEY: 署名に使うPKCS#8(RSAはPKCS#1も可)のPEM
+// JWT_PREVIOUS_PUBLIC_KEYS: ローテーション前の鍵のPEMの公開鍵 複数並べられる
+// JWT_SECRET_KEY: 移行期間中のみHS256のトークンを検証する
+// 本番環境での設定方法とローテーション手順はconfig/release.envに書いている
+func loadKeySet() KeySet {
+	envKeySetOnce.Do(func() {
+		keySet, err := keySetFromEnv()
+		if err != nil {
+			panic(fmt.Sprintf("Failed to load jwt keys\n%v", err.Error()))
+		}
+		envKeySet = keySet
+	})
+
+	return envKeySet
+}
+
+func keySetFromEnv() (KeySet, error) {
+	var keySet KeySet
+	current, err := currentKeyFromEnv()
+	if err != nil {
+		return KeySet{}, err
+	}
+	keySet.Current = current
+
+	rest := []byte(pemEnv("JWT_PREVIOUS_PUBLIC_KEYS"))
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return KeySet{}, err
+		}
+
+		key, err := NewVerificationKey(publicKey)
+		if err != nil {
+			return KeySet{}, err
+		}
+		keySet.Previous = append(keySet.Previous, key)
+	}
+
+	if secret := os.Getenv("JWT_SECRET_KEY"); secret != "" {
+		keySet.LegacySecret = []byte(secret)
+	}
+
+	return keySet, nil
+}
+
+// 開発環境とテスト環境では鍵が設定されていなければ起動ごとに生成する
+func currentKeyFromEnv() (SigningKey, error) {
+	block, _ := pem.Decode([]byte(pemEnv("JWT_PRIVATE_KEY")))
+	if block == nil {
+		if gin.Mode() == gin.ReleaseMode {
+			return SigningKey{}, errors.New("JWT_PRIVATE_KEY is not set")
+		}
+
+		_, privateKey, err := ed25519.GenerateKey(crand.Reader)
+		if err != nil {
+			return SigningKey{}, err
+		}
+		return NewSigningKey(privateKey)
+	}
+
+	if block.Type == "RSA PRIVATE KEY" {
+		privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return SigningKey{}, err
+		}
+		return NewSigningKey(privateKey)
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return SigningKey{}, err
+	}
+	return NewSigningKey(privateKey)
+}
+
+// herokuの環境変数等で改行を\nと書いた場合にも読み込めるようにする
+func pemEnv(key string) string {
+	return strings.ReplaceAll(os.Getenv(key), `\n`, "\n")
+}
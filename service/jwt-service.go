@@ -3,52 +3,117 @@ package service
 // mockgen -source=service/jwt-service.go -destination=mock_service/jwt-service.go
 
 import (
-	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt"
+	"github.com/kuritaeiji/todo-gin-back/config"
 	"github.com/kuritaeiji/todo-gin-back/model"
 )
 
 const (
-	DayFromNowAccessToken       = 365
+	MinuteFromNowAccessToken    = 15
+	MinuteFromNowChallengeToken = 5
 	DayFromNowActivateUserToken = 1
+	jtiByteLength               = 16
 )
 
+// 認証に使えるのはAccessTokenTypeのトークンのみ
+const (
+	AccessTokenType    = "access"
+	ChallengeTokenType = "challenge"
+)
+
+// jtiはStandardClaims.Idに入れる
 type UserClaim struct {
-	ID int `json:"id"`
+	ID        int    `json:"id"`
+	SessionID string `json:"sid,omitempty"`
+	Version   int    `json:"ver"`
+	Type      string `json:"typ,omitempty"`
 	jwt.StandardClaims
 }
 
 type JWTService interface {
 	CreateJWT(user model.User, dayFromNow int) string
+	CreateAccessJWT(user model.User, sessionID string) string
+	CreateChallengeJWT(user model.User) string
 	VerifyJWT(tokdnString string) (*UserClaim, error)
+	JWKS() JWKSet
 }
 
 type jwtService struct {
-	key []byte
+	keySet KeySet
 }
 
 func NewJWTService() JWTService {
-	return &jwtService{[]byte(os.Getenv("JWT_SECRET_KEY"))}
+	return &jwtService{loadKeySet()}
 }
 
 func (s *jwtService) CreateJWT(user model.User, dayFromNow int) string {
-	claim := UserClaim{
-		user.ID,
-		jwt.StandardClaims{ExpiresAt: time.Now().AddDate(0, 0, dayFromNow).Unix()},
-	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claim)
-	tokenString, err := token.SignedString(s.key)
+	return s.createJWT(UserClaim{
+		ID:             user.ID,
+		StandardClaims: jwt.StandardClaims{ExpiresAt: time.Now().AddDate(0, 0, dayFromNow).Unix()},
+	})
+}
+
+// ログイン時に発行するアクセストークン 有効期限が短いのでリフレッシュトークンで再発行する
+// jtiで個別に、verでユーザーの全てのトークンを無効にできる
+func (s *jwtService) CreateAccessJWT(user model.User, sessionID string) string {
+	now := time.Now()
+	return s.createJWT(UserClaim{
+		ID:        user.ID,
+		SessionID: sessionID,
+		Version:   user.TokenVersion,
+		Type:      AccessTokenType,
+		StandardClaims: jwt.StandardClaims{
+			Id:        config.MakeRandomToken(jtiByteLength),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(MinuteFromNowAccessToken * time.Minute).Unix(),
+		},
+	})
+}
+
+// 2段階認証が有効な場合にパスワード認証後に発行するトークン 認証コードと交換でアクセストークンを発行する
+func (s *jwtService) CreateChallengeJWT(user model.User) string {
+	now := time.Now()
+	return s.createJWT(UserClaim{
+		ID:      user.ID,
+		Version: user.TokenVersion,
+		Type:    ChallengeTokenType,
+		StandardClaims: jwt.StandardClaims{
+			Id:        config.MakeRandomToken(jtiByteLength),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(MinuteFromNowChallengeToken * time.Minute).Unix(),
+		},
+	})
+}
+
+func (s *jwtService) createJWT(claim UserClaim) string {
+	key := s.keySet.Current
+	token := jwt.NewWithClaims(key.Method, claim)
+	token.Header["kid"] = key.Kid
+	tokenString, err := token.SignedString(key.PrivateKey)
 	if err != nil {
 		panic(err)
 	}
 	return tokenString
 }
 
+// kidで鍵を選び、その鍵のアルゴリズム以外で署名されたトークンは受け付けない
 func (s *jwtService) VerifyJWT(tokenString string) (*UserClaim, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &UserClaim{}, func(t *jwt.Token) (interface{}, error) {
-		return s.key, nil
+		kid, _ := t.Header["kid"].(string)
+		if kid == "" && s.keySet.LegacySecret != nil && t.Method == jwt.SigningMethodHS256 {
+			return s.keySet.LegacySecret, nil
+		}
+
+		key, ok := s.keySet.find(kid)
+		if !ok {
+			return nil, config.UnknownSigningKeyError
+		}
+		if t.Method.Alg() != key.Method.Alg() {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return key.PublicKey, nil
 	})
 
 	if err != nil {
@@ -61,3 +126,13 @@ func (s *jwtService) VerifyJWT(tokenString string) (*UserClaim, error) {
 
 	return &UserClaim{}, err
 }
+
+// 他のサービスがトークンを検証できるように公開鍵を返す
+func (s *jwtService) JWKS() JWKSet {
+	return s.keySet.JWKS()
+}
+
+// test
+func TestNewJWTService(keySet KeySet) JWTService {
+	return &jwtService{keySet}
+}
@@ -0,0 +1,155 @@
+package service
+
+// mockgen -source=service/label-service.go -destination=mock_service/label-service.go
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/dto"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"gorm.io/gorm"
+)
+
+type LabelService interface {
+	Index(*gin.Context) ([]model.Label, error)
+	Create(*gin.Context) (model.Label, error)
+	Update(*gin.Context) (model.Label, error)
+	Destroy(*gin.Context) error
+	Attach(*gin.Context) (model.Card, error)
+	Detach(*gin.Context) (model.Card, error)
+}
+
+type labelService struct {
+	repository        repository.LabelRepository
+	boardEventService BoardEventService
+}
+
+func NewLabelService() LabelService {
+	return &labelService{repository: repository.NewLabelRepository(), boardEventService: NewBoardEventService()}
+}
+
+func (s *labelService) Index(ctx *gin.Context) ([]model.Label, error) {
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	return s.repository.FindAll(&currentUser)
+}
+
+func (s *labelService) Create(ctx *gin.Context) (model.Label, error) {
+	var dtoLabel dto.Label
+	if err := ctx.ShouldBindJSON(&dtoLabel); err != nil {
+		return model.Label{}, err
+	}
+
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	label := model.Label{UserID: currentUser.ID}
+	dtoLabel.Transfer(&label)
+	if err := s.repository.Create(&label); err != nil {
+		return model.Label{}, err
+	}
+
+	return label, nil
+}
+
+func (s *labelService) Update(ctx *gin.Context) (model.Label, error) {
+	label, err := s.findLabel(ctx, "id")
+	if err != nil {
+		return model.Label{}, err
+	}
+
+	var dtoLabel dto.Label
+	if err := ctx.ShouldBindJSON(&dtoLabel); err != nil {
+		return model.Label{}, err
+	}
+
+	var updatingLabel model.Label
+	dtoLabel.Transfer(&updatingLabel)
+	if err := s.repository.Update(&label, updatingLabel); err != nil {
+		return model.Label{}, err
+	}
+
+	return label, nil
+}
+
+func (s *labelService) Destroy(ctx *gin.Context) error {
+	label, err := s.findLabel(ctx, "id")
+	if err != nil {
+		return err
+	}
+
+	return s.repository.Destroy(&label)
+}
+
+// カードはcardMiddlewareで認可済み 既に付いているラベルはそのまま返す
+func (s *labelService) Attach(ctx *gin.Context) (model.Card, error) {
+	card := ctx.MustGet(config.CardKey).(model.Card)
+	label, err := s.findLabel(ctx, "labelID")
+	if err != nil {
+		return model.Card{}, err
+	}
+
+	if hasLabel(card, label) {
+		return card, nil
+	}
+
+	if err := s.repository.Attach(&card, &label); err != nil {
+		return model.Card{}, err
+	}
+
+	s.boardEventService.PublishCardChanged(card.ID)
+	return card, nil
+}
+
+func (s *labelService) Detach(ctx *gin.Context) (model.Card, error) {
+	card := ctx.MustGet(config.CardKey).(model.Card)
+	label, err := s.findLabel(ctx, "labelID")
+	if err != nil {
+		return model.Card{}, err
+	}
+
+	if !hasLabel(card, label) {
+		return card, nil
+	}
+
+	if err := s.repository.Detach(&card, &label); err != nil {
+		return model.Card{}, err
+	}
+
+	s.boardEventService.PublishCardChanged(card.ID)
+	return card, nil
+}
+
+// 他のユーザーのラベルはForbiddenErrorを返す
+func (s *labelService) findLabel(ctx *gin.Context, param string) (model.Label, error) {
+	id, err := strconv.Atoi(ctx.Param(param))
+	if err != nil {
+		return model.Label{}, gorm.ErrRecordNotFound
+	}
+
+	label, err := s.repository.Find(id)
+	if err != nil {
+		return model.Label{}, err
+	}
+
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	if !currentUser.HasLabel(label) {
+		return model.Label{}, config.ForbiddenError
+	}
+
+	return label, nil
+}
+
+func hasLabel(card model.Card, label model.Label) bool {
+	for _, cardLabel := range card.Labels {
+		if cardLabel.ID == label.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// test
+func TestNewLabelService(repository repository.LabelRepository, boardEventService BoardEventService) LabelService {
+	return &labelService{repository: repository, boardEventService: boardEventService}
+}
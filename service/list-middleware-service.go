@@ -12,16 +12,18 @@ import (
 )
 
 type listMiddlewareServive struct {
-	repository repository.ListRepository
+	repository         repository.ListRepository
+	boardMemberService BoardMemberService
 }
 
 type ListMiddlewareServive interface {
+	// 参照はviewer、それ以外はeditorのロールが必要
 	Authorize(*gin.Context) (model.List, error)
-	FindAndAuthorizeList(id int, currentUser model.User) (model.List, error)
+	FindAndAuthorizeList(id int, currentUser model.User, role string) (model.List, error)
 }
 
 func NewListMiddlewareService() ListMiddlewareServive {
-	return &listMiddlewareServive{repository: repository.NewListRepository()}
+	return &listMiddlewareServive{repository: repository.NewListRepository(), boardMemberService: NewBoardMemberService()}
 }
 
 func (s *listMiddlewareServive) Authorize(ctx *gin.Context) (model.List, error) {
@@ -41,23 +43,23 @@ func (s *listMiddlewareServive) Authorize(ctx *gin.Context) (model.List, error)
 	}
 
 	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
-	return s.FindAndAuthorizeList(id, currentUser)
+	return s.FindAndAuthorizeList(id, currentUser, model.RequiredBoardRole(ctx.Request.Method))
 }
 
-func (s *listMiddlewareServive) FindAndAuthorizeList(id int, currentUser model.User) (model.List, error) {
+func (s *listMiddlewareServive) FindAndAuthorizeList(id int, currentUser model.User, role string) (model.List, error) {
 	list, err := s.repository.Find(id)
 	if err != nil {
 		return model.List{}, err
 	}
 
-	if !currentUser.HasList(list) {
-		return model.List{}, config.ForbiddenError
+	if err := s.boardMemberService.Authorize(list.BoardID, currentUser, role); err != nil {
+		return model.List{}, err
 	}
 
 	return list, nil
 }
 
 // test
-func TestNewListMiddlewareService(listRepository repository.ListRepository) ListMiddlewareServive {
-	return &listMiddlewareServive{repository: listRepository}
+func TestNewListMiddlewareService(listRepository repository.ListRepository, boardMemberService BoardMemberService) ListMiddlewareServive {
+	return &listMiddlewareServive{repository: listRepository, boardMemberService: boardMemberService}
 }
@@ -11,7 +11,8 @@ import (
 )
 
 type listService struct {
-	rep repository.ListRepository
+	rep               repository.ListRepository
+	boardEventService BoardEventService
 }
 
 type ListService interface {
@@ -20,16 +21,28 @@ type ListService interface {
 	Update(*gin.Context) (model.List, error)
 	Destroy(*gin.Context) error
 	Move(*gin.Context) error
+	Archive(*gin.Context) error
 }
 
 func NewListService() ListService {
-	return &listService{rep: repository.NewListRepository()}
+	return &listService{rep: repository.NewListRepository(), boardEventService: NewBoardEventService()}
 }
 
+// boardはboardMiddlewareで認可済み labelクエリがある場合はそのラベルが付いたカードのみを返す
 func (s *listService) Index(ctx *gin.Context) ([]model.List, error) {
-	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
-	err := s.rep.FindListsWithCards(&currentUser)
-	return currentUser.Lists, err
+	var query dto.ListQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		return nil, err
+	}
+
+	board := ctx.MustGet(config.BoardKey).(model.Board)
+	if len(query.LabelIDs) > 0 {
+		err := s.rep.FindListsWithCardsByLabels(&board, query.LabelIDs)
+		return board.Lists, err
+	}
+
+	err := s.rep.FindListsWithCards(&board)
+	return board.Lists, err
 }
 
 func (s *listService) Create(ctx *gin.Context) (model.List, error) {
@@ -41,12 +54,13 @@ func (s *listService) Create(ctx *gin.Context) (model.List, error) {
 
 	var list model.List
 	dtoList.Transfer(&list)
-	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
-	err = s.rep.Create(&currentUser, &list)
+	board := ctx.MustGet(config.BoardKey).(model.Board)
+	err = s.rep.Create(&board, &list)
 	if err != nil {
 		return model.List{}, err
 	}
 
+	s.boardEventService.PublishList(ListCreatedEvent, list)
 	return list, nil
 }
 
@@ -66,12 +80,33 @@ func (s *listService) Update(ctx *gin.Context) (model.List, error) {
 		return list, err
 	}
 
+	s.boardEventService.PublishList(ListUpdatedEvent, list)
 	return list, nil
 }
 
 func (s *listService) Destroy(ctx *gin.Context) error {
 	list := ctx.MustGet(config.ListKey).(model.List)
-	return s.rep.Destroy(&list)
+	if err := s.rep.Destroy(&list); err != nil {
+		return err
+	}
+
+	s.boardEventService.PublishList(ListDeletedEvent, list)
+	return nil
+}
+
+// アーカイブ済みの場合は何もしない
+func (s *listService) Archive(ctx *gin.Context) error {
+	list := ctx.MustGet(config.ListKey).(model.List)
+	if list.ArchivedAt != nil {
+		return nil
+	}
+
+	if err := s.rep.Archive(&list); err != nil {
+		return err
+	}
+
+	s.boardEventService.PublishList(ListArchivedEvent, list)
+	return nil
 }
 
 func (s *listService) Move(ctx *gin.Context) error {
@@ -82,11 +117,16 @@ func (s *listService) Move(ctx *gin.Context) error {
 	}
 
 	list := ctx.MustGet(config.ListKey).(model.List)
-	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
-	return s.rep.Move(&list, moveList.Index, &currentUser)
+	if err := s.rep.Move(&list, moveList.Index); err != nil {
+		return err
+	}
+
+	list.Index = moveList.Index
+	s.boardEventService.PublishList(ListMovedEvent, list)
+	return nil
 }
 
 // test
-func TestNewListService(listRepository repository.ListRepository) ListService {
-	return &listService{rep: listRepository}
+func TestNewListService(listRepository repository.ListRepository, boardEventService BoardEventService) ListService {
+	return &listService{rep: listRepository, boardEventService: boardEventService}
 }
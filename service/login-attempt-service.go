@@ -0,0 +1,155 @@
+package service
+
+// mockgen -source=service/login-attempt-service.go -destination=mock_service/login-attempt-service.go
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	// 失敗回数がこの回数に達するとロックする
+	MaxUserLoginFailures = 5
+	MaxIPLoginFailures   = 20
+	// ロック時間は最初のロックから失敗するたびに2倍にする
+	MinuteBaseLoginLockout = 1
+	HourMaxLoginLockout    = 24
+	// 最後の失敗からこの時間が経過すると失敗回数をリセットする
+	HourResetLoginFailures = 24
+)
+
+type LoginAttemptService interface {
+	Index() ([]model.LoginAttempt, error)
+	CheckIP(ip string) error
+	CheckUser(user model.User) error
+	Fail(ip string, user *model.User) error
+	Succeed(user model.User) error
+}
+
+type loginAttemptService struct {
+	repository   repository.LoginAttemptRepository
+	emailService EmailService
+}
+
+func NewLoginAttemptService() LoginAttemptService {
+	return &loginAttemptService{
+		repository:   repository.NewLoginAttemptRepository(),
+		emailService: NewEmailService(),
+	}
+}
+
+func (s *loginAttemptService) Index() ([]model.LoginAttempt, error) {
+	return s.repository.FindAll()
+}
+
+func (s *loginAttemptService) CheckIP(ip string) error {
+	locked, err := s.isLocked(ipLoginAttemptKey(ip))
+	if err != nil {
+		return err
+	}
+	if locked {
+		return config.TooManyLoginAttemptsError
+	}
+	return nil
+}
+
+func (s *loginAttemptService) CheckUser(user model.User) error {
+	locked, err := s.isLocked(userLoginAttemptKey(user))
+	if err != nil {
+		return err
+	}
+	if locked {
+		return config.AccountLockedError
+	}
+	return nil
+}
+
+// 存在しないメールアドレスでログインした場合はuserにnilを渡してIPアドレスのみ記録する
+// アカウントが新たにロックされた場合は持ち主にメールで通知する
+func (s *loginAttemptService) Fail(ip string, user *model.User) error {
+	if _, err := s.fail(ipLoginAttemptKey(ip), MaxIPLoginFailures); err != nil {
+		return err
+	}
+
+	if user == nil {
+		return nil
+	}
+
+	attempt, err := s.fail(userLoginAttemptKey(*user), MaxUserLoginFailures)
+	if err != nil {
+		return err
+	}
+
+	// 失敗回数は1つずつ増えるので、ロックされた後の失敗では通知しない
+	if attempt.Failures == MaxUserLoginFailures {
+		// 送信失敗のログはemailServiceが出力する
+		s.emailService.AccountLockedEmail(*user, *attempt.LockedUntil)
+	}
+	return nil
+}
+
+// IPアドレスの失敗回数は自分のアカウントへのログインでリセットできないようにそのままにする
+func (s *loginAttemptService) Succeed(user model.User) error {
+	return s.repository.DestroyByKey(userLoginAttemptKey(user))
+}
+
+func (s *loginAttemptService) isLocked(key string) (bool, error) {
+	attempt, err := s.repository.FindByKey(key)
+	if err == gorm.ErrRecordNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return attempt.IsLocked(), nil
+}
+
+func (s *loginAttemptService) fail(key string, maxFailures int) (model.LoginAttempt, error) {
+	now := time.Now()
+	attempt, err := s.repository.Increment(key, now, now.Add(-HourResetLoginFailures*time.Hour))
+	if err != nil {
+		return model.LoginAttempt{}, err
+	}
+
+	if attempt.Failures >= maxFailures {
+		if err := s.repository.Lock(&attempt, now.Add(lockoutDuration(attempt.Failures-maxFailures))); err != nil {
+			return model.LoginAttempt{}, err
+		}
+	}
+	return attempt, nil
+}
+
+// 1分, 2分, 4分...と増やし最大24時間にする
+func lockoutDuration(exponent int) time.Duration {
+	max := HourMaxLoginLockout * time.Hour
+	duration := MinuteBaseLoginLockout * time.Minute
+	for i := 0; i < exponent; i++ {
+		duration *= 2
+		if duration >= max {
+			return max
+		}
+	}
+	return duration
+}
+
+func userLoginAttemptKey(user model.User) string {
+	return fmt.Sprintf("user:%v", user.ID)
+}
+
+func ipLoginAttemptKey(ip string) string {
+	return fmt.Sprintf("ip:%v", ip)
+}
+
+// test
+func TestNewLoginAttemptService(repository repository.LoginAttemptRepository, emailService EmailService) LoginAttemptService {
+	return &loginAttemptService{
+		repository:   repository,
+		emailService: emailService,
+	}
+}
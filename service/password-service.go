@@ -0,0 +1,112 @@
+package service
+
+// mockgen -source=service/password-service.go -destination=mock_service/password-service.go
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/dto"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	MinuteFromNowPasswordResetToken = 30
+	passwordResetTokenByteLength    = 32
+)
+
+type PasswordService interface {
+	Forgot(*gin.Context) error
+	Reset(*gin.Context) error
+}
+
+type passwordService struct {
+	repository     repository.PasswordResetRepository
+	userRepository repository.UserRepository
+	emailService   EmailService
+	auditService   AuditService
+}
+
+func NewPasswordService() PasswordService {
+	return &passwordService{
+		repository:     repository.NewPasswordResetRepository(),
+		userRepository: repository.NewUserRepository(),
+		emailService:   NewEmailService(),
+		auditService:   NewAuditService(),
+	}
+}
+
+// メールアドレスが登録されているかどうかをレスポンスから判別できないようにするため
+// ユーザーが存在しない場合やメールの送信に失敗した場合もエラーを返さない
+func (s *passwordService) Forgot(ctx *gin.Context) error {
+	var dtoForgotPassword dto.ForgotPassword
+	if err := ctx.ShouldBindJSON(&dtoForgotPassword); err != nil {
+		return err
+	}
+
+	user, err := s.userRepository.FindByEmail(dtoForgotPassword.Email)
+	if err == gorm.ErrRecordNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	tokenString := config.MakeRandomToken(passwordResetTokenByteLength)
+	reset := model.PasswordReset{
+		Digest:    digest(tokenString),
+		ExpiresAt: time.Now().Add(MinuteFromNowPasswordResetToken * time.Minute),
+		UserID:    user.ID,
+	}
+	if err := s.repository.Create(&reset); err != nil {
+		return err
+	}
+
+	// 送信失敗のログはemailServiceが出力する
+	if err := s.emailService.PasswordResetEmail(user, tokenString); err != nil {
+		ctx.Error(err)
+	}
+	return nil
+}
+
+func (s *passwordService) Reset(ctx *gin.Context) error {
+	var dtoResetPassword dto.ResetPassword
+	if err := ctx.ShouldBindJSON(&dtoResetPassword); err != nil {
+		return err
+	}
+
+	reset, err := s.repository.FindByDigest(digest(dtoResetPassword.Token))
+	if err == gorm.ErrRecordNotFound {
+		return config.InvalidPasswordResetTokenError
+	}
+	if err != nil {
+		return err
+	}
+
+	// 有効期限切れもしくはユーザーが削除済みの場合
+	if reset.IsExpired() || reset.User.ID == 0 {
+		return config.InvalidPasswordResetTokenError
+	}
+
+	user := reset.User
+	dtoResetPassword.Transfer(&user)
+	if err := s.userRepository.UpdatePassword(&user); err != nil {
+		return err
+	}
+
+	s.auditService.Record(ctx, model.AuditEventPasswordReset, &user, "")
+	return nil
+}
+
+// test
+func TestNewPasswordService(passwordResetRepository repository.PasswordResetRepository, userRepository repository.UserRepository, emailService EmailService, auditService AuditService) PasswordService {
+	return &passwordService{
+		repository:     passwordResetRepository,
+		userRepository: userRepository,
+		emailService:   emailService,
+		auditService:   auditService,
+	}
+}
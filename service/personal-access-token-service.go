@@ -0,0 +1,133 @@
+package service
+
+// mockgen -source=service/personal-access-token-service.go -destination=mock_service/personal-access-token-service.go
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/dto"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"gorm.io/gorm"
+)
+
+const personalAccessTokenByteLength = 32
+
+type PersonalAccessTokenService interface {
+	Index(*gin.Context) ([]model.PersonalAccessToken, error)
+	Create(*gin.Context) (model.PersonalAccessToken, string, error)
+	Destroy(*gin.Context) error
+	Authenticate(tokenString string) (model.PersonalAccessToken, error)
+}
+
+type personalAccessTokenService struct {
+	repository   repository.PersonalAccessTokenRepository
+	auditService AuditService
+}
+
+func NewPersonalAccessTokenService() PersonalAccessTokenService {
+	return &personalAccessTokenService{
+		repository:   repository.NewPersonalAccessTokenRepository(),
+		auditService: NewAuditService(),
+	}
+}
+
+func (s *personalAccessTokenService) Index(ctx *gin.Context) ([]model.PersonalAccessToken, error) {
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	return s.repository.FindAll(&currentUser)
+}
+
+// トークンは作成時にのみ返す
+func (s *personalAccessTokenService) Create(ctx *gin.Context) (model.PersonalAccessToken, string, error) {
+	var dtoToken dto.PersonalAccessToken
+	if err := ctx.ShouldBindJSON(&dtoToken); err != nil {
+		return model.PersonalAccessToken{}, "", err
+	}
+
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	tokenString := model.PersonalAccessTokenPrefix + config.MakeRandomToken(personalAccessTokenByteLength)
+	token := model.PersonalAccessToken{
+		Name:   dtoToken.Name,
+		Digest: digest(tokenString),
+		Scopes: strings.Join(uniqueScopes(dtoToken.Scopes), " "),
+		UserID: currentUser.ID,
+	}
+	if dtoToken.ExpiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, dtoToken.ExpiresInDays)
+		token.ExpiresAt = &expiresAt
+	}
+
+	if err := s.repository.Create(&token); err != nil {
+		return model.PersonalAccessToken{}, "", err
+	}
+
+	s.auditService.Record(ctx, model.AuditEventPersonalAccessTokenCreated, &currentUser, strconv.Itoa(token.ID))
+	return token, tokenString, nil
+}
+
+func (s *personalAccessTokenService) Destroy(ctx *gin.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	if err := s.repository.Destroy(&currentUser, id); err != nil {
+		return err
+	}
+
+	s.auditService.Record(ctx, model.AuditEventPersonalAccessTokenRevoked, &currentUser, strconv.Itoa(id))
+	return nil
+}
+
+// 存在しないトークンと有効期限切れのトークンは区別せずにInvalidPersonalAccessTokenErrorを返す
+func (s *personalAccessTokenService) Authenticate(tokenString string) (model.PersonalAccessToken, error) {
+	if !strings.HasPrefix(tokenString, model.PersonalAccessTokenPrefix) {
+		return model.PersonalAccessToken{}, config.InvalidPersonalAccessTokenError
+	}
+
+	token, err := s.repository.FindByDigest(digest(tokenString))
+	if err == gorm.ErrRecordNotFound {
+		return model.PersonalAccessToken{}, config.InvalidPersonalAccessTokenError
+	}
+	if err != nil {
+		return model.PersonalAccessToken{}, err
+	}
+
+	if token.IsExpired() {
+		return model.PersonalAccessToken{}, config.InvalidPersonalAccessTokenError
+	}
+
+	now := time.Now()
+	if shouldTouch(token.LastUsedAt, now) {
+		if err := s.repository.UpdateLastUsedAt(&token, now); err != nil {
+			return model.PersonalAccessToken{}, err
+		}
+	}
+
+	return token, nil
+}
+
+func uniqueScopes(scopes []string) []string {
+	seen := map[string]bool{}
+	unique := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !seen[scope] {
+			seen[scope] = true
+			unique = append(unique, scope)
+		}
+	}
+	return unique
+}
+
+// test
+func TestNewPersonalAccessTokenService(repository repository.PersonalAccessTokenRepository, auditService AuditService) PersonalAccessTokenService {
+	return &personalAccessTokenService{
+		repository:   repository,
+		auditService: auditService,
+	}
+}
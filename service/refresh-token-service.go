@@ -0,0 +1,119 @@
+package service
+
+// mockgen -source=service/refresh-token-service.go -destination=mock_service/refresh-token-service.go
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	DayFromNowRefreshToken = 30
+	refreshTokenByteLength = 32
+)
+
+type RefreshTokenService interface {
+	Create(user model.User, family string) (string, error)
+	Rotate(tokenString string) (model.RefreshToken, string, error)
+	Revoke(family string) error
+}
+
+type refreshTokenService struct {
+	repository        repository.RefreshTokenRepository
+	sessionRepository repository.SessionRepository
+}
+
+func NewRefreshTokenService() RefreshTokenService {
+	return &refreshTokenService{
+		repository:        repository.NewRefreshTokenRepository(),
+		sessionRepository: repository.NewSessionRepository(),
+	}
+}
+
+// familyはログインごとに作成してアクセストークンのsidと一致させる
+func (s *refreshTokenService) Create(user model.User, family string) (string, error) {
+	tokenString := config.MakeRandomToken(refreshTokenByteLength)
+	token := model.RefreshToken{
+		Digest:         digest(tokenString),
+		Family:         family,
+		SessionTracked: true,
+		ExpiresAt:      time.Now().AddDate(0, 0, DayFromNowRefreshToken),
+		UserID:         user.ID,
+	}
+	if err := s.repository.Create(&token); err != nil {
+		return "", err
+	}
+
+	return tokenString, nil
+}
+
+// リフレッシュトークンを使用済みにして同じfamilyの新しいリフレッシュトークンを発行する
+// 使用済みのトークンが再利用された場合は盗まれたとみなしてfamily全体を無効にする
+func (s *refreshTokenService) Rotate(tokenString string) (model.RefreshToken, string, error) {
+	token, err := s.repository.FindByDigest(digest(tokenString))
+	if err == gorm.ErrRecordNotFound {
+		return model.RefreshToken{}, "", config.InvalidRefreshTokenError
+	}
+	if err != nil {
+		return model.RefreshToken{}, "", err
+	}
+
+	if token.Used {
+		return model.RefreshToken{}, "", s.revokeReusedFamily(token.Family)
+	}
+
+	// 有効期限切れもしくはユーザーが削除済みの場合
+	if token.IsExpired() || token.User.ID == 0 {
+		return model.RefreshToken{}, "", config.InvalidRefreshTokenError
+	}
+
+	err = s.repository.Use(&token)
+	if err == config.ReusedRefreshTokenError {
+		return model.RefreshToken{}, "", s.revokeReusedFamily(token.Family)
+	}
+	if err != nil {
+		return model.RefreshToken{}, "", err
+	}
+
+	newTokenString, err := s.Create(token.User, token.Family)
+	if err != nil {
+		return model.RefreshToken{}, "", err
+	}
+
+	return token, newTokenString, nil
+}
+
+// ログアウト時にfamilyのリフレッシュトークンを全て削除する
+func (s *refreshTokenService) Revoke(family string) error {
+	return s.repository.DestroyFamily(family)
+}
+
+// 発行済みのアクセストークンも使えなくなるようにセッションも削除する
+func (s *refreshTokenService) revokeReusedFamily(family string) error {
+	if err := s.sessionRepository.DestroyBySessionID(family); err != nil {
+		return err
+	}
+
+	if err := s.Revoke(family); err != nil {
+		return err
+	}
+
+	return config.ReusedRefreshTokenError
+}
+
+// トークンはハッシュ値でDBに保存する
+func digest(tokenString string) string {
+	sum := sha256.Sum256([]byte(tokenString))
+	return hex.EncodeToString(sum[:])
+}
+
+// test
+func TestNewRefreshTokenService(repository repository.RefreshTokenRepository, sessionRepository repository.SessionRepository) RefreshTokenService {
+	return &refreshTokenService{repository: repository, sessionRepository: sessionRepository}
+}
@@ -0,0 +1,161 @@
+package service
+
+// mockgen -source=service/session-service.go -destination=mock_service/session-service.go
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	sessionIDByteLength = 16
+	userAgentMaxLength  = 255
+	// 最終アクセス日時や最終使用日時の更新は1分に1回までにしてリクエストごとの書き込みを減らす
+	MinuteTouchInterval = 1
+)
+
+type SessionService interface {
+	Create(ctx *gin.Context, user model.User) (model.Session, error)
+	Index(*gin.Context) ([]model.Session, error)
+	Destroy(*gin.Context) error
+	Revoke(sessionID string) error
+	Touch(sessionID string, jti string, user model.User) error
+	Resume(ctx *gin.Context, usedToken model.RefreshToken) error
+}
+
+type sessionService struct {
+	repository          repository.SessionRepository
+	refreshTokenService RefreshTokenService
+	auditService        AuditService
+}
+
+func NewSessionService() SessionService {
+	return &sessionService{
+		repository:          repository.NewSessionRepository(),
+		refreshTokenService: NewRefreshTokenService(),
+		auditService:        NewAuditService(),
+	}
+}
+
+// ログインごとにリクエストした端末の情報を保存する
+func (s *sessionService) Create(ctx *gin.Context, user model.User) (model.Session, error) {
+	session := newSession(ctx, user, config.MakeRandomToken(sessionIDByteLength))
+	if err := s.repository.Create(&session); err != nil {
+		return model.Session{}, err
+	}
+
+	return session, nil
+}
+
+func (s *sessionService) Index(ctx *gin.Context) ([]model.Session, error) {
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	return s.repository.FindAll(&currentUser)
+}
+
+// 指定した端末をログアウトさせる
+func (s *sessionService) Destroy(ctx *gin.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	session, err := s.repository.Find(&currentUser, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Revoke(session.SessionID); err != nil {
+		return err
+	}
+
+	s.auditService.Record(ctx, model.AuditEventSessionRevoked, &currentUser, strconv.Itoa(session.ID))
+	return nil
+}
+
+// セッションとそのセッションのリフレッシュトークンを削除する
+func (s *sessionService) Revoke(sessionID string) error {
+	if err := s.repository.DestroyBySessionID(sessionID); err != nil {
+		return err
+	}
+
+	return s.refreshTokenService.Revoke(sessionID)
+}
+
+// アクセストークンがログアウト済みでなくセッションも削除されていないことを確認して最終アクセス日時を更新する
+func (s *sessionService) Touch(sessionID string, jti string, user model.User) error {
+	session, err := s.repository.FindActive(sessionID, user.ID, jti)
+	if err == gorm.ErrRecordNotFound {
+		return config.SessionRevokedError
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.updateLastSeenAt(&session)
+}
+
+// トークンの再発行時に最終アクセス日時を更新する
+// セッションの記録を始める前に発行されたリフレッシュトークンの場合はセッションを作成する
+// それ以外でセッションが無い場合は再発行中に端末がログアウトされたので新しいリフレッシュトークンも削除する
+func (s *sessionService) Resume(ctx *gin.Context, usedToken model.RefreshToken) error {
+	session, err := s.repository.FindBySessionID(usedToken.Family)
+	if err == gorm.ErrRecordNotFound && usedToken.SessionTracked {
+		if err := s.refreshTokenService.Revoke(usedToken.Family); err != nil {
+			return err
+		}
+		return config.SessionRevokedError
+	}
+	if err == gorm.ErrRecordNotFound {
+		session = newSession(ctx, usedToken.User, usedToken.Family)
+		return s.repository.Create(&session)
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.updateLastSeenAt(&session)
+}
+
+func (s *sessionService) updateLastSeenAt(session *model.Session) error {
+	now := time.Now()
+	if !shouldTouch(&session.LastSeenAt, now) {
+		return nil
+	}
+
+	return s.repository.UpdateLastSeenAt(session, now)
+}
+
+func shouldTouch(last *time.Time, now time.Time) bool {
+	return last == nil || now.Sub(*last) >= MinuteTouchInterval*time.Minute
+}
+
+func newSession(ctx *gin.Context, user model.User, sessionID string) model.Session {
+	userAgent := ctx.Request.UserAgent()
+	if len(userAgent) > userAgentMaxLength {
+		userAgent = userAgent[:userAgentMaxLength]
+	}
+
+	return model.Session{
+		SessionID:  sessionID,
+		UserAgent:  userAgent,
+		IP:         ctx.ClientIP(),
+		LastSeenAt: time.Now(),
+		UserID:     user.ID,
+	}
+}
+
+// test
+func TestNewSessionService(repository repository.SessionRepository, refreshTokenService RefreshTokenService, auditService AuditService) SessionService {
+	return &sessionService{
+		repository:          repository,
+		refreshTokenService: refreshTokenService,
+		auditService:        auditService,
+	}
+}
@@ -0,0 +1,260 @@
+package service
+
+// mockgen -source=service/totp-service.go -destination=mock_service/totp-service.go
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/dto"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"gorm.io/gorm"
+)
+
+const (
+	TotpIssuer             = "Todo"
+	TotpPeriod             = 30
+	RecoveryCodeCount      = 10
+	recoveryCodeByteLength = 5
+)
+
+type TotpService interface {
+	Enroll(*gin.Context) (string, string, error)
+	Enable(*gin.Context) ([]string, error)
+	Disable(*gin.Context) error
+	Verify(user model.User, code string) (bool, error)
+}
+
+type totpService struct {
+	repository          repository.TotpRepository
+	auditService        AuditService
+	loginAttemptService LoginAttemptService
+}
+
+func NewTotpService() TotpService {
+	return &totpService{
+		repository:          repository.NewTotpRepository(),
+		auditService:        NewAuditService(),
+		loginAttemptService: NewLoginAttemptService(),
+	}
+}
+
+// シークレットを作成して認証アプリに登録するためのURIを返す
+// 最初のコードを検証するまでは有効にしない
+func (s *totpService) Enroll(ctx *gin.Context) (string, string, error) {
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	if currentUser.TotpEnabled {
+		return "", "", config.TotpAlreadyEnabledError
+	}
+
+	// googleでログインしたユーザーはメールアドレスを持たない
+	accountName := currentUser.Email
+	if accountName == "" {
+		accountName = fmt.Sprintf("user%v", currentUser.ID)
+	}
+	key, err := totp.Generate(totp.GenerateOpts{Issuer: TotpIssuer, AccountName: accountName})
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := s.repository.SaveSecret(&currentUser, key.Secret()); err != nil {
+		return "", "", err
+	}
+
+	return key.Secret(), key.URL(), nil
+}
+
+// 最初のコードを検証して有効にする リカバリーコードはこの時だけ平文で返す
+func (s *totpService) Enable(ctx *gin.Context) ([]string, error) {
+	var dtoTotpCode dto.TotpCode
+	if err := ctx.ShouldBindJSON(&dtoTotpCode); err != nil {
+		return nil, err
+	}
+
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	if currentUser.TotpEnabled {
+		return nil, config.TotpAlreadyEnabledError
+	}
+	if currentUser.TotpSecret == "" {
+		return nil, config.TotpNotEnrolledError
+	}
+
+	if err := s.checkAttempts(ctx, currentUser); err != nil {
+		return nil, err
+	}
+
+	ok, err := s.useTotpCode(&currentUser, dtoTotpCode.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if err := s.fail(ctx, &currentUser, "enable"); err != nil {
+			return nil, err
+		}
+		return nil, config.InvalidTotpCodeError
+	}
+
+	if err := s.loginAttemptService.Succeed(currentUser); err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, RecoveryCodeCount)
+	digests := make([]string, 0, RecoveryCodeCount)
+	for i := 0; i < RecoveryCodeCount; i++ {
+		code, err := makeRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		digests = append(digests, digest(code))
+	}
+
+	if err := s.repository.Enable(&currentUser, digests); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(ctx, model.AuditEventTotpEnabled, &currentUser, "")
+	return codes, nil
+}
+
+// 無効にする場合も現在のパスワードと認証アプリのコードかリカバリーコードを要求する
+func (s *totpService) Disable(ctx *gin.Context) error {
+	var dtoTotpDisable dto.TotpDisable
+	if err := ctx.ShouldBindJSON(&dtoTotpDisable); err != nil {
+		return err
+	}
+
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	if !currentUser.TotpEnabled {
+		return config.TotpNotEnrolledError
+	}
+
+	if err := s.checkAttempts(ctx, currentUser); err != nil {
+		return err
+	}
+
+	if !currentUser.Authenticate(dtoTotpDisable.Password) {
+		if err := s.fail(ctx, &currentUser, "password"); err != nil {
+			return err
+		}
+		return config.PasswordAuthenticationError
+	}
+
+	ok, err := s.Verify(currentUser, dtoTotpDisable.Code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if err := s.fail(ctx, &currentUser, "disable"); err != nil {
+			return err
+		}
+		return config.InvalidTotpCodeError
+	}
+
+	if err := s.loginAttemptService.Succeed(currentUser); err != nil {
+		return err
+	}
+
+	if err := s.repository.Disable(&currentUser); err != nil {
+		return err
+	}
+
+	s.auditService.Record(ctx, model.AuditEventTotpDisabled, &currentUser, "")
+	return nil
+}
+
+// 認証アプリのコードが一致しない場合はリカバリーコードとして照合し、一致すれば使用済みにする
+func (s *totpService) Verify(user model.User, code string) (bool, error) {
+	step, ok := matchTotpStep(code, user.TotpSecret, time.Now())
+	if ok {
+		return s.useTimeStep(&user, step)
+	}
+
+	err := s.repository.UseRecoveryCode(&user, digest(normalizeRecoveryCode(code)))
+	if err == gorm.ErrRecordNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// 盗まれたアクセストークンでコードを総当たりされないようにログインと同じ失敗回数でロックする
+func (s *totpService) checkAttempts(ctx *gin.Context, user model.User) error {
+	if err := s.loginAttemptService.CheckIP(ctx.ClientIP()); err != nil {
+		return err
+	}
+	return s.loginAttemptService.CheckUser(user)
+}
+
+func (s *totpService) fail(ctx *gin.Context, user *model.User, detail string) error {
+	s.auditService.Record(ctx, model.AuditEventTotpFailed, user, detail)
+	return s.loginAttemptService.Fail(ctx.ClientIP(), user)
+}
+
+func (s *totpService) useTotpCode(user *model.User, code string) (bool, error) {
+	step, ok := matchTotpStep(code, user.TotpSecret, time.Now())
+	if !ok {
+		return false, nil
+	}
+	return s.useTimeStep(user, step)
+}
+
+// 既に受け付けた時間ステップのコードは盗み見られたコードの再利用の可能性があるので受け付けない
+func (s *totpService) useTimeStep(user *model.User, step int64) (bool, error) {
+	err := s.repository.UseTimeStep(user, step)
+	if err == gorm.ErrRecordNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// 認証アプリとの時刻のずれを考慮して前後1ステップまで照合し、一致した時間ステップを返す
+func matchTotpStep(code, secret string, now time.Time) (int64, bool) {
+	for _, skew := range []int64{0, -1, 1} {
+		t := now.Add(time.Duration(skew*TotpPeriod) * time.Second)
+		ok, _ := totp.ValidateCustom(code, secret, t.UTC(), totp.ValidateOpts{
+			Period:    TotpPeriod,
+			Digits:    otp.DigitsSix,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if ok {
+			return t.Unix() / TotpPeriod, true
+		}
+	}
+	return 0, false
+}
+
+func makeRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeByteLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// 手入力されるので大文字やハイフン区切りでも受け付ける
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
+
+// test
+func TestNewTotpService(repository repository.TotpRepository, auditService AuditService, loginAttemptService LoginAttemptService) TotpService {
+	return &totpService{
+		repository:          repository,
+		auditService:        auditService,
+		loginAttemptService: loginAttemptService,
+	}
+}
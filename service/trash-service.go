@@ -0,0 +1,190 @@
+package service
+
+// mockgen -source=service/trash-service.go -destination=mock_service/trash-service.go
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"gorm.io/gorm"
+)
+
+// ゴミ箱にはアーカイブ中のものと論理削除したものが入る 操作にはボードのeditorのロールが必要
+type TrashService interface {
+	Index(*gin.Context) ([]model.List, []model.Card, error)
+	RestoreList(*gin.Context) (model.List, error)
+	RestoreCard(*gin.Context) (model.Card, error)
+	DestroyList(*gin.Context) error
+	DestroyCard(*gin.Context) error
+}
+
+type trashService struct {
+	listRepository      repository.ListRepository
+	cardRepository      repository.CardRepository
+	cardReminderService CardReminderService
+	attachmentService   AttachmentService
+	boardMemberService  BoardMemberService
+	boardEventService   BoardEventService
+}
+
+func NewTrashService() TrashService {
+	return &trashService{
+		listRepository:      repository.NewListRepository(),
+		cardRepository:      repository.NewCardRepository(),
+		cardReminderService: NewCardReminderService(),
+		attachmentService:   NewAttachmentService(),
+		boardMemberService:  NewBoardMemberService(),
+		boardEventService:   NewBoardEventService(),
+	}
+}
+
+func (s *trashService) Index(ctx *gin.Context) ([]model.List, []model.Card, error) {
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	lists, err := s.listRepository.FindTrashed(&currentUser)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cards, err := s.cardRepository.FindTrashed(&currentUser)
+	return lists, cards, err
+}
+
+func (s *trashService) RestoreList(ctx *gin.Context) (model.List, error) {
+	list, err := s.findList(ctx)
+	if err != nil {
+		return model.List{}, err
+	}
+
+	if err := s.listRepository.Restore(&list); err != nil {
+		return model.List{}, err
+	}
+
+	s.boardEventService.PublishList(ListRestoredEvent, list)
+	cards, err := s.cardRepository.FindWithDueAtByList(&list)
+	if err != nil {
+		return list, err
+	}
+
+	// リストの削除やアーカイブで削除したリマインダーを作り直す
+	for _, card := range cards {
+		if err := s.cardReminderService.Schedule(card); err != nil {
+			return list, err
+		}
+	}
+	return list, nil
+}
+
+// リストがゴミ箱にある場合は先にリストを元に戻す必要がある
+func (s *trashService) RestoreCard(ctx *gin.Context) (model.Card, error) {
+	card, err := s.findCard(ctx)
+	if err != nil {
+		return model.Card{}, err
+	}
+
+	if card.List.DeletedAt.Valid {
+		return model.Card{}, config.ListInTrashError
+	}
+
+	if err := s.cardRepository.Restore(&card); err != nil {
+		return model.Card{}, err
+	}
+
+	s.boardEventService.PublishCard(CardRestoredEvent, card)
+	if card.DueAt != nil {
+		err = s.cardReminderService.Schedule(card)
+	}
+	return card, err
+}
+
+// 添付ファイルを削除してからリストとカードを物理削除する
+func (s *trashService) DestroyList(ctx *gin.Context) error {
+	list, err := s.findList(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.attachmentService.DestroyByList(list); err != nil {
+		return err
+	}
+
+	if err := s.listRepository.DestroyPermanently(&list); err != nil {
+		return err
+	}
+
+	s.boardEventService.PublishList(ListDestroyedEvent, list)
+	return nil
+}
+
+func (s *trashService) DestroyCard(ctx *gin.Context) error {
+	card, err := s.findCard(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.attachmentService.DestroyByCard(card); err != nil {
+		return err
+	}
+
+	if err := s.cardRepository.DestroyPermanently(&card); err != nil {
+		return err
+	}
+
+	s.boardEventService.PublishCard(CardDestroyedEvent, card)
+	return nil
+}
+
+// ゴミ箱に無いリストはErrRecordNotFoundにする
+func (s *trashService) findList(ctx *gin.Context) (model.List, error) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return model.List{}, gorm.ErrRecordNotFound
+	}
+
+	list, err := s.listRepository.FindWithTrashed(id)
+	if err != nil {
+		return model.List{}, err
+	}
+
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	if err := s.boardMemberService.Authorize(list.BoardID, currentUser, model.BoardRoleEditor); err != nil {
+		return model.List{}, err
+	}
+
+	if list.ArchivedAt == nil && !list.DeletedAt.Valid {
+		return model.List{}, gorm.ErrRecordNotFound
+	}
+
+	return list, nil
+}
+
+// ゴミ箱に無いカードはErrRecordNotFoundにする
+func (s *trashService) findCard(ctx *gin.Context) (model.Card, error) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return model.Card{}, gorm.ErrRecordNotFound
+	}
+
+	card, err := s.cardRepository.FindWithTrashed(id)
+	if err != nil {
+		return model.Card{}, err
+	}
+
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	if err := s.boardMemberService.Authorize(card.List.BoardID, currentUser, model.BoardRoleEditor); err != nil {
+		return model.Card{}, err
+	}
+
+	if card.ArchivedAt == nil && !card.DeletedAt.Valid {
+		return model.Card{}, gorm.ErrRecordNotFound
+	}
+
+	return card, nil
+}
+
+// test
+func TestNewTrashService(listRepository repository.ListRepository, cardRepository repository.CardRepository, cardReminderService CardReminderService, attachmentService AttachmentService, boardMemberService BoardMemberService, boardEventService BoardEventService) TrashService {
+	return &trashService{listRepository: listRepository, cardRepository: cardRepository, cardReminderService: cardReminderService, attachmentService: attachmentService, boardMemberService: boardMemberService, boardEventService: boardEventService}
+}
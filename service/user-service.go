@@ -3,31 +3,56 @@ package service
 // mockgen -source=service/user-service.go -destination=./mock_service/user-service.go
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/kuritaeiji/todo-gin-back/config"
 	"github.com/kuritaeiji/todo-gin-back/dto"
 	"github.com/kuritaeiji/todo-gin-back/model"
 	"github.com/kuritaeiji/todo-gin-back/repository"
+	"gorm.io/gorm"
 )
 
 type UserService interface {
 	Create(*gin.Context) (model.User, error)
 	IsUnique(*gin.Context) (bool, error)
 	Activate(*gin.Context) error
+	ResendActivation(*gin.Context) error
 	Destroy(*gin.Context) error
+	ChangePassword(*gin.Context) (TokenPair, error)
+	ChangeEmail(*gin.Context) error
+	ConfirmEmail(*gin.Context) error
 }
 
 type userService struct {
-	jwtService JWTService
-	repository repository.UserRepository
-	dto        dto.User
+	jwtService            JWTService
+	refreshTokenService   RefreshTokenService
+	sessionService        SessionService
+	auditService          AuditService
+	emailService          EmailService
+	attachmentService     AttachmentService
+	repository            repository.UserRepository
+	emailChangeRepository repository.EmailChangeRepository
+	dto                   dto.User
 }
 
+const (
+	DayFromNowEmailChangeToken     = 1
+	emailChangeTokenByteLength     = 32
+	MinuteResendActivationInterval = 5
+)
+
 func NewUserService() UserService {
 	return &userService{
-		jwtService: NewJWTService(),
-		repository: repository.NewUserRepository(),
-		dto:        dto.User{},
+		jwtService:            NewJWTService(),
+		refreshTokenService:   NewRefreshTokenService(),
+		sessionService:        NewSessionService(),
+		auditService:          NewAuditService(),
+		emailService:          NewEmailService(),
+		attachmentService:     NewAttachmentService(),
+		repository:            repository.NewUserRepository(),
+		emailChangeRepository: repository.NewEmailChangeRepository(),
+		dto:                   dto.User{},
 	}
 }
 
@@ -37,6 +62,9 @@ func (s *userService) Create(ctx *gin.Context) (model.User, error) {
 	}
 	var user model.User
 	s.dto.Transfer(&user)
+	// 作成後にコントローラーが有効化メールを送信する
+	now := time.Now()
+	user.ActivationSentAt = &now
 	if err := s.repository.Create(&user); err != nil {
 		return model.User{}, err
 	}
@@ -64,19 +92,162 @@ func (s *userService) Activate(ctx *gin.Context) error {
 		return config.AlreadyActivatedUserError
 	}
 
-	return s.repository.Activate(&user)
+	if err := s.repository.Activate(&user); err != nil {
+		return err
+	}
+
+	s.auditService.Record(ctx, model.AuditEventActivated, &user, "")
+	return nil
+}
+
+// メールアドレスが登録されているかどうかをレスポンスから判別できないようにするため
+// ユーザーが存在しない場合や有効化済みの場合、再送が制限されている場合やメールの送信に失敗した場合もエラーを返さない
+func (s *userService) ResendActivation(ctx *gin.Context) error {
+	var dtoResendActivation dto.ResendActivation
+	if err := ctx.ShouldBindJSON(&dtoResendActivation); err != nil {
+		return err
+	}
+
+	user, err := s.repository.FindByEmail(dtoResendActivation.Email)
+	if err == gorm.ErrRecordNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if user.Activated {
+		return nil
+	}
+
+	now := time.Now()
+	if user.ActivationSentAt != nil && now.Before(user.ActivationSentAt.Add(MinuteResendActivationInterval*time.Minute)) {
+		ctx.Error(config.ActivationEmailThrottledError)
+		return nil
+	}
+
+	if err := s.repository.UpdateActivationSentAt(&user, now); err != nil {
+		return err
+	}
+
+	// 送信失敗のログはemailServiceが出力する
+	if err := s.emailService.ActivationUserEmail(user); err != nil {
+		ctx.Error(err)
+	}
+	return nil
 }
 
 func (c *userService) Destroy(ctx *gin.Context) error {
 	currentUser := ctx.MustGet("currentUser").(model.User)
-	return c.repository.Destroy(&currentUser)
+	if err := c.repository.Destroy(&currentUser); err != nil {
+		return err
+	}
+
+	// 添付ファイルの削除に失敗してもユーザーの削除は成功として扱う
+	if err := c.attachmentService.DestroyByUser(currentUser); err != nil {
+		ctx.Error(err)
+	}
+
+	// 監査ログはユーザーの削除後も保存期間が過ぎるまで残る
+	c.auditService.Record(ctx, model.AuditEventUserDeleted, &currentUser, "")
+	return nil
+}
+
+// 他の端末はログアウトさせ、現在の端末には新しいトークンを返す
+func (s *userService) ChangePassword(ctx *gin.Context) (TokenPair, error) {
+	var dtoChangePassword dto.ChangePassword
+	if err := ctx.ShouldBindJSON(&dtoChangePassword); err != nil {
+		return TokenPair{}, err
+	}
+
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	if !currentUser.Authenticate(dtoChangePassword.CurrentPassword) {
+		return TokenPair{}, config.PasswordAuthenticationError
+	}
+
+	dtoChangePassword.Transfer(&currentUser)
+	if err := s.repository.UpdatePassword(&currentUser); err != nil {
+		return TokenPair{}, err
+	}
+	s.auditService.Record(ctx, model.AuditEventPasswordChanged, &currentUser, "")
+
+	// パスワードの更新で全てのセッションが削除されるので現在の端末のセッションを作り直す
+	session, err := s.sessionService.Create(ctx, currentUser)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	return issueTokenPair(s.jwtService, s.refreshTokenService, currentUser, session.SessionID)
+}
+
+// 新しいメールアドレスに確認メールを送る 確認されるまではメールアドレスを変更しない
+func (s *userService) ChangeEmail(ctx *gin.Context) error {
+	var dtoChangeEmail dto.ChangeEmail
+	if err := ctx.ShouldBindJSON(&dtoChangeEmail); err != nil {
+		return err
+	}
+
+	currentUser := ctx.MustGet(config.CurrentUserKey).(model.User)
+	if !currentUser.Authenticate(dtoChangeEmail.Password) {
+		return config.PasswordAuthenticationError
+	}
+
+	isUnique, err := s.repository.IsUnique(dtoChangeEmail.Email)
+	if err != nil {
+		return err
+	}
+	if !isUnique {
+		return config.UniqueUserError
+	}
+
+	tokenString := config.MakeRandomToken(emailChangeTokenByteLength)
+	change := model.EmailChange{
+		Digest:    digest(tokenString),
+		Email:     dtoChangeEmail.Email,
+		ExpiresAt: time.Now().AddDate(0, 0, DayFromNowEmailChangeToken),
+		UserID:    currentUser.ID,
+	}
+	if err := s.emailChangeRepository.Create(&change); err != nil {
+		return err
+	}
+
+	return s.emailService.EmailChangeEmail(change.Email, tokenString)
+}
+
+// 確認リンクは別の端末で開かれる場合もあるのでログインは必須にしない
+// 確認後は全ての端末からログアウトさせる
+func (s *userService) ConfirmEmail(ctx *gin.Context) error {
+	change, err := s.emailChangeRepository.FindByDigest(digest(ctx.Query("token")))
+	if err == gorm.ErrRecordNotFound {
+		return config.InvalidEmailChangeTokenError
+	}
+	if err != nil {
+		return err
+	}
+
+	if change.IsExpired() || change.User.ID == 0 {
+		return config.InvalidEmailChangeTokenError
+	}
+
+	if err := s.emailChangeRepository.Confirm(&change); err != nil {
+		return err
+	}
+
+	s.auditService.Record(ctx, model.AuditEventEmailChanged, &change.User, change.Email)
+	return nil
 }
 
 // test用
-func TestNewUserService(jwtService JWTService, r repository.UserRepository) UserService {
+func TestNewUserService(jwtService JWTService, refreshTokenService RefreshTokenService, sessionService SessionService, auditService AuditService, emailService EmailService, attachmentService AttachmentService, r repository.UserRepository, emailChangeRepository repository.EmailChangeRepository) UserService {
 	return &userService{
-		jwtService: jwtService,
-		repository: r,
-		dto:        dto.User{},
+		jwtService:            jwtService,
+		refreshTokenService:   refreshTokenService,
+		sessionService:        sessionService,
+		auditService:          auditService,
+		emailService:          emailService,
+		attachmentService:     attachmentService,
+		repository:            r,
+		emailChangeRepository: emailChangeRepository,
+		dto:                   dto.User{},
 	}
 }
@@ -0,0 +1,100 @@
+package controller_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/controller"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type AttachmentControllerTestSuite struct {
+	suite.Suite
+	con                   controller.AttachmentController
+	ctx                   *gin.Context
+	rec                   *httptest.ResponseRecorder
+	attachmentServiceMock *mock_service.MockAttachmentService
+}
+
+func (suite *AttachmentControllerTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *AttachmentControllerTestSuite) SetupTest() {
+	suite.attachmentServiceMock = mock_service.NewMockAttachmentService(gomock.NewController(suite.T()))
+	suite.con = controller.TestNewAttachmentController(suite.attachmentServiceMock)
+	suite.rec = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.rec)
+}
+
+func TestAttachmentControllerSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentControllerTestSuite))
+}
+
+func (suite *AttachmentControllerTestSuite) TestSuccessIndex() {
+	attachments := []model.Attachment{{ID: 1, FileName: "a.png", ContentType: "image/png", Size: 10, StorageKey: "secret"}}
+	suite.attachmentServiceMock.EXPECT().Index(suite.ctx).Return(attachments, nil)
+	suite.con.Index(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), `"fileName":"a.png"`)
+	suite.NotContains(suite.rec.Body.String(), "secret")
+}
+
+func (suite *AttachmentControllerTestSuite) TestBadCreateWithMissingFile() {
+	suite.attachmentServiceMock.EXPECT().Create(suite.ctx).Return(model.Attachment{}, http.ErrMissingFile)
+	suite.con.Create(suite.ctx)
+
+	suite.Equal(config.ValidationErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *AttachmentControllerTestSuite) TestBadCreateWithTooLarge() {
+	suite.attachmentServiceMock.EXPECT().Create(suite.ctx).Return(model.Attachment{}, config.AttachmentTooLargeError)
+	suite.con.Create(suite.ctx)
+
+	suite.Equal(config.AttachmentTooLargeErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *AttachmentControllerTestSuite) TestBadCreateWithQuotaExceeded() {
+	suite.attachmentServiceMock.EXPECT().Create(suite.ctx).Return(model.Attachment{}, config.AttachmentQuotaExceededError)
+	suite.con.Create(suite.ctx)
+
+	suite.Equal(config.AttachmentQuotaExceededErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.AttachmentQuotaExceededError.Error())
+}
+
+func (suite *AttachmentControllerTestSuite) TestSuccessDownload() {
+	attachment := model.Attachment{ID: 1, FileName: "a.html", ContentType: "text/html; charset=utf-8", Size: 7}
+	suite.attachmentServiceMock.EXPECT().Download(suite.ctx).Return(attachment, io.NopCloser(strings.NewReader("content")), nil)
+	suite.con.Download(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Equal("content", suite.rec.Body.String())
+	suite.Equal("text/html; charset=utf-8", suite.rec.Header().Get("Content-Type"))
+	suite.Equal("attachment; filename=a.html", suite.rec.Header().Get("Content-Disposition"))
+	suite.Equal("nosniff", suite.rec.Header().Get("X-Content-Type-Options"))
+}
+
+func (suite *AttachmentControllerTestSuite) TestBadDownloadWithRecordNotFound() {
+	suite.attachmentServiceMock.EXPECT().Download(suite.ctx).Return(model.Attachment{}, nil, gorm.ErrRecordNotFound)
+	suite.con.Download(suite.ctx)
+
+	suite.Equal(config.RecordNotFoundErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *AttachmentControllerTestSuite) TestBadDestroyWithDBError() {
+	suite.attachmentServiceMock.EXPECT().Destroy(suite.ctx).Return(errors.New("db error"))
+	suite.con.Destroy(suite.ctx)
+
+	suite.Equal(500, suite.rec.Code)
+}
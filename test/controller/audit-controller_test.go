@@ -0,0 +1,72 @@
+package controller_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/controller"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditControllerTestSuite struct {
+	suite.Suite
+	con              controller.AuditController
+	ctx              *gin.Context
+	rec              *httptest.ResponseRecorder
+	auditServiceMock *mock_service.MockAuditService
+}
+
+func (suite *AuditControllerTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *AuditControllerTestSuite) SetupTest() {
+	suite.auditServiceMock = mock_service.NewMockAuditService(gomock.NewController(suite.T()))
+	suite.con = controller.TestNewAuditController(suite.auditServiceMock)
+	suite.rec = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.rec)
+}
+
+func TestAuditControllerSuite(t *testing.T) {
+	suite.Run(t, new(AuditControllerTestSuite))
+}
+
+func (suite *AuditControllerTestSuite) TestSuccessIndex() {
+	logs := []model.AuditLog{{ID: 1, Event: model.AuditEventLoginSucceeded, IP: "127.0.0.1"}}
+	suite.auditServiceMock.EXPECT().Index(suite.ctx).Return(logs, nil)
+	suite.con.Index(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), `"event":"login.succeeded"`)
+	suite.Contains(suite.rec.Body.String(), `"ip":"127.0.0.1"`)
+}
+
+func (suite *AuditControllerTestSuite) TestBadIndexWithDBError() {
+	suite.auditServiceMock.EXPECT().Index(suite.ctx).Return(nil, errors.New("db error"))
+	suite.con.Index(suite.ctx)
+
+	suite.Equal(500, suite.rec.Code)
+}
+
+func (suite *AuditControllerTestSuite) TestBadAdminIndexWithValidationError() {
+	suite.auditServiceMock.EXPECT().AdminIndex(suite.ctx).Return(nil, validator.ValidationErrors{})
+	suite.con.AdminIndex(suite.ctx)
+
+	suite.Equal(config.ValidationErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *AuditControllerTestSuite) TestBadAdminIndexWithNotNumber() {
+	_, err := strconv.Atoi("abc")
+	suite.auditServiceMock.EXPECT().AdminIndex(suite.ctx).Return(nil, err)
+	suite.con.AdminIndex(suite.ctx)
+
+	suite.Equal(config.ValidationErrorResponse.Code, suite.rec.Code)
+}
@@ -7,10 +7,12 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 	"github.com/golang/mock/gomock"
 	"github.com/kuritaeiji/todo-gin-back/config"
 	"github.com/kuritaeiji/todo-gin-back/controller"
 	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/service"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
 )
@@ -39,16 +41,27 @@ func TestAuthController(t *testing.T) {
 }
 
 func (suite *AuthControllerTestSuite) TestSuccessLogin() {
-	tokenString := "tokenString"
-	suite.authServiceMock.EXPECT().Login(suite.ctx).Return(tokenString, nil)
+	tokenPair := service.TokenPair{AccessToken: "accessToken", RefreshToken: "refreshToken"}
+	suite.authServiceMock.EXPECT().Login(suite.ctx).Return(tokenPair, "", nil)
 	suite.controller.Login(suite.ctx)
 
 	suite.Equal(200, suite.rec.Code)
-	suite.Contains(suite.rec.Body.String(), tokenString)
+	suite.Contains(suite.rec.Body.String(), tokenPair.AccessToken)
+	suite.Contains(suite.rec.Body.String(), tokenPair.RefreshToken)
+}
+
+func (suite *AuthControllerTestSuite) TestSuccessLoginWithTwoFactorRequired() {
+	suite.authServiceMock.EXPECT().Login(suite.ctx).Return(service.TokenPair{}, "challengeToken", nil)
+	suite.controller.Login(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), `"challengeToken":"challengeToken"`)
+	suite.Contains(suite.rec.Body.String(), `"twoFactorRequired":true`)
+	suite.NotContains(suite.rec.Body.String(), "refreshToken")
 }
 
 func (suite *AuthControllerTestSuite) TestBadLoginWithRecordNotFound() {
-	suite.authServiceMock.EXPECT().Login(suite.ctx).Return("", gorm.ErrRecordNotFound)
+	suite.authServiceMock.EXPECT().Login(suite.ctx).Return(service.TokenPair{}, "", gorm.ErrRecordNotFound)
 	suite.controller.Login(suite.ctx)
 
 	suite.Equal(config.RecordNotFoundErrorResponse.Code, suite.rec.Code)
@@ -56,49 +69,247 @@ func (suite *AuthControllerTestSuite) TestBadLoginWithRecordNotFound() {
 }
 
 func (suite *AuthControllerTestSuite) TestBadLoginWithPasswordAuthenticationError() {
-	suite.authServiceMock.EXPECT().Login(suite.ctx).Return("", config.PasswordAuthenticationError)
+	suite.authServiceMock.EXPECT().Login(suite.ctx).Return(service.TokenPair{}, "", config.PasswordAuthenticationError)
 	suite.controller.Login(suite.ctx)
 
 	suite.Equal(config.PasswordAuthenticationErrorResponse.Code, suite.rec.Code)
 	suite.Contains(suite.rec.Body.String(), config.PasswordAuthenticationErrorResponse.Json["content"])
 }
 
-func (suite *AuthControllerTestSuite) TestSuccessGoogle() {
-	const url = "url"
-	const state = "state"
+func (suite *AuthControllerTestSuite) TestBadLoginWithAccountLocked() {
+	suite.authServiceMock.EXPECT().Login(suite.ctx).Return(service.TokenPair{}, "", config.AccountLockedError)
+	suite.controller.Login(suite.ctx)
+
+	suite.Equal(config.AccountLockedErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.AccountLockedErrorResponse.Json["content"])
+}
+
+func (suite *AuthControllerTestSuite) TestBadLoginWithTooManyLoginAttempts() {
+	suite.authServiceMock.EXPECT().Login(suite.ctx).Return(service.TokenPair{}, "", config.TooManyLoginAttemptsError)
+	suite.controller.Login(suite.ctx)
 
-	suite.authServiceMock.EXPECT().Google(suite.ctx).Return(url, state, nil)
-	suite.controller.Google(suite.ctx)
+	suite.Equal(config.TooManyLoginAttemptsErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.TooManyLoginAttemptsErrorResponse.Json["content"])
+}
 
-	stateCookie := suite.rec.Result().Cookies()[0]
-	suite.Equal(config.StateCookieKey, stateCookie.Name)
-	suite.Equal(state, stateCookie.Value)
-	suite.Equal(os.Getenv("DOMAIN"), stateCookie.Domain)
-	suite.Contains(suite.rec.Body.String(), url)
+func (suite *AuthControllerTestSuite) TestBadLoginWithNotActivatedUser() {
+	suite.authServiceMock.EXPECT().Login(suite.ctx).Return(service.TokenPair{}, "", config.NotActivatedUserError)
+	suite.controller.Login(suite.ctx)
+
+	suite.Equal(config.NotActivatedUserErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.NotActivatedUserErrorResponse.Json["content"])
+}
+
+func (suite *AuthControllerTestSuite) TestSuccessOauth() {
+	authorization := service.OauthAuthorization{URL: "url", State: "state", CodeVerifier: "codeVerifier", Nonce: "nonce"}
+
+	suite.authServiceMock.EXPECT().Oauth(suite.ctx).Return(authorization, nil)
+	suite.controller.Oauth(suite.ctx)
+
+	cookies := suite.rec.Result().Cookies()
+	suite.Len(cookies, 3)
+	suite.Equal(config.StateCookieKey, cookies[0].Name)
+	suite.Equal(authorization.State, cookies[0].Value)
+	suite.Equal(os.Getenv("DOMAIN"), cookies[0].Domain)
+	suite.True(cookies[0].HttpOnly)
+	suite.Equal(config.CodeVerifierCookieKey, cookies[1].Name)
+	suite.Equal(authorization.CodeVerifier, cookies[1].Value)
+	suite.Equal(config.NonceCookieKey, cookies[2].Name)
+	suite.Equal(authorization.Nonce, cookies[2].Value)
+	suite.Contains(suite.rec.Body.String(), authorization.URL)
+	suite.NotContains(suite.rec.Body.String(), authorization.CodeVerifier)
 	suite.Equal(200, suite.rec.Code)
 }
 
-func (suite *AuthControllerTestSuite) TestBadGoogleWithError() {
+func (suite *AuthControllerTestSuite) TestBadOauthWithError() {
 	err := errors.New("error")
-	suite.authServiceMock.EXPECT().Google(suite.ctx).Return("", "", err)
-	suite.controller.Google(suite.ctx)
+	suite.authServiceMock.EXPECT().Oauth(suite.ctx).Return(service.OauthAuthorization{}, err)
+	suite.controller.Oauth(suite.ctx)
 
 	suite.Equal(500, suite.rec.Code)
 }
 
-func (suite *AuthControllerTestSuite) TestSuccessGoogleLogin() {
-	const token = "token"
-	suite.authServiceMock.EXPECT().GoogleLogin(suite.ctx).Return(token, nil)
-	suite.controller.GoogleLogin(suite.ctx)
+func (suite *AuthControllerTestSuite) TestBadOauthWithOauthProviderNotFound() {
+	suite.authServiceMock.EXPECT().Oauth(suite.ctx).Return(service.OauthAuthorization{}, config.OauthProviderNotFoundError)
+	suite.controller.Oauth(suite.ctx)
+
+	suite.Equal(config.OauthProviderNotFoundErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.OauthProviderNotFoundErrorResponse.Json["content"])
+}
+
+func (suite *AuthControllerTestSuite) TestSuccessOauthLogin() {
+	tokenPair := service.TokenPair{AccessToken: "accessToken", RefreshToken: "refreshToken"}
+	suite.authServiceMock.EXPECT().OauthLogin(suite.ctx).Return(tokenPair, "", nil)
+	suite.controller.OauthLogin(suite.ctx)
 
 	suite.Equal(200, suite.rec.Code)
-	suite.Contains(suite.rec.Body.String(), token)
+	suite.Contains(suite.rec.Body.String(), tokenPair.AccessToken)
+	suite.Contains(suite.rec.Body.String(), tokenPair.RefreshToken)
+	for _, cookie := range suite.rec.Result().Cookies() {
+		suite.Empty(cookie.Value)
+		suite.True(cookie.MaxAge < 0)
+	}
+	suite.Len(suite.rec.Result().Cookies(), 3)
 }
 
-func (suite *AuthControllerTestSuite) TestBadGoogleLoginWithError() {
+func (suite *AuthControllerTestSuite) TestBadOauthLoginWithError() {
 	err := errors.New("error")
-	suite.authServiceMock.EXPECT().GoogleLogin(suite.ctx).Return("", err)
-	suite.controller.GoogleLogin(suite.ctx)
+	suite.authServiceMock.EXPECT().OauthLogin(suite.ctx).Return(service.TokenPair{}, "", err)
+	suite.controller.OauthLogin(suite.ctx)
 
 	suite.Equal(500, suite.rec.Code)
 }
+
+func (suite *AuthControllerTestSuite) TestBadOauthLoginWithInvalidNonce() {
+	suite.authServiceMock.EXPECT().OauthLogin(suite.ctx).Return(service.TokenPair{}, "", config.InvalidNonceError)
+	suite.controller.OauthLogin(suite.ctx)
+
+	suite.Equal(config.InvalidNonceErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.InvalidNonceErrorResponse.Json["content"])
+}
+
+func (suite *AuthControllerTestSuite) TestBadOauthLoginWithAccountLinkConflict() {
+	suite.authServiceMock.EXPECT().OauthLogin(suite.ctx).Return(service.TokenPair{}, "", config.AccountLinkConflictError)
+	suite.controller.OauthLogin(suite.ctx)
+
+	suite.Equal(config.AccountLinkConflictErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.AccountLinkConflictErrorResponse.Json["content"])
+}
+
+func (suite *AuthControllerTestSuite) TestSuccessLinkOauth() {
+	suite.authServiceMock.EXPECT().LinkOauth(suite.ctx).Return(nil)
+	suite.controller.LinkOauth(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+}
+
+func (suite *AuthControllerTestSuite) TestBadLinkOauthWithInvalidNonce() {
+	suite.authServiceMock.EXPECT().LinkOauth(suite.ctx).Return(config.InvalidNonceError)
+	suite.controller.LinkOauth(suite.ctx)
+
+	suite.Equal(config.InvalidNonceErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *AuthControllerTestSuite) TestBadLinkOauthWithIdentityAlreadyLinked() {
+	suite.authServiceMock.EXPECT().LinkOauth(suite.ctx).Return(config.IdentityAlreadyLinkedError)
+	suite.controller.LinkOauth(suite.ctx)
+
+	suite.Equal(config.IdentityAlreadyLinkedErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.IdentityAlreadyLinkedErrorResponse.Json["content"])
+}
+
+func (suite *AuthControllerTestSuite) TestSuccessUnlinkOauth() {
+	suite.authServiceMock.EXPECT().UnlinkOauth(suite.ctx).Return(nil)
+	suite.controller.UnlinkOauth(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+}
+
+func (suite *AuthControllerTestSuite) TestBadUnlinkOauthWithRecordNotFound() {
+	suite.authServiceMock.EXPECT().UnlinkOauth(suite.ctx).Return(gorm.ErrRecordNotFound)
+	suite.controller.UnlinkOauth(suite.ctx)
+
+	suite.Equal(config.RecordNotFoundErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *AuthControllerTestSuite) TestBadUnlinkOauthWithLastLoginMethod() {
+	suite.authServiceMock.EXPECT().UnlinkOauth(suite.ctx).Return(config.UnlinkLastLoginMethodError)
+	suite.controller.UnlinkOauth(suite.ctx)
+
+	suite.Equal(config.UnlinkLastLoginMethodErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.UnlinkLastLoginMethodErrorResponse.Json["content"])
+}
+
+func (suite *AuthControllerTestSuite) TestSuccessRefresh() {
+	tokenPair := service.TokenPair{AccessToken: "accessToken", RefreshToken: "refreshToken"}
+	suite.authServiceMock.EXPECT().Refresh(suite.ctx).Return(tokenPair, nil)
+	suite.controller.Refresh(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), tokenPair.AccessToken)
+	suite.Contains(suite.rec.Body.String(), tokenPair.RefreshToken)
+}
+
+func (suite *AuthControllerTestSuite) TestBadRefreshWithValidationError() {
+	suite.authServiceMock.EXPECT().Refresh(suite.ctx).Return(service.TokenPair{}, validator.ValidationErrors{})
+	suite.controller.Refresh(suite.ctx)
+
+	suite.Equal(config.ValidationErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.ValidationErrorResponse.Json["content"])
+}
+
+func (suite *AuthControllerTestSuite) TestBadRefreshWithReusedRefreshToken() {
+	suite.authServiceMock.EXPECT().Refresh(suite.ctx).Return(service.TokenPair{}, config.ReusedRefreshTokenError)
+	suite.controller.Refresh(suite.ctx)
+
+	suite.Equal(config.InvalidRefreshTokenErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.InvalidRefreshTokenErrorResponse.Json["content"])
+}
+
+func (suite *AuthControllerTestSuite) TestBadRefreshWithRevokedSession() {
+	suite.authServiceMock.EXPECT().Refresh(suite.ctx).Return(service.TokenPair{}, config.SessionRevokedError)
+	suite.controller.Refresh(suite.ctx)
+
+	suite.Equal(config.InvalidRefreshTokenErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.InvalidRefreshTokenErrorResponse.Json["content"])
+}
+
+func (suite *AuthControllerTestSuite) TestSuccessLogout() {
+	suite.authServiceMock.EXPECT().Logout(suite.ctx).Return(nil)
+	suite.controller.Logout(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+}
+
+func (suite *AuthControllerTestSuite) TestBadLogoutWithError() {
+	suite.authServiceMock.EXPECT().Logout(suite.ctx).Return(errors.New("error"))
+	suite.controller.Logout(suite.ctx)
+
+	suite.Equal(500, suite.rec.Code)
+}
+
+func (suite *AuthControllerTestSuite) TestSuccessLogoutAll() {
+	suite.authServiceMock.EXPECT().LogoutAll(suite.ctx).Return(nil)
+	suite.controller.LogoutAll(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+}
+
+func (suite *AuthControllerTestSuite) TestBadLogoutAllWithError() {
+	suite.authServiceMock.EXPECT().LogoutAll(suite.ctx).Return(errors.New("error"))
+	suite.controller.LogoutAll(suite.ctx)
+
+	suite.Equal(500, suite.rec.Code)
+}
+
+func (suite *AuthControllerTestSuite) TestSuccessTotpLogin() {
+	tokenPair := service.TokenPair{AccessToken: "accessToken", RefreshToken: "refreshToken"}
+	suite.authServiceMock.EXPECT().TotpLogin(suite.ctx).Return(tokenPair, nil)
+	suite.controller.TotpLogin(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), tokenPair.AccessToken)
+}
+
+func (suite *AuthControllerTestSuite) TestBadTotpLoginWithInvalidChallengeToken() {
+	suite.authServiceMock.EXPECT().TotpLogin(suite.ctx).Return(service.TokenPair{}, config.InvalidChallengeTokenError)
+	suite.controller.TotpLogin(suite.ctx)
+
+	suite.Equal(config.InvalidChallengeTokenErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.InvalidChallengeTokenErrorResponse.Json["content"])
+}
+
+func (suite *AuthControllerTestSuite) TestBadTotpLoginWithInvalidTotpCode() {
+	suite.authServiceMock.EXPECT().TotpLogin(suite.ctx).Return(service.TokenPair{}, config.InvalidTotpCodeError)
+	suite.controller.TotpLogin(suite.ctx)
+
+	suite.Equal(config.InvalidTotpCodeErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.InvalidTotpCodeErrorResponse.Json["content"])
+}
+
+func (suite *AuthControllerTestSuite) TestBadTotpLoginWithAccountLocked() {
+	suite.authServiceMock.EXPECT().TotpLogin(suite.ctx).Return(service.TokenPair{}, config.AccountLockedError)
+	suite.controller.TotpLogin(suite.ctx)
+
+	suite.Equal(config.AccountLockedErrorResponse.Code, suite.rec.Code)
+}
@@ -0,0 +1,106 @@
+package controller_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/controller"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type BoardControllerTestSuite struct {
+	suite.Suite
+	con              controller.BoardController
+	ctx              *gin.Context
+	rec              *httptest.ResponseRecorder
+	boardServiceMock *mock_service.MockBoardService
+}
+
+func (suite *BoardControllerTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *BoardControllerTestSuite) SetupTest() {
+	suite.boardServiceMock = mock_service.NewMockBoardService(gomock.NewController(suite.T()))
+	suite.con = controller.TestNewBoardController(suite.boardServiceMock)
+	suite.rec = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.rec)
+}
+
+func TestBoardControllerSuite(t *testing.T) {
+	suite.Run(t, new(BoardControllerTestSuite))
+}
+
+func (suite *BoardControllerTestSuite) TestSuccessIndex() {
+	boards := []model.Board{{ID: 1, Title: "board", Index: 0}}
+	suite.boardServiceMock.EXPECT().Index(suite.ctx).Return(boards, nil)
+	suite.con.Index(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Equal(`[{"id":1,"index":0,"title":"board"}]`, suite.rec.Body.String())
+}
+
+func (suite *BoardControllerTestSuite) TestBadIndexWithError() {
+	suite.boardServiceMock.EXPECT().Index(suite.ctx).Return(nil, errors.New("db error"))
+	suite.con.Index(suite.ctx)
+
+	suite.Equal(500, suite.rec.Code)
+}
+
+func (suite *BoardControllerTestSuite) TestSuccessCreate() {
+	suite.boardServiceMock.EXPECT().Create(suite.ctx).Return(model.Board{ID: 1, Title: "board"}, nil)
+	suite.con.Create(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), `"title":"board"`)
+}
+
+func (suite *BoardControllerTestSuite) TestBadCreateWithValidationError() {
+	suite.boardServiceMock.EXPECT().Create(suite.ctx).Return(model.Board{}, validator.ValidationErrors{})
+	suite.con.Create(suite.ctx)
+
+	suite.Equal(config.ValidationErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *BoardControllerTestSuite) TestSuccessUpdate() {
+	suite.boardServiceMock.EXPECT().Update(suite.ctx).Return(model.Board{ID: 1, Title: "new title"}, nil)
+	suite.con.Update(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), `"title":"new title"`)
+}
+
+func (suite *BoardControllerTestSuite) TestSuccessDestroy() {
+	suite.boardServiceMock.EXPECT().Destroy(suite.ctx).Return(nil)
+	suite.con.Destroy(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+}
+
+func (suite *BoardControllerTestSuite) TestBadDestroyWithError() {
+	suite.boardServiceMock.EXPECT().Destroy(suite.ctx).Return(errors.New("storage error"))
+	suite.con.Destroy(suite.ctx)
+
+	suite.Equal(500, suite.rec.Code)
+}
+
+func (suite *BoardControllerTestSuite) TestSuccessMove() {
+	suite.boardServiceMock.EXPECT().Move(suite.ctx).Return(nil)
+	suite.con.Move(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+}
+
+func (suite *BoardControllerTestSuite) TestBadMoveWithValidationError() {
+	suite.boardServiceMock.EXPECT().Move(suite.ctx).Return(validator.ValidationErrors{})
+	suite.con.Move(suite.ctx)
+
+	suite.Equal(config.ValidationErrorResponse.Code, suite.rec.Code)
+}
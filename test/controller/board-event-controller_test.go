@@ -0,0 +1,113 @@
+package controller_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/controller"
+	"github.com/kuritaeiji/todo-gin-back/gateway"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/stretchr/testify/suite"
+)
+
+// ctx.StreamがCloseNotifyを使うのでResponseRecorderに追加する
+type closeNotifyingRecorder struct {
+	*httptest.ResponseRecorder
+	closed chan bool
+}
+
+func (r *closeNotifyingRecorder) CloseNotify() <-chan bool {
+	return r.closed
+}
+
+type BoardEventControllerTestSuite struct {
+	suite.Suite
+	con                   controller.BoardEventController
+	ctx                   *gin.Context
+	rec                   *closeNotifyingRecorder
+	boardEventServiceMock *mock_service.MockBoardEventService
+}
+
+func (suite *BoardEventControllerTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *BoardEventControllerTestSuite) SetupTest() {
+	suite.boardEventServiceMock = mock_service.NewMockBoardEventService(gomock.NewController(suite.T()))
+	suite.con = controller.TestNewBoardEventController(suite.boardEventServiceMock, time.Millisecond)
+	suite.rec = &closeNotifyingRecorder{httptest.NewRecorder(), make(chan bool, 1)}
+	suite.ctx, _ = gin.CreateTestContext(suite.rec)
+	suite.ctx.Request = httptest.NewRequest("GET", "/api/boards/1/events", nil)
+}
+
+func TestBoardEventControllerSuite(t *testing.T) {
+	suite.Run(t, new(BoardEventControllerTestSuite))
+}
+
+// 送信済みのイベントを持ち、チャネルが閉じられた購読を返す
+func (suite *BoardEventControllerTestSuite) subscription(gap bool, missed []gateway.BoardEvent, events ...gateway.BoardEvent) (gateway.BoardEventSubscription, *bool) {
+	ch := make(chan gateway.BoardEvent, len(events))
+	for _, event := range events {
+		ch <- event
+	}
+	close(ch)
+	closed := false
+	return gateway.BoardEventSubscription{Missed: missed, Gap: gap, Events: ch, Close: func() { closed = true }}, &closed
+}
+
+func (suite *BoardEventControllerTestSuite) TestSuccessStream() {
+	missed := []gateway.BoardEvent{{ID: 3, BoardID: 1, Type: "list.created", Data: gin.H{"id": 1}}}
+	event := gateway.BoardEvent{ID: 4, BoardID: 1, Type: "card.moved", Data: gin.H{"id": 2}}
+	subscription, closed := suite.subscription(false, missed, event)
+	suite.boardEventServiceMock.EXPECT().Subscribe(suite.ctx).Return(subscription, nil)
+	suite.con.Stream(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Equal("text/event-stream", suite.rec.Header().Get("Content-Type"))
+	suite.Equal("id: 3\nevent: list.created\ndata: {\"id\":1}\n\nid: 4\nevent: card.moved\ndata: {\"id\":2}\n\n", suite.rec.Body.String())
+	suite.True(*closed)
+}
+
+func (suite *BoardEventControllerTestSuite) TestSuccessStreamWithGap() {
+	subscription, _ := suite.subscription(true, nil)
+	suite.boardEventServiceMock.EXPECT().Subscribe(suite.ctx).Return(subscription, nil)
+	suite.con.Stream(suite.ctx)
+
+	suite.Equal("event: reset\ndata: {}\n\n", suite.rec.Body.String())
+}
+
+func (suite *BoardEventControllerTestSuite) TestStopStreamWhenClientGone() {
+	subscription := gateway.BoardEventSubscription{Events: make(chan gateway.BoardEvent), Close: func() {}}
+	suite.boardEventServiceMock.EXPECT().Subscribe(suite.ctx).Return(subscription, nil)
+	suite.rec.closed <- true
+	suite.con.Stream(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+}
+
+func (suite *BoardEventControllerTestSuite) TestBadStreamWithError() {
+	suite.boardEventServiceMock.EXPECT().Subscribe(suite.ctx).Return(gateway.BoardEventSubscription{}, errors.New("hub error"))
+	suite.con.Stream(suite.ctx)
+
+	suite.Equal(500, suite.rec.Code)
+}
+
+func (suite *BoardEventControllerTestSuite) TestSuccessStreamClosesWhenAuthorizationFails() {
+	ch := make(chan gateway.BoardEvent)
+	closed := false
+	subscription := gateway.BoardEventSubscription{Events: ch, Close: func() { closed = true }}
+	suite.boardEventServiceMock.EXPECT().Subscribe(suite.ctx).Return(subscription, nil)
+	gomock.InOrder(
+		suite.boardEventServiceMock.EXPECT().Authorize(suite.ctx).Return(nil),
+		suite.boardEventServiceMock.EXPECT().Authorize(suite.ctx).Return(config.SessionRevokedError),
+	)
+	suite.con.Stream(suite.ctx)
+
+	suite.Equal(": heartbeat\n\n", suite.rec.Body.String())
+	suite.True(closed)
+}
@@ -0,0 +1,131 @@
+package controller_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/controller"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type BoardMemberControllerTestSuite struct {
+	suite.Suite
+	con                    controller.BoardMemberController
+	ctx                    *gin.Context
+	rec                    *httptest.ResponseRecorder
+	boardMemberServiceMock *mock_service.MockBoardMemberService
+}
+
+func (suite *BoardMemberControllerTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *BoardMemberControllerTestSuite) SetupTest() {
+	suite.boardMemberServiceMock = mock_service.NewMockBoardMemberService(gomock.NewController(suite.T()))
+	suite.con = controller.TestNewBoardMemberController(suite.boardMemberServiceMock)
+	suite.rec = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.rec)
+}
+
+func TestBoardMemberControllerSuite(t *testing.T) {
+	suite.Run(t, new(BoardMemberControllerTestSuite))
+}
+
+func (suite *BoardMemberControllerTestSuite) TestSuccessIndex() {
+	members := []model.BoardMember{{ID: 1, UserID: 2, User: model.User{Email: "user@example.com"}, Role: model.BoardRoleViewer}}
+	suite.boardMemberServiceMock.EXPECT().Index(suite.ctx).Return(members, nil)
+	suite.con.Index(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Equal(`[{"accepted":false,"email":"user@example.com","id":1,"role":"viewer","userId":2}]`, suite.rec.Body.String())
+}
+
+func (suite *BoardMemberControllerTestSuite) TestSuccessInvite() {
+	suite.boardMemberServiceMock.EXPECT().Invite(suite.ctx).Return(model.BoardMember{ID: 1, Role: model.BoardRoleEditor}, nil)
+	suite.con.Invite(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), `"role":"editor"`)
+}
+
+func (suite *BoardMemberControllerTestSuite) TestBadInviteWithValidationError() {
+	suite.boardMemberServiceMock.EXPECT().Invite(suite.ctx).Return(model.BoardMember{}, validator.ValidationErrors{})
+	suite.con.Invite(suite.ctx)
+
+	suite.Equal(config.ValidationErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *BoardMemberControllerTestSuite) TestBadInviteWithNotFoundUser() {
+	suite.boardMemberServiceMock.EXPECT().Invite(suite.ctx).Return(model.BoardMember{}, gorm.ErrRecordNotFound)
+	suite.con.Invite(suite.ctx)
+
+	suite.Equal(config.RecordNotFoundErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *BoardMemberControllerTestSuite) TestBadInviteWithAlreadyMember() {
+	suite.boardMemberServiceMock.EXPECT().Invite(suite.ctx).Return(model.BoardMember{}, config.AlreadyBoardMemberError)
+	suite.con.Invite(suite.ctx)
+
+	suite.Equal(config.AlreadyBoardMemberErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.AlreadyBoardMemberErrorResponse.Json["content"])
+}
+
+func (suite *BoardMemberControllerTestSuite) TestBadInviteWithEmailClientError() {
+	suite.boardMemberServiceMock.EXPECT().Invite(suite.ctx).Return(model.BoardMember{}, config.EmailClientError)
+	suite.con.Invite(suite.ctx)
+
+	suite.Equal(config.EmailClientErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *BoardMemberControllerTestSuite) TestBadUpdateWithLastOwner() {
+	suite.boardMemberServiceMock.EXPECT().Update(suite.ctx).Return(model.BoardMember{}, config.LastBoardOwnerError)
+	suite.con.Update(suite.ctx)
+
+	suite.Equal(config.LastBoardOwnerErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.LastBoardOwnerErrorResponse.Json["content"])
+}
+
+func (suite *BoardMemberControllerTestSuite) TestSuccessDestroy() {
+	suite.boardMemberServiceMock.EXPECT().Destroy(suite.ctx).Return(nil)
+	suite.con.Destroy(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+}
+
+func (suite *BoardMemberControllerTestSuite) TestSuccessInvitations() {
+	invitations := []model.BoardMember{{ID: 1, BoardID: 2, Board: model.Board{Title: "board"}, Role: model.BoardRoleEditor}}
+	suite.boardMemberServiceMock.EXPECT().Invitations(suite.ctx).Return(invitations, nil)
+	suite.con.Invitations(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Equal(`[{"boardId":2,"boardTitle":"board","id":1,"role":"editor"}]`, suite.rec.Body.String())
+}
+
+func (suite *BoardMemberControllerTestSuite) TestSuccessAccept() {
+	suite.boardMemberServiceMock.EXPECT().Accept(suite.ctx).Return(model.BoardMember{ID: 1, BoardID: 2}, nil)
+	suite.con.Accept(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+}
+
+func (suite *BoardMemberControllerTestSuite) TestBadAcceptWithNotFound() {
+	suite.boardMemberServiceMock.EXPECT().Accept(suite.ctx).Return(model.BoardMember{}, gorm.ErrRecordNotFound)
+	suite.con.Accept(suite.ctx)
+
+	suite.Equal(config.RecordNotFoundErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *BoardMemberControllerTestSuite) TestBadDeclineWithError() {
+	suite.boardMemberServiceMock.EXPECT().Decline(suite.ctx).Return(errors.New("db error"))
+	suite.con.Decline(suite.ctx)
+
+	suite.Equal(500, suite.rec.Code)
+}
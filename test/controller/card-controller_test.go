@@ -124,3 +124,17 @@ func (suite *CardControllerTestSuite) TestBadMoveCard() {
 
 	suite.Equal(500, suite.rec.Code)
 }
+
+func (suite *CardControllerTestSuite) TestSuccessArchiveCard() {
+	suite.cardServiceMock.EXPECT().Archive(suite.ctx).Return(nil)
+	suite.controller.Archive(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+}
+
+func (suite *CardControllerTestSuite) TestBadArchiveCardWithError() {
+	suite.cardServiceMock.EXPECT().Archive(suite.ctx).Return(errors.New("error"))
+	suite.controller.Archive(suite.ctx)
+
+	suite.Equal(500, suite.rec.Code)
+}
@@ -0,0 +1,85 @@
+package controller_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/controller"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type ChecklistControllerTestSuite struct {
+	suite.Suite
+	con                  controller.ChecklistController
+	ctx                  *gin.Context
+	rec                  *httptest.ResponseRecorder
+	checklistServiceMock *mock_service.MockChecklistService
+}
+
+func (suite *ChecklistControllerTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *ChecklistControllerTestSuite) SetupTest() {
+	suite.checklistServiceMock = mock_service.NewMockChecklistService(gomock.NewController(suite.T()))
+	suite.con = controller.TestNewChecklistController(suite.checklistServiceMock)
+	suite.rec = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.rec)
+}
+
+func TestChecklistControllerSuite(t *testing.T) {
+	suite.Run(t, new(ChecklistControllerTestSuite))
+}
+
+func (suite *ChecklistControllerTestSuite) TestSuccessIndex() {
+	checklists := []model.Checklist{{ID: 1, Title: "todo", Items: []model.ChecklistItem{{ID: 2, Title: "item", Done: true}}}}
+	suite.checklistServiceMock.EXPECT().Index(suite.ctx).Return(checklists, nil)
+	suite.con.Index(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Equal(`[{"id":1,"index":0,"items":[{"done":true,"id":2,"index":0,"title":"item"}],"title":"todo"}]`, suite.rec.Body.String())
+}
+
+func (suite *ChecklistControllerTestSuite) TestBadCreateWithValidationError() {
+	suite.checklistServiceMock.EXPECT().Create(suite.ctx).Return(model.Checklist{}, validator.ValidationErrors{})
+	suite.con.Create(suite.ctx)
+
+	suite.Equal(config.ValidationErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *ChecklistControllerTestSuite) TestBadUpdateWithRecordNotFound() {
+	suite.checklistServiceMock.EXPECT().Update(suite.ctx).Return(model.Checklist{}, gorm.ErrRecordNotFound)
+	suite.con.Update(suite.ctx)
+
+	suite.Equal(config.RecordNotFoundErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *ChecklistControllerTestSuite) TestSuccessMove() {
+	suite.checklistServiceMock.EXPECT().Move(suite.ctx).Return(nil)
+	suite.con.Move(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+}
+
+func (suite *ChecklistControllerTestSuite) TestSuccessUpdateItem() {
+	suite.checklistServiceMock.EXPECT().UpdateItem(suite.ctx).Return(model.ChecklistItem{ID: 2, Title: "item", Done: true}, nil)
+	suite.con.UpdateItem(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), `"done":true`)
+}
+
+func (suite *ChecklistControllerTestSuite) TestBadDestroyItemWithDBError() {
+	suite.checklistServiceMock.EXPECT().DestroyItem(suite.ctx).Return(errors.New("db error"))
+	suite.con.DestroyItem(suite.ctx)
+
+	suite.Equal(500, suite.rec.Code)
+}
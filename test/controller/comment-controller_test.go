@@ -0,0 +1,85 @@
+package controller_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/controller"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type CommentControllerTestSuite struct {
+	suite.Suite
+	con                controller.CommentController
+	ctx                *gin.Context
+	rec                *httptest.ResponseRecorder
+	commentServiceMock *mock_service.MockCommentService
+}
+
+func (suite *CommentControllerTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *CommentControllerTestSuite) SetupTest() {
+	suite.commentServiceMock = mock_service.NewMockCommentService(gomock.NewController(suite.T()))
+	suite.con = controller.TestNewCommentController(suite.commentServiceMock)
+	suite.rec = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.rec)
+}
+
+func TestCommentControllerSuite(t *testing.T) {
+	suite.Run(t, new(CommentControllerTestSuite))
+}
+
+func (suite *CommentControllerTestSuite) TestSuccessIndex() {
+	comments := []model.Comment{{ID: 1, Body: "hello", User: model.User{ID: 2, Email: "user@example.com"}}}
+	suite.commentServiceMock.EXPECT().Index(suite.ctx).Return(comments, nil)
+	suite.con.Index(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), `"author":{"email":"user@example.com","id":2}`)
+}
+
+func (suite *CommentControllerTestSuite) TestBadIndexWithNumError() {
+	suite.commentServiceMock.EXPECT().Index(suite.ctx).Return(nil, &strconv.NumError{})
+	suite.con.Index(suite.ctx)
+
+	suite.Equal(config.ValidationErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *CommentControllerTestSuite) TestSuccessCreate() {
+	suite.commentServiceMock.EXPECT().Create(suite.ctx).Return(model.Comment{ID: 1, Body: "hello"}, nil)
+	suite.con.Create(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), `"body":"hello"`)
+}
+
+func (suite *CommentControllerTestSuite) TestBadUpdateWithForbidden() {
+	suite.commentServiceMock.EXPECT().Update(suite.ctx).Return(model.Comment{}, config.ForbiddenError)
+	suite.con.Update(suite.ctx)
+
+	suite.Equal(config.ForbiddenErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *CommentControllerTestSuite) TestBadDestroyWithRecordNotFound() {
+	suite.commentServiceMock.EXPECT().Destroy(suite.ctx).Return(gorm.ErrRecordNotFound)
+	suite.con.Destroy(suite.ctx)
+
+	suite.Equal(config.RecordNotFoundErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *CommentControllerTestSuite) TestBadDestroyWithDBError() {
+	suite.commentServiceMock.EXPECT().Destroy(suite.ctx).Return(errors.New("db error"))
+	suite.con.Destroy(suite.ctx)
+
+	suite.Equal(500, suite.rec.Code)
+}
@@ -0,0 +1,46 @@
+package controller_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/controller"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"github.com/stretchr/testify/suite"
+)
+
+type JWKSControllerTestSuite struct {
+	suite.Suite
+	con            controller.JWKSController
+	ctx            *gin.Context
+	rec            *httptest.ResponseRecorder
+	jwtServiceMock *mock_service.MockJWTService
+}
+
+func (suite *JWKSControllerTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *JWKSControllerTestSuite) SetupTest() {
+	suite.jwtServiceMock = mock_service.NewMockJWTService(gomock.NewController(suite.T()))
+	suite.con = controller.TestNewJWKSController(suite.jwtServiceMock)
+	suite.rec = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.rec)
+}
+
+func TestJWKSControllerSuite(t *testing.T) {
+	suite.Run(t, new(JWKSControllerTestSuite))
+}
+
+func (suite *JWKSControllerTestSuite) TestSuccessIndex() {
+	jwks := service.JWKSet{Keys: []service.JWK{{Kty: "OKP", Crv: "Ed25519", X: "x", Kid: "kid", Alg: "EdDSA", Use: "sig"}}}
+	suite.jwtServiceMock.EXPECT().JWKS().Return(jwks)
+	suite.con.Index(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.JSONEq(`{"keys":[{"kty":"OKP","crv":"Ed25519","x":"x","kid":"kid","alg":"EdDSA","use":"sig"}]}`, suite.rec.Body.String())
+	suite.Contains(suite.rec.Header().Get("Cache-Control"), "max-age")
+}
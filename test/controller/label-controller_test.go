@@ -0,0 +1,94 @@
+package controller_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/controller"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type LabelControllerTestSuite struct {
+	suite.Suite
+	con              controller.LabelController
+	ctx              *gin.Context
+	rec              *httptest.ResponseRecorder
+	labelServiceMock *mock_service.MockLabelService
+}
+
+func (suite *LabelControllerTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *LabelControllerTestSuite) SetupTest() {
+	suite.labelServiceMock = mock_service.NewMockLabelService(gomock.NewController(suite.T()))
+	suite.con = controller.TestNewLabelController(suite.labelServiceMock)
+	suite.rec = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.rec)
+}
+
+func TestLabelControllerSuite(t *testing.T) {
+	suite.Run(t, new(LabelControllerTestSuite))
+}
+
+func (suite *LabelControllerTestSuite) TestSuccessIndex() {
+	labels := []model.Label{{ID: 1, Name: "bug", Color: "#ff0000"}}
+	suite.labelServiceMock.EXPECT().Index(suite.ctx).Return(labels, nil)
+	suite.con.Index(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Equal(`[{"color":"#ff0000","id":1,"name":"bug"}]`, suite.rec.Body.String())
+}
+
+func (suite *LabelControllerTestSuite) TestSuccessCreate() {
+	suite.labelServiceMock.EXPECT().Create(suite.ctx).Return(model.Label{ID: 1, Name: "bug", Color: "#ff0000"}, nil)
+	suite.con.Create(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), `"name":"bug"`)
+}
+
+func (suite *LabelControllerTestSuite) TestBadCreateWithValidationError() {
+	suite.labelServiceMock.EXPECT().Create(suite.ctx).Return(model.Label{}, validator.ValidationErrors{})
+	suite.con.Create(suite.ctx)
+
+	suite.Equal(config.ValidationErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *LabelControllerTestSuite) TestBadUpdateWithForbidden() {
+	suite.labelServiceMock.EXPECT().Update(suite.ctx).Return(model.Label{}, config.ForbiddenError)
+	suite.con.Update(suite.ctx)
+
+	suite.Equal(config.ForbiddenErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *LabelControllerTestSuite) TestBadDestroyWithRecordNotFound() {
+	suite.labelServiceMock.EXPECT().Destroy(suite.ctx).Return(gorm.ErrRecordNotFound)
+	suite.con.Destroy(suite.ctx)
+
+	suite.Equal(config.RecordNotFoundErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *LabelControllerTestSuite) TestSuccessAttach() {
+	card := model.Card{ID: 1, Labels: []model.Label{{ID: 2, Name: "bug", Color: "#ff0000"}}}
+	suite.labelServiceMock.EXPECT().Attach(suite.ctx).Return(card, nil)
+	suite.con.Attach(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), `"labels":[{"color":"#ff0000","id":2,"name":"bug"}]`)
+}
+
+func (suite *LabelControllerTestSuite) TestBadDetachWithDBError() {
+	suite.labelServiceMock.EXPECT().Detach(suite.ctx).Return(model.Card{}, errors.New("db error"))
+	suite.con.Detach(suite.ctx)
+
+	suite.Equal(500, suite.rec.Code)
+}
@@ -150,3 +150,17 @@ func (suite *ListControllerTestSuite) TestBadMoveOtherError() {
 
 	suite.Equal(500, suite.rec.Code)
 }
+
+func (suite *ListControllerTestSuite) TestSuccessArchive() {
+	suite.listServiceMock.EXPECT().Archive(suite.ctx).Return(nil)
+	suite.con.Archive(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+}
+
+func (suite *ListControllerTestSuite) TestBadArchiveWithOtherError() {
+	suite.listServiceMock.EXPECT().Archive(suite.ctx).Return(errors.New("error"))
+	suite.con.Archive(suite.ctx)
+
+	suite.Equal(500, suite.rec.Code)
+}
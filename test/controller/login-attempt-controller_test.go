@@ -0,0 +1,54 @@
+package controller_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/controller"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type LoginAttemptControllerTestSuite struct {
+	suite.Suite
+	con                     controller.LoginAttemptController
+	ctx                     *gin.Context
+	rec                     *httptest.ResponseRecorder
+	loginAttemptServiceMock *mock_service.MockLoginAttemptService
+}
+
+func (suite *LoginAttemptControllerTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *LoginAttemptControllerTestSuite) SetupTest() {
+	suite.loginAttemptServiceMock = mock_service.NewMockLoginAttemptService(gomock.NewController(suite.T()))
+	suite.con = controller.TestNewLoginAttemptController(suite.loginAttemptServiceMock)
+	suite.rec = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.rec)
+}
+
+func TestLoginAttemptControllerSuite(t *testing.T) {
+	suite.Run(t, new(LoginAttemptControllerTestSuite))
+}
+
+func (suite *LoginAttemptControllerTestSuite) TestSuccessIndex() {
+	attempts := []model.LoginAttempt{{Key: "user:1", Failures: 5}}
+	suite.loginAttemptServiceMock.EXPECT().Index().Return(attempts, nil)
+	suite.con.Index(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), `"key":"user:1"`)
+	suite.Contains(suite.rec.Body.String(), `"failures":5`)
+}
+
+func (suite *LoginAttemptControllerTestSuite) TestBadIndex() {
+	suite.loginAttemptServiceMock.EXPECT().Index().Return(nil, errors.New("error"))
+	suite.con.Index(suite.ctx)
+
+	suite.Equal(500, suite.rec.Code)
+}
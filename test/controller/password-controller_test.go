@@ -0,0 +1,82 @@
+package controller_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/controller"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/stretchr/testify/suite"
+)
+
+type PasswordControllerTestSuite struct {
+	suite.Suite
+	controller          controller.PasswordController
+	passwordServiceMock *mock_service.MockPasswordService
+	rec                 *httptest.ResponseRecorder
+	ctx                 *gin.Context
+}
+
+func (suite *PasswordControllerTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *PasswordControllerTestSuite) SetupTest() {
+	suite.passwordServiceMock = mock_service.NewMockPasswordService(gomock.NewController(suite.T()))
+	suite.controller = controller.TestNewPasswordController(suite.passwordServiceMock)
+	suite.rec = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.rec)
+}
+
+func TestPasswordController(t *testing.T) {
+	suite.Run(t, new(PasswordControllerTestSuite))
+}
+
+func (suite *PasswordControllerTestSuite) TestSuccessForgot() {
+	suite.passwordServiceMock.EXPECT().Forgot(suite.ctx).Return(nil)
+	suite.controller.Forgot(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+}
+
+func (suite *PasswordControllerTestSuite) TestBadForgotWithValidationError() {
+	suite.passwordServiceMock.EXPECT().Forgot(suite.ctx).Return(validator.ValidationErrors{})
+	suite.controller.Forgot(suite.ctx)
+
+	suite.Equal(config.ValidationErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.ValidationErrorResponse.Json["content"])
+}
+
+func (suite *PasswordControllerTestSuite) TestBadForgotWithError() {
+	suite.passwordServiceMock.EXPECT().Forgot(suite.ctx).Return(errors.New("error"))
+	suite.controller.Forgot(suite.ctx)
+
+	suite.Equal(500, suite.rec.Code)
+}
+
+func (suite *PasswordControllerTestSuite) TestSuccessReset() {
+	suite.passwordServiceMock.EXPECT().Reset(suite.ctx).Return(nil)
+	suite.controller.Reset(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+}
+
+func (suite *PasswordControllerTestSuite) TestBadResetWithInvalidToken() {
+	suite.passwordServiceMock.EXPECT().Reset(suite.ctx).Return(config.InvalidPasswordResetTokenError)
+	suite.controller.Reset(suite.ctx)
+
+	suite.Equal(config.InvalidPasswordResetTokenErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.InvalidPasswordResetTokenErrorResponse.Json["content"])
+}
+
+func (suite *PasswordControllerTestSuite) TestBadResetWithError() {
+	suite.passwordServiceMock.EXPECT().Reset(suite.ctx).Return(errors.New("error"))
+	suite.controller.Reset(suite.ctx)
+
+	suite.Equal(500, suite.rec.Code)
+}
@@ -0,0 +1,88 @@
+package controller_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/controller"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type PersonalAccessTokenControllerTestSuite struct {
+	suite.Suite
+	con                            controller.PersonalAccessTokenController
+	ctx                            *gin.Context
+	rec                            *httptest.ResponseRecorder
+	personalAccessTokenServiceMock *mock_service.MockPersonalAccessTokenService
+}
+
+func (suite *PersonalAccessTokenControllerTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *PersonalAccessTokenControllerTestSuite) SetupTest() {
+	suite.personalAccessTokenServiceMock = mock_service.NewMockPersonalAccessTokenService(gomock.NewController(suite.T()))
+	suite.con = controller.TestNewPersonalAccessTokenController(suite.personalAccessTokenServiceMock)
+	suite.rec = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.rec)
+}
+
+func TestPersonalAccessTokenControllerSuite(t *testing.T) {
+	suite.Run(t, new(PersonalAccessTokenControllerTestSuite))
+}
+
+func (suite *PersonalAccessTokenControllerTestSuite) TestSuccessIndex() {
+	tokens := []model.PersonalAccessToken{{ID: 1, Name: "script", Scopes: "lists:read", Digest: "digest"}}
+	suite.personalAccessTokenServiceMock.EXPECT().Index(suite.ctx).Return(tokens, nil)
+	suite.con.Index(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), `"name":"script"`)
+	suite.Contains(suite.rec.Body.String(), `"scopes":["lists:read"]`)
+	suite.NotContains(suite.rec.Body.String(), "digest")
+}
+
+func (suite *PersonalAccessTokenControllerTestSuite) TestSuccessCreate() {
+	token := model.PersonalAccessToken{ID: 1, Name: "script", Scopes: "lists:read"}
+	suite.personalAccessTokenServiceMock.EXPECT().Create(suite.ctx).Return(token, "tgp_token", nil)
+	suite.con.Create(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), `"token":"tgp_token"`)
+}
+
+func (suite *PersonalAccessTokenControllerTestSuite) TestBadCreateWithValidationError() {
+	suite.personalAccessTokenServiceMock.EXPECT().Create(suite.ctx).Return(model.PersonalAccessToken{}, "", validator.ValidationErrors{})
+	suite.con.Create(suite.ctx)
+
+	suite.Equal(config.ValidationErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *PersonalAccessTokenControllerTestSuite) TestSuccessDestroy() {
+	suite.personalAccessTokenServiceMock.EXPECT().Destroy(suite.ctx).Return(nil)
+	suite.con.Destroy(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+}
+
+func (suite *PersonalAccessTokenControllerTestSuite) TestBadDestroyWithRecordNotFound() {
+	suite.personalAccessTokenServiceMock.EXPECT().Destroy(suite.ctx).Return(gorm.ErrRecordNotFound)
+	suite.con.Destroy(suite.ctx)
+
+	suite.Equal(config.RecordNotFoundErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *PersonalAccessTokenControllerTestSuite) TestBadDestroyWithError() {
+	suite.personalAccessTokenServiceMock.EXPECT().Destroy(suite.ctx).Return(errors.New("error"))
+	suite.con.Destroy(suite.ctx)
+
+	suite.Equal(500, suite.rec.Code)
+}
@@ -0,0 +1,74 @@
+package controller_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/controller"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type SessionControllerTestSuite struct {
+	suite.Suite
+	con                controller.SessionController
+	ctx                *gin.Context
+	rec                *httptest.ResponseRecorder
+	sessionServiceMock *mock_service.MockSessionService
+}
+
+func (suite *SessionControllerTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *SessionControllerTestSuite) SetupTest() {
+	suite.sessionServiceMock = mock_service.NewMockSessionService(gomock.NewController(suite.T()))
+	suite.con = controller.TestNewSessionController(suite.sessionServiceMock)
+	suite.rec = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.rec)
+}
+
+func TestSessionControllerSuite(t *testing.T) {
+	suite.Run(t, new(SessionControllerTestSuite))
+}
+
+func (suite *SessionControllerTestSuite) TestSuccessIndex() {
+	sessions := []model.Session{{ID: 1, SessionID: "current", UserAgent: "browser"}, {ID: 2, SessionID: "other"}}
+	suite.ctx.Set(config.ClaimKey, &service.UserClaim{SessionID: "current"})
+	suite.sessionServiceMock.EXPECT().Index(suite.ctx).Return(sessions, nil)
+	suite.con.Index(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), `"userAgent":"browser"`)
+	suite.Contains(suite.rec.Body.String(), `"current":true`)
+	suite.Contains(suite.rec.Body.String(), `"current":false`)
+	suite.NotContains(suite.rec.Body.String(), "other")
+}
+
+func (suite *SessionControllerTestSuite) TestBadIndexWithDBError() {
+	suite.sessionServiceMock.EXPECT().Index(suite.ctx).Return(nil, errors.New("db error"))
+	suite.con.Index(suite.ctx)
+
+	suite.Equal(500, suite.rec.Code)
+}
+
+func (suite *SessionControllerTestSuite) TestSuccessDestroy() {
+	suite.sessionServiceMock.EXPECT().Destroy(suite.ctx).Return(nil)
+	suite.con.Destroy(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+}
+
+func (suite *SessionControllerTestSuite) TestBadDestroyWithNotFound() {
+	suite.sessionServiceMock.EXPECT().Destroy(suite.ctx).Return(gorm.ErrRecordNotFound)
+	suite.con.Destroy(suite.ctx)
+
+	suite.Equal(config.RecordNotFoundErrorResponse.Code, suite.rec.Code)
+}
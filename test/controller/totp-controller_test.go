@@ -0,0 +1,120 @@
+package controller_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/controller"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/stretchr/testify/suite"
+)
+
+type TotpControllerTestSuite struct {
+	suite.Suite
+	con             controller.TotpController
+	ctx             *gin.Context
+	rec             *httptest.ResponseRecorder
+	totpServiceMock *mock_service.MockTotpService
+}
+
+func (suite *TotpControllerTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *TotpControllerTestSuite) SetupTest() {
+	suite.totpServiceMock = mock_service.NewMockTotpService(gomock.NewController(suite.T()))
+	suite.con = controller.TestNewTotpController(suite.totpServiceMock)
+	suite.rec = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.rec)
+}
+
+func TestTotpControllerSuite(t *testing.T) {
+	suite.Run(t, new(TotpControllerTestSuite))
+}
+
+func (suite *TotpControllerTestSuite) TestSuccessEnroll() {
+	suite.totpServiceMock.EXPECT().Enroll(suite.ctx).Return("secret", "otpauth://totp/Todo:user", nil)
+	suite.con.Enroll(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), `"secret":"secret"`)
+	suite.Contains(suite.rec.Body.String(), `"uri":"otpauth://totp/Todo:user"`)
+}
+
+func (suite *TotpControllerTestSuite) TestBadEnrollWithAlreadyEnabled() {
+	suite.totpServiceMock.EXPECT().Enroll(suite.ctx).Return("", "", config.TotpAlreadyEnabledError)
+	suite.con.Enroll(suite.ctx)
+
+	suite.Equal(config.TotpAlreadyEnabledErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.TotpAlreadyEnabledErrorResponse.Json["content"])
+}
+
+func (suite *TotpControllerTestSuite) TestSuccessEnable() {
+	suite.totpServiceMock.EXPECT().Enable(suite.ctx).Return([]string{"code1", "code2"}, nil)
+	suite.con.Enable(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), `"recoveryCodes":["code1","code2"]`)
+}
+
+func (suite *TotpControllerTestSuite) TestBadEnableWithInvalidCode() {
+	suite.totpServiceMock.EXPECT().Enable(suite.ctx).Return(nil, config.InvalidTotpCodeError)
+	suite.con.Enable(suite.ctx)
+
+	suite.Equal(config.InvalidTotpCodeErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.InvalidTotpCodeErrorResponse.Json["content"])
+}
+
+func (suite *TotpControllerTestSuite) TestBadEnableWithTooManyAttempts() {
+	suite.totpServiceMock.EXPECT().Enable(suite.ctx).Return(nil, config.TooManyLoginAttemptsError)
+	suite.con.Enable(suite.ctx)
+
+	suite.Equal(config.TooManyLoginAttemptsErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *TotpControllerTestSuite) TestBadEnableWithValidationError() {
+	suite.totpServiceMock.EXPECT().Enable(suite.ctx).Return(nil, validator.ValidationErrors{})
+	suite.con.Enable(suite.ctx)
+
+	suite.Equal(config.ValidationErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *TotpControllerTestSuite) TestSuccessDisable() {
+	suite.totpServiceMock.EXPECT().Disable(suite.ctx).Return(nil)
+	suite.con.Disable(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+}
+
+func (suite *TotpControllerTestSuite) TestBadDisableWithNotEnrolled() {
+	suite.totpServiceMock.EXPECT().Disable(suite.ctx).Return(config.TotpNotEnrolledError)
+	suite.con.Disable(suite.ctx)
+
+	suite.Equal(config.TotpNotEnrolledErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *TotpControllerTestSuite) TestBadDisableWithWrongPassword() {
+	suite.totpServiceMock.EXPECT().Disable(suite.ctx).Return(config.PasswordAuthenticationError)
+	suite.con.Disable(suite.ctx)
+
+	suite.Equal(config.PasswordAuthenticationErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *TotpControllerTestSuite) TestBadDisableWithLockedAccount() {
+	suite.totpServiceMock.EXPECT().Disable(suite.ctx).Return(config.AccountLockedError)
+	suite.con.Disable(suite.ctx)
+
+	suite.Equal(config.AccountLockedErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *TotpControllerTestSuite) TestBadDisable() {
+	suite.totpServiceMock.EXPECT().Disable(suite.ctx).Return(errors.New("error"))
+	suite.con.Disable(suite.ctx)
+
+	suite.Equal(500, suite.rec.Code)
+}
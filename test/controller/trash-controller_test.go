@@ -0,0 +1,127 @@
+package controller_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/controller"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type TrashControllerTestSuite struct {
+	suite.Suite
+	con              controller.TrashController
+	ctx              *gin.Context
+	rec              *httptest.ResponseRecorder
+	trashServiceMock *mock_service.MockTrashService
+}
+
+func (suite *TrashControllerTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *TrashControllerTestSuite) SetupTest() {
+	suite.trashServiceMock = mock_service.NewMockTrashService(gomock.NewController(suite.T()))
+	suite.con = controller.TestNewTrashController(suite.trashServiceMock)
+	suite.rec = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.rec)
+}
+
+func TestTrashControllerSuite(t *testing.T) {
+	suite.Run(t, new(TrashControllerTestSuite))
+}
+
+func (suite *TrashControllerTestSuite) TestSuccessIndex() {
+	archivedAt := time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)
+	lists := []model.List{{ID: 1, Title: "list", Index: 2, ArchivedAt: &archivedAt}}
+	cards := []model.Card{{ID: 2, Title: "card", ListID: 3, Model: gorm.Model{DeletedAt: gorm.DeletedAt{Time: archivedAt, Valid: true}}}}
+	suite.trashServiceMock.EXPECT().Index(suite.ctx).Return(lists, cards, nil)
+	suite.con.Index(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	body := suite.rec.Body.String()
+	suite.Contains(body, `"lists":[{"archivedAt":"2022-04-01T00:00:00Z","boardId":0,"deletedAt":null,"id":1,"index":2,"title":"list"}]`)
+	suite.Contains(body, `"deletedAt":"2022-04-01T00:00:00Z"`)
+	suite.Contains(body, `"listId":3`)
+}
+
+func (suite *TrashControllerTestSuite) TestBadIndexWithError() {
+	suite.trashServiceMock.EXPECT().Index(suite.ctx).Return(nil, nil, errors.New("db error"))
+	suite.con.Index(suite.ctx)
+
+	suite.Equal(500, suite.rec.Code)
+}
+
+func (suite *TrashControllerTestSuite) TestSuccessRestoreList() {
+	suite.trashServiceMock.EXPECT().RestoreList(suite.ctx).Return(model.List{ID: 1, Title: "list"}, nil)
+	suite.con.RestoreList(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), `"title":"list"`)
+}
+
+func (suite *TrashControllerTestSuite) TestBadRestoreListWithRecordNotFound() {
+	suite.trashServiceMock.EXPECT().RestoreList(suite.ctx).Return(model.List{}, gorm.ErrRecordNotFound)
+	suite.con.RestoreList(suite.ctx)
+
+	suite.Equal(config.RecordNotFoundErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *TrashControllerTestSuite) TestBadRestoreListWithForbiddenError() {
+	suite.trashServiceMock.EXPECT().RestoreList(suite.ctx).Return(model.List{}, config.ForbiddenError)
+	suite.con.RestoreList(suite.ctx)
+
+	suite.Equal(config.ForbiddenErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *TrashControllerTestSuite) TestSuccessRestoreCard() {
+	suite.trashServiceMock.EXPECT().RestoreCard(suite.ctx).Return(model.Card{ID: 1, Title: "card"}, nil)
+	suite.con.RestoreCard(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), `"title":"card"`)
+}
+
+func (suite *TrashControllerTestSuite) TestBadRestoreCardWithListInTrashError() {
+	suite.trashServiceMock.EXPECT().RestoreCard(suite.ctx).Return(model.Card{}, config.ListInTrashError)
+	suite.con.RestoreCard(suite.ctx)
+
+	suite.Equal(config.ListInTrashErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.ListInTrashErrorResponse.Json["content"])
+}
+
+func (suite *TrashControllerTestSuite) TestSuccessDestroyList() {
+	suite.trashServiceMock.EXPECT().DestroyList(suite.ctx).Return(nil)
+	suite.con.DestroyList(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+}
+
+func (suite *TrashControllerTestSuite) TestBadDestroyListWithOtherError() {
+	suite.trashServiceMock.EXPECT().DestroyList(suite.ctx).Return(errors.New("storage error"))
+	suite.con.DestroyList(suite.ctx)
+
+	suite.Equal(500, suite.rec.Code)
+}
+
+func (suite *TrashControllerTestSuite) TestSuccessDestroyCard() {
+	suite.trashServiceMock.EXPECT().DestroyCard(suite.ctx).Return(nil)
+	suite.con.DestroyCard(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+}
+
+func (suite *TrashControllerTestSuite) TestBadDestroyCardWithRecordNotFound() {
+	suite.trashServiceMock.EXPECT().DestroyCard(suite.ctx).Return(gorm.ErrRecordNotFound)
+	suite.con.DestroyCard(suite.ctx)
+
+	suite.Equal(config.RecordNotFoundErrorResponse.Code, suite.rec.Code)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/kuritaeiji/todo-gin-back/controller"
 	"github.com/kuritaeiji/todo-gin-back/mock_service"
 	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
 )
@@ -144,3 +145,78 @@ func (suite *UserControllerTestSuite) TestBadDestroyWithError() {
 	suite.controller.Destroy(suite.ctx)
 	suite.Equal(500, suite.rec.Code)
 }
+
+func (suite *UserControllerTestSuite) TestSuccessChangePassword() {
+	tokenPair := service.TokenPair{AccessToken: "accessToken", RefreshToken: "refreshToken"}
+	suite.userServiceMock.EXPECT().ChangePassword(suite.ctx).Return(tokenPair, nil)
+	suite.controller.ChangePassword(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), tokenPair.AccessToken)
+}
+
+func (suite *UserControllerTestSuite) TestBadChangePasswordWithPasswordAuthenticationError() {
+	suite.userServiceMock.EXPECT().ChangePassword(suite.ctx).Return(service.TokenPair{}, config.PasswordAuthenticationError)
+	suite.controller.ChangePassword(suite.ctx)
+
+	suite.Equal(config.PasswordAuthenticationErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.PasswordAuthenticationErrorResponse.Json["content"])
+}
+
+func (suite *UserControllerTestSuite) TestBadChangePasswordWithValidationError() {
+	suite.userServiceMock.EXPECT().ChangePassword(suite.ctx).Return(service.TokenPair{}, validator.ValidationErrors{})
+	suite.controller.ChangePassword(suite.ctx)
+
+	suite.Equal(config.ValidationErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *UserControllerTestSuite) TestSuccessChangeEmail() {
+	suite.userServiceMock.EXPECT().ChangeEmail(suite.ctx).Return(nil)
+	suite.controller.ChangeEmail(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+}
+
+func (suite *UserControllerTestSuite) TestBadChangeEmailWithUniqueUserError() {
+	suite.userServiceMock.EXPECT().ChangeEmail(suite.ctx).Return(config.UniqueUserError)
+	suite.controller.ChangeEmail(suite.ctx)
+
+	suite.Equal(config.UniqueUserErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.UniqueUserErrorResponse.Json["content"])
+}
+
+func (suite *UserControllerTestSuite) TestBadChangeEmailWithEmailClientError() {
+	suite.userServiceMock.EXPECT().ChangeEmail(suite.ctx).Return(config.EmailClientError)
+	suite.controller.ChangeEmail(suite.ctx)
+
+	suite.Equal(config.EmailClientErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *UserControllerTestSuite) TestSuccessConfirmEmail() {
+	suite.userServiceMock.EXPECT().ConfirmEmail(suite.ctx).Return(nil)
+	suite.controller.ConfirmEmail(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+}
+
+func (suite *UserControllerTestSuite) TestBadConfirmEmailWithInvalidToken() {
+	suite.userServiceMock.EXPECT().ConfirmEmail(suite.ctx).Return(config.InvalidEmailChangeTokenError)
+	suite.controller.ConfirmEmail(suite.ctx)
+
+	suite.Equal(config.InvalidEmailChangeTokenErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.InvalidEmailChangeTokenErrorResponse.Json["content"])
+}
+
+func (suite *UserControllerTestSuite) TestSuccessResendActivation() {
+	suite.userServiceMock.EXPECT().ResendActivation(suite.ctx).Return(nil)
+	suite.controller.ResendActivation(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+}
+
+func (suite *UserControllerTestSuite) TestBadResendActivationWithValidationError() {
+	suite.userServiceMock.EXPECT().ResendActivation(suite.ctx).Return(validator.ValidationErrors{})
+	suite.controller.ResendActivation(suite.ctx)
+
+	suite.Equal(config.ValidationErrorResponse.Code, suite.rec.Code)
+}
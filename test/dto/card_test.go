@@ -4,6 +4,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -64,6 +65,28 @@ func (suite *CardDtoTestSuite) TestBadValidationWithTitleMax100() {
 	suite.Equal("max", verr[0].Tag())
 }
 
+func (suite *CardDtoTestSuite) TestBadValidationWithDescriptionMax10000() {
+	cardConfig := &factory.CardConfig{Description: strings.Repeat("あ", 10001)}
+	req := httptest.NewRequest("POST", "/", factory.CreateCardRequestBody(cardConfig))
+	suite.ctx.Request = req
+	err := suite.ctx.ShouldBindJSON(suite.dto)
+
+	verr, _ := err.(validator.ValidationErrors)
+	suite.Equal("Description", verr[0].Field())
+	suite.Equal("max", verr[0].Tag())
+}
+
+func (suite *CardDtoTestSuite) TestBadValidationWithInvalidTimezone() {
+	cardConfig := &factory.CardConfig{Timezone: "Mars/Olympus"}
+	req := httptest.NewRequest("POST", "/", factory.CreateCardRequestBody(cardConfig))
+	suite.ctx.Request = req
+	err := suite.ctx.ShouldBindJSON(suite.dto)
+
+	verr, _ := err.(validator.ValidationErrors)
+	suite.Equal("Timezone", verr[0].Field())
+	suite.Equal("timezone", verr[0].Tag())
+}
+
 func (suite *CardDtoTestSuite) TestBadValidationWithIndexGTE0() {
 	req := httptest.NewRequest("POST", "/", factory.CreateCardRequestBody(&factory.CardConfig{Index: -1}))
 	suite.ctx.Request = req
@@ -75,10 +98,24 @@ func (suite *CardDtoTestSuite) TestBadValidationWithIndexGTE0() {
 }
 
 func (suite *CardDtoTestSuite) TestTransferMethod() {
-	dto := factory.NewDtoCard(&factory.CardConfig{})
+	dto := factory.NewDtoCard(&factory.CardConfig{Description: "**description**"})
 	var card model.Card
 	dto.Transfer(&card)
 
 	suite.Equal(dto.Title, card.Title)
+	suite.Equal(dto.Description, card.Description)
 	suite.Equal(dto.Index, card.Index)
 }
+
+func (suite *CardDtoTestSuite) TestTransferMethodConvertsToUTC() {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	dueAt := time.Date(2022, 1, 2, 9, 0, 0, 0, tokyo)
+	dto := factory.NewDtoCard(&factory.CardConfig{DueAt: &dueAt, Timezone: "Asia/Tokyo"})
+	var card model.Card
+	dto.Transfer(&card)
+
+	suite.Equal(time.UTC, card.DueAt.Location())
+	suite.True(dueAt.Equal(*card.DueAt))
+	suite.Nil(card.StartAt)
+	suite.Equal("Asia/Tokyo", card.Timezone)
+}
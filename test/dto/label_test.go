@@ -0,0 +1,67 @@
+package dto_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/kuritaeiji/todo-gin-back/dto"
+	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/validators"
+	"github.com/stretchr/testify/suite"
+)
+
+type LabelDtoTestSuite struct {
+	suite.Suite
+	dto dto.Label
+	ctx *gin.Context
+}
+
+func (suite *LabelDtoTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	validators.Init()
+}
+
+func (suite *LabelDtoTestSuite) SetupTest() {
+	suite.dto = dto.Label{}
+	suite.ctx, _ = gin.CreateTestContext(httptest.NewRecorder())
+}
+
+func TestLabelDto(t *testing.T) {
+	suite.Run(t, new(LabelDtoTestSuite))
+}
+
+func (suite *LabelDtoTestSuite) bind(labelConfig *factory.LabelConfig) validator.ValidationErrors {
+	suite.ctx.Request = httptest.NewRequest("POST", "/api/labels", factory.CreateLabelRequestBody(labelConfig))
+	err := suite.ctx.ShouldBindJSON(&suite.dto)
+	verr, _ := err.(validator.ValidationErrors)
+	return verr
+}
+
+func (suite *LabelDtoTestSuite) TestSuccessValidation() {
+	suite.Nil(suite.bind(&factory.LabelConfig{}))
+	suite.Nil(suite.bind(&factory.LabelConfig{Color: "#abc"}))
+}
+
+func (suite *LabelDtoTestSuite) TestBadValidationWithNameMax50() {
+	verr := suite.bind(&factory.LabelConfig{Name: strings.Repeat("a", 51)})
+
+	suite.Equal("max", verr[0].Tag())
+	suite.Equal("Name", verr[0].Field())
+}
+
+func (suite *LabelDtoTestSuite) TestBadValidationWithColor() {
+	verr := suite.bind(&factory.LabelConfig{Color: "red"})
+
+	suite.Equal("hexcolor", verr[0].Tag())
+	suite.Equal("Color", verr[0].Field())
+}
+
+func (suite *LabelDtoTestSuite) TestBadValidationWithRequired() {
+	verr := suite.bind(&factory.LabelConfig{NotUseDefaultValue: true})
+
+	suite.Len(verr, 2)
+	suite.Equal("required", verr[0].Tag())
+}
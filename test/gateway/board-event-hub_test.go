@@ -0,0 +1,161 @@
+package gateway_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kuritaeiji/todo-gin-back/gateway"
+	"github.com/stretchr/testify/suite"
+)
+
+type BoardEventHubTestSuite struct {
+	suite.Suite
+	hub gateway.BoardEventHub
+}
+
+func (suite *BoardEventHubTestSuite) SetupTest() {
+	suite.hub = gateway.NewMemoryBoardEventHub()
+}
+
+func TestBoardEventHub(t *testing.T) {
+	suite.Run(t, new(BoardEventHubTestSuite))
+}
+
+func (suite *BoardEventHubTestSuite) TestPublishToSubscribersOfBoard() {
+	subscription, err := suite.hub.Subscribe(1, 1, 0)
+	suite.Nil(err)
+	defer subscription.Close()
+	otherSubscription, _ := suite.hub.Subscribe(2, 1, 0)
+	defer otherSubscription.Close()
+
+	suite.hub.Publish(1, "list.created", map[string]int{"id": 1})
+	event := <-subscription.Events
+
+	suite.Equal(int64(1), event.ID)
+	suite.Equal(1, event.BoardID)
+	suite.Equal("list.created", event.Type)
+	suite.Len(otherSubscription.Events, 0)
+}
+
+func (suite *BoardEventHubTestSuite) TestSubscribeWithLastEventID() {
+	suite.hub.Publish(1, "list.created", nil)
+	suite.hub.Publish(2, "list.created", nil)
+	suite.hub.Publish(1, "list.updated", nil)
+	suite.hub.Publish(1, "list.moved", nil)
+	subscription, _ := suite.hub.Subscribe(1, 1, 1)
+	defer subscription.Close()
+
+	suite.False(subscription.Gap)
+	suite.Len(subscription.Missed, 2)
+	suite.Equal("list.updated", subscription.Missed[0].Type)
+	suite.Equal("list.moved", subscription.Missed[1].Type)
+}
+
+func (suite *BoardEventHubTestSuite) TestSubscribeWithoutLastEventID() {
+	suite.hub.Publish(1, "list.created", nil)
+	subscription, _ := suite.hub.Subscribe(1, 1, 0)
+	defer subscription.Close()
+
+	suite.False(subscription.Gap)
+	suite.Len(subscription.Missed, 0)
+}
+
+func (suite *BoardEventHubTestSuite) TestSubscribeWithEvictedLastEventID() {
+	for i := 0; i < 101; i++ {
+		suite.hub.Publish(1, "card.updated", nil)
+	}
+	subscription, _ := suite.hub.Subscribe(1, 1, 1)
+	defer subscription.Close()
+
+	suite.False(subscription.Gap)
+	suite.Len(subscription.Missed, 100)
+
+	suite.hub.Publish(1, "card.updated", nil)
+	gapSubscription, _ := suite.hub.Subscribe(1, 1, 1)
+	defer gapSubscription.Close()
+
+	suite.True(gapSubscription.Gap)
+}
+
+// 再起動でidが巻き戻った場合
+func (suite *BoardEventHubTestSuite) TestSubscribeWithUnknownLastEventID() {
+	subscription, _ := suite.hub.Subscribe(1, 1, 10)
+	defer subscription.Close()
+
+	suite.True(subscription.Gap)
+}
+
+func (suite *BoardEventHubTestSuite) TestCloseSlowSubscriber() {
+	subscription, _ := suite.hub.Subscribe(1, 1, 0)
+	defer subscription.Close()
+	for i := 0; i < 17; i++ {
+		suite.hub.Publish(1, "card.updated", nil)
+	}
+
+	count := 0
+	for range subscription.Events {
+		count++
+	}
+	suite.Equal(16, count)
+}
+
+func (suite *BoardEventHubTestSuite) TestClose() {
+	subscription, _ := suite.hub.Subscribe(1, 1, 0)
+	subscription.Close()
+	subscription.Close()
+	suite.hub.Publish(1, "card.updated", nil)
+
+	_, ok := <-subscription.Events
+	suite.False(ok)
+}
+
+func (suite *BoardEventHubTestSuite) TestCloseUser() {
+	subscription, _ := suite.hub.Subscribe(1, 1, 0)
+	defer subscription.Close()
+	otherSubscription, _ := suite.hub.Subscribe(1, 2, 0)
+	defer otherSubscription.Close()
+	suite.hub.CloseUser(1, 1)
+	suite.hub.Publish(1, "card.updated", nil)
+
+	_, ok := <-subscription.Events
+	suite.False(ok)
+	event := <-otherSubscription.Events
+	suite.Equal("card.updated", event.Type)
+}
+
+// 購読者がいないボードはTTLを過ぎると保持しているイベントを捨てるので、再接続時は取りこぼしとして扱う
+func (suite *BoardEventHubTestSuite) TestPruneBoardWithoutSubscribers() {
+	suite.hub = gateway.TestNewMemoryBoardEventHub(time.Millisecond, 1<<20)
+	suite.hub.Publish(1, "list.created", nil)
+	suite.hub.Publish(1, "list.updated", nil)
+	subscribed, _ := suite.hub.Subscribe(2, 1, 0)
+	defer subscribed.Close()
+	time.Sleep(5 * time.Millisecond)
+	suite.hub.Publish(3, "list.created", nil)
+
+	subscription, _ := suite.hub.Subscribe(1, 1, 1)
+	defer subscription.Close()
+	suite.True(subscription.Gap)
+	suite.Len(subscription.Missed, 0)
+}
+
+func (suite *BoardEventHubTestSuite) TestEvictOldestEventsOverMaxBytes() {
+	data := strings.Repeat("a", 100)
+	suite.hub = gateway.TestNewMemoryBoardEventHub(time.Hour, 250)
+	suite.hub.Publish(1, "card.updated", data)
+	suite.hub.Publish(1, "card.updated", data)
+	suite.hub.Publish(2, "card.updated", data)
+	suite.hub.Publish(1, "card.updated", data)
+
+	// 全てのボードの中で古いイベントから捨てる
+	subscription, _ := suite.hub.Subscribe(1, 1, 1)
+	defer subscription.Close()
+	suite.True(subscription.Gap)
+	suite.Len(subscription.Missed, 1)
+	suite.Equal(int64(4), subscription.Missed[0].ID)
+	otherSubscription, _ := suite.hub.Subscribe(2, 1, 2)
+	defer otherSubscription.Close()
+	suite.False(otherSubscription.Gap)
+	suite.Len(otherSubscription.Missed, 1)
+}
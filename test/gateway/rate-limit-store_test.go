@@ -0,0 +1,69 @@
+package gateway_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/gateway"
+	"github.com/stretchr/testify/suite"
+)
+
+type RateLimitStoreTestSuite struct {
+	suite.Suite
+	store gateway.RateLimitStore
+	now   time.Time
+}
+
+func (suite *RateLimitStoreTestSuite) SetupTest() {
+	suite.now = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	suite.store = gateway.TestNewMemoryRateLimitStore(func() time.Time { return suite.now })
+}
+
+func TestRateLimitStore(t *testing.T) {
+	suite.Run(t, new(RateLimitStoreTestSuite))
+}
+
+func (suite *RateLimitStoreTestSuite) TestSuccessTake() {
+	result, err := suite.store.Take("key", 2, time.Minute)
+
+	suite.Nil(err)
+	suite.True(result.Allowed)
+	suite.Equal(1, result.Remaining)
+	suite.Equal(30*time.Second, result.ResetAfter)
+}
+
+func (suite *RateLimitStoreTestSuite) TestBadTakeWithEmptyBucket() {
+	suite.store.Take("key", 2, time.Minute)
+	suite.store.Take("key", 2, time.Minute)
+	result, err := suite.store.Take("key", 2, time.Minute)
+
+	suite.Nil(err)
+	suite.False(result.Allowed)
+	suite.Equal(0, result.Remaining)
+	suite.Equal(30*time.Second, result.RetryAfter)
+	suite.Equal(time.Minute, result.ResetAfter)
+}
+
+func (suite *RateLimitStoreTestSuite) TestSuccessTakeAfterRefill() {
+	suite.store.Take("key", 2, time.Minute)
+	suite.store.Take("key", 2, time.Minute)
+	suite.now = suite.now.Add(30 * time.Second)
+	result, _ := suite.store.Take("key", 2, time.Minute)
+
+	suite.True(result.Allowed)
+	suite.Equal(0, result.Remaining)
+}
+
+func (suite *RateLimitStoreTestSuite) TestSuccessTakeWithOtherKey() {
+	suite.store.Take("key", 1, time.Minute)
+	result, _ := suite.store.Take("other", 1, time.Minute)
+
+	suite.True(result.Allowed)
+}
+
+func (suite *RateLimitStoreTestSuite) TestBadTakeWithZeroLimit() {
+	_, err := suite.store.Take("key", 0, time.Minute)
+
+	suite.Equal(config.InvalidRateLimitError, err)
+}
@@ -0,0 +1,63 @@
+package gateway_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/gateway"
+	"github.com/stretchr/testify/suite"
+)
+
+type LocalStorageGatewayTestSuite struct {
+	suite.Suite
+	dir     string
+	storage gateway.StorageGateway
+}
+
+func (suite *LocalStorageGatewayTestSuite) SetupTest() {
+	suite.dir = suite.T().TempDir()
+	suite.storage = gateway.NewLocalStorageGateway(suite.dir)
+}
+
+func TestLocalStorageGateway(t *testing.T) {
+	suite.Run(t, new(LocalStorageGatewayTestSuite))
+}
+
+func (suite *LocalStorageGatewayTestSuite) TestSuccessPutAndGet() {
+	err := suite.storage.Put("attachments/1/key", strings.NewReader("content"))
+	suite.Nil(err)
+
+	body, err := suite.storage.Get("attachments/1/key")
+	suite.Nil(err)
+	defer body.Close()
+	content, _ := io.ReadAll(body)
+	suite.Equal("content", string(content))
+}
+
+func (suite *LocalStorageGatewayTestSuite) TestSuccessDelete() {
+	suite.storage.Put("attachments/1/key", strings.NewReader("content"))
+	err := suite.storage.Delete("attachments/1/key")
+	suite.Nil(err)
+
+	_, err = os.Stat(filepath.Join(suite.dir, "attachments", "1", "key"))
+	suite.True(os.IsNotExist(err))
+	suite.Nil(suite.storage.Delete("attachments/1/key"))
+}
+
+func (suite *LocalStorageGatewayTestSuite) TestBadGetWithNotFound() {
+	_, err := suite.storage.Get("attachments/1/unknown")
+
+	suite.Equal(config.StorageObjectNotFoundError, err)
+}
+
+func (suite *LocalStorageGatewayTestSuite) TestBadPutWithPathTraversal() {
+	err := suite.storage.Put("../outside", strings.NewReader("content"))
+
+	suite.NotNil(err)
+	_, statErr := os.Stat(filepath.Join(filepath.Dir(suite.dir), "outside"))
+	suite.True(os.IsNotExist(statErr))
+}
@@ -0,0 +1,35 @@
+package markdown_test
+
+import (
+	"testing"
+
+	"github.com/kuritaeiji/todo-gin-back/markdown"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	assert := assert.New(t)
+	tests := map[string]struct {
+		source      string
+		contains    []string
+		notContains []string
+	}{
+		"emphasis":   {source: "**bold** ~~del~~", contains: []string{"<strong>bold</strong>", "<del>del</del>"}},
+		"hardWrap":   {source: "line1\nline2", contains: []string{"line1<br>"}},
+		"taskList":   {source: "- [x] done", contains: []string{`<input checked="" disabled="" type="checkbox">`}},
+		"rawHTML":    {source: "<script>alert(1)</script><img src=x onerror=alert(1)>", notContains: []string{"<script", "onerror"}},
+		"jsLink":     {source: "[link](javascript:alert(1))", notContains: []string{"javascript:"}},
+		"externLink": {source: "[link](https://example.com)", contains: []string{`href="https://example.com"`, `target="_blank"`, "noopener"}},
+	}
+
+	for name, tt := range tests {
+		html, err := markdown.Render(tt.source)
+		assert.Nil(err, name)
+		for _, s := range tt.contains {
+			assert.Contains(html, s, name)
+		}
+		for _, s := range tt.notContains {
+			assert.NotContains(html, s, name)
+		}
+	}
+}
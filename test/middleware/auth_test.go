@@ -19,11 +19,13 @@ import (
 
 type AuthMiddlewareTestSuite struct {
 	suite.Suite
-	middleware         middleware.AuthMiddleware
-	jwtServiceMock     *mock_service.MockJWTService
-	userRepositoryMock *mock_repository.MockUserRepository
-	rec                *httptest.ResponseRecorder
-	ctx                *gin.Context
+	middleware                     middleware.AuthMiddleware
+	jwtServiceMock                 *mock_service.MockJWTService
+	personalAccessTokenServiceMock *mock_service.MockPersonalAccessTokenService
+	sessionServiceMock             *mock_service.MockSessionService
+	userRepositoryMock             *mock_repository.MockUserRepository
+	rec                            *httptest.ResponseRecorder
+	ctx                            *gin.Context
 }
 
 func (suite *AuthMiddlewareTestSuite) SetupSuite() {
@@ -33,7 +35,9 @@ func (suite *AuthMiddlewareTestSuite) SetupSuite() {
 func (suite *AuthMiddlewareTestSuite) SetupTest() {
 	suite.jwtServiceMock = mock_service.NewMockJWTService(gomock.NewController(suite.T()))
 	suite.userRepositoryMock = mock_repository.NewMockUserRepository(gomock.NewController(suite.T()))
-	suite.middleware = middleware.TestNewAuthMiddleware(suite.jwtServiceMock, suite.userRepositoryMock)
+	suite.personalAccessTokenServiceMock = mock_service.NewMockPersonalAccessTokenService(gomock.NewController(suite.T()))
+	suite.sessionServiceMock = mock_service.NewMockSessionService(gomock.NewController(suite.T()))
+	suite.middleware = middleware.TestNewAuthMiddleware(suite.jwtServiceMock, suite.personalAccessTokenServiceMock, suite.sessionServiceMock, suite.userRepositoryMock)
 	suite.rec = httptest.NewRecorder()
 	suite.ctx, _ = gin.CreateTestContext(suite.rec)
 }
@@ -45,11 +49,10 @@ func TestAuthMiddleware(t *testing.T) {
 func (suite *AuthMiddlewareTestSuite) TestSuccessAuth() {
 	var user model.User
 	accessToken := "token"
-	suite.jwtServiceMock.EXPECT().VerifyJWT(accessToken).Return(
-		&service.UserClaim{ID: user.ID, StandardClaims: jwt.StandardClaims{}},
-		nil,
-	)
+	claim := &service.UserClaim{ID: user.ID, Type: service.AccessTokenType, StandardClaims: jwt.StandardClaims{Id: "jti"}}
+	suite.jwtServiceMock.EXPECT().VerifyJWT(accessToken).Return(claim, nil)
 	suite.userRepositoryMock.EXPECT().Find(user.ID).Return(user, nil)
+	suite.sessionServiceMock.EXPECT().Touch(claim.SessionID, claim.Id, user).Return(nil)
 	req := httptest.NewRequest("POST", "/users", nil)
 	req.Header.Add(config.TokenHeader, accessToken)
 	suite.ctx.Request = req
@@ -57,6 +60,54 @@ func (suite *AuthMiddlewareTestSuite) TestSuccessAuth() {
 
 	currentUser := suite.ctx.MustGet("currentUser").(model.User)
 	suite.Equal(user, currentUser)
+	suite.Equal(claim, suite.ctx.MustGet(config.ClaimKey))
+}
+
+func (suite *AuthMiddlewareTestSuite) TestBadAuthWithRevokedJWT() {
+	accessToken := "token"
+	claim := &service.UserClaim{Type: service.AccessTokenType, StandardClaims: jwt.StandardClaims{Id: "jti"}}
+	suite.jwtServiceMock.EXPECT().VerifyJWT(accessToken).Return(claim, nil)
+	suite.userRepositoryMock.EXPECT().Find(claim.ID).Return(model.User{}, nil)
+	suite.sessionServiceMock.EXPECT().Touch(claim.SessionID, claim.Id, model.User{}).Return(config.SessionRevokedError)
+	req := httptest.NewRequest("POST", "/users", nil)
+	req.Header.Add(config.TokenHeader, accessToken)
+	suite.ctx.Request = req
+	suite.middleware.Auth(suite.ctx)
+
+	suite.Equal(config.NotLoggedInErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.NotLoggedInErrorResponse.Json["content"])
+}
+
+func (suite *AuthMiddlewareTestSuite) TestBadAuthWithRevokedSession() {
+	user := model.User{ID: 1}
+	accessToken := "token"
+	claim := &service.UserClaim{ID: user.ID, SessionID: "sid", Type: service.AccessTokenType, StandardClaims: jwt.StandardClaims{Id: "jti"}}
+	suite.jwtServiceMock.EXPECT().VerifyJWT(accessToken).Return(claim, nil)
+	suite.userRepositoryMock.EXPECT().Find(user.ID).Return(user, nil)
+	suite.sessionServiceMock.EXPECT().Touch(claim.SessionID, claim.Id, user).Return(config.SessionRevokedError)
+	req := httptest.NewRequest("POST", "/users", nil)
+	req.Header.Add(config.TokenHeader, accessToken)
+	suite.ctx.Request = req
+	suite.middleware.Auth(suite.ctx)
+
+	suite.True(suite.ctx.IsAborted())
+	suite.Equal(config.NotLoggedInErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.NotLoggedInErrorResponse.Json["content"])
+}
+
+func (suite *AuthMiddlewareTestSuite) TestBadAuthWithOldTokenVersion() {
+	user := model.User{ID: 1, TokenVersion: 1}
+	accessToken := "token"
+	claim := &service.UserClaim{ID: user.ID, Version: 0, Type: service.AccessTokenType, StandardClaims: jwt.StandardClaims{Id: "jti"}}
+	suite.jwtServiceMock.EXPECT().VerifyJWT(accessToken).Return(claim, nil)
+	suite.userRepositoryMock.EXPECT().Find(user.ID).Return(user, nil)
+	req := httptest.NewRequest("POST", "/users", nil)
+	req.Header.Add(config.TokenHeader, accessToken)
+	suite.ctx.Request = req
+	suite.middleware.Auth(suite.ctx)
+
+	suite.Equal(config.NotLoggedInErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.NotLoggedInErrorResponse.Json["content"])
 }
 
 func (suite *AuthMiddlewareTestSuite) TestBadAuthWithExpiredJWT() {
@@ -89,7 +140,7 @@ func (suite *AuthMiddlewareTestSuite) TestBadAuthWithJWTValidationError() {
 
 func (suite *AuthMiddlewareTestSuite) TestBadAuthWithNotRecordFound() {
 	accessToken := "token"
-	suite.jwtServiceMock.EXPECT().VerifyJWT(accessToken).Return(&service.UserClaim{}, nil)
+	suite.jwtServiceMock.EXPECT().VerifyJWT(accessToken).Return(&service.UserClaim{Type: service.AccessTokenType}, nil)
 	suite.userRepositoryMock.EXPECT().Find(0).Return(model.User{}, gorm.ErrRecordNotFound)
 	req := httptest.NewRequest("POST", "/users", nil)
 	req.Header.Add(config.TokenHeader, accessToken)
@@ -100,6 +151,84 @@ func (suite *AuthMiddlewareTestSuite) TestBadAuthWithNotRecordFound() {
 	suite.Contains(suite.rec.Body.String(), config.NotLoggedInErrorResponse.Json["content"])
 }
 
+func (suite *AuthMiddlewareTestSuite) TestBadAuthWithChallengeToken() {
+	accessToken := "token"
+	claim := &service.UserClaim{ID: 1, Type: service.ChallengeTokenType, StandardClaims: jwt.StandardClaims{Id: "jti"}}
+	suite.jwtServiceMock.EXPECT().VerifyJWT(accessToken).Return(claim, nil)
+	req := httptest.NewRequest("POST", "/users", nil)
+	req.Header.Add(config.TokenHeader, accessToken)
+	suite.ctx.Request = req
+	suite.middleware.Auth(suite.ctx)
+
+	suite.Equal(config.NotLoggedInErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.NotLoggedInErrorResponse.Json["content"])
+}
+
+func (suite *AuthMiddlewareTestSuite) TestBadAuthWithPersonalAccessToken() {
+	req := httptest.NewRequest("DELETE", "/users", nil)
+	req.Header.Add(config.TokenHeader, config.Bearer+model.PersonalAccessTokenPrefix+"token")
+	suite.ctx.Request = req
+	suite.middleware.Auth(suite.ctx)
+
+	suite.Equal(config.InsufficientScopeErrorResponse.Code, suite.rec.Code)
+	suite.True(suite.ctx.IsAborted())
+}
+
+func (suite *AuthMiddlewareTestSuite) TestSuccessScopeWithJWT() {
+	user := model.User{ID: 1}
+	accessToken := "token"
+	claim := &service.UserClaim{ID: user.ID, Type: service.AccessTokenType, StandardClaims: jwt.StandardClaims{Id: "jti"}}
+	suite.jwtServiceMock.EXPECT().VerifyJWT(accessToken).Return(claim, nil)
+	suite.userRepositoryMock.EXPECT().Find(user.ID).Return(user, nil)
+	suite.sessionServiceMock.EXPECT().Touch(claim.SessionID, claim.Id, user).Return(nil)
+	req := httptest.NewRequest("POST", "/lists", nil)
+	req.Header.Add(config.TokenHeader, accessToken)
+	suite.ctx.Request = req
+	suite.middleware.Scope(model.ScopeListsRead, model.ScopeListsWrite)(suite.ctx)
+
+	suite.False(suite.ctx.IsAborted())
+	suite.Equal(user, suite.ctx.MustGet(config.CurrentUserKey))
+}
+
+func (suite *AuthMiddlewareTestSuite) TestSuccessScopeWithPersonalAccessToken() {
+	tokenString := model.PersonalAccessTokenPrefix + "token"
+	token := model.PersonalAccessToken{ID: 1, Scopes: "lists:read", User: model.User{ID: 1}}
+	suite.personalAccessTokenServiceMock.EXPECT().Authenticate(tokenString).Return(token, nil)
+	req := httptest.NewRequest("GET", "/lists", nil)
+	req.Header.Add(config.TokenHeader, config.Bearer+tokenString)
+	suite.ctx.Request = req
+	suite.middleware.Scope(model.ScopeListsRead, model.ScopeListsWrite)(suite.ctx)
+
+	suite.False(suite.ctx.IsAborted())
+	suite.Equal(token.User, suite.ctx.MustGet(config.CurrentUserKey))
+	suite.Equal(token, suite.ctx.MustGet(config.PersonalAccessTokenKey))
+}
+
+func (suite *AuthMiddlewareTestSuite) TestBadScopeWithInsufficientScope() {
+	tokenString := model.PersonalAccessTokenPrefix + "token"
+	token := model.PersonalAccessToken{ID: 1, Scopes: "lists:read", User: model.User{ID: 1}}
+	suite.personalAccessTokenServiceMock.EXPECT().Authenticate(tokenString).Return(token, nil)
+	req := httptest.NewRequest("POST", "/lists", nil)
+	req.Header.Add(config.TokenHeader, config.Bearer+tokenString)
+	suite.ctx.Request = req
+	suite.middleware.Scope(model.ScopeListsRead, model.ScopeListsWrite)(suite.ctx)
+
+	suite.Equal(config.InsufficientScopeErrorResponse.Code, suite.rec.Code)
+	suite.True(suite.ctx.IsAborted())
+}
+
+func (suite *AuthMiddlewareTestSuite) TestBadScopeWithInvalidPersonalAccessToken() {
+	tokenString := model.PersonalAccessTokenPrefix + "token"
+	suite.personalAccessTokenServiceMock.EXPECT().Authenticate(tokenString).Return(model.PersonalAccessToken{}, config.InvalidPersonalAccessTokenError)
+	req := httptest.NewRequest("GET", "/lists", nil)
+	req.Header.Add(config.TokenHeader, config.Bearer+tokenString)
+	suite.ctx.Request = req
+	suite.middleware.Scope(model.ScopeListsRead, model.ScopeListsWrite)(suite.ctx)
+
+	suite.Equal(config.NotLoggedInErrorResponse.Code, suite.rec.Code)
+	suite.True(suite.ctx.IsAborted())
+}
+
 func (suite *AuthMiddlewareTestSuite) TestSuccessGuest() {
 	req := httptest.NewRequest("POST", "/users", nil)
 	suite.ctx.Request = req
@@ -117,3 +246,19 @@ func (suite *AuthMiddlewareTestSuite) TestBadGuestWithLoggedIn() {
 	suite.Equal(config.GuestErrorResponse.Code, suite.rec.Code)
 	suite.Contains(suite.rec.Body.String(), config.GuestErrorResponse.Json["content"])
 }
+
+func (suite *AuthMiddlewareTestSuite) TestSuccessAdmin() {
+	suite.ctx.Set(config.CurrentUserKey, model.User{ID: 1, Admin: true})
+	suite.middleware.Admin(suite.ctx)
+
+	suite.Equal(200, suite.rec.Code)
+	suite.False(suite.ctx.IsAborted())
+}
+
+func (suite *AuthMiddlewareTestSuite) TestBadAdminWithNotAdmin() {
+	suite.ctx.Set(config.CurrentUserKey, model.User{ID: 1})
+	suite.middleware.Admin(suite.ctx)
+
+	suite.Equal(config.ForbiddenErrorResponse.Code, suite.rec.Code)
+	suite.True(suite.ctx.IsAborted())
+}
@@ -0,0 +1,82 @@
+package middleware_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/middleware"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type BoardMiddlewareTestSuite struct {
+	suite.Suite
+	middleware                 middleware.BoardMiddleware
+	boardMiddlewareServiceMock *mock_service.MockBoardMiddlewareService
+	rec                        *httptest.ResponseRecorder
+	ctx                        *gin.Context
+}
+
+func (suite *BoardMiddlewareTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *BoardMiddlewareTestSuite) SetupTest() {
+	suite.boardMiddlewareServiceMock = mock_service.NewMockBoardMiddlewareService(gomock.NewController(suite.T()))
+	suite.middleware = middleware.TestNewBoardMiddleware(suite.boardMiddlewareServiceMock)
+	suite.rec = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.rec)
+}
+
+func TestBoardMiddleware(t *testing.T) {
+	suite.Run(t, new(BoardMiddlewareTestSuite))
+}
+
+func (suite *BoardMiddlewareTestSuite) TestSuccessAuthorize() {
+	board := factory.NewBoard(&factory.BoardConfig{})
+	suite.boardMiddlewareServiceMock.EXPECT().Authorize(suite.ctx).Return(board, nil)
+	suite.middleware.Authorize(suite.ctx)
+
+	rBoard := suite.ctx.MustGet(config.BoardKey).(model.Board)
+	suite.Equal(board, rBoard)
+}
+
+func (suite *BoardMiddlewareTestSuite) TestBadAuthorize() {
+	suite.boardMiddlewareServiceMock.EXPECT().Authorize(suite.ctx).Return(model.Board{}, gorm.ErrRecordNotFound)
+	suite.middleware.Authorize(suite.ctx)
+
+	suite.Equal(config.RecordNotFoundErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.RecordNotFoundErrorResponse.Json["content"])
+}
+
+func (suite *BoardMiddlewareTestSuite) TestBadAuthorizeWithForbiddenError() {
+	suite.boardMiddlewareServiceMock.EXPECT().Authorize(suite.ctx).Return(model.Board{}, config.ForbiddenError)
+	suite.middleware.Authorize(suite.ctx)
+
+	suite.Equal(config.ForbiddenErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.ForbiddenErrorResponse.Json["content"])
+}
+
+func (suite *BoardMiddlewareTestSuite) TestSuccessAuthorizeOwner() {
+	board := factory.NewBoard(&factory.BoardConfig{})
+	suite.boardMiddlewareServiceMock.EXPECT().AuthorizeOwner(suite.ctx).Return(board, nil)
+	suite.middleware.AuthorizeOwner(suite.ctx)
+
+	rBoard := suite.ctx.MustGet(config.BoardKey).(model.Board)
+	suite.Equal(board, rBoard)
+}
+
+func (suite *BoardMiddlewareTestSuite) TestBadAuthorizeOwnerWithForbiddenError() {
+	suite.boardMiddlewareServiceMock.EXPECT().AuthorizeOwner(suite.ctx).Return(model.Board{}, config.ForbiddenError)
+	suite.middleware.AuthorizeOwner(suite.ctx)
+
+	suite.Equal(config.ForbiddenErrorResponse.Code, suite.rec.Code)
+	_, exists := suite.ctx.Get(config.BoardKey)
+	suite.False(exists)
+}
@@ -0,0 +1,101 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/gateway"
+	"github.com/kuritaeiji/todo-gin-back/middleware"
+	"github.com/kuritaeiji/todo-gin-back/mock_gateway"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type RateLimitMiddlewareTestSuite struct {
+	suite.Suite
+	middleware         middleware.RateLimitMiddleware
+	rateLimitStoreMock *mock_gateway.MockRateLimitStore
+	policy             middleware.RateLimitPolicy
+	rec                *httptest.ResponseRecorder
+	ctx                *gin.Context
+}
+
+func (suite *RateLimitMiddlewareTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *RateLimitMiddlewareTestSuite) SetupTest() {
+	suite.rateLimitStoreMock = mock_gateway.NewMockRateLimitStore(gomock.NewController(suite.T()))
+	suite.policy = middleware.RateLimitPolicy{Name: "test", Limit: 10, Period: time.Minute, Key: middleware.UserRateLimitKey}
+	suite.middleware = middleware.NewRateLimitMiddleware(suite.policy, suite.rateLimitStoreMock)
+	suite.rec = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.rec)
+	suite.ctx.Request = httptest.NewRequest("GET", "/api/lists", nil)
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	suite.Run(t, new(RateLimitMiddlewareTestSuite))
+}
+
+func (suite *RateLimitMiddlewareTestSuite) TestSuccessLimit() {
+	suite.ctx.Set(config.CurrentUserKey, model.User{ID: 1})
+	result := gateway.RateLimitResult{Allowed: true, Remaining: 9, ResetAfter: 6 * time.Second}
+	suite.rateLimitStoreMock.EXPECT().Take("test:user:1", suite.policy.Limit, suite.policy.Period).Return(result, nil)
+	suite.middleware.Limit(suite.ctx)
+
+	suite.False(suite.ctx.IsAborted())
+	suite.Equal("10", suite.rec.Header().Get("RateLimit-Limit"))
+	suite.Equal("9", suite.rec.Header().Get("RateLimit-Remaining"))
+	suite.Equal("6", suite.rec.Header().Get("RateLimit-Reset"))
+	suite.Empty(suite.rec.Header().Get("Retry-After"))
+}
+
+func (suite *RateLimitMiddlewareTestSuite) TestSuccessLimitWithIPKey() {
+	suite.rateLimitStoreMock.EXPECT().Take("test:ip:192.0.2.1", suite.policy.Limit, suite.policy.Period).Return(gateway.RateLimitResult{Allowed: true}, nil)
+	suite.middleware.Limit(suite.ctx)
+
+	suite.False(suite.ctx.IsAborted())
+}
+
+func (suite *RateLimitMiddlewareTestSuite) TestBadLimitWithExceeded() {
+	suite.ctx.Set(config.CurrentUserKey, model.User{ID: 1})
+	result := gateway.RateLimitResult{Allowed: false, Remaining: 0, ResetAfter: time.Minute, RetryAfter: 1500 * time.Millisecond}
+	suite.rateLimitStoreMock.EXPECT().Take("test:user:1", suite.policy.Limit, suite.policy.Period).Return(result, nil)
+	suite.middleware.Limit(suite.ctx)
+
+	suite.True(suite.ctx.IsAborted())
+	suite.Equal(config.RateLimitErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.RateLimitErrorResponse.Json["content"])
+	suite.Equal("0", suite.rec.Header().Get("RateLimit-Remaining"))
+	suite.Equal("2", suite.rec.Header().Get("Retry-After"))
+}
+
+func (suite *RateLimitMiddlewareTestSuite) TestSuccessLimitWithStoreError() {
+	suite.rateLimitStoreMock.EXPECT().Take(gomock.Any(), gomock.Any(), gomock.Any()).Return(gateway.RateLimitResult{}, errors.New("error"))
+	suite.middleware.Limit(suite.ctx)
+
+	suite.False(suite.ctx.IsAborted())
+}
+
+func (suite *RateLimitMiddlewareTestSuite) TestSuccessLimitWithEnvLimit() {
+	suite.T().Setenv("RATE_LIMIT_TEST", "5")
+	m := middleware.NewRateLimitMiddleware(suite.policy, suite.rateLimitStoreMock)
+	suite.rateLimitStoreMock.EXPECT().Take(gomock.Any(), 5, suite.policy.Period).Return(gateway.RateLimitResult{Allowed: true}, nil)
+	m.Limit(suite.ctx)
+
+	suite.False(suite.ctx.IsAborted())
+}
+
+func (suite *RateLimitMiddlewareTestSuite) TestSuccessLimitWithZeroEnvLimit() {
+	suite.T().Setenv("RATE_LIMIT_TEST", "0")
+	m := middleware.NewRateLimitMiddleware(suite.policy, suite.rateLimitStoreMock)
+	suite.rateLimitStoreMock.EXPECT().Take(gomock.Any(), suite.policy.Limit, suite.policy.Period).Return(gateway.RateLimitResult{Allowed: true}, nil)
+	m.Limit(suite.ctx)
+
+	suite.False(suite.ctx.IsAborted())
+}
@@ -0,0 +1,56 @@
+package model_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type BoardMemberModelTestSuite struct {
+	suite.Suite
+}
+
+func (suite *BoardMemberModelTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func TestBoardMemberModel(t *testing.T) {
+	suite.Run(t, new(BoardMemberModelTestSuite))
+}
+
+func (suite *BoardMemberModelTestSuite) TestCan() {
+	now := time.Now()
+	owner := model.BoardMember{Role: model.BoardRoleOwner, AcceptedAt: &now}
+	editor := model.BoardMember{Role: model.BoardRoleEditor, AcceptedAt: &now}
+	viewer := model.BoardMember{Role: model.BoardRoleViewer, AcceptedAt: &now}
+
+	suite.True(owner.Can(model.BoardRoleOwner))
+	suite.True(owner.Can(model.BoardRoleViewer))
+	suite.False(editor.Can(model.BoardRoleOwner))
+	suite.True(editor.Can(model.BoardRoleEditor))
+	suite.False(viewer.Can(model.BoardRoleEditor))
+	suite.True(viewer.Can(model.BoardRoleViewer))
+}
+
+func (suite *BoardMemberModelTestSuite) TestCanNotWithoutAccepting() {
+	member := model.BoardMember{Role: model.BoardRoleOwner}
+
+	suite.False(member.Can(model.BoardRoleViewer))
+}
+
+func (suite *BoardMemberModelTestSuite) TestRequiredBoardRole() {
+	suite.Equal(model.BoardRoleViewer, model.RequiredBoardRole(http.MethodGet))
+	suite.Equal(model.BoardRoleEditor, model.RequiredBoardRole(http.MethodPost))
+	suite.Equal(model.BoardRoleEditor, model.RequiredBoardRole(http.MethodPut))
+	suite.Equal(model.BoardRoleEditor, model.RequiredBoardRole(http.MethodDelete))
+}
+
+func (suite *BoardMemberModelTestSuite) TestToJson() {
+	member := model.BoardMember{ID: 1, UserID: 2, User: model.User{Email: "user@example.com"}, Role: model.BoardRoleEditor}
+
+	suite.Equal(gin.H{"id": 1, "userId": 2, "email": "user@example.com", "role": model.BoardRoleEditor, "accepted": false}, member.ToJson())
+}
@@ -25,7 +25,35 @@ func (suite *CardModelTestSuite) TestToJson() {
 	card := factory.NewCard(&factory.CardConfig{})
 	cardJson := card.ToJson()
 
-	suite.Equal(gin.H{"id": card.ID, "title": card.Title}, cardJson)
+	suite.Equal(gin.H{"id": card.ID, "title": card.Title, "description": "", "descriptionHtml": "", "startAt": card.StartAt, "dueAt": card.DueAt, "timezone": "", "labels": []gin.H{}, "checklistItems": gin.H{"done": 0, "total": 0}, "commentCount": 0}, cardJson)
+}
+
+func (suite *CardModelTestSuite) TestChecklistProgress() {
+	card := factory.NewCard(&factory.CardConfig{})
+	card.Checklists = []model.Checklist{
+		{Items: []model.ChecklistItem{{Done: true}, {Done: false}}},
+		{Items: []model.ChecklistItem{{Done: true}}},
+	}
+
+	suite.Equal(gin.H{"done": 2, "total": 3}, card.ToJson()["checklistItems"])
+}
+
+func (suite *CardModelTestSuite) TestToJsonWithLabels() {
+	card := factory.NewCard(&factory.CardConfig{})
+	card.Labels = []model.Label{{ID: 1, Name: "bug", Color: "#ff0000"}}
+	cardJson := card.ToJson()
+
+	suite.Equal([]gin.H{{"id": 1, "name": "bug", "color": "#ff0000"}}, cardJson["labels"])
+}
+
+func (suite *CardModelTestSuite) TestToJsonWithDescription() {
+	card := factory.NewCard(&factory.CardConfig{Description: "**bold**\n\n<script>alert(1)</script>\n\n[link](javascript:alert(1))"})
+	cardJson := card.ToJson()
+
+	suite.Equal(card.Description, cardJson["description"])
+	suite.Contains(cardJson["descriptionHtml"], "<strong>bold</strong>")
+	suite.NotContains(cardJson["descriptionHtml"], "<script>")
+	suite.NotContains(cardJson["descriptionHtml"], "javascript:")
 }
 
 func (suite *CardModelTestSuite) TestToJsonCardSlice() {
@@ -35,5 +63,5 @@ func (suite *CardModelTestSuite) TestToJsonCardSlice() {
 	}
 	cardsJson := model.ToJsonCardSlice(cards)
 
-	suite.Equal([]gin.H{{"id": cards[0].ID, "title": cards[0].Title}, {"id": cards[1].ID, "title": cards[1].Title}}, cardsJson)
+	suite.Equal([]gin.H{cards[0].ToJson(), cards[1].ToJson()}, cardsJson)
 }
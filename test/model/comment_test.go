@@ -0,0 +1,36 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommentModelTestSuite struct {
+	suite.Suite
+}
+
+func TestCommentModel(t *testing.T) {
+	suite.Run(t, new(CommentModelTestSuite))
+}
+
+func (suite *CommentModelTestSuite) TestToJson() {
+	editedAt := time.Now()
+	comment := model.Comment{ID: 1, Body: "**bold**", EditedAt: &editedAt, User: model.User{ID: 2, Email: "user@example.com", PasswordDigest: "digest"}}
+	commentJson := comment.ToJson()
+
+	suite.Equal(1, commentJson["id"])
+	suite.Equal("**bold**", commentJson["body"])
+	suite.Contains(commentJson["bodyHtml"], "<strong>bold</strong>")
+	suite.Equal(gin.H{"id": 2, "email": "user@example.com"}, commentJson["author"])
+	suite.Equal(&editedAt, commentJson["editedAt"])
+}
+
+func (suite *CommentModelTestSuite) TestToJsonWithUnsafeBody() {
+	comment := model.Comment{Body: "<script>alert(1)</script>"}
+
+	suite.NotContains(comment.ToJson()["bodyHtml"], "<script>")
+}
@@ -33,19 +33,3 @@ func (suite *UserModelTestSuite) TestSuccessAuthenticate() {
 func (suite *UserModelTestSuite) TestBadAuthenticate() {
 	suite.False(suite.model.Authenticate("invalid password"))
 }
-
-func (suite *UserModelTestSuite) TestTrueHasList() {
-	var user model.User
-	var list model.List
-	list.UserID = user.ID
-
-	suite.True(user.HasList(list))
-}
-
-func (suite *UserModelTestSuite) TestFalseHasList() {
-	var user model.User
-	var list model.List
-	list.UserID = user.ID + 1
-
-	suite.False(user.HasList(list))
-}
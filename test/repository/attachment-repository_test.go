@@ -0,0 +1,118 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type AttachmentRepositoryTestSuite struct {
+	suite.Suite
+	repository repository.AttachmentRepository
+	user       model.User
+	card       model.Card
+}
+
+func (suite *AttachmentRepositoryTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	config.Init()
+	db.Init()
+	suite.repository = repository.NewAttachmentRepository()
+}
+
+func (suite *AttachmentRepositoryTestSuite) SetupTest() {
+	suite.user = factory.CreateUser(&factory.UserConfig{})
+	list := factory.CreateList(&factory.ListConfig{}, suite.user)
+	suite.card = factory.CreateCard(&factory.CardConfig{}, list)
+}
+
+func (suite *AttachmentRepositoryTestSuite) TearDownSuite() {
+	db.CloseDB()
+}
+
+func (suite *AttachmentRepositoryTestSuite) TearDownTest() {
+	db.DeleteAll()
+}
+
+func TestAttachmentRepository(t *testing.T) {
+	suite.Run(t, new(AttachmentRepositoryTestSuite))
+}
+
+func (suite *AttachmentRepositoryTestSuite) createAttachment(key string, size int64, card model.Card, user model.User) model.Attachment {
+	attachment := model.Attachment{FileName: "file", Size: size, StorageKey: key, CardID: card.ID, UserID: user.ID}
+	suite.repository.Create(&attachment, 1<<30)
+	return attachment
+}
+
+func (suite *AttachmentRepositoryTestSuite) TestSuccessCreate() {
+	attachment := model.Attachment{FileName: "file", Size: 10, StorageKey: "a", CardID: suite.card.ID, UserID: suite.user.ID}
+	err := suite.repository.Create(&attachment, 10)
+
+	suite.Nil(err)
+	suite.NotZero(attachment.ID)
+}
+
+func (suite *AttachmentRepositoryTestSuite) TestBadCreateWithQuotaExceeded() {
+	suite.createAttachment("a", 10, suite.card, suite.user)
+	attachment := model.Attachment{FileName: "file", Size: 11, StorageKey: "b", CardID: suite.card.ID, UserID: suite.user.ID}
+	err := suite.repository.Create(&attachment, 20)
+
+	suite.Equal(config.AttachmentQuotaExceededError, err)
+	total, _ := suite.repository.TotalSize(&suite.user)
+	suite.Equal(int64(10), total)
+}
+
+func (suite *AttachmentRepositoryTestSuite) TestSuccessTotalSize() {
+	anotherUser := factory.CreateUser(&factory.UserConfig{})
+	suite.createAttachment("a", 10, suite.card, suite.user)
+	suite.createAttachment("b", 20, suite.card, suite.user)
+	suite.createAttachment("c", 40, suite.card, anotherUser)
+	total, err := suite.repository.TotalSize(&suite.user)
+
+	suite.Nil(err)
+	suite.Equal(int64(30), total)
+
+	total, err = suite.repository.TotalSize(&model.User{ID: anotherUser.ID + 1})
+	suite.Nil(err)
+	suite.Equal(int64(0), total)
+}
+
+func (suite *AttachmentRepositoryTestSuite) TestBadFindWithOtherCard() {
+	attachment := suite.createAttachment("a", 10, suite.card, suite.user)
+	_, err := suite.repository.Find(&model.Card{ID: suite.card.ID + 1}, attachment.ID)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *AttachmentRepositoryTestSuite) TestSuccessDestroyAllByCard() {
+	suite.createAttachment("a", 10, suite.card, suite.user)
+	attachments, err := suite.repository.DestroyAllByCard(&suite.card)
+
+	suite.Nil(err)
+	suite.Len(attachments, 1)
+	rAttachments, _ := suite.repository.FindAll(&suite.card)
+	suite.Len(rAttachments, 0)
+}
+
+func (suite *AttachmentRepositoryTestSuite) TestSuccessDestroyAllByUser() {
+	anotherUser := factory.CreateUser(&factory.UserConfig{})
+	anotherList := factory.CreateList(&factory.ListConfig{}, anotherUser)
+	anotherCard := factory.CreateCard(&factory.CardConfig{}, anotherList)
+	// ユーザーのカードに他のユーザーがアップロードしたものも削除する
+	suite.createAttachment("a", 10, suite.card, anotherUser)
+	suite.createAttachment("b", 10, anotherCard, anotherUser)
+	attachments, err := suite.repository.DestroyAllByUser(&suite.user)
+
+	suite.Nil(err)
+	suite.Len(attachments, 1)
+	suite.Equal("a", attachments[0].StorageKey)
+	rAttachments, _ := suite.repository.FindAll(&anotherCard)
+	suite.Len(rAttachments, 1)
+}
@@ -0,0 +1,72 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type AuditLogRepositoryTestSuite struct {
+	suite.Suite
+	repository repository.AuditLogRepository
+	db         *gorm.DB
+}
+
+func (suite *AuditLogRepositoryTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	config.Init()
+	db.Init()
+	suite.repository = repository.NewAuditLogRepository()
+	suite.db = db.GetDB()
+}
+
+func (suite *AuditLogRepositoryTestSuite) TearDownSuite() {
+	db.CloseDB()
+}
+
+func (suite *AuditLogRepositoryTestSuite) TearDownTest() {
+	db.DeleteAll()
+}
+
+func TestAuditLogRepository(t *testing.T) {
+	suite.Run(t, new(AuditLogRepositoryTestSuite))
+}
+
+func (suite *AuditLogRepositoryTestSuite) TestSuccessDestroyExpired() {
+	expired := model.AuditLog{Event: model.AuditEventLogout}
+	suite.db.Create(&expired)
+	suite.db.Model(&expired).Update("created_at", time.Now().AddDate(-2, 0, 0))
+	err := suite.repository.Create(&model.AuditLog{Event: model.AuditEventLoginSucceeded})
+	suite.Nil(err)
+
+	err = suite.repository.DestroyExpired(time.Now().AddDate(-1, 0, 0))
+	suite.Nil(err)
+
+	var count int64
+	suite.db.Model(&model.AuditLog{}).Count(&count)
+	suite.Equal(int64(1), count)
+}
+
+func (suite *AuditLogRepositoryTestSuite) TestSuccessFindAll() {
+	userID := 1
+	anotherUserID := 2
+	suite.repository.Create(&model.AuditLog{Event: model.AuditEventLoginSucceeded, UserID: &userID})
+	suite.repository.Create(&model.AuditLog{Event: model.AuditEventLoginFailed, UserID: &userID})
+	suite.repository.Create(&model.AuditLog{Event: model.AuditEventLoginFailed, UserID: &anotherUserID})
+
+	logs, err := suite.repository.FindAll(repository.AuditLogQuery{UserID: userID, Limit: 10})
+	suite.Nil(err)
+	suite.Len(logs, 2)
+	suite.Equal(model.AuditEventLoginFailed, logs[0].Event)
+
+	logs, err = suite.repository.FindAll(repository.AuditLogQuery{Event: model.AuditEventLoginFailed, BeforeID: logs[0].ID, Limit: 10})
+	suite.Nil(err)
+	suite.Len(logs, 0)
+}
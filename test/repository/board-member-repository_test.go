@@ -0,0 +1,112 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type BoardMemberRepositoryTestSuite struct {
+	suite.Suite
+	repository      repository.BoardMemberRepository
+	boardRepository repository.BoardRepository
+}
+
+func (suite *BoardMemberRepositoryTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	config.Init()
+	db.Init()
+	suite.repository = repository.NewBoardMemberRepository()
+	suite.boardRepository = repository.NewBoardRepository()
+}
+
+func (suite *BoardMemberRepositoryTestSuite) TearDownSuite() {
+	db.CloseDB()
+}
+
+func (suite *BoardMemberRepositoryTestSuite) TearDownTest() {
+	db.DeleteAll()
+}
+
+func TestBoardMemberRepository(t *testing.T) {
+	suite.Run(t, new(BoardMemberRepositoryTestSuite))
+}
+
+func (suite *BoardMemberRepositoryTestSuite) TestBoardCreatorIsOwner() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	board := factory.NewBoard(&factory.BoardConfig{})
+	suite.boardRepository.Create(&user, &board)
+	member, err := suite.repository.Find(board.ID, user.ID)
+
+	suite.Nil(err)
+	suite.Equal(model.BoardRoleOwner, member.Role)
+	suite.NotNil(member.AcceptedAt)
+}
+
+func (suite *BoardMemberRepositoryTestSuite) TestAcceptInvitation() {
+	owner := factory.CreateUser(&factory.UserConfig{})
+	user := factory.CreateUser(&factory.UserConfig{})
+	board := factory.CreateBoard(&factory.BoardConfig{}, owner)
+	invitation := model.BoardMember{BoardID: board.ID, UserID: user.ID, Role: model.BoardRoleViewer}
+	suite.repository.Create(&invitation)
+
+	boards, _ := suite.boardRepository.FindAll(&user)
+	suite.Len(boards, 0)
+	invitations, err := suite.repository.FindInvitations(user.ID)
+	suite.Nil(err)
+	suite.Len(invitations, 1)
+	suite.Equal(board.Title, invitations[0].Board.Title)
+
+	found, err := suite.repository.FindInvitation(invitation.ID, user.ID)
+	suite.Nil(err)
+	suite.Nil(suite.repository.Accept(&found))
+	boards, _ = suite.boardRepository.FindAll(&user)
+	suite.Len(boards, 1)
+	_, err = suite.repository.FindInvitation(invitation.ID, user.ID)
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *BoardMemberRepositoryTestSuite) TestBadFindInvitationWithOtherUser() {
+	owner := factory.CreateUser(&factory.UserConfig{})
+	user := factory.CreateUser(&factory.UserConfig{})
+	board := factory.CreateBoard(&factory.BoardConfig{}, owner)
+	invitation := model.BoardMember{BoardID: board.ID, UserID: user.ID, Role: model.BoardRoleViewer}
+	suite.repository.Create(&invitation)
+	_, err := suite.repository.FindInvitation(invitation.ID, owner.ID)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *BoardMemberRepositoryTestSuite) TestUpdateRoleKeepsLastOwner() {
+	owner := factory.CreateUser(&factory.UserConfig{})
+	user := factory.CreateUser(&factory.UserConfig{})
+	board := factory.CreateBoard(&factory.BoardConfig{}, owner)
+	ownerMember, _ := suite.repository.Find(board.ID, owner.ID)
+	member := factory.CreateBoardMember(board, user, model.BoardRoleEditor)
+
+	err := suite.repository.UpdateRole(&ownerMember, model.BoardRoleEditor)
+	suite.Equal(config.LastBoardOwnerError, err)
+
+	suite.Nil(suite.repository.UpdateRole(&member, model.BoardRoleOwner))
+	err = suite.repository.UpdateRole(&ownerMember, model.BoardRoleEditor)
+	suite.Nil(err)
+	err = suite.repository.Destroy(&member)
+	suite.Equal(config.LastBoardOwnerError, err)
+}
+
+func (suite *BoardMemberRepositoryTestSuite) TestBadFindInBoardWithOtherBoard() {
+	owner := factory.CreateUser(&factory.UserConfig{})
+	board := factory.CreateBoard(&factory.BoardConfig{}, owner)
+	otherBoard := factory.CreateBoard(&factory.BoardConfig{}, owner)
+	member, _ := suite.repository.Find(board.ID, owner.ID)
+	_, err := suite.repository.FindInBoard(otherBoard.ID, member.ID)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
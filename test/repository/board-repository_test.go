@@ -0,0 +1,92 @@
+package repository_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type BoardRepositoryTestSuite struct {
+	suite.Suite
+	repository     repository.BoardRepository
+	listRepository repository.ListRepository
+	cardRepository repository.CardRepository
+}
+
+func (suite *BoardRepositoryTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	config.Init()
+	db.Init()
+	suite.repository = repository.NewBoardRepository()
+	suite.listRepository = repository.NewListRepository()
+	suite.cardRepository = repository.NewCardRepository()
+}
+
+func (suite *BoardRepositoryTestSuite) TearDownSuite() {
+	db.CloseDB()
+}
+
+func (suite *BoardRepositoryTestSuite) TearDownTest() {
+	db.DeleteAll()
+}
+
+func TestBoardRepository(t *testing.T) {
+	suite.Run(t, new(BoardRepositoryTestSuite))
+}
+
+func (suite *BoardRepositoryTestSuite) TestSuccessCreateAndFindAll() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	otherUser := factory.CreateUser(&factory.UserConfig{Email: "other@example.com"})
+	factory.CreateBoard(&factory.BoardConfig{}, otherUser)
+	board := factory.NewBoard(&factory.BoardConfig{Title: "work"})
+	err := suite.repository.Create(&user, &board)
+
+	suite.Nil(err)
+	boards, err := suite.repository.FindAll(&user)
+	suite.Nil(err)
+	suite.Len(boards, 1)
+	suite.Equal("work", boards[0].Title)
+}
+
+func (suite *BoardRepositoryTestSuite) TestSuccessMove() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	boards := make([]model.Board, 0, 3)
+	for i := 0; i <= 2; i++ {
+		boards = append(boards, factory.CreateBoard(&factory.BoardConfig{Index: i, Title: strconv.Itoa(i)}, user))
+	}
+	err := suite.repository.Move(&boards[0], 2)
+
+	suite.Nil(err)
+	dbBoards, _ := suite.repository.FindAll(&user)
+	suite.Equal("1", dbBoards[0].Title)
+	suite.Equal("2", dbBoards[1].Title)
+	suite.Equal("0", dbBoards[2].Title)
+}
+
+func (suite *BoardRepositoryTestSuite) TestSuccessDestroy() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	board := factory.CreateBoard(&factory.BoardConfig{Index: 0}, user)
+	nextBoard := factory.CreateBoard(&factory.BoardConfig{Index: 1}, user)
+	list := factory.CreateBoardList(&factory.ListConfig{}, board)
+	card := factory.CreateCard(&factory.CardConfig{}, list)
+	suite.cardRepository.Destroy(&card)
+	err := suite.repository.Destroy(&board)
+
+	suite.Nil(err)
+	_, err = suite.repository.Find(board.ID)
+	suite.Equal(gorm.ErrRecordNotFound, err)
+	_, err = suite.listRepository.FindWithTrashed(list.ID)
+	suite.Equal(gorm.ErrRecordNotFound, err)
+	_, err = suite.cardRepository.FindWithTrashed(card.ID)
+	suite.Equal(gorm.ErrRecordNotFound, err)
+	dbBoard, _ := suite.repository.Find(nextBoard.ID)
+	suite.Equal(0, dbBoard.Index)
+}
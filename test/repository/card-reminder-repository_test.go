@@ -0,0 +1,139 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type CardReminderRepositoryTestSuite struct {
+	suite.Suite
+	repository repository.CardReminderRepository
+	db         *gorm.DB
+}
+
+func (suite *CardReminderRepositoryTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	config.Init()
+	db.Init()
+	suite.repository = repository.NewCardReminderRepository()
+	suite.db = db.GetDB()
+}
+
+func (suite *CardReminderRepositoryTestSuite) TearDownSuite() {
+	db.CloseDB()
+}
+
+func (suite *CardReminderRepositoryTestSuite) TearDownTest() {
+	db.DeleteAll()
+}
+
+func TestCardReminderRepository(t *testing.T) {
+	suite.Run(t, new(CardReminderRepositoryTestSuite))
+}
+
+func (suite *CardReminderRepositoryTestSuite) TestSuccessReplaceKeepsSentReminders() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	list := factory.CreateList(&factory.ListConfig{}, user)
+	card := factory.CreateCard(&factory.CardConfig{}, list)
+	sentAt := time.Now()
+	suite.db.Create(&model.CardReminder{CardID: card.ID, RemindAt: time.Now(), SentAt: &sentAt})
+	suite.db.Create(&model.CardReminder{CardID: card.ID, RemindAt: time.Now()})
+
+	err := suite.repository.Replace(&card, []model.CardReminder{{CardID: card.ID, RemindAt: time.Now().Add(time.Hour)}})
+	suite.Nil(err)
+
+	var count int64
+	suite.db.Model(&model.CardReminder{}).Where("card_id = ?", card.ID).Count(&count)
+	suite.Equal(int64(2), count)
+}
+
+func (suite *CardReminderRepositoryTestSuite) TestSuccessFindDueAndMarkSent() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	list := factory.CreateList(&factory.ListConfig{}, user)
+	card := factory.CreateCard(&factory.CardConfig{}, list)
+	suite.repository.Replace(&card, []model.CardReminder{
+		{CardID: card.ID, RemindAt: time.Now().Add(-time.Minute)},
+		{CardID: card.ID, RemindAt: time.Now().Add(time.Hour)},
+	})
+
+	reminders, err := suite.repository.FindDue(time.Now())
+	suite.Nil(err)
+	suite.Len(reminders, 1)
+	suite.Len(reminders[0].Card.List.Board.Members, 1)
+	suite.Equal(user.Email, reminders[0].Card.List.Board.Members[0].User.Email)
+
+	claimed, err := suite.repository.MarkSent(&reminders[0], time.Now())
+	suite.Nil(err)
+	suite.True(claimed)
+	claimed, _ = suite.repository.MarkSent(&reminders[0], time.Now())
+	suite.False(claimed)
+}
+
+func (suite *CardReminderRepositoryTestSuite) TestSuccessCardDestroyDeletesReminders() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	list := factory.CreateList(&factory.ListConfig{}, user)
+	card := factory.CreateCard(&factory.CardConfig{}, list)
+	suite.repository.Replace(&card, []model.CardReminder{{CardID: card.ID, RemindAt: time.Now()}})
+
+	err := repository.NewCardRepository().Destroy(&card)
+	suite.Nil(err)
+
+	var count int64
+	suite.db.Model(&model.CardReminder{}).Count(&count)
+	suite.Equal(int64(0), count)
+}
+
+func (suite *CardReminderRepositoryTestSuite) TestSuccessListArchiveDeletesUnsentReminders() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	list := factory.CreateList(&factory.ListConfig{}, user)
+	card := factory.CreateCard(&factory.CardConfig{}, list)
+	sentAt := time.Now()
+	suite.db.Create(&model.CardReminder{CardID: card.ID, RemindAt: time.Now(), SentAt: &sentAt})
+	suite.db.Create(&model.CardReminder{CardID: card.ID, RemindAt: time.Now().Add(time.Hour)})
+
+	err := repository.NewListRepository().Archive(&list)
+	suite.Nil(err)
+
+	var count int64
+	suite.db.Model(&model.CardReminder{}).Where("card_id = ?", card.ID).Count(&count)
+	suite.Equal(int64(1), count)
+}
+
+func (suite *CardReminderRepositoryTestSuite) TestSuccessListDestroyDeletesUnsentReminders() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	list := factory.CreateList(&factory.ListConfig{}, user)
+	card := factory.CreateCard(&factory.CardConfig{}, list)
+	suite.repository.Replace(&card, []model.CardReminder{{CardID: card.ID, RemindAt: time.Now().Add(time.Hour)}})
+
+	err := repository.NewListRepository().Destroy(&list)
+	suite.Nil(err)
+
+	var count int64
+	suite.db.Model(&model.CardReminder{}).Count(&count)
+	suite.Equal(int64(0), count)
+}
+
+func (suite *CardReminderRepositoryTestSuite) TestSuccessFindDueWithoutRemovedCreator() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	member := factory.CreateUser(&factory.UserConfig{})
+	list := factory.CreateList(&factory.ListConfig{}, user)
+	factory.CreateBoardMember(list.Board, member, model.BoardRoleOwner)
+	suite.db.Unscoped().Where("board_id = ? AND user_id = ?", list.BoardID, user.ID).Delete(&model.BoardMember{})
+	card := factory.CreateCard(&factory.CardConfig{}, list)
+	suite.repository.Replace(&card, []model.CardReminder{{CardID: card.ID, RemindAt: time.Now().Add(-time.Minute)}})
+
+	reminders, err := suite.repository.FindDue(time.Now())
+	suite.Nil(err)
+	suite.Len(reminders, 1)
+	suite.Len(reminders[0].Card.List.Board.Members, 1)
+	suite.Equal(member.ID, reminders[0].Card.List.Board.Members[0].UserID)
+}
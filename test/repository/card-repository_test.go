@@ -3,6 +3,7 @@ package repository_test
 import (
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/kuritaeiji/todo-gin-back/config"
@@ -57,12 +58,13 @@ func (suite *CardRepositoryTestSuite) TestSuccessUpdate() {
 	user := factory.CreateUser(&factory.UserConfig{})
 	list := factory.CreateList(&factory.ListConfig{}, user)
 	card := factory.CreateCard(&factory.CardConfig{}, list)
-	updatingCard := factory.NewCard(&factory.CardConfig{Title: "updated title"})
+	updatingCard := factory.NewCard(&factory.CardConfig{Title: "updated title", Description: "updated description"})
 	err := suite.repository.Update(&card, &updatingCard)
 
 	suite.Nil(err)
 	rCard, _ := suite.repository.Find(card.ID)
 	suite.Equal(updatingCard.Title, rCard.Title)
+	suite.Equal(updatingCard.Description, rCard.Description)
 }
 
 func (suite *CardRepositoryTestSuite) TestSuccessMoveWhenIncreaseIndex() {
@@ -75,8 +77,9 @@ func (suite *CardRepositoryTestSuite) TestSuccessMoveWhenIncreaseIndex() {
 	err := suite.repository.Move(&cards[1], cards[1].ListID, 2)
 
 	suite.Nil(err)
-	suite.listRepository.FindListsWithCards(&user)
-	dbCards := user.Lists[0].Cards
+	board := factory.UserBoard(user)
+	suite.listRepository.FindListsWithCards(&board)
+	dbCards := board.Lists[0].Cards
 	suite.Equal("0", dbCards[0].Title)
 	suite.Equal("2", dbCards[1].Title)
 	suite.Equal("1", dbCards[2].Title)
@@ -94,8 +97,9 @@ func (suite *CardRepositoryTestSuite) TestSuccessMoveWhenDecreaseIndex() {
 	err := suite.repository.Move(&cards[2], list.ID, 1)
 
 	suite.Nil(err)
-	suite.listRepository.FindListsWithCards(&user)
-	dbCards := user.Lists[0].Cards
+	board := factory.UserBoard(user)
+	suite.listRepository.FindListsWithCards(&board)
+	dbCards := board.Lists[0].Cards
 	suite.Equal("0", dbCards[0].Title)
 	suite.Equal("2", dbCards[1].Title)
 	suite.Equal("1", dbCards[2].Title)
@@ -117,9 +121,10 @@ func (suite *CardRepositoryTestSuite) TestSuccessMoveWhenChangeList() {
 	err := suite.repository.Move(&cards[1], toList.ID, 2)
 
 	suite.Nil(err)
-	suite.listRepository.FindListsWithCards(&user)
-	cards = user.Lists[0].Cards
-	toListCards = user.Lists[1].Cards
+	board := factory.UserBoard(user)
+	suite.listRepository.FindListsWithCards(&board)
+	cards = board.Lists[0].Cards
+	toListCards = board.Lists[1].Cards
 	suite.Equal("card0", cards[0].Title)
 	suite.Equal("card2", cards[1].Title)
 	suite.Equal("toListCard0", toListCards[0].Title)
@@ -128,6 +133,39 @@ func (suite *CardRepositoryTestSuite) TestSuccessMoveWhenChangeList() {
 	suite.Equal("toListCard2", toListCards[3].Title)
 }
 
+func (suite *CardRepositoryTestSuite) TestSuccessMoveKeepsArchivedCardIndex() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	list := factory.CreateList(&factory.ListConfig{}, user)
+	cards := make([]model.Card, 0, 3)
+	for i := 0; i <= 2; i++ {
+		cards = append(cards, factory.CreateCard(&factory.CardConfig{Index: i}, list))
+	}
+	suite.repository.Archive(&cards[1])
+	err := suite.repository.Move(&cards[0], list.ID, 1)
+
+	suite.Nil(err)
+	archived, _ := suite.repository.FindWithTrashed(cards[1].ID)
+	suite.Equal(1, archived.Index)
+}
+
+func (suite *CardRepositoryTestSuite) TestSuccessMoveWhenChangeListKeepsArchivedCardIndex() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	list := factory.CreateList(&factory.ListConfig{Index: 0}, user)
+	toList := factory.CreateList(&factory.ListConfig{Index: 1}, user)
+	card := factory.CreateCard(&factory.CardConfig{Index: 0}, list)
+	archivedInList := factory.CreateCard(&factory.CardConfig{Index: 1}, list)
+	archivedInToList := factory.CreateCard(&factory.CardConfig{Index: 0}, toList)
+	suite.repository.Archive(&archivedInList)
+	suite.repository.Archive(&archivedInToList)
+	err := suite.repository.Move(&card, toList.ID, 0)
+
+	suite.Nil(err)
+	rArchivedInList, _ := suite.repository.FindWithTrashed(archivedInList.ID)
+	suite.Equal(1, rArchivedInList.Index)
+	rArchivedInToList, _ := suite.repository.FindWithTrashed(archivedInToList.ID)
+	suite.Equal(0, rArchivedInToList.Index)
+}
+
 func (suite *CardRepositoryTestSuite) TestSuccessFind() {
 	user := factory.CreateUser(&factory.UserConfig{})
 	list := factory.CreateList(&factory.ListConfig{}, user)
@@ -148,3 +186,81 @@ func (suite *CardRepositoryTestSuite) TestSuccessDestroy() {
 	_, err = suite.repository.Find(card.ID)
 	suite.Equal(gorm.ErrRecordNotFound, err)
 }
+
+func (suite *CardRepositoryTestSuite) TestSuccessArchive() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	list := factory.CreateList(&factory.ListConfig{}, user)
+	cards := make([]model.Card, 0, 3)
+	for i := 0; i <= 2; i++ {
+		cards = append(cards, factory.CreateCard(&factory.CardConfig{Index: i}, list))
+	}
+	err := suite.repository.Archive(&cards[0])
+
+	suite.Nil(err)
+	board := factory.UserBoard(user)
+	suite.listRepository.FindListsWithCards(&board)
+	suite.Len(board.Lists[0].Cards, 2)
+	suite.Equal(0, board.Lists[0].Cards[0].Index)
+	trashed, _ := suite.repository.FindTrashed(&user)
+	suite.Len(trashed, 1)
+	suite.Equal(cards[0].ID, trashed[0].ID)
+}
+
+func (suite *CardRepositoryTestSuite) TestSuccessFindTrashedWithoutCardsOfDestroyedList() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	list := factory.CreateList(&factory.ListConfig{}, user)
+	factory.CreateCard(&factory.CardConfig{}, list)
+	suite.listRepository.Destroy(&list)
+	cards, err := suite.repository.FindTrashed(&user)
+
+	suite.Nil(err)
+	suite.Len(cards, 0)
+}
+
+func (suite *CardRepositoryTestSuite) TestSuccessRestore() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	list := factory.CreateList(&factory.ListConfig{}, user)
+	cards := make([]model.Card, 0, 3)
+	for i := 0; i <= 2; i++ {
+		cards = append(cards, factory.CreateCard(&factory.CardConfig{Index: i}, list))
+	}
+	suite.repository.Destroy(&cards[1])
+	card, err := suite.repository.FindWithTrashed(cards[1].ID)
+	suite.Nil(err)
+	suite.Equal(list.ID, card.List.ID)
+	err = suite.repository.Restore(&card)
+
+	suite.Nil(err)
+	suite.Equal(1, card.Index)
+	card2, _ := suite.repository.Find(cards[2].ID)
+	suite.Equal(2, card2.Index)
+}
+
+func (suite *CardRepositoryTestSuite) TestSuccessFindWithDueAtByList() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	list := factory.CreateList(&factory.ListConfig{}, user)
+	dueAt := time.Now().Add(time.Hour)
+	card := factory.CreateCard(&factory.CardConfig{DueAt: &dueAt}, list)
+	factory.CreateCard(&factory.CardConfig{Index: 1}, list)
+	archivedCard := factory.CreateCard(&factory.CardConfig{Index: 2, DueAt: &dueAt}, list)
+	suite.repository.Archive(&archivedCard)
+	cards, err := suite.repository.FindWithDueAtByList(&list)
+
+	suite.Nil(err)
+	suite.Len(cards, 1)
+	suite.Equal(card.ID, cards[0].ID)
+}
+
+func (suite *CardRepositoryTestSuite) TestSuccessDestroyPermanently() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	list := factory.CreateList(&factory.ListConfig{}, user)
+	card := factory.CreateCard(&factory.CardConfig{}, list)
+	label := factory.CreateLabel(&factory.LabelConfig{}, user)
+	repository.NewLabelRepository().Attach(&card, &label)
+	suite.repository.Destroy(&card)
+	err := suite.repository.DestroyPermanently(&card)
+
+	suite.Nil(err)
+	_, err = suite.repository.FindWithTrashed(card.ID)
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
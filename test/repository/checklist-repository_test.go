@@ -0,0 +1,103 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type ChecklistRepositoryTestSuite struct {
+	suite.Suite
+	repository     repository.ChecklistRepository
+	cardRepository repository.CardRepository
+	card           model.Card
+}
+
+func (suite *ChecklistRepositoryTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	config.Init()
+	db.Init()
+	suite.repository = repository.NewChecklistRepository()
+	suite.cardRepository = repository.NewCardRepository()
+}
+
+func (suite *ChecklistRepositoryTestSuite) SetupTest() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	list := factory.CreateList(&factory.ListConfig{}, user)
+	suite.card = factory.CreateCard(&factory.CardConfig{}, list)
+}
+
+func (suite *ChecklistRepositoryTestSuite) TearDownSuite() {
+	db.CloseDB()
+}
+
+func (suite *ChecklistRepositoryTestSuite) TearDownTest() {
+	db.DeleteAll()
+}
+
+func TestChecklistRepository(t *testing.T) {
+	suite.Run(t, new(ChecklistRepositoryTestSuite))
+}
+
+func (suite *ChecklistRepositoryTestSuite) createChecklists(count int) []model.Checklist {
+	checklists := make([]model.Checklist, 0, count)
+	for i := 0; i < count; i++ {
+		checklist := model.Checklist{Title: "checklist", Index: i, CardID: suite.card.ID}
+		suite.repository.Create(&checklist)
+		checklists = append(checklists, checklist)
+	}
+	return checklists
+}
+
+func (suite *ChecklistRepositoryTestSuite) TestSuccessFindWithOtherCard() {
+	checklist := suite.createChecklists(1)[0]
+	_, err := suite.repository.Find(&model.Card{ID: suite.card.ID + 1}, checklist.ID)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *ChecklistRepositoryTestSuite) TestSuccessMove() {
+	checklists := suite.createChecklists(3)
+	err := suite.repository.Move(&checklists[0], 2)
+	suite.Nil(err)
+
+	rChecklists, _ := suite.repository.FindAll(&suite.card)
+	suite.Equal([]int{checklists[1].ID, checklists[2].ID, checklists[0].ID}, []int{rChecklists[0].ID, rChecklists[1].ID, rChecklists[2].ID})
+	suite.Equal([]int{0, 1, 2}, []int{rChecklists[0].Index, rChecklists[1].Index, rChecklists[2].Index})
+}
+
+func (suite *ChecklistRepositoryTestSuite) TestSuccessDestroy() {
+	checklists := suite.createChecklists(2)
+	suite.repository.CreateItem(&model.ChecklistItem{Title: "item", ChecklistID: checklists[0].ID})
+	err := suite.repository.Destroy(&checklists[0])
+	suite.Nil(err)
+
+	rChecklists, _ := suite.repository.FindAll(&suite.card)
+	suite.Len(rChecklists, 1)
+	suite.Equal(0, rChecklists[0].Index)
+}
+
+func (suite *ChecklistRepositoryTestSuite) TestSuccessMoveItemAndProgress() {
+	checklist := suite.createChecklists(1)[0]
+	items := make([]model.ChecklistItem, 0, 3)
+	for i := 0; i < 3; i++ {
+		item := model.ChecklistItem{Title: "item", Done: i == 0, Index: i, ChecklistID: checklist.ID}
+		suite.repository.CreateItem(&item)
+		items = append(items, item)
+	}
+
+	err := suite.repository.MoveItem(&items[2], 0)
+	suite.Nil(err)
+	rChecklist, _ := suite.repository.Find(&suite.card, checklist.ID)
+	suite.Equal([]int{items[2].ID, items[0].ID, items[1].ID}, []int{rChecklist.Items[0].ID, rChecklist.Items[1].ID, rChecklist.Items[2].ID})
+
+	card, _ := suite.cardRepository.Find(suite.card.ID)
+	suite.Equal(gin.H{"done": 1, "total": 3}, card.ChecklistProgress())
+}
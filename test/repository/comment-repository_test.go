@@ -0,0 +1,102 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type CommentRepositoryTestSuite struct {
+	suite.Suite
+	repository     repository.CommentRepository
+	cardRepository repository.CardRepository
+	listRepository repository.ListRepository
+	user           model.User
+	card           model.Card
+}
+
+func (suite *CommentRepositoryTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	config.Init()
+	db.Init()
+	suite.repository = repository.NewCommentRepository()
+	suite.cardRepository = repository.NewCardRepository()
+	suite.listRepository = repository.NewListRepository()
+}
+
+func (suite *CommentRepositoryTestSuite) SetupTest() {
+	suite.user = factory.CreateUser(&factory.UserConfig{})
+	list := factory.CreateList(&factory.ListConfig{}, suite.user)
+	suite.card = factory.CreateCard(&factory.CardConfig{}, list)
+}
+
+func (suite *CommentRepositoryTestSuite) TearDownSuite() {
+	db.CloseDB()
+}
+
+func (suite *CommentRepositoryTestSuite) TearDownTest() {
+	db.DeleteAll()
+}
+
+func TestCommentRepository(t *testing.T) {
+	suite.Run(t, new(CommentRepositoryTestSuite))
+}
+
+func (suite *CommentRepositoryTestSuite) createComments(count int) []model.Comment {
+	comments := make([]model.Comment, 0, count)
+	for i := 0; i < count; i++ {
+		comment := model.Comment{Body: "comment", CardID: suite.card.ID, UserID: suite.user.ID}
+		suite.repository.Create(&comment)
+		comments = append(comments, comment)
+	}
+	return comments
+}
+
+func (suite *CommentRepositoryTestSuite) TestSuccessFindAllWithBefore() {
+	comments := suite.createComments(3)
+	rComments, err := suite.repository.FindAll(&suite.card, comments[2].ID, 1)
+
+	suite.Nil(err)
+	suite.Len(rComments, 1)
+	suite.Equal(comments[1].ID, rComments[0].ID)
+	suite.Equal(suite.user.ID, rComments[0].User.ID)
+}
+
+func (suite *CommentRepositoryTestSuite) TestSuccessUpdate() {
+	comment := suite.createComments(1)[0]
+	err := suite.repository.Update(&comment, "edited", time.Now())
+	suite.Nil(err)
+
+	rComment, _ := suite.repository.Find(comment.ID)
+	suite.Equal("edited", rComment.Body)
+	suite.NotNil(rComment.EditedAt)
+	suite.Equal(suite.card.ListID, rComment.Card.ListID)
+}
+
+func (suite *CommentRepositoryTestSuite) TestBadFindWithDestroyedCard() {
+	comment := suite.createComments(1)[0]
+	suite.cardRepository.Destroy(&suite.card)
+	_, err := suite.repository.Find(comment.ID)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *CommentRepositoryTestSuite) TestSuccessCommentCount() {
+	comments := suite.createComments(3)
+	suite.repository.Destroy(&comments[0])
+
+	card, _ := suite.cardRepository.Find(suite.card.ID)
+	suite.Equal(2, card.CommentCount)
+
+	board := factory.UserBoard(suite.user)
+	suite.listRepository.FindListsWithCards(&board)
+	suite.Equal(2, board.Lists[0].Cards[0].CommentCount)
+}
@@ -0,0 +1,90 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type EmailChangeRepositoryTestSuite struct {
+	suite.Suite
+	repository repository.EmailChangeRepository
+	db         *gorm.DB
+}
+
+func (suite *EmailChangeRepositoryTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	config.Init()
+	db.Init()
+	suite.repository = repository.NewEmailChangeRepository()
+	suite.db = db.GetDB()
+}
+
+func (suite *EmailChangeRepositoryTestSuite) TearDownSuite() {
+	db.CloseDB()
+}
+
+func (suite *EmailChangeRepositoryTestSuite) TearDownTest() {
+	db.DeleteAll()
+}
+
+func TestEmailChangeRepository(t *testing.T) {
+	suite.Run(t, new(EmailChangeRepositoryTestSuite))
+}
+
+func (suite *EmailChangeRepositoryTestSuite) TestSuccessCreateReplacesPendingChange() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	suite.repository.Create(&model.EmailChange{Digest: "digest1", Email: "old@example.com", UserID: user.ID})
+	err := suite.repository.Create(&model.EmailChange{Digest: "digest2", Email: "new@example.com", UserID: user.ID})
+
+	suite.Nil(err)
+	var changes []model.EmailChange
+	suite.db.Find(&changes)
+	suite.Len(changes, 1)
+	suite.Equal("digest2", changes[0].Digest)
+}
+
+func (suite *EmailChangeRepositoryTestSuite) TestSuccessFindByDigest() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	change := model.EmailChange{Digest: "digest", Email: "new@example.com", ExpiresAt: time.Now().Add(time.Hour), UserID: user.ID}
+	suite.repository.Create(&change)
+	rChange, err := suite.repository.FindByDigest(change.Digest)
+
+	suite.Nil(err)
+	suite.Equal(change.ID, rChange.ID)
+	suite.Equal(user.ID, rChange.User.ID)
+}
+
+func (suite *EmailChangeRepositoryTestSuite) TestSuccessConfirm() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	suite.repository.Create(&model.EmailChange{Digest: "digest", Email: "new@example.com", UserID: user.ID})
+	change, _ := suite.repository.FindByDigest("digest")
+	err := suite.repository.Confirm(&change)
+
+	suite.Nil(err)
+	var rUser model.User
+	suite.db.First(&rUser, user.ID)
+	suite.Equal("new@example.com", rUser.Email)
+	suite.Equal(1, rUser.TokenVersion)
+	var count int64
+	suite.db.Model(model.EmailChange{}).Count(&count)
+	suite.Equal(int64(0), count)
+}
+
+func (suite *EmailChangeRepositoryTestSuite) TestBadConfirmWithTakenEmail() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	other := factory.CreateUser(&factory.UserConfig{})
+	suite.repository.Create(&model.EmailChange{Digest: "digest", Email: other.Email, UserID: user.ID})
+	change, _ := suite.repository.FindByDigest("digest")
+	err := suite.repository.Confirm(&change)
+
+	suite.Equal(config.UniqueUserError, err)
+}
@@ -0,0 +1,118 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type LabelRepositoryTestSuite struct {
+	suite.Suite
+	repository     repository.LabelRepository
+	listRepository repository.ListRepository
+	cardRepository repository.CardRepository
+	db             *gorm.DB
+}
+
+func (suite *LabelRepositoryTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	config.Init()
+	db.Init()
+	suite.repository = repository.NewLabelRepository()
+	suite.listRepository = repository.NewListRepository()
+	suite.cardRepository = repository.NewCardRepository()
+	suite.db = db.GetDB()
+}
+
+func (suite *LabelRepositoryTestSuite) TearDownSuite() {
+	db.CloseDB()
+}
+
+func (suite *LabelRepositoryTestSuite) TearDownTest() {
+	db.DeleteAll()
+}
+
+func TestLabelRepository(t *testing.T) {
+	suite.Run(t, new(LabelRepositoryTestSuite))
+}
+
+func (suite *LabelRepositoryTestSuite) TestSuccessFindAll() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	anotherUser := factory.CreateUser(&factory.UserConfig{})
+	label := factory.CreateLabel(&factory.LabelConfig{}, user)
+	factory.CreateLabel(&factory.LabelConfig{}, anotherUser)
+	labels, err := suite.repository.FindAll(&user)
+
+	suite.Nil(err)
+	suite.Len(labels, 1)
+	suite.Equal(label.ID, labels[0].ID)
+}
+
+func (suite *LabelRepositoryTestSuite) TestSuccessUpdate() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	label := factory.CreateLabel(&factory.LabelConfig{}, user)
+	err := suite.repository.Update(&label, model.Label{Name: "updated", Color: "#000000"})
+	suite.Nil(err)
+
+	rLabel, _ := suite.repository.Find(label.ID)
+	suite.Equal("updated", rLabel.Name)
+	suite.Equal("#000000", rLabel.Color)
+}
+
+func (suite *LabelRepositoryTestSuite) TestSuccessAttachAndDetach() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	list := factory.CreateList(&factory.ListConfig{}, user)
+	card := factory.CreateCard(&factory.CardConfig{}, list)
+	label := factory.CreateLabel(&factory.LabelConfig{}, user)
+
+	err := suite.repository.Attach(&card, &label)
+	suite.Nil(err)
+	rCard, _ := suite.cardRepository.Find(card.ID)
+	suite.Len(rCard.Labels, 1)
+
+	err = suite.repository.Detach(&rCard, &label)
+	suite.Nil(err)
+	rCard, _ = suite.cardRepository.Find(card.ID)
+	suite.Len(rCard.Labels, 0)
+}
+
+func (suite *LabelRepositoryTestSuite) TestSuccessDestroyDetachesCards() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	list := factory.CreateList(&factory.ListConfig{}, user)
+	card := factory.CreateCard(&factory.CardConfig{}, list)
+	label := factory.CreateLabel(&factory.LabelConfig{}, user)
+	suite.repository.Attach(&card, &label)
+
+	err := suite.repository.Destroy(&label)
+	suite.Nil(err)
+
+	var count int64
+	suite.db.Table("card_labels").Where("label_id = ?", label.ID).Count(&count)
+	suite.Equal(int64(0), count)
+	_, err = suite.repository.Find(label.ID)
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *LabelRepositoryTestSuite) TestSuccessFindListsWithCardsByLabels() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	list := factory.CreateList(&factory.ListConfig{}, user)
+	card := factory.CreateCard(&factory.CardConfig{}, list)
+	factory.CreateCard(&factory.CardConfig{Index: 1}, list)
+	label := factory.CreateLabel(&factory.LabelConfig{}, user)
+	suite.repository.Attach(&card, &label)
+
+	board := factory.UserBoard(user)
+	err := suite.listRepository.FindListsWithCardsByLabels(&board, []int{label.ID})
+	suite.Nil(err)
+	suite.Len(board.Lists, 1)
+	suite.Len(board.Lists[0].Cards, 1)
+	suite.Equal(card.ID, board.Lists[0].Cards[0].ID)
+	suite.Equal(label.ID, board.Lists[0].Cards[0].Labels[0].ID)
+}
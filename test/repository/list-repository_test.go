@@ -43,11 +43,13 @@ func TestListRepositoryTestSuite(t *testing.T) {
 
 func (suite *ListRepositoryTestSuite) TestSuccessCreate() {
 	user := factory.CreateUser(&factory.UserConfig{})
+	board := factory.CreateBoard(&factory.BoardConfig{}, user)
 	list := factory.NewList(&factory.ListConfig{})
-	err := suite.repository.Create(&user, &list)
+	err := suite.repository.Create(&board, &list)
 
 	suite.Nil(err)
-	suite.Equal(list.Title, user.Lists[0].Title)
+	suite.Equal(list.Title, board.Lists[0].Title)
+	suite.Equal(board.ID, list.BoardID)
 }
 
 func (suite *ListRepositoryTestSuite) TestSuccessUpdate() {
@@ -102,16 +104,17 @@ func (suite *ListRepositoryTestSuite) TestSuccessMoveWhenIncreaseIndex() {
 	for i := 0; i <= 4; i++ {
 		lists = append(lists, factory.CreateList(&factory.ListConfig{Index: i, Title: strconv.Itoa(i)}, user))
 	}
-	err := suite.repository.Move(&lists[1], 3, &user)
+	err := suite.repository.Move(&lists[1], 3)
 
 	suite.Nil(err)
-	suite.repository.FindListsWithCards(&user)
-	suite.Equal("1", user.Lists[3].Title)
-	suite.Equal("3", user.Lists[2].Title)
-	suite.Equal("2", user.Lists[1].Title)
-
-	suite.Equal("0", user.Lists[0].Title)
-	suite.Equal("4", user.Lists[4].Title)
+	board := factory.UserBoard(user)
+	suite.repository.FindListsWithCards(&board)
+	suite.Equal("1", board.Lists[3].Title)
+	suite.Equal("3", board.Lists[2].Title)
+	suite.Equal("2", board.Lists[1].Title)
+
+	suite.Equal("0", board.Lists[0].Title)
+	suite.Equal("4", board.Lists[4].Title)
 }
 
 func (suite *ListRepositoryTestSuite) TestSuccessMoveWhenDecreaseIndex() {
@@ -120,15 +123,16 @@ func (suite *ListRepositoryTestSuite) TestSuccessMoveWhenDecreaseIndex() {
 	for i := 0; i <= 4; i++ {
 		lists = append(lists, factory.CreateList(&factory.ListConfig{Index: i, Title: strconv.Itoa(i)}, user))
 	}
-	err := suite.repository.Move(&lists[3], 1, &user)
+	err := suite.repository.Move(&lists[3], 1)
 
 	suite.Nil(err)
-	suite.repository.FindListsWithCards(&user)
-	suite.Equal("0", user.Lists[0].Title)
-	suite.Equal("3", user.Lists[1].Title)
-	suite.Equal("1", user.Lists[2].Title)
-	suite.Equal("2", user.Lists[3].Title)
-	suite.Equal("4", user.Lists[4].Title)
+	board := factory.UserBoard(user)
+	suite.repository.FindListsWithCards(&board)
+	suite.Equal("0", board.Lists[0].Title)
+	suite.Equal("3", board.Lists[1].Title)
+	suite.Equal("1", board.Lists[2].Title)
+	suite.Equal("2", board.Lists[3].Title)
+	suite.Equal("4", board.Lists[4].Title)
 }
 
 func (suite *ListRepositoryTestSuite) TestSuccessFind() {
@@ -151,18 +155,89 @@ func (suite *ListRepositoryTestSuite) TestSuccessFindLists() {
 		list1Cards = append(list1Cards, factory.CreateCard(&factory.CardConfig{Index: i}, list1))
 		list2Cards = append(list2Cards, factory.CreateCard(&factory.CardConfig{Index: i}, list2))
 	}
-	err := suite.repository.FindListsWithCards(&user)
+	board := factory.UserBoard(user)
+	err := suite.repository.FindListsWithCards(&board)
 
 	suite.Nil(err)
-	suite.Equal(list1.ID, user.Lists[0].ID)
-	suite.Equal(list2.ID, user.Lists[1].ID)
-	suite.Equal([]model.Card{list1Cards[1], list1Cards[0]}, user.Lists[0].Cards)
-	suite.Equal([]model.Card{list2Cards[1], list2Cards[0]}, user.Lists[1].Cards)
+	suite.Equal(list1.ID, board.Lists[0].ID)
+	suite.Equal(list2.ID, board.Lists[1].ID)
+	suite.Equal([]model.Card{list1Cards[1], list1Cards[0]}, board.Lists[0].Cards)
+	suite.Equal([]model.Card{list2Cards[1], list2Cards[0]}, board.Lists[1].Cards)
 }
 
 func (suite *ListRepositoryTestSuite) TestFindLists() {
 	user := factory.CreateUser(&factory.UserConfig{})
-	err := suite.repository.FindListsWithCards(&user)
+	board := factory.UserBoard(user)
+	err := suite.repository.FindListsWithCards(&board)
+
+	suite.Nil(err)
+}
+
+func (suite *ListRepositoryTestSuite) TestSuccessArchive() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	lists := make([]model.List, 0, 3)
+	for i := 0; i <= 2; i++ {
+		lists = append(lists, factory.CreateList(&factory.ListConfig{Index: i}, user))
+	}
+	err := suite.repository.Archive(&lists[1])
+
+	suite.Nil(err)
+	board := factory.UserBoard(user)
+	suite.repository.FindListsWithCards(&board)
+	suite.Len(board.Lists, 2)
+	suite.Equal(lists[2].ID, board.Lists[1].ID)
+	suite.Equal(1, board.Lists[1].Index)
+	trashed, _ := suite.repository.FindTrashed(&user)
+	suite.Len(trashed, 1)
+	suite.Equal(lists[1].ID, trashed[0].ID)
+}
+
+func (suite *ListRepositoryTestSuite) TestSuccessRestoreDestroyedListWithCards() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	lists := make([]model.List, 0, 3)
+	for i := 0; i <= 2; i++ {
+		lists = append(lists, factory.CreateList(&factory.ListConfig{Index: i}, user))
+	}
+	card := factory.CreateCard(&factory.CardConfig{}, lists[1])
+	destroyedCard := factory.CreateCard(&factory.CardConfig{Index: 1}, lists[1])
+	suite.cardRepository.Destroy(&destroyedCard)
+	suite.repository.Destroy(&lists[1])
+
+	list, err := suite.repository.FindWithTrashed(lists[1].ID)
+	suite.Nil(err)
+	err = suite.repository.Restore(&list)
+
+	suite.Nil(err)
+	suite.Equal(1, list.Index)
+	list2, _ := suite.repository.Find(lists[2].ID)
+	suite.Equal(2, list2.Index)
+	_, err = suite.cardRepository.Find(card.ID)
+	suite.Nil(err)
+	// リストより先に削除したカードはゴミ箱に残す
+	_, err = suite.cardRepository.Find(destroyedCard.ID)
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *ListRepositoryTestSuite) TestSuccessRestoreAtEndWhenIndexIsOutOfRange() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	list := factory.CreateList(&factory.ListConfig{Index: 5}, user)
+	suite.repository.Archive(&list)
+	err := suite.repository.Restore(&list)
 
 	suite.Nil(err)
+	suite.Equal(0, list.Index)
+}
+
+func (suite *ListRepositoryTestSuite) TestSuccessDestroyPermanently() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	list := factory.CreateList(&factory.ListConfig{}, user)
+	card := factory.CreateCard(&factory.CardConfig{}, list)
+	suite.repository.Destroy(&list)
+	err := suite.repository.DestroyPermanently(&list)
+
+	suite.Nil(err)
+	_, err = suite.repository.FindWithTrashed(list.ID)
+	suite.Equal(gorm.ErrRecordNotFound, err)
+	_, err = suite.cardRepository.FindWithTrashed(card.ID)
+	suite.Equal(gorm.ErrRecordNotFound, err)
 }
@@ -0,0 +1,124 @@
+package repository_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type LoginAttemptRepositoryTestSuite struct {
+	suite.Suite
+	repository repository.LoginAttemptRepository
+	db         *gorm.DB
+}
+
+func (suite *LoginAttemptRepositoryTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	config.Init()
+	db.Init()
+	suite.repository = repository.NewLoginAttemptRepository()
+	suite.db = db.GetDB()
+}
+
+func (suite *LoginAttemptRepositoryTestSuite) TearDownSuite() {
+	db.CloseDB()
+}
+
+func (suite *LoginAttemptRepositoryTestSuite) TearDownTest() {
+	db.DeleteAll()
+}
+
+func TestLoginAttemptRepository(t *testing.T) {
+	suite.Run(t, new(LoginAttemptRepositoryTestSuite))
+}
+
+func (suite *LoginAttemptRepositoryTestSuite) TestSuccessIncrementAndFindByKey() {
+	now := time.Now()
+	attempt, err := suite.repository.Increment("user:1", now, now.Add(-time.Hour))
+	suite.Nil(err)
+	suite.Equal(1, attempt.Failures)
+
+	attempt, err = suite.repository.Increment("user:1", now, now.Add(-time.Hour))
+	suite.Nil(err)
+	suite.Equal(2, attempt.Failures)
+
+	rAttempt, err := suite.repository.FindByKey("user:1")
+	suite.Nil(err)
+	suite.Equal(attempt.ID, rAttempt.ID)
+	suite.Equal(2, rAttempt.Failures)
+}
+
+func (suite *LoginAttemptRepositoryTestSuite) TestSuccessIncrementWithResetFailures() {
+	lastFailedAt := time.Now().Add(-2 * time.Hour)
+	lockedUntil := time.Now().Add(-time.Hour)
+	suite.db.Create(&model.LoginAttempt{Key: "user:1", Failures: 5, LastFailedAt: lastFailedAt, LockedUntil: &lockedUntil})
+	now := time.Now()
+	attempt, err := suite.repository.Increment("user:1", now, now.Add(-time.Hour))
+
+	suite.Nil(err)
+	suite.Equal(1, attempt.Failures)
+	suite.Nil(attempt.LockedUntil)
+	suite.WithinDuration(now, attempt.LastFailedAt, time.Second)
+}
+
+func (suite *LoginAttemptRepositoryTestSuite) TestSuccessIncrementConcurrently() {
+	const n = 10
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			now := time.Now()
+			suite.repository.Increment("ip:192.0.2.1", now, now.Add(-time.Hour))
+		}()
+	}
+	wg.Wait()
+	attempt, err := suite.repository.FindByKey("ip:192.0.2.1")
+
+	suite.Nil(err)
+	suite.Equal(n, attempt.Failures)
+}
+
+func (suite *LoginAttemptRepositoryTestSuite) TestSuccessLock() {
+	now := time.Now()
+	attempt, _ := suite.repository.Increment("user:1", now, now.Add(-time.Hour))
+	lockedUntil := now.Add(time.Hour)
+	err := suite.repository.Lock(&attempt, lockedUntil)
+	suite.Nil(err)
+
+	// 短いロックで上書きしない
+	err = suite.repository.Lock(&attempt, now.Add(time.Minute))
+	suite.Nil(err)
+	rAttempt, _ := suite.repository.FindByKey("user:1")
+	suite.WithinDuration(lockedUntil, *rAttempt.LockedUntil, time.Second)
+}
+
+func (suite *LoginAttemptRepositoryTestSuite) TestSuccessFindAll() {
+	now := time.Now()
+	suite.repository.Increment("user:1", now, now.Add(-time.Hour))
+	suite.repository.Increment("ip:192.0.2.1", now, now.Add(-time.Hour))
+	suite.repository.Increment("ip:192.0.2.1", now, now.Add(-time.Hour))
+	attempts, err := suite.repository.FindAll()
+
+	suite.Nil(err)
+	suite.Len(attempts, 2)
+	suite.Equal("ip:192.0.2.1", attempts[0].Key)
+}
+
+func (suite *LoginAttemptRepositoryTestSuite) TestSuccessDestroyByKey() {
+	now := time.Now()
+	suite.repository.Increment("user:1", now, now.Add(-time.Hour))
+	err := suite.repository.DestroyByKey("user:1")
+
+	suite.Nil(err)
+	_, err = suite.repository.FindByKey("user:1")
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
@@ -0,0 +1,52 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type PasswordResetRepositoryTestSuite struct {
+	suite.Suite
+	repository repository.PasswordResetRepository
+	db         *gorm.DB
+}
+
+func (suite *PasswordResetRepositoryTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	config.Init()
+	db.Init()
+	suite.repository = repository.NewPasswordResetRepository()
+	suite.db = db.GetDB()
+}
+
+func (suite *PasswordResetRepositoryTestSuite) TearDownSuite() {
+	db.CloseDB()
+}
+
+func (suite *PasswordResetRepositoryTestSuite) TearDownTest() {
+	db.DeleteAll()
+}
+
+func TestPasswordResetRepository(t *testing.T) {
+	suite.Run(t, new(PasswordResetRepositoryTestSuite))
+}
+
+func (suite *PasswordResetRepositoryTestSuite) TestSuccessFindByDigest() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	reset := model.PasswordReset{Digest: "digest", ExpiresAt: time.Now().Add(time.Minute), UserID: user.ID}
+	suite.repository.Create(&reset)
+	rReset, err := suite.repository.FindByDigest(reset.Digest)
+
+	suite.Nil(err)
+	suite.Equal(reset.ID, rReset.ID)
+	suite.Equal(user.ID, rReset.User.ID)
+}
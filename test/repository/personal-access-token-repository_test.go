@@ -0,0 +1,97 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type PersonalAccessTokenRepositoryTestSuite struct {
+	suite.Suite
+	repository repository.PersonalAccessTokenRepository
+	db         *gorm.DB
+}
+
+func (suite *PersonalAccessTokenRepositoryTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	config.Init()
+	db.Init()
+	suite.repository = repository.NewPersonalAccessTokenRepository()
+	suite.db = db.GetDB()
+}
+
+func (suite *PersonalAccessTokenRepositoryTestSuite) TearDownSuite() {
+	db.CloseDB()
+}
+
+func (suite *PersonalAccessTokenRepositoryTestSuite) TearDownTest() {
+	db.DeleteAll()
+}
+
+func TestPersonalAccessTokenRepository(t *testing.T) {
+	suite.Run(t, new(PersonalAccessTokenRepositoryTestSuite))
+}
+
+func (suite *PersonalAccessTokenRepositoryTestSuite) TestSuccessCreateAndFindByDigest() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	token := model.PersonalAccessToken{Name: "script", Digest: "digest", Scopes: "lists:read", UserID: user.ID}
+	err := suite.repository.Create(&token)
+	suite.Nil(err)
+
+	rToken, err := suite.repository.FindByDigest("digest")
+	suite.Nil(err)
+	suite.Equal(token.ID, rToken.ID)
+	suite.Equal(user.ID, rToken.User.ID)
+}
+
+func (suite *PersonalAccessTokenRepositoryTestSuite) TestSuccessFindAll() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	anotherUser := factory.CreateUser(&factory.UserConfig{})
+	suite.repository.Create(&model.PersonalAccessToken{Name: "1", Digest: "1", Scopes: "lists:read", UserID: user.ID})
+	suite.repository.Create(&model.PersonalAccessToken{Name: "2", Digest: "2", Scopes: "lists:read", UserID: anotherUser.ID})
+	tokens, err := suite.repository.FindAll(&user)
+
+	suite.Nil(err)
+	suite.Len(tokens, 1)
+	suite.Equal("1", tokens[0].Name)
+}
+
+func (suite *PersonalAccessTokenRepositoryTestSuite) TestSuccessUpdateLastUsedAt() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	token := model.PersonalAccessToken{Name: "script", Digest: "digest", Scopes: "lists:read", UserID: user.ID}
+	suite.repository.Create(&token)
+	err := suite.repository.UpdateLastUsedAt(&token, time.Now())
+
+	suite.Nil(err)
+	rToken, _ := suite.repository.FindByDigest("digest")
+	suite.NotNil(rToken.LastUsedAt)
+}
+
+func (suite *PersonalAccessTokenRepositoryTestSuite) TestSuccessDestroy() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	token := model.PersonalAccessToken{Name: "script", Digest: "digest", Scopes: "lists:read", UserID: user.ID}
+	suite.repository.Create(&token)
+	err := suite.repository.Destroy(&user, token.ID)
+
+	suite.Nil(err)
+	_, err = suite.repository.FindByDigest("digest")
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *PersonalAccessTokenRepositoryTestSuite) TestBadDestroyWithAnotherUsersToken() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	anotherUser := factory.CreateUser(&factory.UserConfig{})
+	token := model.PersonalAccessToken{Name: "script", Digest: "digest", Scopes: "lists:read", UserID: anotherUser.ID}
+	suite.repository.Create(&token)
+	err := suite.repository.Destroy(&user, token.ID)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
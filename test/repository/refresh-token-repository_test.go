@@ -0,0 +1,91 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepositoryTestSuite struct {
+	suite.Suite
+	repository repository.RefreshTokenRepository
+	db         *gorm.DB
+}
+
+func (suite *RefreshTokenRepositoryTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	config.Init()
+	db.Init()
+	suite.repository = repository.NewRefreshTokenRepository()
+	suite.db = db.GetDB()
+}
+
+func (suite *RefreshTokenRepositoryTestSuite) TearDownSuite() {
+	db.CloseDB()
+}
+
+func (suite *RefreshTokenRepositoryTestSuite) TearDownTest() {
+	db.DeleteAll()
+}
+
+func TestRefreshTokenRepository(t *testing.T) {
+	suite.Run(t, new(RefreshTokenRepositoryTestSuite))
+}
+
+func (suite *RefreshTokenRepositoryTestSuite) createRefreshToken(digest, family string, user model.User) model.RefreshToken {
+	token := model.RefreshToken{Digest: digest, Family: family, ExpiresAt: time.Now().Add(time.Hour), UserID: user.ID}
+	suite.repository.Create(&token)
+	return token
+}
+
+func (suite *RefreshTokenRepositoryTestSuite) TestSuccessFindByDigest() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	token := suite.createRefreshToken("digest", "family", user)
+	rToken, err := suite.repository.FindByDigest(token.Digest)
+
+	suite.Nil(err)
+	suite.Equal(token.ID, rToken.ID)
+	suite.Equal(user.ID, rToken.User.ID)
+}
+
+func (suite *RefreshTokenRepositoryTestSuite) TestSuccessUse() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	token := suite.createRefreshToken("digest", "family", user)
+	err := suite.repository.Use(&token)
+
+	suite.Nil(err)
+	suite.True(token.Used)
+	rToken, _ := suite.repository.FindByDigest(token.Digest)
+	suite.True(rToken.Used)
+}
+
+func (suite *RefreshTokenRepositoryTestSuite) TestBadUseWithUsedToken() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	token := suite.createRefreshToken("digest", "family", user)
+	suite.repository.Use(&token)
+	err := suite.repository.Use(&token)
+
+	suite.Equal(config.ReusedRefreshTokenError, err)
+}
+
+func (suite *RefreshTokenRepositoryTestSuite) TestSuccessDestroyFamily() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	suite.createRefreshToken("digest1", "family", user)
+	suite.createRefreshToken("digest2", "family", user)
+	other := suite.createRefreshToken("digest3", "other", user)
+	err := suite.repository.DestroyFamily("family")
+
+	suite.Nil(err)
+	var tokens []model.RefreshToken
+	suite.db.Unscoped().Find(&tokens)
+	suite.Len(tokens, 1)
+	suite.Equal(other.ID, tokens[0].ID)
+}
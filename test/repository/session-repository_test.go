@@ -0,0 +1,110 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type SessionRepositoryTestSuite struct {
+	suite.Suite
+	repository repository.SessionRepository
+	db         *gorm.DB
+}
+
+func (suite *SessionRepositoryTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	config.Init()
+	db.Init()
+	suite.repository = repository.NewSessionRepository()
+	suite.db = db.GetDB()
+}
+
+func (suite *SessionRepositoryTestSuite) TearDownSuite() {
+	db.CloseDB()
+}
+
+func (suite *SessionRepositoryTestSuite) TearDownTest() {
+	db.DeleteAll()
+}
+
+func TestSessionRepository(t *testing.T) {
+	suite.Run(t, new(SessionRepositoryTestSuite))
+}
+
+func (suite *SessionRepositoryTestSuite) TestSuccessCreateAndFindBySessionID() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	session := model.Session{SessionID: "sid", UserAgent: "browser", IP: "127.0.0.1", LastSeenAt: time.Now(), UserID: user.ID}
+	err := suite.repository.Create(&session)
+	suite.Nil(err)
+
+	rSession, err := suite.repository.FindBySessionID("sid")
+	suite.Nil(err)
+	suite.Equal(session.ID, rSession.ID)
+}
+
+func (suite *SessionRepositoryTestSuite) TestSuccessFindAll() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	anotherUser := factory.CreateUser(&factory.UserConfig{})
+	factory.CreateSession(user, "1")
+	factory.CreateSession(anotherUser, "2")
+	sessions, err := suite.repository.FindAll(&user)
+
+	suite.Nil(err)
+	suite.Len(sessions, 1)
+	suite.Equal("1", sessions[0].SessionID)
+}
+
+func (suite *SessionRepositoryTestSuite) TestBadFindWithOtherUserSession() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	anotherUser := factory.CreateUser(&factory.UserConfig{})
+	session := factory.CreateSession(anotherUser, "sid")
+	_, err := suite.repository.Find(&user, session.ID)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *SessionRepositoryTestSuite) TestSuccessFindActive() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	session := factory.CreateSession(user, "sid")
+	rSession, err := suite.repository.FindActive("sid", user.ID, "jti")
+
+	suite.Nil(err)
+	suite.Equal(session.ID, rSession.ID)
+}
+
+func (suite *SessionRepositoryTestSuite) TestBadFindActiveWithRevokedJTI() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	factory.CreateSession(user, "sid")
+	suite.db.Create(&model.RevokedToken{JTI: "jti", ExpiresAt: time.Now().Add(time.Hour)})
+	_, err := suite.repository.FindActive("sid", user.ID, "jti")
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *SessionRepositoryTestSuite) TestBadFindActiveWithOtherUserSession() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	anotherUser := factory.CreateUser(&factory.UserConfig{})
+	factory.CreateSession(anotherUser, "sid")
+	_, err := suite.repository.FindActive("sid", user.ID, "jti")
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *SessionRepositoryTestSuite) TestSuccessDestroyBySessionID() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	factory.CreateSession(user, "sid")
+	err := suite.repository.DestroyBySessionID("sid")
+	suite.Nil(err)
+
+	_, err = suite.repository.FindBySessionID("sid")
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
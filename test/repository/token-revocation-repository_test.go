@@ -0,0 +1,93 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type TokenRevocationRepositoryTestSuite struct {
+	suite.Suite
+	repository repository.TokenRevocationRepository
+	db         *gorm.DB
+}
+
+func (suite *TokenRevocationRepositoryTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	config.Init()
+	db.Init()
+	suite.repository = repository.NewTokenRevocationRepository()
+	suite.db = db.GetDB()
+}
+
+func (suite *TokenRevocationRepositoryTestSuite) TearDownSuite() {
+	db.CloseDB()
+}
+
+func (suite *TokenRevocationRepositoryTestSuite) TearDownTest() {
+	db.DeleteAll()
+}
+
+func TestTokenRevocationRepository(t *testing.T) {
+	suite.Run(t, new(TokenRevocationRepositoryTestSuite))
+}
+
+func (suite *TokenRevocationRepositoryTestSuite) TestSuccessRevoke() {
+	err := suite.repository.Revoke("jti", time.Now().Add(time.Minute))
+
+	suite.Nil(err)
+	revoked, err := suite.repository.IsRevoked("jti")
+	suite.Nil(err)
+	suite.True(revoked)
+}
+
+func (suite *TokenRevocationRepositoryTestSuite) TestSuccessRevokeDeletesExpiredJTI() {
+	suite.repository.Revoke("expired", time.Now().Add(-time.Minute))
+	suite.repository.Revoke("jti", time.Now().Add(time.Minute))
+
+	revoked, _ := suite.repository.IsRevoked("expired")
+	suite.False(revoked)
+}
+
+func (suite *TokenRevocationRepositoryTestSuite) TestSuccessRevokeOnce() {
+	ok, err := suite.repository.RevokeOnce("jti", time.Now().Add(time.Minute))
+	suite.Nil(err)
+	suite.True(ok)
+
+	ok, err = suite.repository.RevokeOnce("jti", time.Now().Add(time.Minute))
+	suite.Nil(err)
+	suite.False(ok)
+}
+
+func (suite *TokenRevocationRepositoryTestSuite) TestSuccessIsRevokedWithNotRevokedJTI() {
+	revoked, err := suite.repository.IsRevoked("jti")
+
+	suite.Nil(err)
+	suite.False(revoked)
+}
+
+func (suite *TokenRevocationRepositoryTestSuite) TestSuccessRevokeAll() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	suite.db.Create(&model.RefreshToken{Digest: "digest", Family: "family", UserID: user.ID})
+	suite.db.Create(&model.PersonalAccessToken{Name: "ci", Digest: "digest", Scopes: model.ScopeListsRead, UserID: user.ID})
+	err := suite.repository.RevokeAll(&user)
+
+	suite.Nil(err)
+	suite.Equal(1, user.TokenVersion)
+	var rUser model.User
+	suite.db.First(&rUser, user.ID)
+	suite.Equal(1, rUser.TokenVersion)
+	var count int64
+	suite.db.Model(model.RefreshToken{}).Count(&count)
+	suite.Equal(int64(0), count)
+	suite.db.Model(model.PersonalAccessToken{}).Count(&count)
+	suite.Equal(int64(0), count)
+}
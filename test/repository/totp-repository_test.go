@@ -0,0 +1,107 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type TotpRepositoryTestSuite struct {
+	suite.Suite
+	repository repository.TotpRepository
+	db         *gorm.DB
+}
+
+func (suite *TotpRepositoryTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	config.Init()
+	db.Init()
+	suite.repository = repository.NewTotpRepository()
+	suite.db = db.GetDB()
+}
+
+func (suite *TotpRepositoryTestSuite) TearDownSuite() {
+	db.CloseDB()
+}
+
+func (suite *TotpRepositoryTestSuite) TearDownTest() {
+	db.DeleteAll()
+}
+
+func TestTotpRepository(t *testing.T) {
+	suite.Run(t, new(TotpRepositoryTestSuite))
+}
+
+func (suite *TotpRepositoryTestSuite) TestSuccessSaveSecret() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	err := suite.repository.SaveSecret(&user, "secret")
+
+	suite.Nil(err)
+	var rUser model.User
+	suite.db.First(&rUser, user.ID)
+	suite.Equal("secret", rUser.TotpSecret)
+	suite.False(rUser.TotpEnabled)
+}
+
+func (suite *TotpRepositoryTestSuite) TestSuccessEnable() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	suite.repository.Enable(&user, []string{"old"})
+	err := suite.repository.Enable(&user, []string{"digest1", "digest2"})
+
+	suite.Nil(err)
+	var rUser model.User
+	suite.db.First(&rUser, user.ID)
+	suite.True(rUser.TotpEnabled)
+	var codes []model.RecoveryCode
+	suite.db.Where("user_id = ?", user.ID).Find(&codes)
+	suite.Len(codes, 2)
+}
+
+func (suite *TotpRepositoryTestSuite) TestSuccessDisable() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	suite.repository.SaveSecret(&user, "secret")
+	suite.repository.Enable(&user, []string{"digest"})
+	err := suite.repository.Disable(&user)
+
+	suite.Nil(err)
+	var rUser model.User
+	suite.db.First(&rUser, user.ID)
+	suite.False(rUser.TotpEnabled)
+	suite.Empty(rUser.TotpSecret)
+	var count int64
+	suite.db.Model(model.RecoveryCode{}).Count(&count)
+	suite.Equal(int64(0), count)
+}
+
+func (suite *TotpRepositoryTestSuite) TestSuccessUseRecoveryCode() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	suite.repository.Enable(&user, []string{"digest"})
+	err := suite.repository.UseRecoveryCode(&user, "digest")
+	suite.Nil(err)
+
+	err = suite.repository.UseRecoveryCode(&user, "digest")
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *TotpRepositoryTestSuite) TestSuccessUseTimeStep() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	err := suite.repository.UseTimeStep(&user, 100)
+	suite.Nil(err)
+	suite.Equal(int64(100), user.TotpLastStep)
+
+	// 同じステップや以前のステップは使えない
+	err = suite.repository.UseTimeStep(&user, 100)
+	suite.Equal(gorm.ErrRecordNotFound, err)
+	err = suite.repository.UseTimeStep(&user, 99)
+	suite.Equal(gorm.ErrRecordNotFound, err)
+
+	err = suite.repository.UseTimeStep(&user, 101)
+	suite.Nil(err)
+}
@@ -2,6 +2,7 @@ package repository_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/kuritaeiji/todo-gin-back/config"
@@ -89,6 +90,35 @@ func (suite *UserRepositoryTestSuite) TestSuccessActivate() {
 	suite.Nil(err)
 }
 
+func (suite *UserRepositoryTestSuite) TestSuccessUpdateActivationSentAt() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	sentAt := time.Now()
+	err := suite.userRepository.UpdateActivationSentAt(&user, sentAt)
+
+	suite.Nil(err)
+	var rUser model.User
+	suite.db.First(&rUser, user.ID)
+	suite.NotNil(rUser.ActivationSentAt)
+	suite.WithinDuration(sentAt, *rUser.ActivationSentAt, time.Second)
+}
+
+func (suite *UserRepositoryTestSuite) TestSuccessUpdatePassword() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	suite.db.Create(&model.PasswordReset{Digest: "digest1", UserID: user.ID})
+	suite.db.Create(&model.PasswordReset{Digest: "digest2", UserID: user.ID})
+	user.PasswordDigest = "new digest"
+	err := suite.userRepository.UpdatePassword(&user)
+
+	suite.Nil(err)
+	var rUser model.User
+	suite.db.First(&rUser, user.ID)
+	suite.Equal("new digest", rUser.PasswordDigest)
+	suite.Equal(1, rUser.TokenVersion)
+	var count int64
+	suite.db.Model(model.PasswordReset{}).Count(&count)
+	suite.Equal(int64(0), count)
+}
+
 func (suite *UserRepositoryTestSuite) TestSuccessFindByEmail() {
 	email := "user@example.com"
 	user := model.User{Email: email}
@@ -120,6 +150,46 @@ func (suite *UserRepositoryTestSuite) TestSuccessDestroy() {
 	suite.Equal(gorm.ErrRecordNotFound, err)
 }
 
+func (suite *UserRepositoryTestSuite) TestSuccessDestroyKeepsBoardWithOtherOwner() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	coOwner := factory.CreateUser(&factory.UserConfig{})
+	editor := factory.CreateUser(&factory.UserConfig{})
+	board := factory.CreateBoard(&factory.BoardConfig{}, user)
+	factory.CreateBoardMember(board, coOwner, model.BoardRoleOwner)
+	factory.CreateBoardMember(board, editor, model.BoardRoleEditor)
+	factory.CreateBoard(&factory.BoardConfig{}, coOwner)
+	list := factory.CreateBoardList(&factory.ListConfig{}, board)
+	soleOwnedBoard := factory.CreateBoard(&factory.BoardConfig{}, user)
+	factory.CreateBoardMember(soleOwnedBoard, editor, model.BoardRoleEditor)
+	err := suite.userRepository.Destroy(&user)
+
+	suite.Nil(err)
+	var rBoard model.Board
+	suite.Nil(suite.db.First(&rBoard, board.ID).Error)
+	suite.Equal(coOwner.ID, rBoard.UserID)
+	suite.Equal(1, rBoard.Index)
+	_, err = suite.listRepository.Find(list.ID)
+	suite.Nil(err)
+	var count int64
+	suite.db.Model(model.BoardMember{}).Where("board_id = ?", board.ID).Count(&count)
+	suite.Equal(int64(2), count)
+	suite.Equal(gorm.ErrRecordNotFound, suite.db.First(&model.Board{}, soleOwnedBoard.ID).Error)
+	suite.db.Model(model.BoardMember{}).Where("board_id = ?", soleOwnedBoard.ID).Count(&count)
+	suite.Equal(int64(0), count)
+}
+
+func (suite *UserRepositoryTestSuite) TestSuccessDestroyRevokesTokens() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	suite.db.Create(&model.RefreshToken{Digest: "digest", Family: "family", UserID: user.ID})
+	err := suite.userRepository.Destroy(&user)
+
+	suite.Nil(err)
+	suite.Equal(1, user.TokenVersion)
+	var count int64
+	suite.db.Model(model.RefreshToken{}).Where("user_id = ?", user.ID).Count(&count)
+	suite.Equal(int64(0), count)
+}
+
 func (suite *UserRepositoryTestSuite) TestBadDestroyWithDBError() {
 	user := factory.NewUser(&factory.UserConfig{})
 	err := suite.userRepository.Destroy(&user)
@@ -127,42 +197,144 @@ func (suite *UserRepositoryTestSuite) TestBadDestroyWithDBError() {
 	suite.Error(err)
 }
 
-func (suite *UserRepositoryTestSuite) TestSuccessFindOrCreateByOpenIDWhenUserHasBeenAlreadyCreated() {
-	const openID = "1"
-	user := factory.CreateUser(&factory.UserConfig{OpenID: openID})
-	rUser, err := suite.userRepository.FindOrCreateByOpenID(openID)
+func (suite *UserRepositoryTestSuite) identityCount(query string, args ...interface{}) int64 {
+	var count int64
+	suite.db.Model(model.Identity{}).Where(query, args...).Count(&count)
+	return count
+}
+
+func (suite *UserRepositoryTestSuite) TestSuccessFindOrCreateByIdentityWhenUserHasBeenAlreadyCreated() {
+	const subject = "1"
+	user := factory.CreateUser(&factory.UserConfig{})
+	factory.CreateIdentity(user, "google", subject)
+	rUser, err := suite.userRepository.FindOrCreateByIdentity("google", subject, "", true)
 
 	suite.Nil(err)
-	suite.Equal(user, rUser)
+	suite.Equal(user.ID, rUser.ID)
 }
 
-func (suite *UserRepositoryTestSuite) TestSuccessFindOrCreateByOpenIDWhenUserHasNotBeenCreated() {
-	const openID = "1"
-	_, err := suite.userRepository.FindOrCreateByOpenID(openID)
-	rUser, _ := suite.userRepository.FindOrCreateByOpenID(openID)
+func (suite *UserRepositoryTestSuite) TestSuccessFindOrCreateByIdentityWhenUserHasNotBeenCreated() {
+	const subject = "1"
+	rUser, err := suite.userRepository.FindOrCreateByIdentity("google", subject, "", true)
+	rUser2, _ := suite.userRepository.FindOrCreateByIdentity("google", subject, "", true)
 
 	suite.Nil(err)
-	suite.Equal(openID, rUser.OpenID)
+	suite.Equal(rUser.ID, rUser2.ID)
 	suite.True(rUser.Activated)
+	suite.Equal(int64(1), suite.identityCount("user_id = ? AND provider = ? AND subject = ?", rUser.ID, "google", subject))
 }
 
-func (suite *UserRepositoryTestSuite) TestTrueHasCard() {
+func (suite *UserRepositoryTestSuite) TestSuccessFindOrCreateByIdentityWithSameSubjectOfAnotherProvider() {
+	const subject = "1"
 	user := factory.CreateUser(&factory.UserConfig{})
-	list := factory.CreateList(&factory.ListConfig{}, user)
-	card := factory.CreateCard(&factory.CardConfig{}, list)
-	hasCard, err := suite.userRepository.HasCard(card, user)
+	factory.CreateIdentity(user, "google", subject)
+	rUser, err := suite.userRepository.FindOrCreateByIdentity("company", subject, "", true)
+
+	suite.Nil(err)
+	suite.NotEqual(user.ID, rUser.ID)
+}
+
+func (suite *UserRepositoryTestSuite) TestSuccessFindOrCreateByIdentityWithLinkingActivatedUser() {
+	const subject = "1"
+	user := factory.CreateUser(&factory.UserConfig{Activated: true})
+	rUser, err := suite.userRepository.FindOrCreateByIdentity("google", subject, user.Email, true)
 
-	suite.True(hasCard)
 	suite.Nil(err)
+	suite.Equal(user.ID, rUser.ID)
+	suite.Equal(int64(1), suite.identityCount("user_id = ? AND provider = ? AND subject = ?", user.ID, "google", subject))
+	var count int64
+	suite.db.Model(model.User{}).Count(&count)
+	suite.Equal(int64(1), count)
+}
+
+func (suite *UserRepositoryTestSuite) TestSuccessFindOrCreateByIdentityWithNewVerifiedEmail() {
+	const email = "google@example.com"
+	rUser, err := suite.userRepository.FindOrCreateByIdentity("google", "1", email, true)
+
+	suite.Nil(err)
+	suite.Equal(email, rUser.Email)
+	suite.Equal(int64(1), suite.identityCount("user_id = ?", rUser.ID))
 }
 
-func (suite *UserRepositoryTestSuite) TestFalseHasCard() {
+func (suite *UserRepositoryTestSuite) TestBadFindOrCreateByIdentityWithUntrustedProvider() {
+	user := factory.CreateUser(&factory.UserConfig{Activated: true})
+	_, err := suite.userRepository.FindOrCreateByIdentity("company", "1", user.Email, false)
+
+	suite.Equal(config.AccountLinkConflictError, err)
+	suite.Equal(int64(0), suite.identityCount("user_id = ?", user.ID))
+}
+
+func (suite *UserRepositoryTestSuite) TestBadFindOrCreateByIdentityWithNotActivatedUser() {
 	user := factory.CreateUser(&factory.UserConfig{})
-	otherUser := factory.CreateUser(&factory.UserConfig{})
-	list := factory.CreateList(&factory.ListConfig{}, otherUser)
-	card := factory.CreateCard(&factory.CardConfig{}, list)
-	hasCard, err := suite.userRepository.HasCard(card, user)
+	_, err := suite.userRepository.FindOrCreateByIdentity("google", "1", user.Email, true)
+
+	suite.Equal(config.AccountLinkConflictError, err)
+}
+
+func (suite *UserRepositoryTestSuite) TestBadFindOrCreateByIdentityWithAnotherIdentityOfSameProvider() {
+	user := factory.CreateUser(&factory.UserConfig{Activated: true})
+	factory.CreateIdentity(user, "google", "2")
+	_, err := suite.userRepository.FindOrCreateByIdentity("google", "1", user.Email, true)
+
+	suite.Equal(config.AccountLinkConflictError, err)
+}
+
+func (suite *UserRepositoryTestSuite) TestSuccessLinkIdentity() {
+	user := factory.CreateUser(&factory.UserConfig{Activated: true})
+	factory.CreateIdentity(user, "company", "1")
+	err := suite.userRepository.LinkIdentity(&user, "google", "1")
+
+	suite.Nil(err)
+	suite.Equal(int64(2), suite.identityCount("user_id = ?", user.ID))
+}
+
+func (suite *UserRepositoryTestSuite) TestSuccessLinkIdentityWithReplacingSameProvider() {
+	user := factory.CreateUser(&factory.UserConfig{Activated: true})
+	factory.CreateIdentity(user, "google", "1")
+	err := suite.userRepository.LinkIdentity(&user, "google", "2")
 
-	suite.False(hasCard)
 	suite.Nil(err)
+	suite.Equal(int64(1), suite.identityCount("user_id = ?", user.ID))
+	suite.Equal(int64(1), suite.identityCount("user_id = ? AND subject = ?", user.ID, "2"))
+}
+
+func (suite *UserRepositoryTestSuite) TestBadLinkIdentityWithLinkedToAnotherUser() {
+	anotherUser := factory.CreateUser(&factory.UserConfig{})
+	factory.CreateIdentity(anotherUser, "google", "1")
+	user := factory.CreateUser(&factory.UserConfig{Activated: true})
+	err := suite.userRepository.LinkIdentity(&user, "google", "1")
+
+	suite.Equal(config.IdentityAlreadyLinkedError, err)
+}
+
+func (suite *UserRepositoryTestSuite) TestSuccessUnlinkIdentity() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	factory.CreateIdentity(user, "google", "1")
+	err := suite.userRepository.UnlinkIdentity(&user, "google")
+
+	suite.Nil(err)
+	suite.Equal(int64(0), suite.identityCount("user_id = ?", user.ID))
+}
+
+func (suite *UserRepositoryTestSuite) TestSuccessUnlinkIdentityWithoutPasswordWhenAnotherIdentityIsLinked() {
+	user, _ := suite.userRepository.FindOrCreateByIdentity("google", "1", "", true)
+	factory.CreateIdentity(user, "company", "1")
+	err := suite.userRepository.UnlinkIdentity(&user, "google")
+
+	suite.Nil(err)
+	suite.Equal(int64(1), suite.identityCount("user_id = ?", user.ID))
+}
+
+func (suite *UserRepositoryTestSuite) TestBadUnlinkIdentityWithoutPassword() {
+	user, _ := suite.userRepository.FindOrCreateByIdentity("google", "1", "", true)
+	err := suite.userRepository.UnlinkIdentity(&user, "google")
+
+	suite.Equal(config.UnlinkLastLoginMethodError, err)
+}
+
+func (suite *UserRepositoryTestSuite) TestBadUnlinkIdentityWithRecordNotFound() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	err := suite.userRepository.UnlinkIdentity(&user, "google")
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
 }
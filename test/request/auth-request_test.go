@@ -15,6 +15,7 @@ import (
 	"github.com/kuritaeiji/todo-gin-back/db"
 	"github.com/kuritaeiji/todo-gin-back/dto"
 	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/gateway"
 	"github.com/kuritaeiji/todo-gin-back/model"
 	"github.com/kuritaeiji/todo-gin-back/server"
 	"github.com/kuritaeiji/todo-gin-back/service"
@@ -35,11 +36,12 @@ func (suite *AuthRequestTestSuite) SetupSuite() {
 	config.Init()
 	db.Init()
 	validators.Init()
-	suite.router = server.RouterSetup(controller.NewUserController())
 	suite.db = db.GetDB()
 }
 
 func (suite *AuthRequestTestSuite) SetupTest() {
+	// テストごとにレート制限をリセットする
+	suite.router = server.RouterSetup(controller.NewUserController(), gateway.NewMemoryRateLimitStore())
 	suite.rec = httptest.NewRecorder()
 }
 
@@ -64,6 +66,7 @@ func (suite *AuthRequestTestSuite) TestSuccessLogin() {
 	dtoUser := dto.User{Email: email, Password: password}
 	var user model.User
 	dtoUser.Transfer(&user)
+	user.Activated = true
 	suite.db.Create(&user)
 
 	body := map[string]string{
@@ -80,7 +83,8 @@ func (suite *AuthRequestTestSuite) TestSuccessLogin() {
 	tokenString := re.FindStringSubmatch(suite.rec.Body.String())[1]
 	claim, err := service.NewJWTService().VerifyJWT(tokenString)
 	suite.Equal(user.ID, claim.ID)
-	suite.InEpsilon(time.Now().AddDate(0, 0, service.DayFromNowAccessToken).Unix(), claim.ExpiresAt, 30)
+	suite.InEpsilon(time.Now().Add(service.MinuteFromNowAccessToken*time.Minute).Unix(), claim.ExpiresAt, 30)
+	suite.Regexp(`"refreshToken":"(.+?)"`, suite.rec.Body.String())
 	suite.Nil(err)
 }
 
@@ -126,3 +130,88 @@ func (suite *AuthRequestTestSuite) TestBadLoginWithPasswordAuthenticationError()
 	suite.Equal(config.PasswordAuthenticationErrorResponse.Code, suite.rec.Code)
 	suite.Contains(suite.rec.Body.String(), config.PasswordAuthenticationErrorResponse.Json["content"])
 }
+
+func (suite *AuthRequestTestSuite) TestBadLoginWithNotActivatedUser() {
+	userConfig := factory.UserConfig{}
+	factory.CreateUser(&userConfig)
+	req := httptest.NewRequest("POST", "/api/login", factory.CreateUserRequestBody(&userConfig))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.router.ServeHTTP(suite.rec, req)
+
+	suite.Equal(config.NotActivatedUserErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.NotActivatedUserErrorResponse.Json["content"])
+}
+
+func (suite *AuthRequestTestSuite) TestSuccessRefresh() {
+	user := factory.CreateUser(&factory.UserConfig{Activated: true})
+	refreshToken, _ := service.NewRefreshTokenService().Create(user, "family")
+	req := httptest.NewRequest("POST", "/api/token/refresh", factory.CreateRefreshTokenRequestBody(refreshToken))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.router.ServeHTTP(suite.rec, req)
+
+	suite.Equal(200, suite.rec.Code)
+	re, _ := regexp.Compile(`"token":"(.+?)"`)
+	claim, err := service.NewJWTService().VerifyJWT(re.FindStringSubmatch(suite.rec.Body.String())[1])
+	suite.Equal(user.ID, claim.ID)
+	suite.Nil(err)
+	re, _ = regexp.Compile(`"refreshToken":"(.+?)"`)
+	suite.NotEqual(refreshToken, re.FindStringSubmatch(suite.rec.Body.String())[1])
+}
+
+func (suite *AuthRequestTestSuite) TestBadRefreshWithReusedRefreshToken() {
+	user := factory.CreateUser(&factory.UserConfig{Activated: true})
+	refreshToken, _ := service.NewRefreshTokenService().Create(user, "family")
+	_, newRefreshToken, _ := service.NewRefreshTokenService().Rotate(refreshToken)
+
+	req := httptest.NewRequest("POST", "/api/token/refresh", factory.CreateRefreshTokenRequestBody(refreshToken))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.router.ServeHTTP(suite.rec, req)
+
+	suite.Equal(config.InvalidRefreshTokenErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.InvalidRefreshTokenErrorResponse.Json["content"])
+
+	// 同じfamilyのトークンは全て無効になる
+	var count int64
+	suite.db.Model(model.RefreshToken{}).Count(&count)
+	suite.Equal(int64(0), count)
+	_, _, err := service.NewRefreshTokenService().Rotate(newRefreshToken)
+	suite.Equal(config.InvalidRefreshTokenError, err)
+}
+
+func (suite *AuthRequestTestSuite) TestSuccessLogout() {
+	user := factory.CreateUser(&factory.UserConfig{Activated: true})
+	refreshToken, _ := service.NewRefreshTokenService().Create(user, "sessionID")
+	accessToken := service.NewJWTService().CreateAccessJWT(user, "sessionID")
+	req := httptest.NewRequest("DELETE", "/api/logout", nil)
+	req.Header.Add(config.TokenHeader, config.Bearer+accessToken)
+	suite.router.ServeHTTP(suite.rec, req)
+
+	suite.Equal(200, suite.rec.Code)
+	_, _, err := service.NewRefreshTokenService().Rotate(refreshToken)
+	suite.Equal(config.InvalidRefreshTokenError, err)
+
+	rec := httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/boards", nil)
+	req.Header.Add(config.TokenHeader, config.Bearer+accessToken)
+	suite.router.ServeHTTP(rec, req)
+	suite.Equal(config.NotLoggedInErrorResponse.Code, rec.Code)
+}
+
+func (suite *AuthRequestTestSuite) TestSuccessLogoutAll() {
+	user := factory.CreateUser(&factory.UserConfig{Activated: true})
+	otherAccessToken := service.NewJWTService().CreateAccessJWT(user, "other")
+	refreshToken, _ := service.NewRefreshTokenService().Create(user, "other")
+	req := httptest.NewRequest("DELETE", "/api/sessions", nil)
+	req.Header.Add(config.TokenHeader, config.Bearer+service.NewJWTService().CreateAccessJWT(user, "sessionID"))
+	suite.router.ServeHTTP(suite.rec, req)
+
+	suite.Equal(200, suite.rec.Code)
+	_, _, err := service.NewRefreshTokenService().Rotate(refreshToken)
+	suite.Equal(config.InvalidRefreshTokenError, err)
+
+	rec := httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/boards", nil)
+	req.Header.Add(config.TokenHeader, config.Bearer+otherAccessToken)
+	suite.router.ServeHTTP(rec, req)
+	suite.Equal(config.NotLoggedInErrorResponse.Code, rec.Code)
+}
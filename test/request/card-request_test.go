@@ -13,6 +13,7 @@ import (
 	"github.com/kuritaeiji/todo-gin-back/db"
 	"github.com/kuritaeiji/todo-gin-back/dto"
 	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/gateway"
 	"github.com/kuritaeiji/todo-gin-back/model"
 	"github.com/kuritaeiji/todo-gin-back/repository"
 	"github.com/kuritaeiji/todo-gin-back/server"
@@ -35,13 +36,14 @@ func (suite *CardRequestTestSuite) SetupSuite() {
 	config.Init()
 	validators.Init()
 	db.Init()
-	suite.router = server.RouterSetup(controller.NewUserController())
 	suite.db = db.GetDB()
 	suite.repository = repository.NewCardRepository()
 	suite.listRepository = repository.NewListRepository()
 }
 
 func (suite *CardRequestTestSuite) SetupTest() {
+	// テストごとにレート制限をリセットする
+	suite.router = server.RouterSetup(controller.NewUserController(), gateway.NewMemoryRateLimitStore())
 	suite.rec = httptest.NewRecorder()
 }
 
@@ -190,8 +192,9 @@ func (suite *CardRequestTestSuite) TestSuccessMoveCardWhenIncreaseIndex() {
 	suite.router.ServeHTTP(suite.rec, req)
 
 	suite.Equal(200, suite.rec.Code)
-	suite.listRepository.FindListsWithCards(&user)
-	cards = user.Lists[0].Cards
+	board := factory.UserBoard(user)
+	suite.listRepository.FindListsWithCards(&board)
+	cards = board.Lists[0].Cards
 	suite.Equal("0", cards[0].Title)
 	suite.Equal("2", cards[1].Title)
 	suite.Equal("3", cards[2].Title)
@@ -213,8 +216,9 @@ func (suite *CardRequestTestSuite) TestSuccessMoveCardWhenDecreaseIndex() {
 
 	suite.Equal(200, suite.rec.Code)
 	fmt.Println(suite.rec.Body.String())
-	suite.listRepository.FindListsWithCards(&user)
-	cards = user.Lists[0].Cards
+	board := factory.UserBoard(user)
+	suite.listRepository.FindListsWithCards(&board)
+	cards = board.Lists[0].Cards
 	suite.Equal("0", cards[0].Title)
 	suite.Equal("3", cards[1].Title)
 	suite.Equal("1", cards[2].Title)
@@ -238,9 +242,10 @@ func (suite *CardRequestTestSuite) TestSuccessMoveCardWhenChangeList() {
 	suite.router.ServeHTTP(suite.rec, req)
 
 	suite.Equal(200, suite.rec.Code)
-	suite.listRepository.FindListsWithCards(&user)
-	cards1 = user.Lists[0].Cards
-	cards2 = user.Lists[1].Cards
+	board := factory.UserBoard(user)
+	suite.listRepository.FindListsWithCards(&board)
+	cards1 = board.Lists[0].Cards
+	cards2 = board.Lists[1].Cards
 	suite.Equal("0card1", cards1[0].Title)
 	suite.Equal("2card1", cards1[1].Title)
 	suite.Equal("0card2", cards2[0].Title)
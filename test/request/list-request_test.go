@@ -12,6 +12,7 @@ import (
 	"github.com/kuritaeiji/todo-gin-back/controller"
 	"github.com/kuritaeiji/todo-gin-back/db"
 	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/gateway"
 	"github.com/kuritaeiji/todo-gin-back/model"
 	"github.com/kuritaeiji/todo-gin-back/repository"
 	"github.com/kuritaeiji/todo-gin-back/server"
@@ -33,12 +34,13 @@ func (suite *ListRequestTestSuite) SetupSuite() {
 	config.Init()
 	validators.Init()
 	db.Init()
-	suite.router = server.RouterSetup(controller.NewUserController())
 	suite.db = db.GetDB()
 	suite.repository = repository.NewListRepository()
 }
 
 func (suite *ListRequestTestSuite) SetupTest() {
+	// テストごとにレート制限をリセットする
+	suite.router = server.RouterSetup(controller.NewUserController(), gateway.NewMemoryRateLimitStore())
 	suite.rec = httptest.NewRecorder()
 }
 
@@ -61,7 +63,7 @@ func (suite *ListRequestTestSuite) TestSuccessIndex() {
 	list2 := factory.CreateList(&factory.ListConfig{Index: 1}, user)
 	list1.Cards = []model.Card{factory.CreateCard(&factory.CardConfig{}, list1)}
 	list2.Cards = []model.Card{factory.CreateCard(&factory.CardConfig{}, list2)}
-	req := httptest.NewRequest("GET", "/api/lists", nil)
+	req := httptest.NewRequest("GET", fmt.Sprintf("/api/boards/%v/lists", list1.BoardID), nil)
 	req.Header.Add(config.TokenHeader, token)
 	suite.router.ServeHTTP(suite.rec, req)
 
@@ -82,9 +84,10 @@ func (suite *ListRequestTestSuite) TestSuccessIndex() {
 func (suite *ListRequestTestSuite) TestSuccessCreate() {
 	user := factory.CreateUser(&factory.UserConfig{})
 	token := factory.CreateAccessToken(user)
+	board := factory.CreateBoard(&factory.BoardConfig{}, user)
 	listConfig := &factory.ListConfig{}
 	body := factory.CreateListRequestBody(listConfig)
-	req := httptest.NewRequest("POST", "/api/lists", body)
+	req := httptest.NewRequest("POST", fmt.Sprintf("/api/boards/%v/lists", board.ID), body)
 	req.Header.Add(config.TokenHeader, token)
 	suite.router.ServeHTTP(suite.rec, req)
 
@@ -102,8 +105,9 @@ func (suite *ListRequestTestSuite) TestSuccessCreate() {
 func (suite *ListRequestTestSuite) TestBadCreateWithValidationError() {
 	user := factory.CreateUser(&factory.UserConfig{})
 	token := factory.CreateAccessToken(user)
+	board := factory.CreateBoard(&factory.BoardConfig{}, user)
 	body := factory.CreateListRequestBody(&factory.ListConfig{NotUseDefaultValue: true})
-	req := httptest.NewRequest("POST", "/api/lists", body)
+	req := httptest.NewRequest("POST", fmt.Sprintf("/api/boards/%v/lists", board.ID), body)
 	req.Header.Add(config.TokenHeader, token)
 	suite.router.ServeHTTP(suite.rec, req)
 
@@ -221,12 +225,13 @@ func (suite *ListRequestTestSuite) TestSuccessMoveWhenIncreaseIndex() {
 	suite.router.ServeHTTP(suite.rec, req)
 
 	suite.Equal(200, suite.rec.Code)
-	suite.repository.FindListsWithCards(&user)
-	suite.Equal("0", user.Lists[0].Title)
-	suite.Equal("2", user.Lists[1].Title)
-	suite.Equal("3", user.Lists[2].Title)
-	suite.Equal("1", user.Lists[3].Title)
-	suite.Equal("4", user.Lists[4].Title)
+	board := factory.UserBoard(user)
+	suite.repository.FindListsWithCards(&board)
+	suite.Equal("0", board.Lists[0].Title)
+	suite.Equal("2", board.Lists[1].Title)
+	suite.Equal("3", board.Lists[2].Title)
+	suite.Equal("1", board.Lists[3].Title)
+	suite.Equal("4", board.Lists[4].Title)
 }
 
 func (suite *ListRequestTestSuite) TestSuccessMoveWhenDecreaseIndex() {
@@ -245,12 +250,13 @@ func (suite *ListRequestTestSuite) TestSuccessMoveWhenDecreaseIndex() {
 	suite.router.ServeHTTP(suite.rec, req)
 
 	suite.Equal(200, suite.rec.Code)
-	suite.repository.FindListsWithCards(&user)
-	suite.Equal("0", user.Lists[0].Title)
-	suite.Equal("3", user.Lists[1].Title)
-	suite.Equal("1", user.Lists[2].Title)
-	suite.Equal("2", user.Lists[3].Title)
-	suite.Equal("4", user.Lists[4].Title)
+	board := factory.UserBoard(user)
+	suite.repository.FindListsWithCards(&board)
+	suite.Equal("0", board.Lists[0].Title)
+	suite.Equal("3", board.Lists[1].Title)
+	suite.Equal("1", board.Lists[2].Title)
+	suite.Equal("2", board.Lists[3].Title)
+	suite.Equal("4", board.Lists[4].Title)
 }
 
 func (suite *ListRequestTestSuite) TestBadMoveWithNotFoundList() {
@@ -276,3 +282,39 @@ func (suite *ListRequestTestSuite) TestBadMoveWithForbiddenError() {
 	suite.Equal(config.ForbiddenErrorResponse.Code, suite.rec.Code)
 	suite.Contains(suite.rec.Body.String(), config.ForbiddenErrorResponse.Json["content"])
 }
+
+func (suite *ListRequestTestSuite) TestBadIndexWithOtherUsersBoard() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	otherUser := factory.CreateUser(&factory.UserConfig{Email: "other@example.com"})
+	board := factory.CreateBoard(&factory.BoardConfig{}, otherUser)
+	req := httptest.NewRequest("GET", fmt.Sprintf("/api/boards/%v/lists", board.ID), nil)
+	req.Header.Add(config.TokenHeader, factory.CreateAccessToken(user))
+	suite.router.ServeHTTP(suite.rec, req)
+
+	suite.Equal(config.ForbiddenErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *ListRequestTestSuite) TestBadMoveWithViewer() {
+	owner := factory.CreateUser(&factory.UserConfig{})
+	list := factory.CreateList(&factory.ListConfig{}, owner)
+	viewer := factory.CreateUser(&factory.UserConfig{})
+	factory.CreateBoardMember(factory.UserBoard(owner), viewer, model.BoardRoleViewer)
+	req := httptest.NewRequest("PUT", fmt.Sprintf("/api/lists/%v/move", list.ID), factory.CreateListRequestBody(&factory.ListConfig{Index: 0}))
+	req.Header.Add(config.TokenHeader, factory.CreateAccessToken(viewer))
+	suite.router.ServeHTTP(suite.rec, req)
+
+	suite.Equal(config.ForbiddenErrorResponse.Code, suite.rec.Code)
+}
+
+func (suite *ListRequestTestSuite) TestSuccessIndexWithViewer() {
+	owner := factory.CreateUser(&factory.UserConfig{})
+	board := factory.UserBoard(owner)
+	factory.CreateBoardList(&factory.ListConfig{}, board)
+	viewer := factory.CreateUser(&factory.UserConfig{})
+	factory.CreateBoardMember(board, viewer, model.BoardRoleViewer)
+	req := httptest.NewRequest("GET", fmt.Sprintf("/api/boards/%v/lists", board.ID), nil)
+	req.Header.Add(config.TokenHeader, factory.CreateAccessToken(viewer))
+	suite.router.ServeHTTP(suite.rec, req)
+
+	suite.Equal(200, suite.rec.Code)
+}
@@ -0,0 +1,99 @@
+package request_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/controller"
+	"github.com/kuritaeiji/todo-gin-back/db"
+	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/gateway"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"github.com/kuritaeiji/todo-gin-back/server"
+	"github.com/kuritaeiji/todo-gin-back/validators"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type PasswordRequestTestSuite struct {
+	suite.Suite
+	router *gin.Engine
+	rec    *httptest.ResponseRecorder
+	db     *gorm.DB
+}
+
+func (suite *PasswordRequestTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	config.Init()
+	db.Init()
+	validators.Init()
+	suite.db = db.GetDB()
+}
+
+func (suite *PasswordRequestTestSuite) SetupTest() {
+	// テストごとにレート制限をリセットする
+	suite.router = server.RouterSetup(controller.NewUserController(), gateway.NewMemoryRateLimitStore())
+	suite.rec = httptest.NewRecorder()
+}
+
+func (suite *PasswordRequestTestSuite) TearDownSuite() {
+	db.CloseDB()
+}
+
+func (suite *PasswordRequestTestSuite) TearDownTest() {
+	db.DeleteAll()
+}
+
+func TestPasswordRequest(t *testing.T) {
+	suite.Run(t, new(PasswordRequestTestSuite))
+}
+
+func (suite *PasswordRequestTestSuite) TestSuccessForgotWithNotFoundUser() {
+	req := httptest.NewRequest("POST", "/api/password/forgot", factory.CreateForgotPasswordRequestBody("notfound@example.com"))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.router.ServeHTTP(suite.rec, req)
+
+	suite.Equal(200, suite.rec.Code)
+	var count int64
+	suite.db.Model(model.PasswordReset{}).Count(&count)
+	suite.Equal(int64(0), count)
+}
+
+func (suite *PasswordRequestTestSuite) TestSuccessReset() {
+	user := factory.CreateUser(&factory.UserConfig{Activated: true})
+	const token = "token"
+	const newPassword = "NewPassword1010"
+	suite.db.Create(&model.PasswordReset{Digest: factory.Digest(token), ExpiresAt: time.Now().Add(time.Minute), UserID: user.ID})
+	req := httptest.NewRequest("PUT", "/api/password/reset", factory.CreateResetPasswordRequestBody(token, newPassword))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.router.ServeHTTP(suite.rec, req)
+
+	suite.Equal(200, suite.rec.Code)
+	rUser, _ := repository.NewUserRepository().Find(user.ID)
+	suite.True(rUser.Authenticate(newPassword))
+	suite.Equal(user.TokenVersion+1, rUser.TokenVersion)
+
+	// 同じトークンは2回使えない
+	rec := httptest.NewRecorder()
+	req = httptest.NewRequest("PUT", "/api/password/reset", factory.CreateResetPasswordRequestBody(token, newPassword))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.router.ServeHTTP(rec, req)
+	suite.Equal(config.InvalidPasswordResetTokenErrorResponse.Code, rec.Code)
+}
+
+func (suite *PasswordRequestTestSuite) TestBadResetWithExpiredToken() {
+	user := factory.CreateUser(&factory.UserConfig{Activated: true})
+	const token = "token"
+	suite.db.Create(&model.PasswordReset{Digest: factory.Digest(token), ExpiresAt: time.Now().Add(-time.Minute), UserID: user.ID})
+	req := httptest.NewRequest("PUT", "/api/password/reset", factory.CreateResetPasswordRequestBody(token, "NewPassword1010"))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.router.ServeHTTP(suite.rec, req)
+
+	suite.Equal(config.InvalidPasswordResetTokenErrorResponse.Code, suite.rec.Code)
+	suite.Contains(suite.rec.Body.String(), config.InvalidPasswordResetTokenErrorResponse.Json["content"])
+}
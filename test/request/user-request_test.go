@@ -15,6 +15,7 @@ import (
 	"github.com/kuritaeiji/todo-gin-back/config"
 	"github.com/kuritaeiji/todo-gin-back/db"
 	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/middleware"
 	"github.com/kuritaeiji/todo-gin-back/mock_gateway"
 	"github.com/kuritaeiji/todo-gin-back/model"
 	"github.com/kuritaeiji/todo-gin-back/server"
@@ -141,6 +142,19 @@ func (suite *UserRequestTestSuite) TestSuccessUnique() {
 	suite.Equal(200, suite.rec.Code)
 }
 
+func (suite *UserRequestTestSuite) TestBadUniqueWithRateLimitExceeded() {
+	for i := 0; i < middleware.GuestRateLimitPolicy.Limit; i++ {
+		rec := httptest.NewRecorder()
+		suite.router.ServeHTTP(rec, httptest.NewRequest("GET", "/api/users/unique?email=email", nil))
+		suite.Equal(200, rec.Code)
+	}
+	req := httptest.NewRequest("GET", "/api/users/unique?email=email", nil)
+	suite.router.ServeHTTP(suite.rec, req)
+
+	suite.Equal(config.RateLimitErrorResponse.Code, suite.rec.Code)
+	suite.NotEmpty(suite.rec.Header().Get("Retry-After"))
+}
+
 func (suite *UserRequestTestSuite) TestBadIsUniqueWithNotGuest() {
 	req := httptest.NewRequest("GET", "/api/users/unique", nil)
 	req.Header.Add("Authorization", "Bearer token")
@@ -254,3 +268,24 @@ func (suite *UserRequestTestSuite) TestDestroyListsWhenDestroyUser() {
 	suite.db.Model(&model.List{}).Count(&count)
 	suite.Equal(int64(0), count)
 }
+
+func (suite *UserRequestTestSuite) TestSuccessResendActivation() {
+	user := factory.CreateUser(&factory.UserConfig{})
+	suite.mock.EXPECT().Send(user.Email, "アカウント有効化リンク", gomock.Any()).Return(nil)
+	req := httptest.NewRequest("POST", "/api/users/activation/resend", strings.NewReader(fmt.Sprintf(`{"email":"%v"}`, user.Email)))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.router.ServeHTTP(suite.rec, req)
+
+	suite.Equal(200, suite.rec.Code)
+	var rUser model.User
+	suite.db.First(&rUser, user.ID)
+	suite.NotNil(rUser.ActivationSentAt)
+
+	// 続けて再送するとメールは送信されないが、レスポンスは変わらない
+	rec := httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/users/activation/resend", strings.NewReader(fmt.Sprintf(`{"email":"%v"}`, user.Email)))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.router.ServeHTTP(rec, req)
+
+	suite.Equal(200, rec.Code)
+}
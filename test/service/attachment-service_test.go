@@ -0,0 +1,226 @@
+package service_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/mock_gateway"
+	"github.com/kuritaeiji/todo-gin-back/mock_repository"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+type AttachmentServiceTestSuite struct {
+	suite.Suite
+	service                  service.AttachmentService
+	attachmentRepositoryMock *mock_repository.MockAttachmentRepository
+	storageGatewayMock       *mock_gateway.MockStorageGateway
+	ctx                      *gin.Context
+	currentUser              model.User
+	card                     model.Card
+}
+
+func (suite *AttachmentServiceTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *AttachmentServiceTestSuite) SetupTest() {
+	suite.attachmentRepositoryMock = mock_repository.NewMockAttachmentRepository(gomock.NewController(suite.T()))
+	suite.storageGatewayMock = mock_gateway.NewMockStorageGateway(gomock.NewController(suite.T()))
+	suite.service = service.TestNewAttachmentService(suite.attachmentRepositoryMock, suite.storageGatewayMock)
+	suite.ctx, _ = gin.CreateTestContext(httptest.NewRecorder())
+	suite.currentUser = model.User{ID: 1}
+	suite.card = model.Card{ID: 2}
+	suite.ctx.Set(config.CurrentUserKey, suite.currentUser)
+	suite.ctx.Set(config.CardKey, suite.card)
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "2"}, {Key: "attachmentID", Value: "3"}}
+}
+
+func (suite *AttachmentServiceTestSuite) TearDownTest() {
+	os.Unsetenv("ATTACHMENT_MAX_SIZE_MB")
+	os.Unsetenv("ATTACHMENT_USER_QUOTA_MB")
+}
+
+func TestAttachmentService(t *testing.T) {
+	suite.Run(t, new(AttachmentServiceTestSuite))
+}
+
+func (suite *AttachmentServiceTestSuite) setUploadRequest(fileName string, content []byte) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", fileName)
+	part.Write(content)
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/api/cards/2/attachments", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	suite.ctx.Request = req
+}
+
+func (suite *AttachmentServiceTestSuite) TestSuccessCreate() {
+	content := append(pngHeader, []byte("image")...)
+	suite.setUploadRequest("screenshot.txt", content)
+	suite.attachmentRepositoryMock.EXPECT().TotalSize(&suite.currentUser).Return(int64(0), nil)
+	var stored []byte
+	suite.storageGatewayMock.EXPECT().Put(gomock.Any(), gomock.Any()).DoAndReturn(func(key string, body io.Reader) error {
+		suite.True(strings.HasPrefix(key, "attachments/2/"))
+		stored, _ = io.ReadAll(body)
+		return nil
+	})
+	suite.attachmentRepositoryMock.EXPECT().Create(gomock.Any(), int64(service.MBDefaultAttachmentUserQuota<<20)).Return(nil)
+	attachment, err := suite.service.Create(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal(content, stored)
+	suite.Equal("screenshot.txt", attachment.FileName)
+	// 拡張子やクライアントの申告ではなく中身から判定する
+	suite.Equal("image/png", attachment.ContentType)
+	suite.Equal(int64(len(content)), attachment.Size)
+	suite.Equal(suite.card.ID, attachment.CardID)
+	suite.Equal(suite.currentUser.ID, attachment.UserID)
+}
+
+func (suite *AttachmentServiceTestSuite) TestBadCreateWithMissingFile() {
+	suite.ctx.Request = httptest.NewRequest("POST", "/api/cards/2/attachments", nil)
+	_, err := suite.service.Create(suite.ctx)
+
+	suite.Equal(http.ErrMissingFile, err)
+}
+
+func (suite *AttachmentServiceTestSuite) TestBadCreateWithTooLargeFile() {
+	os.Setenv("ATTACHMENT_MAX_SIZE_MB", "1")
+	suite.setUploadRequest("large.bin", make([]byte, 1<<20+1))
+	_, err := suite.service.Create(suite.ctx)
+
+	suite.Equal(config.AttachmentTooLargeError, err)
+}
+
+func (suite *AttachmentServiceTestSuite) TestBadCreateWithTooLargeBodyWithoutContentLength() {
+	os.Setenv("ATTACHMENT_MAX_SIZE_MB", "1")
+	suite.setUploadRequest("large.bin", make([]byte, 3<<20))
+	suite.ctx.Request.ContentLength = -1
+	_, err := suite.service.Create(suite.ctx)
+
+	suite.Equal(config.AttachmentTooLargeError, err)
+}
+
+func (suite *AttachmentServiceTestSuite) TestBadCreateWithQuotaExceededByConcurrentUpload() {
+	suite.setUploadRequest("file.txt", []byte("content"))
+	suite.attachmentRepositoryMock.EXPECT().TotalSize(&suite.currentUser).Return(int64(0), nil)
+	suite.storageGatewayMock.EXPECT().Put(gomock.Any(), gomock.Any()).Return(nil)
+	suite.attachmentRepositoryMock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(config.AttachmentQuotaExceededError)
+	suite.storageGatewayMock.EXPECT().Delete(gomock.Any()).Return(nil)
+	_, err := suite.service.Create(suite.ctx)
+
+	suite.Equal(config.AttachmentQuotaExceededError, err)
+}
+
+func (suite *AttachmentServiceTestSuite) TestBadCreateWithQuotaExceeded() {
+	os.Setenv("ATTACHMENT_USER_QUOTA_MB", "1")
+	suite.setUploadRequest("file.txt", []byte("content"))
+	suite.attachmentRepositoryMock.EXPECT().TotalSize(&suite.currentUser).Return(int64(1<<20-1), nil)
+	_, err := suite.service.Create(suite.ctx)
+
+	suite.Equal(config.AttachmentQuotaExceededError, err)
+}
+
+func (suite *AttachmentServiceTestSuite) TestBadCreateWithDBErrorDeletesObject() {
+	suite.setUploadRequest("file.txt", []byte("content"))
+	dbError := errors.New("db error")
+	suite.attachmentRepositoryMock.EXPECT().TotalSize(&suite.currentUser).Return(int64(0), nil)
+	var storedKey string
+	suite.storageGatewayMock.EXPECT().Put(gomock.Any(), gomock.Any()).DoAndReturn(func(key string, body io.Reader) error {
+		storedKey = key
+		return nil
+	})
+	suite.attachmentRepositoryMock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(dbError)
+	suite.storageGatewayMock.EXPECT().Delete(gomock.Any()).DoAndReturn(func(key string) error {
+		suite.Equal(storedKey, key)
+		return nil
+	})
+	_, err := suite.service.Create(suite.ctx)
+
+	suite.Equal(dbError, err)
+}
+
+func (suite *AttachmentServiceTestSuite) TestSuccessDownload() {
+	attachment := model.Attachment{ID: 3, StorageKey: "attachments/2/key"}
+	body := io.NopCloser(strings.NewReader("content"))
+	suite.attachmentRepositoryMock.EXPECT().Find(&suite.card, 3).Return(attachment, nil)
+	suite.storageGatewayMock.EXPECT().Get("attachments/2/key").Return(body, nil)
+	rAttachment, rBody, err := suite.service.Download(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal(attachment, rAttachment)
+	suite.Equal(body, rBody)
+}
+
+func (suite *AttachmentServiceTestSuite) TestBadDownloadWithMissingObject() {
+	attachment := model.Attachment{ID: 3, StorageKey: "attachments/2/key"}
+	suite.attachmentRepositoryMock.EXPECT().Find(&suite.card, 3).Return(attachment, nil)
+	suite.storageGatewayMock.EXPECT().Get("attachments/2/key").Return(nil, config.StorageObjectNotFoundError)
+	_, _, err := suite.service.Download(suite.ctx)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *AttachmentServiceTestSuite) TestSuccessDestroy() {
+	attachment := model.Attachment{ID: 3, StorageKey: "attachments/2/key"}
+	suite.attachmentRepositoryMock.EXPECT().Find(&suite.card, 3).Return(attachment, nil)
+	suite.attachmentRepositoryMock.EXPECT().Destroy(&attachment).Return(nil)
+	suite.storageGatewayMock.EXPECT().Delete("attachments/2/key").Return(nil)
+	err := suite.service.Destroy(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *AttachmentServiceTestSuite) TestBadDestroyWithInvalidID() {
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "2"}, {Key: "attachmentID", Value: "a"}}
+	err := suite.service.Destroy(suite.ctx)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *AttachmentServiceTestSuite) TestSuccessDestroyByCard() {
+	attachments := []model.Attachment{{StorageKey: "a"}, {StorageKey: "b"}}
+	storageError := errors.New("storage error")
+	suite.attachmentRepositoryMock.EXPECT().DestroyAllByCard(&suite.card).Return(attachments, nil)
+	suite.storageGatewayMock.EXPECT().Delete("a").Return(storageError)
+	suite.storageGatewayMock.EXPECT().Delete("b").Return(nil)
+	err := suite.service.DestroyByCard(suite.card)
+
+	suite.Equal(storageError, err)
+}
+
+func (suite *AttachmentServiceTestSuite) TestSuccessDestroyByList() {
+	list := model.List{ID: 3}
+	attachments := []model.Attachment{{StorageKey: "a"}}
+	suite.attachmentRepositoryMock.EXPECT().DestroyAllByList(&list).Return(attachments, nil)
+	suite.storageGatewayMock.EXPECT().Delete("a").Return(nil)
+	err := suite.service.DestroyByList(list)
+
+	suite.Nil(err)
+}
+
+func (suite *AttachmentServiceTestSuite) TestSuccessDestroyByUser() {
+	attachments := []model.Attachment{{StorageKey: "a"}}
+	suite.attachmentRepositoryMock.EXPECT().DestroyAllByUser(&suite.currentUser).Return(attachments, nil)
+	suite.storageGatewayMock.EXPECT().Delete("a").Return(nil)
+	err := suite.service.DestroyByUser(suite.currentUser)
+
+	suite.Nil(err)
+}
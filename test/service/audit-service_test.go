@@ -0,0 +1,120 @@
+package service_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/mock_repository"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/repository"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"github.com/kuritaeiji/todo-gin-back/validators"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditServiceTestSuite struct {
+	suite.Suite
+	service                service.AuditService
+	auditLogRepositoryMock *mock_repository.MockAuditLogRepository
+	ctx                    *gin.Context
+}
+
+func (suite *AuditServiceTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	validators.Init()
+}
+
+func (suite *AuditServiceTestSuite) SetupTest() {
+	suite.auditLogRepositoryMock = mock_repository.NewMockAuditLogRepository(gomock.NewController(suite.T()))
+	suite.service = service.TestNewAuditService(suite.auditLogRepositoryMock)
+	suite.ctx, _ = gin.CreateTestContext(httptest.NewRecorder())
+	suite.ctx.Request = httptest.NewRequest("POST", "/api/login", nil)
+	suite.ctx.Request.Header.Set("User-Agent", "browser")
+}
+
+func (suite *AuditServiceTestSuite) TearDownTest() {
+	os.Unsetenv("AUDIT_LOG_RETENTION_DAYS")
+}
+
+func TestAuditService(t *testing.T) {
+	suite.Run(t, new(AuditServiceTestSuite))
+}
+
+func (suite *AuditServiceTestSuite) TestSuccessRecord() {
+	suite.auditLogRepositoryMock.EXPECT().Create(gomock.Any()).Return(nil).Do(func(log *model.AuditLog) {
+		suite.Equal(model.AuditEventLoginSucceeded, log.Event)
+		suite.Equal(1, *log.UserID)
+		suite.Equal("browser", log.UserAgent)
+		suite.Equal(suite.ctx.ClientIP(), log.IP)
+	})
+	suite.service.Record(suite.ctx, model.AuditEventLoginSucceeded, &model.User{ID: 1}, "")
+
+	suite.Empty(suite.ctx.Errors)
+}
+
+func (suite *AuditServiceTestSuite) TestSuccessRecordWithoutUser() {
+	suite.auditLogRepositoryMock.EXPECT().Create(gomock.Any()).Return(nil).Do(func(log *model.AuditLog) {
+		suite.Nil(log.UserID)
+		suite.Equal("unknown@example.com", log.Detail)
+	})
+	suite.service.Record(suite.ctx, model.AuditEventLoginFailed, nil, "unknown@example.com")
+}
+
+func (suite *AuditServiceTestSuite) TestBadRecordWithDBError() {
+	suite.auditLogRepositoryMock.EXPECT().Create(gomock.Any()).Return(errors.New("db error"))
+	suite.service.Record(suite.ctx, model.AuditEventLogout, &model.User{ID: 1}, "")
+
+	suite.Len(suite.ctx.Errors, 1)
+}
+
+func (suite *AuditServiceTestSuite) TestSuccessDestroyExpired() {
+	os.Setenv("AUDIT_LOG_RETENTION_DAYS", "30")
+	suite.auditLogRepositoryMock.EXPECT().DestroyExpired(gomock.Any()).Return(nil).Do(func(expiredBefore time.Time) {
+		suite.WithinDuration(time.Now().AddDate(0, 0, -30), expiredBefore, time.Minute)
+	})
+	err := suite.service.DestroyExpired()
+
+	suite.Nil(err)
+}
+
+func (suite *AuditServiceTestSuite) TestSuccessDestroyExpiredWithDefaultRetention() {
+	suite.auditLogRepositoryMock.EXPECT().DestroyExpired(gomock.Any()).Return(nil).Do(func(expiredBefore time.Time) {
+		suite.WithinDuration(time.Now().AddDate(0, 0, -service.DayDefaultAuditLogRetention), expiredBefore, time.Minute)
+	})
+	err := suite.service.DestroyExpired()
+
+	suite.Nil(err)
+}
+
+func (suite *AuditServiceTestSuite) TestSuccessIndex() {
+	suite.ctx.Request = httptest.NewRequest("GET", "/api/users/audit?userId=2&before=10", nil)
+	suite.ctx.Set(config.CurrentUserKey, model.User{ID: 1})
+	logs := []model.AuditLog{{ID: 9}}
+	suite.auditLogRepositoryMock.EXPECT().FindAll(repository.AuditLogQuery{UserID: 1, BeforeID: 10, Limit: service.AuditLogPageSize}).Return(logs, nil)
+	rlogs, err := suite.service.Index(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal(logs, rlogs)
+}
+
+func (suite *AuditServiceTestSuite) TestSuccessAdminIndex() {
+	suite.ctx.Request = httptest.NewRequest("GET", "/api/admin/audit-logs?userId=2&event=login.failed", nil)
+	suite.auditLogRepositoryMock.EXPECT().FindAll(repository.AuditLogQuery{UserID: 2, Event: model.AuditEventLoginFailed, Limit: service.AuditLogPageSize}).Return(nil, nil)
+	_, err := suite.service.AdminIndex(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *AuditServiceTestSuite) TestBadAdminIndexWithValidationError() {
+	suite.ctx.Request = httptest.NewRequest("GET", "/api/admin/audit-logs?userId=-1", nil)
+	_, err := suite.service.AdminIndex(suite.ctx)
+
+	suite.IsType(validator.ValidationErrors{}, err)
+}
@@ -1,34 +1,51 @@
 package service_test
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang-jwt/jwt"
 	"github.com/golang/mock/gomock"
 	"github.com/kuritaeiji/todo-gin-back/config"
 	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/gateway"
 	"github.com/kuritaeiji/todo-gin-back/mock_gateway"
 	"github.com/kuritaeiji/todo-gin-back/mock_repository"
 	"github.com/kuritaeiji/todo-gin-back/mock_service"
 	"github.com/kuritaeiji/todo-gin-back/model"
 	"github.com/kuritaeiji/todo-gin-back/service"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/oauth2"
 	"gorm.io/gorm"
 )
 
 type AuthServiceTestSuite struct {
 	suite.Suite
-	service            service.AuthService
-	userRepositoryMock *mock_repository.MockUserRepository
-	jwtServiceMock     *mock_service.MockJWTService
-	oauthGatewayMock   *mock_gateway.MockOauthGateway
-	rec                *httptest.ResponseRecorder
-	ctx                *gin.Context
+	service                       service.AuthService
+	userRepositoryMock            *mock_repository.MockUserRepository
+	tokenRevocationRepositoryMock *mock_repository.MockTokenRevocationRepository
+	jwtServiceMock                *mock_service.MockJWTService
+	refreshTokenServiceMock       *mock_service.MockRefreshTokenService
+	sessionServiceMock            *mock_service.MockSessionService
+	auditServiceMock              *mock_service.MockAuditService
+	loginAttemptServiceMock       *mock_service.MockLoginAttemptService
+	totpServiceMock               *mock_service.MockTotpService
+	oauthGatewayMock              *mock_gateway.MockOauthGateway
+	rec                           *httptest.ResponseRecorder
+	ctx                           *gin.Context
 }
 
 func (suite *AuthServiceTestSuite) SetupSuite() {
@@ -39,8 +56,14 @@ func (suite *AuthServiceTestSuite) SetupSuite() {
 func (suite *AuthServiceTestSuite) SetupTest() {
 	suite.userRepositoryMock = mock_repository.NewMockUserRepository(gomock.NewController(suite.T()))
 	suite.jwtServiceMock = mock_service.NewMockJWTService(gomock.NewController(suite.T()))
+	suite.tokenRevocationRepositoryMock = mock_repository.NewMockTokenRevocationRepository(gomock.NewController(suite.T()))
+	suite.refreshTokenServiceMock = mock_service.NewMockRefreshTokenService(gomock.NewController(suite.T()))
+	suite.loginAttemptServiceMock = mock_service.NewMockLoginAttemptService(gomock.NewController(suite.T()))
+	suite.totpServiceMock = mock_service.NewMockTotpService(gomock.NewController(suite.T()))
 	suite.oauthGatewayMock = mock_gateway.NewMockOauthGateway(gomock.NewController(suite.T()))
-	suite.service = service.TestNewAuthService(suite.userRepositoryMock, suite.jwtServiceMock, suite.oauthGatewayMock)
+	suite.sessionServiceMock = mock_service.NewMockSessionService(gomock.NewController(suite.T()))
+	suite.auditServiceMock = mock_service.NewMockAuditService(gomock.NewController(suite.T()))
+	suite.service = service.TestNewAuthService(suite.userRepositoryMock, suite.tokenRevocationRepositoryMock, suite.jwtServiceMock, suite.refreshTokenServiceMock, suite.sessionServiceMock, suite.auditServiceMock, suite.loginAttemptServiceMock, suite.totpServiceMock, suite.oauthGatewayMock)
 	suite.rec = httptest.NewRecorder()
 	suite.ctx, _ = gin.CreateTestContext(suite.rec)
 }
@@ -50,63 +73,316 @@ func TestAuthService(t *testing.T) {
 }
 
 func (suite *AuthServiceTestSuite) TestSuccessLogin() {
-	var userConfig factory.UserConfig
+	suite.auditServiceMock.EXPECT().Record(gomock.Any(), model.AuditEventLoginSucceeded, gomock.Any(), "")
+	userConfig := factory.UserConfig{Activated: true}
 	user := factory.NewUser(&userConfig)
-	tokenString := factory.CreateAccessToken(user)
+	tokenString := "accessToken"
+	const refreshToken = "refreshToken"
 	suite.userRepositoryMock.EXPECT().FindByEmail(userConfig.Email).Return(user, nil)
-	suite.jwtServiceMock.EXPECT().CreateJWT(user, service.DayFromNowAccessToken).Return(tokenString)
+	suite.loginAttemptServiceMock.EXPECT().CheckIP(gomock.Any()).Return(nil)
+	suite.loginAttemptServiceMock.EXPECT().CheckUser(user).Return(nil)
+	suite.loginAttemptServiceMock.EXPECT().Succeed(user).Return(nil)
+	suite.sessionServiceMock.EXPECT().Create(gomock.Any(), user).Return(model.Session{SessionID: "sessionID"}, nil)
+	suite.refreshTokenServiceMock.EXPECT().Create(user, "sessionID").Return(refreshToken, nil)
+	suite.jwtServiceMock.EXPECT().CreateAccessJWT(user, "sessionID").Return(tokenString)
 
 	req := httptest.NewRequest("POST", "/login", factory.CreateUserRequestBody(&userConfig))
 	req.Header.Add("Content-Type", binding.MIMEJSON)
 	suite.ctx.Request = req
-	token, err := suite.service.Login(suite.ctx)
+	tokenPair, challengeToken, err := suite.service.Login(suite.ctx)
 
-	suite.Equal(tokenString, token)
+	suite.Equal(tokenString, tokenPair.AccessToken)
+	suite.Equal(refreshToken, tokenPair.RefreshToken)
+	suite.Empty(challengeToken)
 	suite.Nil(err)
 }
 
+func (suite *AuthServiceTestSuite) TestBadLoginWithRefreshTokenError() {
+	userConfig := factory.UserConfig{Activated: true}
+	user := factory.NewUser(&userConfig)
+	err := errors.New("error")
+	suite.userRepositoryMock.EXPECT().FindByEmail(userConfig.Email).Return(user, nil)
+	suite.loginAttemptServiceMock.EXPECT().CheckIP(gomock.Any()).Return(nil)
+	suite.loginAttemptServiceMock.EXPECT().CheckUser(user).Return(nil)
+	suite.loginAttemptServiceMock.EXPECT().Succeed(user).Return(nil)
+	suite.sessionServiceMock.EXPECT().Create(gomock.Any(), user).Return(model.Session{SessionID: "sessionID"}, nil)
+	suite.refreshTokenServiceMock.EXPECT().Create(user, gomock.Any()).Return("", err)
+
+	req := httptest.NewRequest("POST", "/login", factory.CreateUserRequestBody(&userConfig))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	_, _, rerr := suite.service.Login(suite.ctx)
+
+	suite.Equal(err, rerr)
+}
+
 func (suite *AuthServiceTestSuite) TestBadLoginWithCannotBind() {
 	req := httptest.NewRequest("POST", "/login", nil)
 	req.Header.Add("Content-Type", binding.MIMEJSON)
 	suite.ctx.Request = req
-	_, err := suite.service.Login(suite.ctx)
+	_, _, err := suite.service.Login(suite.ctx)
 
 	suite.Error(err)
 }
 
 func (suite *AuthServiceTestSuite) TestBadLoginWithRecordNotFound() {
+	suite.auditServiceMock.EXPECT().Record(gomock.Any(), model.AuditEventLoginFailed, nil, gomock.Any())
 	var userConfig factory.UserConfig
 	req := httptest.NewRequest("POST", "/login", factory.CreateUserRequestBody(&userConfig))
 	req.Header.Add("Content-Type", binding.MIMEJSON)
 	suite.ctx.Request = req
 	suite.userRepositoryMock.EXPECT().FindByEmail(userConfig.Email).Return(model.User{}, gorm.ErrRecordNotFound)
-	_, err := suite.service.Login(suite.ctx)
+	suite.loginAttemptServiceMock.EXPECT().CheckIP(gomock.Any()).Return(nil)
+	suite.loginAttemptServiceMock.EXPECT().Fail(gomock.Any(), nil).Return(nil)
+	_, _, err := suite.service.Login(suite.ctx)
 
 	suite.Equal(gorm.ErrRecordNotFound, err)
 }
 
 func (suite *AuthServiceTestSuite) TestBadLoginWithPasswordAuthenticationError() {
+	suite.auditServiceMock.EXPECT().Record(gomock.Any(), model.AuditEventLoginFailed, gomock.Not(nil), "")
 	var userConfig factory.UserConfig
 	user := factory.NewUser(&userConfig)
 	suite.userRepositoryMock.EXPECT().FindByEmail(user.Email).Return(user, nil)
+	suite.loginAttemptServiceMock.EXPECT().CheckIP(gomock.Any()).Return(nil)
+	suite.loginAttemptServiceMock.EXPECT().CheckUser(user).Return(nil)
+	suite.loginAttemptServiceMock.EXPECT().Fail(gomock.Any(), &user).Return(nil)
 
 	userConfig.Password = "invalid password"
 	req := httptest.NewRequest("POST", "/login", factory.CreateUserRequestBody(&userConfig))
 	req.Header.Add("Content-Type", binding.MIMEJSON)
 	suite.ctx.Request = req
-	_, err := suite.service.Login(suite.ctx)
+	_, _, err := suite.service.Login(suite.ctx)
 
 	suite.Equal(config.PasswordAuthenticationError, err)
 }
 
-func (suite *AuthServiceTestSuite) TestSuccessGoogle() {
+func (suite *AuthServiceTestSuite) TestBadLoginWithNotActivatedUser() {
+	var userConfig factory.UserConfig
+	user := factory.NewUser(&userConfig)
+	suite.userRepositoryMock.EXPECT().FindByEmail(user.Email).Return(user, nil)
+	suite.loginAttemptServiceMock.EXPECT().CheckIP(gomock.Any()).Return(nil)
+	suite.loginAttemptServiceMock.EXPECT().CheckUser(user).Return(nil)
+
+	req := httptest.NewRequest("POST", "/login", factory.CreateUserRequestBody(&userConfig))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	_, _, err := suite.service.Login(suite.ctx)
+
+	suite.Equal(config.NotActivatedUserError, err)
+}
+
+func (suite *AuthServiceTestSuite) TestBadLoginWithTooManyLoginAttempts() {
+	var userConfig factory.UserConfig
+	factory.NewUser(&userConfig)
+	suite.loginAttemptServiceMock.EXPECT().CheckIP(gomock.Any()).Return(config.TooManyLoginAttemptsError)
+
+	req := httptest.NewRequest("POST", "/login", factory.CreateUserRequestBody(&userConfig))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	_, _, err := suite.service.Login(suite.ctx)
+
+	suite.Equal(config.TooManyLoginAttemptsError, err)
+}
+
+func (suite *AuthServiceTestSuite) TestBadLoginWithAccountLocked() {
+	userConfig := factory.UserConfig{Activated: true}
+	user := factory.NewUser(&userConfig)
+	suite.userRepositoryMock.EXPECT().FindByEmail(user.Email).Return(user, nil)
+	suite.loginAttemptServiceMock.EXPECT().CheckIP(gomock.Any()).Return(nil)
+	suite.loginAttemptServiceMock.EXPECT().CheckUser(user).Return(config.AccountLockedError)
+
+	req := httptest.NewRequest("POST", "/login", factory.CreateUserRequestBody(&userConfig))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	_, _, err := suite.service.Login(suite.ctx)
+
+	suite.Equal(config.AccountLockedError, err)
+}
+
+func (suite *AuthServiceTestSuite) TestSuccessLoginWithTotpEnabled() {
+	userConfig := factory.UserConfig{Activated: true}
+	user := factory.NewUser(&userConfig)
+	user.TotpEnabled = true
+	suite.userRepositoryMock.EXPECT().FindByEmail(user.Email).Return(user, nil)
+	suite.loginAttemptServiceMock.EXPECT().CheckIP(gomock.Any()).Return(nil)
+	suite.loginAttemptServiceMock.EXPECT().CheckUser(user).Return(nil)
+	suite.jwtServiceMock.EXPECT().CreateChallengeJWT(user).Return("challengeToken")
+
+	req := httptest.NewRequest("POST", "/login", factory.CreateUserRequestBody(&userConfig))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	tokenPair, challengeToken, err := suite.service.Login(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal("challengeToken", challengeToken)
+	suite.Empty(tokenPair.AccessToken)
+}
+
+func (suite *AuthServiceTestSuite) TestSuccessTotpLogin() {
+	suite.auditServiceMock.EXPECT().Record(gomock.Any(), model.AuditEventLoginSucceeded, gomock.Any(), "totp")
+	user := model.User{ID: 1, TokenVersion: 1, TotpEnabled: true}
+	expiresAt := time.Now().Add(service.MinuteFromNowChallengeToken * time.Minute).Unix()
+	claim := &service.UserClaim{ID: user.ID, Version: user.TokenVersion, Type: service.ChallengeTokenType, StandardClaims: jwt.StandardClaims{Id: "jti", ExpiresAt: expiresAt}}
+	suite.jwtServiceMock.EXPECT().VerifyJWT("challengeToken").Return(claim, nil)
+	suite.loginAttemptServiceMock.EXPECT().CheckIP(gomock.Any()).Return(nil)
+	suite.userRepositoryMock.EXPECT().Find(user.ID).Return(user, nil)
+	suite.loginAttemptServiceMock.EXPECT().CheckUser(user).Return(nil)
+	suite.totpServiceMock.EXPECT().Verify(user, "123456").Return(true, nil)
+	suite.tokenRevocationRepositoryMock.EXPECT().RevokeOnce("jti", time.Unix(expiresAt, 0)).Return(true, nil)
+	suite.loginAttemptServiceMock.EXPECT().Succeed(user).Return(nil)
+	suite.sessionServiceMock.EXPECT().Create(gomock.Any(), user).Return(model.Session{SessionID: "sessionID"}, nil)
+	suite.refreshTokenServiceMock.EXPECT().Create(user, gomock.Any()).Return("refreshToken", nil)
+	suite.jwtServiceMock.EXPECT().CreateAccessJWT(user, gomock.Any()).Return("accessToken")
+
+	req := httptest.NewRequest("POST", "/login/totp", strings.NewReader(`{"challengeToken":"challengeToken","code":"123456"}`))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	tokenPair, err := suite.service.TotpLogin(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal("accessToken", tokenPair.AccessToken)
+	suite.Equal("refreshToken", tokenPair.RefreshToken)
+}
+
+func (suite *AuthServiceTestSuite) TestBadTotpLoginWithUsedChallengeToken() {
+	user := model.User{ID: 1, TotpEnabled: true}
+	claim := &service.UserClaim{ID: user.ID, Type: service.ChallengeTokenType, StandardClaims: jwt.StandardClaims{Id: "jti"}}
+	suite.jwtServiceMock.EXPECT().VerifyJWT("challengeToken").Return(claim, nil)
+	suite.loginAttemptServiceMock.EXPECT().CheckIP(gomock.Any()).Return(nil)
+	suite.userRepositoryMock.EXPECT().Find(user.ID).Return(user, nil)
+	suite.loginAttemptServiceMock.EXPECT().CheckUser(user).Return(nil)
+	suite.totpServiceMock.EXPECT().Verify(user, "123456").Return(true, nil)
+	suite.tokenRevocationRepositoryMock.EXPECT().RevokeOnce("jti", gomock.Any()).Return(false, nil)
+
+	req := httptest.NewRequest("POST", "/login/totp", strings.NewReader(`{"challengeToken":"challengeToken","code":"123456"}`))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	_, err := suite.service.TotpLogin(suite.ctx)
+
+	suite.Equal(config.InvalidChallengeTokenError, err)
+}
+
+func (suite *AuthServiceTestSuite) TestBadTotpLoginWithAccessToken() {
+	claim := &service.UserClaim{ID: 1, Type: service.AccessTokenType}
+	suite.jwtServiceMock.EXPECT().VerifyJWT("challengeToken").Return(claim, nil)
+
+	req := httptest.NewRequest("POST", "/login/totp", strings.NewReader(`{"challengeToken":"challengeToken","code":"123456"}`))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	_, err := suite.service.TotpLogin(suite.ctx)
+
+	suite.Equal(config.InvalidChallengeTokenError, err)
+}
+
+func (suite *AuthServiceTestSuite) TestBadTotpLoginWithInvalidCode() {
+	suite.auditServiceMock.EXPECT().Record(gomock.Any(), model.AuditEventLoginFailed, gomock.Any(), "totp")
+	user := model.User{ID: 1, TotpEnabled: true}
+	claim := &service.UserClaim{ID: user.ID, Type: service.ChallengeTokenType}
+	suite.jwtServiceMock.EXPECT().VerifyJWT("challengeToken").Return(claim, nil)
+	suite.loginAttemptServiceMock.EXPECT().CheckIP(gomock.Any()).Return(nil)
+	suite.userRepositoryMock.EXPECT().Find(user.ID).Return(user, nil)
+	suite.loginAttemptServiceMock.EXPECT().CheckUser(user).Return(nil)
+	suite.totpServiceMock.EXPECT().Verify(user, "000000").Return(false, nil)
+	suite.loginAttemptServiceMock.EXPECT().Fail(gomock.Any(), &user).Return(nil)
+
+	req := httptest.NewRequest("POST", "/login/totp", strings.NewReader(`{"challengeToken":"challengeToken","code":"000000"}`))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	_, err := suite.service.TotpLogin(suite.ctx)
+
+	suite.Equal(config.InvalidTotpCodeError, err)
+}
+
+func (suite *AuthServiceTestSuite) TestSuccessRefresh() {
+	user := factory.NewUser(&factory.UserConfig{ID: 1})
+	const (
+		oldRefreshToken = "oldRefreshToken"
+		newRefreshToken = "newRefreshToken"
+		accessToken     = "accessToken"
+	)
+	usedToken := model.RefreshToken{Family: "family", User: user}
+	suite.refreshTokenServiceMock.EXPECT().Rotate(oldRefreshToken).Return(usedToken, newRefreshToken, nil)
+	suite.sessionServiceMock.EXPECT().Resume(gomock.Any(), usedToken).Return(nil)
+	suite.jwtServiceMock.EXPECT().CreateAccessJWT(user, usedToken.Family).Return(accessToken)
+
+	req := httptest.NewRequest("POST", "/api/token/refresh", factory.CreateRefreshTokenRequestBody(oldRefreshToken))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	tokenPair, err := suite.service.Refresh(suite.ctx)
+
+	suite.Equal(accessToken, tokenPair.AccessToken)
+	suite.Equal(newRefreshToken, tokenPair.RefreshToken)
+	suite.Nil(err)
+}
+
+func (suite *AuthServiceTestSuite) TestBadRefreshWithCannotBind() {
+	req := httptest.NewRequest("POST", "/api/token/refresh", factory.CreateRefreshTokenRequestBody(""))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	_, err := suite.service.Refresh(suite.ctx)
+
+	suite.IsType(validator.ValidationErrors{}, err)
+}
+
+func (suite *AuthServiceTestSuite) TestBadRefreshWithReusedRefreshToken() {
+	const refreshToken = "refreshToken"
+	suite.refreshTokenServiceMock.EXPECT().Rotate(refreshToken).Return(model.RefreshToken{}, "", config.ReusedRefreshTokenError)
+
+	req := httptest.NewRequest("POST", "/api/token/refresh", factory.CreateRefreshTokenRequestBody(refreshToken))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	_, err := suite.service.Refresh(suite.ctx)
+
+	suite.Equal(config.ReusedRefreshTokenError, err)
+}
+
+func (suite *AuthServiceTestSuite) TestSuccessLogout() {
+	suite.auditServiceMock.EXPECT().Record(gomock.Any(), model.AuditEventLogout, gomock.Any(), "")
+	expiresAt := time.Now().Add(time.Minute).Unix()
+	claim := &service.UserClaim{SessionID: "sessionID", StandardClaims: jwt.StandardClaims{Id: "jti", ExpiresAt: expiresAt}}
+	suite.ctx.Set(config.ClaimKey, claim)
+	suite.ctx.Set(config.CurrentUserKey, model.User{ID: 1})
+	suite.tokenRevocationRepositoryMock.EXPECT().Revoke(claim.Id, time.Unix(expiresAt, 0)).Return(nil)
+	suite.sessionServiceMock.EXPECT().Revoke(claim.SessionID).Return(nil)
+	err := suite.service.Logout(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *AuthServiceTestSuite) TestBadLogoutWithRevokeError() {
+	err := errors.New("error")
+	claim := &service.UserClaim{SessionID: "sessionID", StandardClaims: jwt.StandardClaims{Id: "jti"}}
+	suite.ctx.Set(config.ClaimKey, claim)
+	suite.tokenRevocationRepositoryMock.EXPECT().Revoke(claim.Id, gomock.Any()).Return(err)
+	rerr := suite.service.Logout(suite.ctx)
+
+	suite.Equal(err, rerr)
+}
+
+func (suite *AuthServiceTestSuite) TestSuccessLogoutAll() {
+	suite.auditServiceMock.EXPECT().Record(gomock.Any(), model.AuditEventLogoutAll, gomock.Any(), "")
+	user := model.User{ID: 1}
+	suite.ctx.Set(config.CurrentUserKey, user)
+	suite.tokenRevocationRepositoryMock.EXPECT().RevokeAll(&user).Return(nil)
+	err := suite.service.LogoutAll(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *AuthServiceTestSuite) setProvider(name string) {
+	suite.ctx.Params = gin.Params{{Key: "provider", Value: name}}
+}
+
+func (suite *AuthServiceTestSuite) TestSuccessOauth() {
 	const authURL = "https://example.com/auth"
 	providerConfig := &oidc.ProviderConfig{AuthURL: authURL}
 	provider := providerConfig.NewProvider(suite.ctx)
-	suite.oauthGatewayMock.EXPECT().SearchProvider(suite.ctx).Return(provider, nil)
+	suite.setProvider("google")
+	suite.oauthGatewayMock.EXPECT().SearchProvider(suite.ctx, os.Getenv("OAUTH_GOOGLE_ISSUER")).Return(provider, nil)
 
-	uri, state, err := suite.service.Google(suite.ctx)
-	u, uerr := url.Parse(uri)
+	authorization, err := suite.service.Oauth(suite.ctx)
+	u, uerr := url.Parse(authorization.URL)
 	if uerr != nil {
 		suite.Fail("url parse error")
 	}
@@ -120,33 +396,184 @@ func (suite *AuthServiceTestSuite) TestSuccessGoogle() {
 	suite.Equal(sampleUrl.Hostname(), u.Hostname())
 	suite.Equal(sampleUrl.Path, u.Path)
 	suite.Equal(os.Getenv("CLIENT_ID"), u.Query()["client_id"][0])
-	suite.Equal(os.Getenv("REDIRECT_URL"), u.Query()["redirect_uri"][0])
+	suite.Equal(os.Getenv("OAUTH_GOOGLE_REDIRECT_URL"), u.Query()["redirect_uri"][0])
 	suite.Equal("code", u.Query()["response_type"][0])
-	suite.Equal("openid", u.Query()["scope"][0])
-	suite.Equal(u.Query()["state"][0], state)
+	suite.Equal("openid email", u.Query()["scope"][0])
+	suite.Equal(u.Query()["state"][0], authorization.State)
+	suite.Equal(u.Query()["nonce"][0], authorization.Nonce)
+	sum := sha256.Sum256([]byte(authorization.CodeVerifier))
+	suite.Equal(base64.RawURLEncoding.EncodeToString(sum[:]), u.Query()["code_challenge"][0])
+	suite.Equal("S256", u.Query()["code_challenge_method"][0])
+	suite.GreaterOrEqual(len(authorization.CodeVerifier), 43)
 	suite.Nil(err)
 }
 
-// func (suite *AuthServiceTestSuite) TestSuccessGoogleLogin() {
-// 	// stateの検証
-// 	const state = "state"
-// 	const code = "code"
-// 	suite.ctx.SetCookie("state", state, 1000, "", "", false, false)
-// 	body := gin.H{
-// 		"state": state,
-// 		"code":  "code",
-// 	}
-// 	json, _ := json.Marshal(body)
-// 	req := httptest.NewRequest("POST", "/api/google/login", strings.NewReader(string(json)))
-// 	suite.ctx.Request = req
-
-// 	// トークンエンドポイントにリクエスト
-// 	providerConfig := &oidc.ProviderConfig{}
-// 	provider := providerConfig.NewProvider(suite.ctx)
-// 	oauth2Config := service.CreateOauth2Config(provider)
-// 	const tokenID = "fjoajfhofa1jojo"
-// 	reflect.ValueOf(oauth2).MethodByName()
-// 	// internalToken := internal.Token{Raw: map[string]interface{"token_id": tokenID}}
-// 	suite.oauthGatewayMock.EXPECT().RequestTokenEndpoint(oauth2Config, suite.ctx, code)
-
-// }
+func (suite *AuthServiceTestSuite) TestSuccessOauthWithUniqueRandomValues() {
+	provider := (&oidc.ProviderConfig{AuthURL: "https://example.com/auth"}).NewProvider(suite.ctx)
+	suite.setProvider("google")
+	suite.oauthGatewayMock.EXPECT().SearchProvider(suite.ctx, gomock.Any()).Return(provider, nil).Times(2)
+	authorization, _ := suite.service.Oauth(suite.ctx)
+	authorization2, _ := suite.service.Oauth(suite.ctx)
+
+	suite.NotEqual(authorization.State, authorization2.State)
+	suite.NotEqual(authorization.CodeVerifier, authorization2.CodeVerifier)
+	suite.NotEqual(authorization.Nonce, authorization2.Nonce)
+	suite.NotEqual(authorization.State, authorization.CodeVerifier)
+}
+
+func (suite *AuthServiceTestSuite) TestSuccessOauthWithConfiguredProvider() {
+	suite.T().Setenv("OAUTH_PROVIDERS", "google,company")
+	suite.T().Setenv("OAUTH_COMPANY_ISSUER", "https://idp.example.com")
+	suite.T().Setenv("OAUTH_COMPANY_CLIENT_ID", "companyClientID")
+	suite.T().Setenv("OAUTH_COMPANY_REDIRECT_URL", "http://localhost:3000/oauth/company/callback")
+	suite.T().Setenv("OAUTH_COMPANY_SCOPES", "openid email profile")
+	provider := (&oidc.ProviderConfig{AuthURL: "https://idp.example.com/auth"}).NewProvider(suite.ctx)
+	suite.setProvider("company")
+	suite.oauthGatewayMock.EXPECT().SearchProvider(suite.ctx, "https://idp.example.com").Return(provider, nil)
+
+	authorization, err := suite.service.Oauth(suite.ctx)
+	u, _ := url.Parse(authorization.URL)
+
+	suite.Nil(err)
+	suite.Equal("idp.example.com", u.Hostname())
+	suite.Equal("companyClientID", u.Query()["client_id"][0])
+	suite.Equal("http://localhost:3000/oauth/company/callback", u.Query()["redirect_uri"][0])
+	suite.Equal("openid email profile", u.Query()["scope"][0])
+}
+
+func (suite *AuthServiceTestSuite) TestBadOauthWithUnknownProvider() {
+	suite.setProvider("unknown")
+	_, err := suite.service.Oauth(suite.ctx)
+
+	suite.Equal(config.OauthProviderNotFoundError, err)
+}
+
+func (suite *AuthServiceTestSuite) setOauthLoginRequest(cookieState, state string) {
+	req := httptest.NewRequest("POST", "/api/oauth/google/login", strings.NewReader(fmt.Sprintf(`{"state":"%v","code":"code"}`, state)))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	req.AddCookie(&http.Cookie{Name: config.StateCookieKey, Value: cookieState})
+	req.AddCookie(&http.Cookie{Name: config.CodeVerifierCookieKey, Value: "codeVerifier"})
+	req.AddCookie(&http.Cookie{Name: config.NonceCookieKey, Value: "nonce"})
+	suite.ctx.Request = req
+	suite.setProvider("google")
+}
+
+func (suite *AuthServiceTestSuite) expectIDToken(claims gateway.IDTokenClaims) {
+	provider := (&oidc.ProviderConfig{}).NewProvider(suite.ctx)
+	oauth2Token := (&oauth2.Token{}).WithExtra(map[string]interface{}{"id_token": "rawIDToken"})
+	suite.oauthGatewayMock.EXPECT().SearchProvider(suite.ctx, os.Getenv("OAUTH_GOOGLE_ISSUER")).Return(provider, nil)
+	suite.oauthGatewayMock.EXPECT().RequestTokenEndpoint(gomock.Any(), suite.ctx, "code", "codeVerifier").Return(oauth2Token, nil)
+	suite.oauthGatewayMock.EXPECT().VerifyIDToken(suite.ctx, provider, os.Getenv("CLIENT_ID"), "rawIDToken", "nonce").Return(claims, nil)
+}
+
+func (suite *AuthServiceTestSuite) TestSuccessOauthLoginWithVerifiedEmail() {
+	suite.auditServiceMock.EXPECT().Record(gomock.Any(), model.AuditEventOauthLogin, gomock.Any(), "google")
+	user := model.User{ID: 1, Email: "user@example.com", Activated: true}
+	suite.setOauthLoginRequest("state", "state")
+	suite.expectIDToken(gateway.IDTokenClaims{Subject: "sub", Email: user.Email, EmailVerified: true})
+	suite.userRepositoryMock.EXPECT().FindOrCreateByIdentity("google", "sub", user.Email, true).Return(user, nil)
+	suite.sessionServiceMock.EXPECT().Create(gomock.Any(), user).Return(model.Session{SessionID: "sessionID"}, nil)
+	suite.refreshTokenServiceMock.EXPECT().Create(user, gomock.Any()).Return("refreshToken", nil)
+	suite.jwtServiceMock.EXPECT().CreateAccessJWT(user, gomock.Any()).Return("accessToken")
+	tokenPair, challengeToken, err := suite.service.OauthLogin(suite.ctx)
+
+	suite.Nil(err)
+	suite.Empty(challengeToken)
+	suite.Equal("accessToken", tokenPair.AccessToken)
+}
+
+func (suite *AuthServiceTestSuite) TestSuccessOauthLoginWithUnverifiedEmail() {
+	suite.auditServiceMock.EXPECT().Record(gomock.Any(), model.AuditEventOauthLogin, gomock.Any(), "google")
+	user := model.User{ID: 1, Activated: true}
+	suite.setOauthLoginRequest("state", "state")
+	suite.expectIDToken(gateway.IDTokenClaims{Subject: "sub", Email: "user@example.com", EmailVerified: false})
+	suite.userRepositoryMock.EXPECT().FindOrCreateByIdentity("google", "sub", "", true).Return(user, nil)
+	suite.sessionServiceMock.EXPECT().Create(gomock.Any(), user).Return(model.Session{SessionID: "sessionID"}, nil)
+	suite.refreshTokenServiceMock.EXPECT().Create(user, gomock.Any()).Return("refreshToken", nil)
+	suite.jwtServiceMock.EXPECT().CreateAccessJWT(user, gomock.Any()).Return("accessToken")
+	_, _, err := suite.service.OauthLogin(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *AuthServiceTestSuite) TestBadOauthLoginWithUntrustedProvider() {
+	suite.T().Setenv("OAUTH_GOOGLE_TRUST_EMAIL", "")
+	suite.setOauthLoginRequest("state", "state")
+	suite.expectIDToken(gateway.IDTokenClaims{Subject: "sub", Email: "user@example.com", EmailVerified: true})
+	suite.userRepositoryMock.EXPECT().FindOrCreateByIdentity("google", "sub", "user@example.com", false).Return(model.User{}, config.AccountLinkConflictError)
+	_, _, err := suite.service.OauthLogin(suite.ctx)
+
+	suite.Equal(config.AccountLinkConflictError, err)
+}
+
+func (suite *AuthServiceTestSuite) TestBadOauthLoginWithInvalidState() {
+	suite.setOauthLoginRequest("state", "invalid")
+	_, _, err := suite.service.OauthLogin(suite.ctx)
+
+	suite.Equal(config.CsrfError, err)
+}
+
+func (suite *AuthServiceTestSuite) TestBadOauthLoginWithoutNonceCookie() {
+	req := httptest.NewRequest("POST", "/api/oauth/google/login", strings.NewReader(`{"state":"state","code":"code"}`))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	req.AddCookie(&http.Cookie{Name: config.StateCookieKey, Value: "state"})
+	req.AddCookie(&http.Cookie{Name: config.CodeVerifierCookieKey, Value: "codeVerifier"})
+	suite.ctx.Request = req
+	suite.setProvider("google")
+	_, _, err := suite.service.OauthLogin(suite.ctx)
+
+	suite.Equal(http.ErrNoCookie, err)
+}
+
+func (suite *AuthServiceTestSuite) TestBadOauthLoginWithInvalidNonce() {
+	provider := (&oidc.ProviderConfig{}).NewProvider(suite.ctx)
+	oauth2Token := (&oauth2.Token{}).WithExtra(map[string]interface{}{"id_token": "rawIDToken"})
+	suite.setOauthLoginRequest("state", "state")
+	suite.oauthGatewayMock.EXPECT().SearchProvider(suite.ctx, gomock.Any()).Return(provider, nil)
+	suite.oauthGatewayMock.EXPECT().RequestTokenEndpoint(gomock.Any(), suite.ctx, "code", "codeVerifier").Return(oauth2Token, nil)
+	suite.oauthGatewayMock.EXPECT().VerifyIDToken(suite.ctx, provider, gomock.Any(), "rawIDToken", "nonce").Return(gateway.IDTokenClaims{}, config.InvalidNonceError)
+	_, _, err := suite.service.OauthLogin(suite.ctx)
+
+	suite.Equal(config.InvalidNonceError, err)
+}
+
+func (suite *AuthServiceTestSuite) TestBadOauthLoginWithInvalidIDToken() {
+	provider := (&oidc.ProviderConfig{}).NewProvider(suite.ctx)
+	oauth2Token := (&oauth2.Token{}).WithExtra(map[string]interface{}{"id_token": "rawIDToken"})
+	suite.setOauthLoginRequest("state", "state")
+	suite.oauthGatewayMock.EXPECT().SearchProvider(suite.ctx, gomock.Any()).Return(provider, nil)
+	suite.oauthGatewayMock.EXPECT().RequestTokenEndpoint(gomock.Any(), suite.ctx, "code", "codeVerifier").Return(oauth2Token, nil)
+	suite.oauthGatewayMock.EXPECT().VerifyIDToken(suite.ctx, provider, gomock.Any(), "rawIDToken", "nonce").Return(gateway.IDTokenClaims{}, errors.New("expired"))
+	_, _, err := suite.service.OauthLogin(suite.ctx)
+
+	suite.Equal(config.StandardError, err)
+}
+
+func (suite *AuthServiceTestSuite) TestSuccessLinkOauth() {
+	user := model.User{ID: 1, Email: "user@example.com"}
+	suite.ctx.Set(config.CurrentUserKey, user)
+	suite.setOauthLoginRequest("state", "state")
+	suite.expectIDToken(gateway.IDTokenClaims{Subject: "sub"})
+	suite.userRepositoryMock.EXPECT().LinkIdentity(&user, "google", "sub").Return(nil)
+	err := suite.service.LinkOauth(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *AuthServiceTestSuite) TestSuccessUnlinkOauth() {
+	user := model.User{ID: 1, PasswordDigest: "digest"}
+	suite.ctx.Set(config.CurrentUserKey, user)
+	suite.setProvider("google")
+	suite.userRepositoryMock.EXPECT().UnlinkIdentity(&user, "google").Return(nil)
+	err := suite.service.UnlinkOauth(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *AuthServiceTestSuite) TestBadUnlinkOauthWithUnknownProvider() {
+	suite.ctx.Set(config.CurrentUserKey, model.User{ID: 1})
+	suite.setProvider("unknown")
+	err := suite.service.UnlinkOauth(suite.ctx)
+
+	suite.Equal(config.OauthProviderNotFoundError, err)
+}
@@ -0,0 +1,185 @@
+package service_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/gateway"
+	"github.com/kuritaeiji/todo-gin-back/mock_gateway"
+	"github.com/kuritaeiji/todo-gin-back/mock_repository"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type BoardEventServiceTestSuite struct {
+	suite.Suite
+	service                           service.BoardEventService
+	hubMock                           *mock_gateway.MockBoardEventHub
+	listRepositoryMock                *mock_repository.MockListRepository
+	cardRepositoryMock                *mock_repository.MockCardRepository
+	personalAccessTokenRepositoryMock *mock_repository.MockPersonalAccessTokenRepository
+	sessionServiceMock                *mock_service.MockSessionService
+	boardMemberServiceMock            *mock_service.MockBoardMemberService
+	ctx                               *gin.Context
+	currentUser                       model.User
+}
+
+func (suite *BoardEventServiceTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *BoardEventServiceTestSuite) SetupTest() {
+	suite.hubMock = mock_gateway.NewMockBoardEventHub(gomock.NewController(suite.T()))
+	suite.listRepositoryMock = mock_repository.NewMockListRepository(gomock.NewController(suite.T()))
+	suite.cardRepositoryMock = mock_repository.NewMockCardRepository(gomock.NewController(suite.T()))
+	suite.personalAccessTokenRepositoryMock = mock_repository.NewMockPersonalAccessTokenRepository(gomock.NewController(suite.T()))
+	suite.sessionServiceMock = mock_service.NewMockSessionService(gomock.NewController(suite.T()))
+	suite.boardMemberServiceMock = mock_service.NewMockBoardMemberService(gomock.NewController(suite.T()))
+	suite.service = service.TestNewBoardEventService(suite.hubMock, suite.listRepositoryMock, suite.cardRepositoryMock, suite.personalAccessTokenRepositoryMock, suite.sessionServiceMock, suite.boardMemberServiceMock)
+	suite.ctx, _ = gin.CreateTestContext(httptest.NewRecorder())
+	suite.ctx.Request = httptest.NewRequest("GET", "/api/boards/1/events", nil)
+	suite.currentUser = model.User{ID: 2}
+	suite.ctx.Set(config.BoardKey, model.Board{ID: 1})
+	suite.ctx.Set(config.CurrentUserKey, suite.currentUser)
+}
+
+func TestBoardEventService(t *testing.T) {
+	suite.Run(t, new(BoardEventServiceTestSuite))
+}
+
+func (suite *BoardEventServiceTestSuite) TestSuccessSubscribe() {
+	subscription := gateway.BoardEventSubscription{Gap: true}
+	suite.hubMock.EXPECT().Subscribe(1, 2, int64(0)).Return(subscription, nil)
+	rSubscription, err := suite.service.Subscribe(suite.ctx)
+
+	suite.Nil(err)
+	suite.True(rSubscription.Gap)
+}
+
+func (suite *BoardEventServiceTestSuite) TestSuccessSubscribeWithLastEventID() {
+	suite.ctx.Request.Header.Set("Last-Event-ID", "5")
+	suite.hubMock.EXPECT().Subscribe(1, 2, int64(5)).Return(gateway.BoardEventSubscription{}, nil)
+	_, err := suite.service.Subscribe(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *BoardEventServiceTestSuite) setClaim(expiresAt time.Time) *service.UserClaim {
+	claim := &service.UserClaim{ID: 2, SessionID: "sid", StandardClaims: jwt.StandardClaims{Id: "jti", ExpiresAt: expiresAt.Unix()}}
+	suite.ctx.Set(config.ClaimKey, claim)
+	return claim
+}
+
+func (suite *BoardEventServiceTestSuite) TestSuccessAuthorize() {
+	suite.setClaim(time.Now().Add(time.Minute))
+	suite.sessionServiceMock.EXPECT().Touch("sid", "jti", suite.currentUser).Return(nil)
+	suite.boardMemberServiceMock.EXPECT().Authorize(1, suite.currentUser, model.BoardRoleViewer).Return(nil)
+	err := suite.service.Authorize(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *BoardEventServiceTestSuite) TestBadAuthorizeWithExpiredAccessToken() {
+	suite.setClaim(time.Now().Add(-time.Second))
+	err := suite.service.Authorize(suite.ctx)
+
+	suite.Equal(config.AccessTokenExpiredError, err)
+}
+
+func (suite *BoardEventServiceTestSuite) TestBadAuthorizeWithRevokedSession() {
+	suite.setClaim(time.Now().Add(time.Minute))
+	suite.sessionServiceMock.EXPECT().Touch("sid", "jti", suite.currentUser).Return(config.SessionRevokedError)
+	err := suite.service.Authorize(suite.ctx)
+
+	suite.Equal(config.SessionRevokedError, err)
+}
+
+func (suite *BoardEventServiceTestSuite) TestBadAuthorizeWithRemovedMember() {
+	suite.setClaim(time.Now().Add(time.Minute))
+	suite.sessionServiceMock.EXPECT().Touch("sid", "jti", suite.currentUser).Return(nil)
+	suite.boardMemberServiceMock.EXPECT().Authorize(1, suite.currentUser, model.BoardRoleViewer).Return(config.ForbiddenError)
+	err := suite.service.Authorize(suite.ctx)
+
+	suite.Equal(config.ForbiddenError, err)
+}
+
+func (suite *BoardEventServiceTestSuite) TestSuccessAuthorizeWithPersonalAccessToken() {
+	token := model.PersonalAccessToken{ID: 3, Digest: "digest"}
+	suite.ctx.Set(config.PersonalAccessTokenKey, token)
+	suite.personalAccessTokenRepositoryMock.EXPECT().FindByDigest("digest").Return(token, nil)
+	suite.boardMemberServiceMock.EXPECT().Authorize(1, suite.currentUser, model.BoardRoleViewer).Return(nil)
+	err := suite.service.Authorize(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *BoardEventServiceTestSuite) TestBadAuthorizeWithRevokedPersonalAccessToken() {
+	suite.ctx.Set(config.PersonalAccessTokenKey, model.PersonalAccessToken{ID: 3, Digest: "digest"})
+	suite.personalAccessTokenRepositoryMock.EXPECT().FindByDigest("digest").Return(model.PersonalAccessToken{}, gorm.ErrRecordNotFound)
+	err := suite.service.Authorize(suite.ctx)
+
+	suite.Equal(config.InvalidPersonalAccessTokenError, err)
+}
+
+func (suite *BoardEventServiceTestSuite) TestPublishList() {
+	list := model.List{ID: 2, Title: "list", Index: 3, BoardID: 1}
+	suite.hubMock.EXPECT().Publish(1, service.ListMovedEvent, gin.H{"id": 2, "title": "list", "index": 3}).Return(nil)
+	suite.service.PublishList(service.ListMovedEvent, list)
+}
+
+func (suite *BoardEventServiceTestSuite) TestPublishCard() {
+	card := model.Card{ID: 2, ListID: 3, Index: 1}
+	suite.listRepositoryMock.EXPECT().FindWithTrashed(3).Return(model.List{ID: 3, BoardID: 1}, nil)
+	suite.hubMock.EXPECT().Publish(1, service.CardUpdatedEvent, gomock.Any()).Return(nil).Do(func(boardID int, eventType string, data interface{}) {
+		suite.Equal(3, data.(gin.H)["listId"])
+		suite.Equal(1, data.(gin.H)["index"])
+	})
+	suite.service.PublishCard(service.CardUpdatedEvent, card)
+}
+
+func (suite *BoardEventServiceTestSuite) TestPublishCardMovedToOtherBoard() {
+	card := model.Card{ID: 2, ListID: 3}
+	suite.listRepositoryMock.EXPECT().FindWithTrashed(3).Return(model.List{ID: 3, BoardID: 1}, nil)
+	suite.listRepositoryMock.EXPECT().FindWithTrashed(4).Return(model.List{ID: 4, BoardID: 5}, nil)
+	suite.hubMock.EXPECT().Publish(1, service.CardMovedEvent, gomock.Any()).Return(nil)
+	suite.hubMock.EXPECT().Publish(5, service.CardMovedEvent, gomock.Any()).Return(nil)
+	suite.service.PublishCard(service.CardMovedEvent, card, 3, 4)
+}
+
+func (suite *BoardEventServiceTestSuite) TestPublishCardMovedInSameBoardOnce() {
+	card := model.Card{ID: 2, ListID: 3}
+	suite.listRepositoryMock.EXPECT().FindWithTrashed(3).Return(model.List{ID: 3, BoardID: 1}, nil)
+	suite.listRepositoryMock.EXPECT().FindWithTrashed(4).Return(model.List{ID: 4, BoardID: 1}, nil)
+	suite.hubMock.EXPECT().Publish(1, service.CardMovedEvent, gomock.Any()).Return(nil).Times(1)
+	suite.service.PublishCard(service.CardMovedEvent, card, 3, 4)
+}
+
+func (suite *BoardEventServiceTestSuite) TestPublishCardWithDBError() {
+	card := model.Card{ID: 2, ListID: 3}
+	suite.listRepositoryMock.EXPECT().FindWithTrashed(3).Return(model.List{}, errors.New("db error"))
+	suite.service.PublishCard(service.CardDeletedEvent, card)
+}
+
+func (suite *BoardEventServiceTestSuite) TestPublishCardChanged() {
+	card := model.Card{ID: 2, ListID: 3, Labels: []model.Label{{ID: 4}}}
+	suite.cardRepositoryMock.EXPECT().Find(2).Return(card, nil)
+	suite.listRepositoryMock.EXPECT().FindWithTrashed(3).Return(model.List{ID: 3, BoardID: 1}, nil)
+	suite.hubMock.EXPECT().Publish(1, service.CardUpdatedEvent, gomock.Any()).Return(nil).Do(func(boardID int, eventType string, data interface{}) {
+		suite.Len(data.(gin.H)["labels"], 1)
+	})
+	suite.service.PublishCardChanged(2)
+}
+
+func (suite *BoardEventServiceTestSuite) TestPublishCardChangedWithDBError() {
+	suite.cardRepositoryMock.EXPECT().Find(2).Return(model.Card{}, errors.New("db error"))
+	suite.service.PublishCardChanged(2)
+}
@@ -0,0 +1,230 @@
+package service_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/mock_gateway"
+	"github.com/kuritaeiji/todo-gin-back/mock_repository"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type BoardMemberServiceTestSuite struct {
+	suite.Suite
+	service                   service.BoardMemberService
+	boardMemberRepositoryMock *mock_repository.MockBoardMemberRepository
+	userRepositoryMock        *mock_repository.MockUserRepository
+	emailServiceMock          *mock_service.MockEmailService
+	hubMock                   *mock_gateway.MockBoardEventHub
+	ctx                       *gin.Context
+	currentUser               model.User
+	board                     model.Board
+	acceptedAt                time.Time
+}
+
+func (suite *BoardMemberServiceTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *BoardMemberServiceTestSuite) SetupTest() {
+	suite.boardMemberRepositoryMock = mock_repository.NewMockBoardMemberRepository(gomock.NewController(suite.T()))
+	suite.userRepositoryMock = mock_repository.NewMockUserRepository(gomock.NewController(suite.T()))
+	suite.emailServiceMock = mock_service.NewMockEmailService(gomock.NewController(suite.T()))
+	suite.hubMock = mock_gateway.NewMockBoardEventHub(gomock.NewController(suite.T()))
+	suite.service = service.TestNewBoardMemberService(suite.boardMemberRepositoryMock, suite.userRepositoryMock, suite.emailServiceMock, suite.hubMock)
+	suite.ctx, _ = gin.CreateTestContext(httptest.NewRecorder())
+	suite.currentUser = model.User{ID: 1, Email: "owner@example.com"}
+	suite.board = model.Board{ID: 2, Title: "board"}
+	suite.ctx.Set(config.CurrentUserKey, suite.currentUser)
+	suite.ctx.Set(config.BoardKey, suite.board)
+	suite.acceptedAt = time.Now()
+}
+
+func TestBoardMemberService(t *testing.T) {
+	suite.Run(t, new(BoardMemberServiceTestSuite))
+}
+
+func (suite *BoardMemberServiceTestSuite) setBody(body string) {
+	suite.ctx.Request = httptest.NewRequest("POST", "/", strings.NewReader(body))
+}
+
+func (suite *BoardMemberServiceTestSuite) TestSuccessAuthorize() {
+	member := model.BoardMember{Role: model.BoardRoleEditor, AcceptedAt: &suite.acceptedAt}
+	suite.boardMemberRepositoryMock.EXPECT().Find(2, 1).Return(member, nil)
+	err := suite.service.Authorize(2, suite.currentUser, model.BoardRoleEditor)
+
+	suite.Nil(err)
+}
+
+func (suite *BoardMemberServiceTestSuite) TestBadAuthorizeWithViewer() {
+	member := model.BoardMember{Role: model.BoardRoleViewer, AcceptedAt: &suite.acceptedAt}
+	suite.boardMemberRepositoryMock.EXPECT().Find(2, 1).Return(member, nil)
+	err := suite.service.Authorize(2, suite.currentUser, model.BoardRoleEditor)
+
+	suite.Equal(config.ForbiddenError, err)
+}
+
+func (suite *BoardMemberServiceTestSuite) TestBadAuthorizeWithPendingInvitation() {
+	member := model.BoardMember{Role: model.BoardRoleOwner}
+	suite.boardMemberRepositoryMock.EXPECT().Find(2, 1).Return(member, nil)
+	err := suite.service.Authorize(2, suite.currentUser, model.BoardRoleViewer)
+
+	suite.Equal(config.ForbiddenError, err)
+}
+
+func (suite *BoardMemberServiceTestSuite) TestBadAuthorizeWithNotMember() {
+	suite.boardMemberRepositoryMock.EXPECT().Find(2, 1).Return(model.BoardMember{}, gorm.ErrRecordNotFound)
+	err := suite.service.Authorize(2, suite.currentUser, model.BoardRoleViewer)
+
+	suite.Equal(config.ForbiddenError, err)
+}
+
+func (suite *BoardMemberServiceTestSuite) TestSuccessInvite() {
+	suite.setBody(`{"email":"user@example.com","role":"editor"}`)
+	user := model.User{ID: 3, Email: "user@example.com"}
+	suite.userRepositoryMock.EXPECT().FindByEmail(user.Email).Return(user, nil)
+	suite.boardMemberRepositoryMock.EXPECT().Find(2, 3).Return(model.BoardMember{}, gorm.ErrRecordNotFound)
+	suite.boardMemberRepositoryMock.EXPECT().Create(&model.BoardMember{BoardID: 2, UserID: 3, User: user, Role: model.BoardRoleEditor}).Return(nil)
+	suite.emailServiceMock.EXPECT().BoardInvitationEmail(user, suite.board, suite.currentUser).Return(nil)
+	member, err := suite.service.Invite(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal(model.BoardRoleEditor, member.Role)
+	suite.Nil(member.AcceptedAt)
+}
+
+func (suite *BoardMemberServiceTestSuite) TestBadInviteWithValidationError() {
+	suite.setBody(`{"email":"user@example.com","role":"admin"}`)
+	_, err := suite.service.Invite(suite.ctx)
+
+	suite.IsType(validator.ValidationErrors{}, err)
+}
+
+func (suite *BoardMemberServiceTestSuite) TestBadInviteWithNotFoundUser() {
+	suite.setBody(`{"email":"user@example.com","role":"viewer"}`)
+	suite.userRepositoryMock.EXPECT().FindByEmail("user@example.com").Return(model.User{}, gorm.ErrRecordNotFound)
+	_, err := suite.service.Invite(suite.ctx)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *BoardMemberServiceTestSuite) TestBadInviteWithAlreadyMember() {
+	suite.setBody(`{"email":"user@example.com","role":"viewer"}`)
+	suite.userRepositoryMock.EXPECT().FindByEmail("user@example.com").Return(model.User{ID: 3}, nil)
+	suite.boardMemberRepositoryMock.EXPECT().Find(2, 3).Return(model.BoardMember{ID: 4}, nil)
+	_, err := suite.service.Invite(suite.ctx)
+
+	suite.Equal(config.AlreadyBoardMemberError, err)
+}
+
+func (suite *BoardMemberServiceTestSuite) TestSuccessUpdate() {
+	suite.setBody(`{"role":"viewer"}`)
+	suite.ctx.Params = gin.Params{{Key: "memberID", Value: "4"}}
+	member := model.BoardMember{ID: 4, BoardID: 2, UserID: 3, Role: model.BoardRoleEditor, AcceptedAt: &suite.acceptedAt}
+	suite.boardMemberRepositoryMock.EXPECT().FindInBoard(2, 4).Return(member, nil)
+	suite.boardMemberRepositoryMock.EXPECT().UpdateRole(&member, model.BoardRoleViewer).Return(nil)
+	suite.hubMock.EXPECT().CloseUser(2, 3)
+	_, err := suite.service.Update(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *BoardMemberServiceTestSuite) TestBadUpdateWithLastOwner() {
+	suite.setBody(`{"role":"editor"}`)
+	suite.ctx.Params = gin.Params{{Key: "memberID", Value: "4"}}
+	member := model.BoardMember{ID: 4, BoardID: 2, Role: model.BoardRoleOwner, AcceptedAt: &suite.acceptedAt}
+	suite.boardMemberRepositoryMock.EXPECT().FindInBoard(2, 4).Return(member, nil)
+	suite.boardMemberRepositoryMock.EXPECT().UpdateRole(&member, model.BoardRoleEditor).Return(config.LastBoardOwnerError)
+	_, err := suite.service.Update(suite.ctx)
+
+	suite.Equal(config.LastBoardOwnerError, err)
+}
+
+func (suite *BoardMemberServiceTestSuite) TestSuccessUpdateOwnerWithOtherOwner() {
+	suite.setBody(`{"role":"editor"}`)
+	suite.ctx.Params = gin.Params{{Key: "memberID", Value: "4"}}
+	member := model.BoardMember{ID: 4, BoardID: 2, Role: model.BoardRoleOwner, AcceptedAt: &suite.acceptedAt}
+	suite.boardMemberRepositoryMock.EXPECT().FindInBoard(2, 4).Return(member, nil)
+	suite.boardMemberRepositoryMock.EXPECT().UpdateRole(&member, model.BoardRoleEditor).Return(nil)
+	suite.hubMock.EXPECT().CloseUser(2, 0)
+	_, err := suite.service.Update(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *BoardMemberServiceTestSuite) TestBadUpdateWithOtherBoardsMember() {
+	suite.setBody(`{"role":"editor"}`)
+	suite.ctx.Params = gin.Params{{Key: "memberID", Value: "4"}}
+	suite.boardMemberRepositoryMock.EXPECT().FindInBoard(2, 4).Return(model.BoardMember{}, gorm.ErrRecordNotFound)
+	_, err := suite.service.Update(suite.ctx)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *BoardMemberServiceTestSuite) TestSuccessDestroy() {
+	suite.ctx.Params = gin.Params{{Key: "memberID", Value: "4"}}
+	member := model.BoardMember{ID: 4, BoardID: 2, UserID: 3, Role: model.BoardRoleViewer, AcceptedAt: &suite.acceptedAt}
+	suite.boardMemberRepositoryMock.EXPECT().FindInBoard(2, 4).Return(member, nil)
+	suite.boardMemberRepositoryMock.EXPECT().Destroy(&member).Return(nil)
+	suite.hubMock.EXPECT().CloseUser(2, 3)
+	err := suite.service.Destroy(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *BoardMemberServiceTestSuite) TestBadDestroyWithLastOwner() {
+	suite.ctx.Params = gin.Params{{Key: "memberID", Value: "4"}}
+	member := model.BoardMember{ID: 4, BoardID: 2, Role: model.BoardRoleOwner, AcceptedAt: &suite.acceptedAt}
+	suite.boardMemberRepositoryMock.EXPECT().FindInBoard(2, 4).Return(member, nil)
+	suite.boardMemberRepositoryMock.EXPECT().Destroy(&member).Return(config.LastBoardOwnerError)
+	err := suite.service.Destroy(suite.ctx)
+
+	suite.Equal(config.LastBoardOwnerError, err)
+}
+
+func (suite *BoardMemberServiceTestSuite) TestSuccessInvitations() {
+	invitations := []model.BoardMember{{ID: 4, BoardID: 2, UserID: 1}}
+	suite.boardMemberRepositoryMock.EXPECT().FindInvitations(1).Return(invitations, nil)
+	rInvitations, err := suite.service.Invitations(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal(invitations, rInvitations)
+}
+
+func (suite *BoardMemberServiceTestSuite) TestSuccessAccept() {
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "4"}}
+	member := model.BoardMember{ID: 4, BoardID: 2, UserID: 1, Role: model.BoardRoleViewer}
+	suite.boardMemberRepositoryMock.EXPECT().FindInvitation(4, 1).Return(member, nil)
+	suite.boardMemberRepositoryMock.EXPECT().Accept(&member).Return(nil)
+	_, err := suite.service.Accept(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *BoardMemberServiceTestSuite) TestBadAcceptWithOtherUsersInvitation() {
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "4"}}
+	suite.boardMemberRepositoryMock.EXPECT().FindInvitation(4, 1).Return(model.BoardMember{}, gorm.ErrRecordNotFound)
+	_, err := suite.service.Accept(suite.ctx)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *BoardMemberServiceTestSuite) TestSuccessDecline() {
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "4"}}
+	member := model.BoardMember{ID: 4, BoardID: 2, UserID: 1, Role: model.BoardRoleViewer}
+	suite.boardMemberRepositoryMock.EXPECT().FindInvitation(4, 1).Return(member, nil)
+	suite.boardMemberRepositoryMock.EXPECT().Destroy(&member).Return(nil)
+	err := suite.service.Decline(suite.ctx)
+
+	suite.Nil(err)
+}
@@ -0,0 +1,101 @@
+package service_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/mock_repository"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type BoardMiddlewareServiceTestSuite struct {
+	suite.Suite
+	service                service.BoardMiddlewareService
+	boardRepositoryMock    *mock_repository.MockBoardRepository
+	boardMemberServiceMock *mock_service.MockBoardMemberService
+	ctx                    *gin.Context
+	currentUser            model.User
+}
+
+func (suite *BoardMiddlewareServiceTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *BoardMiddlewareServiceTestSuite) SetupTest() {
+	suite.boardRepositoryMock = mock_repository.NewMockBoardRepository(gomock.NewController(suite.T()))
+	suite.boardMemberServiceMock = mock_service.NewMockBoardMemberService(gomock.NewController(suite.T()))
+	suite.service = service.TestNewBoardMiddlewareService(suite.boardRepositoryMock, suite.boardMemberServiceMock)
+	suite.ctx, _ = gin.CreateTestContext(httptest.NewRecorder())
+	suite.ctx.Request = httptest.NewRequest("POST", "/", nil)
+	suite.currentUser = model.User{ID: 1}
+	suite.ctx.Set(config.CurrentUserKey, suite.currentUser)
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "2"}}
+}
+
+func TestBoardMiddlewareService(t *testing.T) {
+	suite.Run(t, new(BoardMiddlewareServiceTestSuite))
+}
+
+func (suite *BoardMiddlewareServiceTestSuite) TestSuccessAuthorize() {
+	board := model.Board{ID: 2}
+	suite.boardRepositoryMock.EXPECT().Find(2).Return(board, nil)
+	suite.boardMemberServiceMock.EXPECT().Authorize(2, suite.currentUser, model.BoardRoleEditor).Return(nil)
+	rBoard, err := suite.service.Authorize(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal(board, rBoard)
+}
+
+func (suite *BoardMiddlewareServiceTestSuite) TestBadAuthorizeWithInvalidID() {
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "a"}}
+	_, err := suite.service.Authorize(suite.ctx)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *BoardMiddlewareServiceTestSuite) TestBadAuthorizeWithNotFoundBoard() {
+	suite.boardRepositoryMock.EXPECT().Find(2).Return(model.Board{}, gorm.ErrRecordNotFound)
+	_, err := suite.service.Authorize(suite.ctx)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *BoardMiddlewareServiceTestSuite) TestBadAuthorizeWithForbiddenError() {
+	suite.boardRepositoryMock.EXPECT().Find(2).Return(model.Board{ID: 2}, nil)
+	suite.boardMemberServiceMock.EXPECT().Authorize(2, suite.currentUser, model.BoardRoleEditor).Return(config.ForbiddenError)
+	_, err := suite.service.Authorize(suite.ctx)
+
+	suite.Equal(config.ForbiddenError, err)
+}
+
+func (suite *BoardMiddlewareServiceTestSuite) TestAuthorizeRequiresViewerForGet() {
+	suite.ctx.Request = httptest.NewRequest("GET", "/", nil)
+	suite.boardRepositoryMock.EXPECT().Find(2).Return(model.Board{ID: 2}, nil)
+	suite.boardMemberServiceMock.EXPECT().Authorize(2, suite.currentUser, model.BoardRoleViewer).Return(nil)
+	_, err := suite.service.Authorize(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *BoardMiddlewareServiceTestSuite) TestSuccessAuthorizeOwner() {
+	suite.boardRepositoryMock.EXPECT().Find(2).Return(model.Board{ID: 2}, nil)
+	suite.boardMemberServiceMock.EXPECT().Authorize(2, suite.currentUser, model.BoardRoleOwner).Return(nil)
+	_, err := suite.service.AuthorizeOwner(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *BoardMiddlewareServiceTestSuite) TestBadAuthorizeOwnerWithForbiddenError() {
+	suite.boardRepositoryMock.EXPECT().Find(2).Return(model.Board{ID: 2}, nil)
+	suite.boardMemberServiceMock.EXPECT().Authorize(2, suite.currentUser, model.BoardRoleOwner).Return(config.ForbiddenError)
+	_, err := suite.service.AuthorizeOwner(suite.ctx)
+
+	suite.Equal(config.ForbiddenError, err)
+}
@@ -0,0 +1,124 @@
+package service_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/mock_repository"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"github.com/kuritaeiji/todo-gin-back/validators"
+	"github.com/stretchr/testify/suite"
+)
+
+type BoardServiceTestSuite struct {
+	suite.Suite
+	service               service.BoardService
+	boardRepositoryMock   *mock_repository.MockBoardRepository
+	attachmentServiceMock *mock_service.MockAttachmentService
+	ctx                   *gin.Context
+	currentUser           model.User
+	board                 model.Board
+}
+
+func (suite *BoardServiceTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	validators.Init()
+}
+
+func (suite *BoardServiceTestSuite) SetupTest() {
+	suite.boardRepositoryMock = mock_repository.NewMockBoardRepository(gomock.NewController(suite.T()))
+	suite.attachmentServiceMock = mock_service.NewMockAttachmentService(gomock.NewController(suite.T()))
+	suite.service = service.TestNewBoardService(suite.boardRepositoryMock, suite.attachmentServiceMock)
+	suite.ctx, _ = gin.CreateTestContext(httptest.NewRecorder())
+	suite.currentUser = model.User{ID: 1}
+	suite.board = model.Board{ID: 2, Title: "board", UserID: suite.currentUser.ID}
+	suite.ctx.Set(config.CurrentUserKey, suite.currentUser)
+	suite.ctx.Set(config.BoardKey, suite.board)
+}
+
+func TestBoardService(t *testing.T) {
+	suite.Run(t, new(BoardServiceTestSuite))
+}
+
+func (suite *BoardServiceTestSuite) setRequest(method string, boardConfig *factory.BoardConfig) {
+	req := httptest.NewRequest(method, "/api/boards", factory.CreateBoardRequestBody(boardConfig))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+}
+
+func (suite *BoardServiceTestSuite) TestSuccessIndex() {
+	boards := []model.Board{suite.board}
+	suite.boardRepositoryMock.EXPECT().FindAll(&suite.currentUser).Return(boards, nil)
+	rBoards, err := suite.service.Index(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal(boards, rBoards)
+}
+
+func (suite *BoardServiceTestSuite) TestSuccessCreate() {
+	boardConfig := &factory.BoardConfig{Index: 1}
+	suite.setRequest("POST", boardConfig)
+	board := factory.NewBoard(boardConfig)
+	suite.boardRepositoryMock.EXPECT().Create(&suite.currentUser, &board).Return(nil)
+	rBoard, err := suite.service.Create(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal(board, rBoard)
+}
+
+func (suite *BoardServiceTestSuite) TestBadCreateWithValidationError() {
+	suite.setRequest("POST", &factory.BoardConfig{NotUseDefaultValue: true})
+	_, err := suite.service.Create(suite.ctx)
+
+	suite.IsType(validator.ValidationErrors{}, err)
+}
+
+func (suite *BoardServiceTestSuite) TestSuccessUpdate() {
+	suite.setRequest("PUT", &factory.BoardConfig{Title: "new title"})
+	suite.boardRepositoryMock.EXPECT().Update(&suite.board, model.Board{Title: "new title"}).Return(nil)
+	_, err := suite.service.Update(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *BoardServiceTestSuite) TestSuccessDestroy() {
+	suite.attachmentServiceMock.EXPECT().DestroyByBoard(suite.board).Return(nil)
+	suite.boardRepositoryMock.EXPECT().Destroy(&suite.board).Return(nil)
+	err := suite.service.Destroy(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *BoardServiceTestSuite) TestBadDestroyWithStorageError() {
+	storageError := errors.New("storage error")
+	suite.attachmentServiceMock.EXPECT().DestroyByBoard(suite.board).Return(storageError)
+	err := suite.service.Destroy(suite.ctx)
+
+	suite.Equal(storageError, err)
+}
+
+func (suite *BoardServiceTestSuite) TestSuccessMove() {
+	suite.setRequest("PUT", &factory.BoardConfig{Index: 3})
+	suite.boardRepositoryMock.EXPECT().Move(&suite.board, 3).Return(nil)
+	err := suite.service.Move(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *BoardServiceTestSuite) TestBadMoveWithValidationError() {
+	req := httptest.NewRequest("PUT", "/api/boards/2/move", strings.NewReader(`{"index":-1}`))
+	suite.ctx.Request = req
+	err := suite.service.Move(suite.ctx)
+
+	suite.IsType(validator.ValidationErrors{}, err)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/kuritaeiji/todo-gin-back/config"
 	"github.com/kuritaeiji/todo-gin-back/factory"
 	"github.com/kuritaeiji/todo-gin-back/mock_repository"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
 	"github.com/kuritaeiji/todo-gin-back/model"
 	"github.com/kuritaeiji/todo-gin-back/service"
 	"github.com/stretchr/testify/suite"
@@ -19,10 +20,10 @@ import (
 
 type CardMiddlewareServiceTestSuite struct {
 	suite.Suite
-	service            service.CardMiddlewareService
-	cardRepositoryMock *mock_repository.MockCardRepository
-	userRepositoryMock *mock_repository.MockUserRepository
-	ctx                *gin.Context
+	service                   service.CardMiddlewareService
+	cardRepositoryMock        *mock_repository.MockCardRepository
+	listMiddlewareServiceMock *mock_service.MockListMiddlewareServive
+	ctx                       *gin.Context
 }
 
 func (suite *CardMiddlewareServiceTestSuite) SetupSuite() {
@@ -31,9 +32,10 @@ func (suite *CardMiddlewareServiceTestSuite) SetupSuite() {
 
 func (suite *CardMiddlewareServiceTestSuite) SetupTest() {
 	suite.cardRepositoryMock = mock_repository.NewMockCardRepository(gomock.NewController(suite.T()))
-	suite.userRepositoryMock = mock_repository.NewMockUserRepository(gomock.NewController(suite.T()))
-	suite.service = service.TestNewCardMiddlewareService(suite.cardRepositoryMock, suite.userRepositoryMock)
+	suite.listMiddlewareServiceMock = mock_service.NewMockListMiddlewareServive(gomock.NewController(suite.T()))
+	suite.service = service.TestNewCardMiddlewareService(suite.cardRepositoryMock, suite.listMiddlewareServiceMock)
 	suite.ctx, _ = gin.CreateTestContext(httptest.NewRecorder())
+	suite.ctx.Request = httptest.NewRequest("PUT", "/", nil)
 }
 
 func TestCardMiddlewareService(t *testing.T) {
@@ -47,7 +49,7 @@ func (suite *CardMiddlewareServiceTestSuite) TestSuccessAuthorize() {
 	suite.cardRepositoryMock.EXPECT().Find(cardID).Return(card, nil)
 	currentUser := factory.NewUser(&factory.UserConfig{})
 	suite.ctx.Set(config.CurrentUserKey, currentUser)
-	suite.userRepositoryMock.EXPECT().HasCard(card, currentUser).Return(true, nil)
+	suite.listMiddlewareServiceMock.EXPECT().FindAndAuthorizeList(card.ListID, currentUser, model.BoardRoleEditor).Return(model.List{}, nil)
 	rCard, err := suite.service.Authorize(suite.ctx)
 
 	suite.Equal(card, rCard)
@@ -77,7 +79,7 @@ func (suite *CardMiddlewareServiceTestSuite) TestBadAuthorizeWithForbiddenError(
 	suite.cardRepositoryMock.EXPECT().Find(cardID).Return(card, nil)
 	currentUser := factory.NewUser(&factory.UserConfig{})
 	suite.ctx.Set(config.CurrentUserKey, currentUser)
-	suite.userRepositoryMock.EXPECT().HasCard(card, currentUser).Return(false, nil)
+	suite.listMiddlewareServiceMock.EXPECT().FindAndAuthorizeList(card.ListID, currentUser, model.BoardRoleEditor).Return(model.List{}, config.ForbiddenError)
 	_, err := suite.service.Authorize(suite.ctx)
 
 	suite.Equal(config.ForbiddenError, err)
@@ -91,8 +93,22 @@ func (suite *CardMiddlewareServiceTestSuite) TestBadAuthorizeWithDBError() {
 	currentUser := factory.NewUser(&factory.UserConfig{})
 	suite.ctx.Set(config.CurrentUserKey, currentUser)
 	err := errors.New("db error")
-	suite.userRepositoryMock.EXPECT().HasCard(card, currentUser).Return(false, err)
+	suite.listMiddlewareServiceMock.EXPECT().FindAndAuthorizeList(card.ListID, currentUser, model.BoardRoleEditor).Return(model.List{}, err)
 	_, rerr := suite.service.Authorize(suite.ctx)
 
 	suite.Equal(err, rerr)
 }
+
+func (suite *CardMiddlewareServiceTestSuite) TestAuthorizeRequiresViewerForGet() {
+	cardID := 1
+	suite.ctx.Params = gin.Params{gin.Param{Key: "id", Value: strconv.Itoa(cardID)}}
+	suite.ctx.Request = httptest.NewRequest("GET", "/", nil)
+	card := factory.NewCard(&factory.CardConfig{})
+	suite.cardRepositoryMock.EXPECT().Find(cardID).Return(card, nil)
+	currentUser := factory.NewUser(&factory.UserConfig{})
+	suite.ctx.Set(config.CurrentUserKey, currentUser)
+	suite.listMiddlewareServiceMock.EXPECT().FindAndAuthorizeList(card.ListID, currentUser, model.BoardRoleViewer).Return(model.List{}, nil)
+	_, err := suite.service.Authorize(suite.ctx)
+
+	suite.Nil(err)
+}
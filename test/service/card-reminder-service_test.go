@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/mock_repository"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"github.com/stretchr/testify/suite"
+)
+
+type CardReminderServiceTestSuite struct {
+	suite.Suite
+	service                    service.CardReminderService
+	cardReminderRepositoryMock *mock_repository.MockCardReminderRepository
+	emailServiceMock           *mock_service.MockEmailService
+}
+
+func (suite *CardReminderServiceTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *CardReminderServiceTestSuite) SetupTest() {
+	suite.cardReminderRepositoryMock = mock_repository.NewMockCardReminderRepository(gomock.NewController(suite.T()))
+	suite.emailServiceMock = mock_service.NewMockEmailService(gomock.NewController(suite.T()))
+	suite.service = service.TestNewCardReminderService(suite.cardReminderRepositoryMock, suite.emailServiceMock)
+}
+
+func (suite *CardReminderServiceTestSuite) TearDownTest() {
+	os.Unsetenv("CARD_REMINDER_OFFSETS")
+}
+
+func TestCardReminderService(t *testing.T) {
+	suite.Run(t, new(CardReminderServiceTestSuite))
+}
+
+func (suite *CardReminderServiceTestSuite) TestSuccessSchedule() {
+	dueAt := time.Now().Add(48 * time.Hour)
+	card := model.Card{ID: 1, DueAt: &dueAt}
+	suite.cardReminderRepositoryMock.EXPECT().Replace(&card, gomock.Any()).Return(nil).Do(func(_ *model.Card, reminders []model.CardReminder) {
+		suite.Len(reminders, 2)
+		suite.Equal(dueAt.Add(-24*time.Hour), reminders[0].RemindAt)
+		suite.Equal(24*60, reminders[0].OffsetMinutes)
+		suite.Equal(dueAt.Add(-time.Hour), reminders[1].RemindAt)
+		suite.Equal(card.ID, reminders[1].CardID)
+	})
+	err := suite.service.Schedule(card)
+
+	suite.Nil(err)
+}
+
+func (suite *CardReminderServiceTestSuite) TestSuccessScheduleSkipsPastReminders() {
+	os.Setenv("CARD_REMINDER_OFFSETS", "24h,30m,invalid")
+	dueAt := time.Now().Add(2 * time.Hour)
+	card := model.Card{ID: 1, DueAt: &dueAt}
+	suite.cardReminderRepositoryMock.EXPECT().Replace(&card, gomock.Any()).Return(nil).Do(func(_ *model.Card, reminders []model.CardReminder) {
+		suite.Len(reminders, 1)
+		suite.Equal(30, reminders[0].OffsetMinutes)
+	})
+	err := suite.service.Schedule(card)
+
+	suite.Nil(err)
+}
+
+func (suite *CardReminderServiceTestSuite) TestSuccessScheduleWithoutDueAt() {
+	card := model.Card{ID: 1}
+	suite.cardReminderRepositoryMock.EXPECT().Replace(&card, gomock.Len(0)).Return(nil)
+	err := suite.service.Schedule(card)
+
+	suite.Nil(err)
+}
+
+func (suite *CardReminderServiceTestSuite) TestSuccessSendDue() {
+	dueAt := time.Now().Add(time.Hour)
+	user := model.User{ID: 1, Email: "user@example.com"}
+	member := model.User{ID: 2, Email: "member@example.com"}
+	board := model.Board{Members: []model.BoardMember{{UserID: user.ID, User: user}, {UserID: member.ID, User: member}}}
+	card := model.Card{ID: 1, DueAt: &dueAt, List: model.List{Board: board}}
+	reminders := []model.CardReminder{{ID: 1, Card: card}, {ID: 2, Card: card}}
+	suite.cardReminderRepositoryMock.EXPECT().FindDue(gomock.Any()).Return(reminders, nil)
+	suite.cardReminderRepositoryMock.EXPECT().MarkSent(&reminders[0], gomock.Any()).Return(true, nil)
+	// 他のインスタンスが先に送信した
+	suite.cardReminderRepositoryMock.EXPECT().MarkSent(&reminders[1], gomock.Any()).Return(false, nil)
+	suite.emailServiceMock.EXPECT().CardReminderEmail(user, card).Return(nil).Times(1)
+	suite.emailServiceMock.EXPECT().CardReminderEmail(member, card).Return(nil).Times(1)
+	err := suite.service.SendDue()
+
+	suite.Nil(err)
+}
+
+func (suite *CardReminderServiceTestSuite) TestSuccessSendDueSkipsDeletedList() {
+	dueAt := time.Now().Add(time.Hour)
+	reminders := []model.CardReminder{{ID: 1, Card: model.Card{ID: 1, DueAt: &dueAt}}}
+	suite.cardReminderRepositoryMock.EXPECT().FindDue(gomock.Any()).Return(reminders, nil)
+	suite.cardReminderRepositoryMock.EXPECT().MarkSent(&reminders[0], gomock.Any()).Return(true, nil)
+	err := suite.service.SendDue()
+
+	suite.Nil(err)
+}
+
+func (suite *CardReminderServiceTestSuite) TestBadSendDueWithDBError() {
+	err := errors.New("db error")
+	suite.cardReminderRepositoryMock.EXPECT().FindDue(gomock.Any()).Return(nil, err)
+	rerr := suite.service.SendDue()
+
+	suite.Equal(err, rerr)
+}
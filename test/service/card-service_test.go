@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -24,6 +25,8 @@ type CardServiceTestSuite struct {
 	service                   service.CardService
 	cardRepositoryMock        *mock_repository.MockCardRepository
 	listMiddlewareServiceMock *mock_service.MockListMiddlewareServive
+	cardReminderServiceMock   *mock_service.MockCardReminderService
+	boardEventServiceMock     *mock_service.MockBoardEventService
 	ctx                       *gin.Context
 }
 
@@ -35,7 +38,9 @@ func (suite *CardServiceTestSuite) SetupSuite() {
 func (suite *CardServiceTestSuite) SetupTest() {
 	suite.cardRepositoryMock = mock_repository.NewMockCardRepository(gomock.NewController(suite.T()))
 	suite.listMiddlewareServiceMock = mock_service.NewMockListMiddlewareServive(gomock.NewController(suite.T()))
-	suite.service = service.TestNewCardService(suite.cardRepositoryMock, suite.listMiddlewareServiceMock)
+	suite.cardReminderServiceMock = mock_service.NewMockCardReminderService(gomock.NewController(suite.T()))
+	suite.boardEventServiceMock = mock_service.NewMockBoardEventService(gomock.NewController(suite.T()))
+	suite.service = service.TestNewCardService(suite.cardRepositoryMock, suite.listMiddlewareServiceMock, suite.cardReminderServiceMock, suite.boardEventServiceMock)
 	suite.ctx, _ = gin.CreateTestContext(httptest.NewRecorder())
 }
 
@@ -53,6 +58,7 @@ func (suite *CardServiceTestSuite) TestSuccessCreate() {
 		suite.Equal(cardFactory.Title, argCard.Title)
 		suite.Equal(cardFactory.Index, argCard.Index)
 	})
+	suite.boardEventServiceMock.EXPECT().PublishCard(service.CardCreatedEvent, gomock.Any())
 	rCard, err := suite.service.Create(suite.ctx)
 
 	suite.Nil(err)
@@ -60,6 +66,21 @@ func (suite *CardServiceTestSuite) TestSuccessCreate() {
 	suite.Equal(cardFactory.Index, rCard.Index)
 }
 
+func (suite *CardServiceTestSuite) TestSuccessCreateWithDueAt() {
+	dueAt := time.Now().Add(48 * time.Hour)
+	suite.ctx.Request = httptest.NewRequest("POST", "/api/lists/listID/cards", factory.CreateCardRequestBody(&factory.CardConfig{DueAt: &dueAt}))
+	list := factory.NewList(&factory.ListConfig{})
+	suite.ctx.Set(config.ListKey, list)
+	suite.cardRepositoryMock.EXPECT().Create(gomock.Any(), &list).Return(nil)
+	suite.cardReminderServiceMock.EXPECT().Schedule(gomock.Any()).Return(nil).Do(func(card model.Card) {
+		suite.True(dueAt.Equal(*card.DueAt))
+	})
+	suite.boardEventServiceMock.EXPECT().PublishCard(service.CardCreatedEvent, gomock.Any())
+	_, err := suite.service.Create(suite.ctx)
+
+	suite.Nil(err)
+}
+
 func (suite *CardServiceTestSuite) TestBadCreateWithValidation() {
 	suite.ctx.Request = httptest.NewRequest("POST", "/api/lists/listID/cards", factory.CreateCardRequestBody(&factory.CardConfig{NotUseDefaultValue: true}))
 	_, err := suite.service.Create(suite.ctx)
@@ -87,12 +108,52 @@ func (suite *CardServiceTestSuite) TestSuccessUpdate() {
 	suite.cardRepositoryMock.EXPECT().Update(&card, gomock.Any()).Return(nil).Do(func(card *model.Card, updatingCard *model.Card) {
 		suite.Equal(updatingCardConfig.Title, updatingCard.Title)
 	})
+	suite.boardEventServiceMock.EXPECT().PublishCard(service.CardUpdatedEvent, gomock.Any())
 	rCard, err := suite.service.Update(suite.ctx)
 
 	suite.Equal(card, rCard)
 	suite.Nil(err)
 }
 
+func (suite *CardServiceTestSuite) TestSuccessUpdateWithChangedDueAt() {
+	dueAt := time.Now().Add(48 * time.Hour)
+	suite.ctx.Request = httptest.NewRequest("PUT", "/api/cards/1", factory.CreateCardRequestBody(&factory.CardConfig{DueAt: &dueAt}))
+	card := factory.NewCard(&factory.CardConfig{})
+	suite.ctx.Set(config.CardKey, card)
+	suite.cardRepositoryMock.EXPECT().Update(&card, gomock.Any()).Return(nil)
+	suite.cardReminderServiceMock.EXPECT().Schedule(gomock.Any()).Return(nil).Do(func(card model.Card) {
+		suite.True(dueAt.Equal(*card.DueAt))
+	})
+	suite.boardEventServiceMock.EXPECT().PublishCard(service.CardUpdatedEvent, gomock.Any())
+	_, err := suite.service.Update(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *CardServiceTestSuite) TestSuccessUpdateWithRemovedDueAt() {
+	dueAt := time.Now().Add(48 * time.Hour)
+	suite.ctx.Request = httptest.NewRequest("PUT", "/api/cards/1", factory.CreateCardRequestBody(&factory.CardConfig{}))
+	card := factory.NewCard(&factory.CardConfig{DueAt: &dueAt})
+	suite.ctx.Set(config.CardKey, card)
+	suite.cardRepositoryMock.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+	suite.cardReminderServiceMock.EXPECT().Schedule(gomock.Any()).Return(nil).Do(func(card model.Card) {
+		suite.Nil(card.DueAt)
+	})
+	suite.boardEventServiceMock.EXPECT().PublishCard(service.CardUpdatedEvent, gomock.Any())
+	_, err := suite.service.Update(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *CardServiceTestSuite) TestBadUpdateWithDueAtBeforeStartAt() {
+	startAt := time.Now().Add(48 * time.Hour)
+	dueAt := startAt.Add(-time.Hour)
+	suite.ctx.Request = httptest.NewRequest("PUT", "/api/cards/1", factory.CreateCardRequestBody(&factory.CardConfig{StartAt: &startAt, DueAt: &dueAt}))
+	_, err := suite.service.Update(suite.ctx)
+
+	suite.IsType(validator.ValidationErrors{}, err)
+}
+
 func (suite *CardServiceTestSuite) TestBadUpdateWithValidationError() {
 	req := httptest.NewRequest("PUT", "/api/cards/1", factory.CreateCardRequestBody(&factory.CardConfig{NotUseDefaultValue: true}))
 	suite.ctx.Request = req
@@ -117,6 +178,7 @@ func (suite *CardServiceTestSuite) TestSuccessDestroyCard() {
 	var card model.Card
 	suite.ctx.Set(config.CardKey, card)
 	suite.cardRepositoryMock.EXPECT().Destroy(&card).Return(nil)
+	suite.boardEventServiceMock.EXPECT().PublishCard(service.CardDeletedEvent, gomock.Any())
 	err := suite.service.Destroy(suite.ctx)
 
 	suite.Nil(err)
@@ -138,10 +200,14 @@ func (suite *CardServiceTestSuite) TestSuccessMoveCard() {
 	suite.ctx.Request = req
 	var currentUser model.User
 	suite.ctx.Set(config.CurrentUserKey, currentUser)
-	suite.listMiddlewareServiceMock.EXPECT().FindAndAuthorizeList(dtoMoveCard.ToListID, currentUser).Return(model.List{}, nil)
-	var card model.Card
+	suite.listMiddlewareServiceMock.EXPECT().FindAndAuthorizeList(dtoMoveCard.ToListID, currentUser, model.BoardRoleEditor).Return(model.List{}, nil)
+	card := model.Card{ID: 1, ListID: 2}
 	suite.ctx.Set(config.CardKey, card)
 	suite.cardRepositoryMock.EXPECT().Move(&card, dtoMoveCard.ToListID, dtoMoveCard.ToIndex).Return(nil)
+	suite.boardEventServiceMock.EXPECT().PublishCard(service.CardMovedEvent, gomock.Any(), dtoMoveCard.ToListID, card.ListID).Do(func(eventType string, movedCard model.Card, listIDs ...int) {
+		suite.Equal(dtoMoveCard.ToListID, movedCard.ListID)
+		suite.Equal(dtoMoveCard.ToIndex, movedCard.Index)
+	})
 	err := suite.service.Move(suite.ctx)
 
 	suite.Nil(err)
@@ -162,7 +228,7 @@ func (suite *CardServiceTestSuite) TestBadMoveCardWithToListNotAuthorized() {
 	suite.ctx.Request = req
 	var user model.User
 	suite.ctx.Set(config.CurrentUserKey, user)
-	suite.listMiddlewareServiceMock.EXPECT().FindAndAuthorizeList(dtoMoveCard.ToListID, user).Return(model.List{}, config.ForbiddenError)
+	suite.listMiddlewareServiceMock.EXPECT().FindAndAuthorizeList(dtoMoveCard.ToListID, user, model.BoardRoleEditor).Return(model.List{}, config.ForbiddenError)
 	err := suite.service.Move(suite.ctx)
 
 	suite.Equal(config.ForbiddenError, err)
@@ -174,7 +240,7 @@ func (suite *CardServiceTestSuite) TestBadMoveCardWithDBError() {
 	suite.ctx.Request = req
 	var currentUser model.User
 	suite.ctx.Set(config.CurrentUserKey, currentUser)
-	suite.listMiddlewareServiceMock.EXPECT().FindAndAuthorizeList(dtoMoveCard.ToListID, currentUser).Return(model.List{}, nil)
+	suite.listMiddlewareServiceMock.EXPECT().FindAndAuthorizeList(dtoMoveCard.ToListID, currentUser, model.BoardRoleEditor).Return(model.List{}, nil)
 	var card model.Card
 	suite.ctx.Set(config.CardKey, card)
 	err := errors.New("db error")
@@ -183,3 +249,22 @@ func (suite *CardServiceTestSuite) TestBadMoveCardWithDBError() {
 
 	suite.Equal(err, rerr)
 }
+
+func (suite *CardServiceTestSuite) TestSuccessArchive() {
+	card := model.Card{ID: 1}
+	suite.ctx.Set(config.CardKey, card)
+	suite.cardRepositoryMock.EXPECT().Archive(&card).Return(nil)
+	suite.boardEventServiceMock.EXPECT().PublishCard(service.CardArchivedEvent, gomock.Any())
+	err := suite.service.Archive(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *CardServiceTestSuite) TestSuccessArchiveWhenAlreadyArchived() {
+	archivedAt := time.Now()
+	card := model.Card{ID: 1, ArchivedAt: &archivedAt}
+	suite.ctx.Set(config.CardKey, card)
+	err := suite.service.Archive(suite.ctx)
+
+	suite.Nil(err)
+}
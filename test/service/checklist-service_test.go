@@ -0,0 +1,175 @@
+package service_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/mock_repository"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"github.com/kuritaeiji/todo-gin-back/validators"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type ChecklistServiceTestSuite struct {
+	suite.Suite
+	service                 service.ChecklistService
+	checklistRepositoryMock *mock_repository.MockChecklistRepository
+	boardEventServiceMock   *mock_service.MockBoardEventService
+	ctx                     *gin.Context
+	card                    model.Card
+}
+
+func (suite *ChecklistServiceTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	validators.Init()
+}
+
+func (suite *ChecklistServiceTestSuite) SetupTest() {
+	suite.checklistRepositoryMock = mock_repository.NewMockChecklistRepository(gomock.NewController(suite.T()))
+	suite.boardEventServiceMock = mock_service.NewMockBoardEventService(gomock.NewController(suite.T()))
+	suite.service = service.TestNewChecklistService(suite.checklistRepositoryMock, suite.boardEventServiceMock)
+	suite.ctx, _ = gin.CreateTestContext(httptest.NewRecorder())
+	suite.card = model.Card{ID: 1}
+	suite.ctx.Set(config.CardKey, suite.card)
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "1"}, {Key: "checklistID", Value: "2"}, {Key: "itemID", Value: "3"}}
+}
+
+func TestChecklistService(t *testing.T) {
+	suite.Run(t, new(ChecklistServiceTestSuite))
+}
+
+func (suite *ChecklistServiceTestSuite) setRequest(method string, body string) {
+	req := httptest.NewRequest(method, "/api/cards/1/checklists", strings.NewReader(body))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+}
+
+func (suite *ChecklistServiceTestSuite) TestSuccessIndex() {
+	checklists := []model.Checklist{{ID: 2, CardID: 1}}
+	suite.checklistRepositoryMock.EXPECT().FindAll(&suite.card).Return(checklists, nil)
+	rChecklists, err := suite.service.Index(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal(checklists, rChecklists)
+}
+
+func (suite *ChecklistServiceTestSuite) TestSuccessCreate() {
+	suite.setRequest("POST", `{"title":"todo","index":1}`)
+	suite.checklistRepositoryMock.EXPECT().Create(&model.Checklist{Title: "todo", Index: 1, CardID: 1}).Return(nil)
+	suite.boardEventServiceMock.EXPECT().PublishCardChanged(1)
+	checklist, err := suite.service.Create(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal("todo", checklist.Title)
+}
+
+func (suite *ChecklistServiceTestSuite) TestBadCreateWithValidationError() {
+	suite.setRequest("POST", `{"title":"","index":0}`)
+	_, err := suite.service.Create(suite.ctx)
+
+	_, ok := err.(validator.ValidationErrors)
+	suite.True(ok)
+}
+
+func (suite *ChecklistServiceTestSuite) TestSuccessUpdate() {
+	suite.setRequest("PUT", `{"title":"updated"}`)
+	checklist := model.Checklist{ID: 2, CardID: 1}
+	suite.checklistRepositoryMock.EXPECT().Find(&suite.card, 2).Return(checklist, nil)
+	suite.checklistRepositoryMock.EXPECT().Update(&checklist, model.Checklist{Title: "updated"}).Return(nil)
+	suite.boardEventServiceMock.EXPECT().PublishCardChanged(1)
+	_, err := suite.service.Update(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *ChecklistServiceTestSuite) TestBadUpdateWithRecordNotFound() {
+	suite.checklistRepositoryMock.EXPECT().Find(&suite.card, 2).Return(model.Checklist{}, gorm.ErrRecordNotFound)
+	_, err := suite.service.Update(suite.ctx)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *ChecklistServiceTestSuite) TestBadDestroyWithInvalidID() {
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "1"}, {Key: "checklistID", Value: "a"}}
+	err := suite.service.Destroy(suite.ctx)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *ChecklistServiceTestSuite) TestSuccessDestroy() {
+	checklist := model.Checklist{ID: 2, CardID: 1}
+	suite.checklistRepositoryMock.EXPECT().Find(&suite.card, 2).Return(checklist, nil)
+	suite.checklistRepositoryMock.EXPECT().Destroy(&checklist).Return(nil)
+	suite.boardEventServiceMock.EXPECT().PublishCardChanged(1)
+	err := suite.service.Destroy(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *ChecklistServiceTestSuite) TestSuccessMove() {
+	suite.setRequest("PUT", `{"toIndex":3}`)
+	checklist := model.Checklist{ID: 2, CardID: 1}
+	suite.checklistRepositoryMock.EXPECT().Find(&suite.card, 2).Return(checklist, nil)
+	suite.checklistRepositoryMock.EXPECT().Move(&checklist, 3).Return(nil)
+	suite.boardEventServiceMock.EXPECT().PublishCardChanged(1)
+	err := suite.service.Move(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *ChecklistServiceTestSuite) TestSuccessCreateItem() {
+	suite.setRequest("POST", `{"title":"item","done":true,"index":0}`)
+	checklist := model.Checklist{ID: 2, CardID: 1}
+	suite.checklistRepositoryMock.EXPECT().Find(&suite.card, 2).Return(checklist, nil)
+	suite.checklistRepositoryMock.EXPECT().CreateItem(&model.ChecklistItem{Title: "item", Done: true, ChecklistID: 2}).Return(nil)
+	suite.boardEventServiceMock.EXPECT().PublishCardChanged(1)
+	item, err := suite.service.CreateItem(suite.ctx)
+
+	suite.Nil(err)
+	suite.True(item.Done)
+}
+
+func (suite *ChecklistServiceTestSuite) TestSuccessUpdateItem() {
+	suite.setRequest("PUT", `{"title":"item","done":true}`)
+	checklist := model.Checklist{ID: 2, CardID: 1}
+	item := model.ChecklistItem{ID: 3, ChecklistID: 2}
+	suite.checklistRepositoryMock.EXPECT().Find(&suite.card, 2).Return(checklist, nil)
+	suite.checklistRepositoryMock.EXPECT().FindItem(&checklist, 3).Return(item, nil)
+	suite.checklistRepositoryMock.EXPECT().UpdateItem(&item, model.ChecklistItem{Title: "item", Done: true}).Return(nil)
+	suite.boardEventServiceMock.EXPECT().PublishCardChanged(1)
+	_, err := suite.service.UpdateItem(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *ChecklistServiceTestSuite) TestBadDestroyItemWithRecordNotFound() {
+	checklist := model.Checklist{ID: 2, CardID: 1}
+	suite.checklistRepositoryMock.EXPECT().Find(&suite.card, 2).Return(checklist, nil)
+	suite.checklistRepositoryMock.EXPECT().FindItem(&checklist, 3).Return(model.ChecklistItem{}, gorm.ErrRecordNotFound)
+	err := suite.service.DestroyItem(suite.ctx)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *ChecklistServiceTestSuite) TestBadMoveItemWithDBError() {
+	suite.setRequest("PUT", `{"toIndex":0}`)
+	checklist := model.Checklist{ID: 2, CardID: 1}
+	item := model.ChecklistItem{ID: 3, ChecklistID: 2, Index: 1}
+	dbError := errors.New("db error")
+	suite.checklistRepositoryMock.EXPECT().Find(&suite.card, 2).Return(checklist, nil)
+	suite.checklistRepositoryMock.EXPECT().FindItem(&checklist, 3).Return(item, nil)
+	suite.checklistRepositoryMock.EXPECT().MoveItem(&item, 0).Return(dbError)
+	err := suite.service.MoveItem(suite.ctx)
+
+	suite.Equal(dbError, err)
+}
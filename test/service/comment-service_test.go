@@ -0,0 +1,151 @@
+package service_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/mock_repository"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"github.com/kuritaeiji/todo-gin-back/validators"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type CommentServiceTestSuite struct {
+	suite.Suite
+	service                   service.CommentService
+	commentRepositoryMock     *mock_repository.MockCommentRepository
+	listMiddlewareServiceMock *mock_service.MockListMiddlewareServive
+	ctx                       *gin.Context
+	currentUser               model.User
+	card                      model.Card
+}
+
+func (suite *CommentServiceTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	validators.Init()
+}
+
+func (suite *CommentServiceTestSuite) SetupTest() {
+	suite.commentRepositoryMock = mock_repository.NewMockCommentRepository(gomock.NewController(suite.T()))
+	suite.listMiddlewareServiceMock = mock_service.NewMockListMiddlewareServive(gomock.NewController(suite.T()))
+	suite.service = service.TestNewCommentService(suite.commentRepositoryMock, suite.listMiddlewareServiceMock)
+	suite.ctx, _ = gin.CreateTestContext(httptest.NewRecorder())
+	suite.currentUser = model.User{ID: 1, Email: "user@example.com"}
+	suite.card = model.Card{ID: 2, ListID: 4}
+	suite.ctx.Set(config.CurrentUserKey, suite.currentUser)
+	suite.ctx.Set(config.CardKey, suite.card)
+}
+
+func TestCommentService(t *testing.T) {
+	suite.Run(t, new(CommentServiceTestSuite))
+}
+
+func (suite *CommentServiceTestSuite) setRequest(method string, path string, body string) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+}
+
+func (suite *CommentServiceTestSuite) TestSuccessIndex() {
+	suite.setRequest("GET", "/api/cards/2/comments?before=10", "")
+	comments := []model.Comment{{ID: 9}}
+	suite.commentRepositoryMock.EXPECT().FindAll(&suite.card, 10, service.CommentPageSize).Return(comments, nil)
+	rComments, err := suite.service.Index(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal(comments, rComments)
+}
+
+func (suite *CommentServiceTestSuite) TestBadIndexWithValidationError() {
+	suite.setRequest("GET", "/api/cards/2/comments?before=-1", "")
+	_, err := suite.service.Index(suite.ctx)
+
+	_, ok := err.(validator.ValidationErrors)
+	suite.True(ok)
+}
+
+func (suite *CommentServiceTestSuite) TestSuccessCreate() {
+	suite.setRequest("POST", "/api/cards/2/comments", `{"body":"hello"}`)
+	suite.commentRepositoryMock.EXPECT().Create(&model.Comment{Body: "hello", CardID: 2, UserID: 1}).Return(nil)
+	comment, err := suite.service.Create(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal(suite.currentUser, comment.User)
+}
+
+func (suite *CommentServiceTestSuite) TestBadCreateWithValidationError() {
+	suite.setRequest("POST", "/api/cards/2/comments", `{"body":""}`)
+	_, err := suite.service.Create(suite.ctx)
+
+	_, ok := err.(validator.ValidationErrors)
+	suite.True(ok)
+}
+
+func (suite *CommentServiceTestSuite) TestSuccessUpdate() {
+	suite.setRequest("PUT", "/api/comments/3", `{"body":"edited"}`)
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "3"}}
+	comment := model.Comment{ID: 3, UserID: 1, Card: suite.card}
+	suite.commentRepositoryMock.EXPECT().Find(3).Return(comment, nil)
+	suite.listMiddlewareServiceMock.EXPECT().FindAndAuthorizeList(4, suite.currentUser, model.BoardRoleEditor).Return(model.List{ID: 4}, nil)
+	suite.commentRepositoryMock.EXPECT().Update(&comment, "edited", gomock.Any()).Return(nil)
+	_, err := suite.service.Update(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *CommentServiceTestSuite) TestBadUpdateWithOtherAuthor() {
+	suite.setRequest("PUT", "/api/comments/3", `{"body":"edited"}`)
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "3"}}
+	suite.commentRepositoryMock.EXPECT().Find(3).Return(model.Comment{ID: 3, UserID: 100, Card: suite.card}, nil)
+	suite.listMiddlewareServiceMock.EXPECT().FindAndAuthorizeList(4, suite.currentUser, model.BoardRoleEditor).Return(model.List{ID: 4}, nil)
+	_, err := suite.service.Update(suite.ctx)
+
+	suite.Equal(config.ForbiddenError, err)
+}
+
+func (suite *CommentServiceTestSuite) TestBadUpdateWithoutEditorRole() {
+	suite.setRequest("PUT", "/api/comments/3", `{"body":"edited"}`)
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "3"}}
+	suite.commentRepositoryMock.EXPECT().Find(3).Return(model.Comment{ID: 3, UserID: 1, Card: suite.card}, nil)
+	suite.listMiddlewareServiceMock.EXPECT().FindAndAuthorizeList(4, suite.currentUser, model.BoardRoleEditor).Return(model.List{}, config.ForbiddenError)
+	_, err := suite.service.Update(suite.ctx)
+
+	suite.Equal(config.ForbiddenError, err)
+}
+
+func (suite *CommentServiceTestSuite) TestSuccessDestroy() {
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "3"}}
+	comment := model.Comment{ID: 3, UserID: 1, Card: suite.card}
+	suite.commentRepositoryMock.EXPECT().Find(3).Return(comment, nil)
+	suite.listMiddlewareServiceMock.EXPECT().FindAndAuthorizeList(4, suite.currentUser, model.BoardRoleEditor).Return(model.List{ID: 4}, nil)
+	suite.commentRepositoryMock.EXPECT().Destroy(&comment).Return(nil)
+	err := suite.service.Destroy(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *CommentServiceTestSuite) TestBadDestroyWithRemovedMember() {
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "3"}}
+	suite.commentRepositoryMock.EXPECT().Find(3).Return(model.Comment{ID: 3, UserID: 1, Card: suite.card}, nil)
+	suite.listMiddlewareServiceMock.EXPECT().FindAndAuthorizeList(4, suite.currentUser, model.BoardRoleEditor).Return(model.List{}, config.ForbiddenError)
+	err := suite.service.Destroy(suite.ctx)
+
+	suite.Equal(config.ForbiddenError, err)
+}
+
+func (suite *CommentServiceTestSuite) TestBadDestroyWithRecordNotFound() {
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "3"}}
+	suite.commentRepositoryMock.EXPECT().Find(3).Return(model.Comment{}, gorm.ErrRecordNotFound)
+	err := suite.service.Destroy(suite.ctx)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -61,3 +62,75 @@ func (suite *EmailServiceTestSuite) TestBadActivationUserEmailWithEmailGatewayEr
 
 	suite.Equal(config.EmailClientError, rerr)
 }
+
+func (suite *EmailServiceTestSuite) TestSuccessPasswordResetEmail() {
+	var user model.User
+	token := "token"
+	doFunc := func(to, subject, htmlString string) {
+		suite.Contains(htmlString, fmt.Sprintf(`<a href="%v/password/reset?token=%v`, os.Getenv("FRONT_ORIGIN"), token))
+	}
+	suite.emailGatewayMock.EXPECT().Send(user.Email, "パスワード再設定リンク", gomock.Any()).Return(nil).Do(doFunc)
+	err := suite.service.PasswordResetEmail(user, token)
+
+	suite.Nil(err)
+}
+
+func (suite *EmailServiceTestSuite) TestBadPasswordResetEmailWithEmailGatewayError() {
+	var user model.User
+	suite.emailGatewayMock.EXPECT().Send(user.Email, "パスワード再設定リンク", gomock.Any()).Return(errors.New("email client error"))
+	err := suite.service.PasswordResetEmail(user, "token")
+
+	suite.Equal(config.EmailClientError, err)
+}
+
+func (suite *EmailServiceTestSuite) TestSuccessEmailChangeEmail() {
+	const email = "new@example.com"
+	token := "token"
+	doFunc := func(to, subject, htmlString string) {
+		suite.Contains(htmlString, fmt.Sprintf(`<a href="%v/email/confirm?token=%v`, os.Getenv("FRONT_ORIGIN"), token))
+	}
+	suite.emailGatewayMock.EXPECT().Send(email, "メールアドレス確認リンク", gomock.Any()).Return(nil).Do(doFunc)
+	err := suite.service.EmailChangeEmail(email, token)
+
+	suite.Nil(err)
+}
+
+func (suite *EmailServiceTestSuite) TestSuccessAccountLockedEmail() {
+	user := model.User{Email: "user@example.com"}
+	lockedUntil := time.Date(2022, 1, 2, 3, 4, 0, 0, time.Local)
+	doFunc := func(to, subject, htmlString string) {
+		suite.Contains(htmlString, "2022/01/02 03:04")
+	}
+	suite.emailGatewayMock.EXPECT().Send(user.Email, "アカウントロックのお知らせ", gomock.Any()).Return(nil).Do(doFunc)
+	err := suite.service.AccountLockedEmail(user, lockedUntil)
+
+	suite.Nil(err)
+}
+
+func (suite *EmailServiceTestSuite) TestSuccessCardReminderEmail() {
+	user := model.User{Email: "user@example.com"}
+	dueAt := time.Date(2022, 1, 2, 3, 4, 0, 0, time.UTC)
+	card := model.Card{Title: "<b>card</b>", DueAt: &dueAt, Timezone: "Asia/Tokyo"}
+	doFunc := func(to, subject, htmlString string) {
+		suite.Contains(htmlString, "2022/01/02 12:04 JST")
+		suite.Contains(htmlString, "&lt;b&gt;card&lt;/b&gt;")
+	}
+	suite.emailGatewayMock.EXPECT().Send(user.Email, "期限が近づいています: <b>card</b>", gomock.Any()).Return(nil).Do(doFunc)
+	err := suite.service.CardReminderEmail(user, card)
+
+	suite.Nil(err)
+}
+
+func (suite *EmailServiceTestSuite) TestSuccessBoardInvitationEmail() {
+	user := model.User{Email: "user@example.com"}
+	inviter := model.User{Email: "inviter@example.com"}
+	board := model.Board{Title: "board"}
+	doFunc := func(to, subject, htmlString string) {
+		suite.Contains(htmlString, "inviter@example.com")
+		suite.Contains(htmlString, "/invitations")
+	}
+	suite.emailGatewayMock.EXPECT().Send(user.Email, "ボードに招待されました: board", gomock.Any()).Return(nil).Do(doFunc)
+	err := suite.service.BoardInvitationEmail(user, board, inviter)
+
+	suite.Nil(err)
+}
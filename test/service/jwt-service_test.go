@@ -1,6 +1,10 @@
 package service_test
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
 	"testing"
 	"time"
 
@@ -54,3 +58,138 @@ func (suite *JWTServiceTestSuite) TestBadVerifyJWTWithExpired() {
 
 	suite.IsType(&jwt.ValidationError{}, err)
 }
+
+func (suite *JWTServiceTestSuite) TestSuccessCreateAccessJWT() {
+	user := model.User{ID: 1, TokenVersion: 2}
+	const sessionID = "sessionID"
+	tokenString := suite.service.CreateAccessJWT(user, sessionID)
+	claim, err := suite.service.VerifyJWT(tokenString)
+
+	suite.Nil(err)
+	suite.Equal(user.ID, claim.ID)
+	suite.Equal(sessionID, claim.SessionID)
+	suite.Equal(user.TokenVersion, claim.Version)
+	suite.Equal(service.AccessTokenType, claim.Type)
+	suite.NotEmpty(claim.Id)
+	suite.InEpsilon(time.Now().Add(service.MinuteFromNowAccessToken*time.Minute).Unix(), claim.ExpiresAt, 30)
+}
+
+func (suite *JWTServiceTestSuite) TestSuccessCreateChallengeJWT() {
+	user := model.User{ID: 1, TokenVersion: 2}
+	tokenString := suite.service.CreateChallengeJWT(user)
+	claim, err := suite.service.VerifyJWT(tokenString)
+
+	suite.Nil(err)
+	suite.Equal(user.ID, claim.ID)
+	suite.Equal(user.TokenVersion, claim.Version)
+	suite.Equal(service.ChallengeTokenType, claim.Type)
+	suite.InEpsilon(time.Now().Add(service.MinuteFromNowChallengeToken*time.Minute).Unix(), claim.ExpiresAt, 30)
+}
+
+func (suite *JWTServiceTestSuite) newEd25519Key() service.SigningKey {
+	_, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+	key, err := service.NewSigningKey(privateKey)
+	suite.Nil(err)
+	return key
+}
+
+func (suite *JWTServiceTestSuite) parseHeader(tokenString string) map[string]interface{} {
+	token, _, err := new(jwt.Parser).ParseUnverified(tokenString, &service.UserClaim{})
+	suite.Nil(err)
+	return token.Header
+}
+
+func (suite *JWTServiceTestSuite) TestSuccessCreateJWTWithKid() {
+	key := suite.newEd25519Key()
+	s := service.TestNewJWTService(service.KeySet{Current: key})
+	header := suite.parseHeader(s.CreateAccessJWT(model.User{ID: 1}, ""))
+
+	suite.Equal("EdDSA", header["alg"])
+	suite.Equal(key.Kid, header["kid"])
+}
+
+func (suite *JWTServiceTestSuite) TestSuccessVerifyJWTWithRS256() {
+	privateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	key, err := service.NewSigningKey(privateKey)
+	suite.Nil(err)
+	s := service.TestNewJWTService(service.KeySet{Current: key})
+	tokenString := s.CreateAccessJWT(model.User{ID: 1}, "")
+	claim, err := s.VerifyJWT(tokenString)
+
+	suite.Nil(err)
+	suite.Equal(1, claim.ID)
+	suite.Equal("RS256", suite.parseHeader(tokenString)["alg"])
+}
+
+func (suite *JWTServiceTestSuite) TestSuccessVerifyJWTWithPreviousKey() {
+	oldKey := suite.newEd25519Key()
+	tokenString := service.TestNewJWTService(service.KeySet{Current: oldKey}).CreateAccessJWT(model.User{ID: 1}, "")
+	previousKey, _ := service.NewVerificationKey(oldKey.PublicKey)
+	s := service.TestNewJWTService(service.KeySet{Current: suite.newEd25519Key(), Previous: []service.SigningKey{previousKey}})
+	claim, err := s.VerifyJWT(tokenString)
+
+	suite.Nil(err)
+	suite.Equal(1, claim.ID)
+}
+
+func (suite *JWTServiceTestSuite) TestBadVerifyJWTWithUnknownKid() {
+	tokenString := service.TestNewJWTService(service.KeySet{Current: suite.newEd25519Key()}).CreateAccessJWT(model.User{ID: 1}, "")
+	s := service.TestNewJWTService(service.KeySet{Current: suite.newEd25519Key()})
+	_, err := s.VerifyJWT(tokenString)
+
+	suite.IsType(&jwt.ValidationError{}, err)
+}
+
+func (suite *JWTServiceTestSuite) TestBadVerifyJWTWithAlgorithmMismatch() {
+	key := suite.newEd25519Key()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, service.UserClaim{ID: 1})
+	token.Header["kid"] = key.Kid
+	tokenString, _ := token.SignedString([]byte(key.PublicKey.(ed25519.PublicKey)))
+	s := service.TestNewJWTService(service.KeySet{Current: key})
+	_, err := s.VerifyJWT(tokenString)
+
+	suite.IsType(&jwt.ValidationError{}, err)
+}
+
+func (suite *JWTServiceTestSuite) TestSuccessVerifyJWTWithLegacySecret() {
+	tokenString, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, service.UserClaim{ID: 1}).SignedString([]byte("secret"))
+	s := service.TestNewJWTService(service.KeySet{Current: suite.newEd25519Key(), LegacySecret: []byte("secret")})
+	claim, err := s.VerifyJWT(tokenString)
+
+	suite.Nil(err)
+	suite.Equal(1, claim.ID)
+}
+
+func (suite *JWTServiceTestSuite) TestBadVerifyJWTWithoutLegacySecret() {
+	tokenString, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, service.UserClaim{ID: 1}).SignedString([]byte("secret"))
+	s := service.TestNewJWTService(service.KeySet{Current: suite.newEd25519Key()})
+	_, err := s.VerifyJWT(tokenString)
+
+	suite.IsType(&jwt.ValidationError{}, err)
+}
+
+func (suite *JWTServiceTestSuite) TestSuccessJWKS() {
+	key := suite.newEd25519Key()
+	privateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	previousKey, _ := service.NewVerificationKey(&privateKey.PublicKey)
+	s := service.TestNewJWTService(service.KeySet{Current: key, Previous: []service.SigningKey{previousKey}})
+	jwks := s.JWKS()
+
+	suite.Len(jwks.Keys, 2)
+	suite.Equal(key.Kid, jwks.Keys[0].Kid)
+	suite.Equal("OKP", jwks.Keys[0].Kty)
+	suite.Equal("EdDSA", jwks.Keys[0].Alg)
+	suite.Equal("sig", jwks.Keys[0].Use)
+	suite.Equal(previousKey.Kid, jwks.Keys[1].Kid)
+	suite.Equal("RSA", jwks.Keys[1].Kty)
+	suite.Equal("RS256", jwks.Keys[1].Alg)
+	suite.NotEmpty(jwks.Keys[1].N)
+	suite.Equal("AQAB", jwks.Keys[1].E)
+}
+
+func (suite *JWTServiceTestSuite) TestBadNewVerificationKeyWithShortRSAKey() {
+	privateKey, _ := rsa.GenerateKey(rand.Reader, 1024)
+	_, err := service.NewVerificationKey(&privateKey.PublicKey)
+
+	suite.True(strings.Contains(err.Error(), "2048"))
+}
@@ -0,0 +1,186 @@
+package service_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/mock_repository"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"github.com/kuritaeiji/todo-gin-back/validators"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type LabelServiceTestSuite struct {
+	suite.Suite
+	service               service.LabelService
+	labelRepositoryMock   *mock_repository.MockLabelRepository
+	boardEventServiceMock *mock_service.MockBoardEventService
+	ctx                   *gin.Context
+	currentUser           model.User
+}
+
+func (suite *LabelServiceTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	validators.Init()
+}
+
+func (suite *LabelServiceTestSuite) SetupTest() {
+	suite.labelRepositoryMock = mock_repository.NewMockLabelRepository(gomock.NewController(suite.T()))
+	suite.boardEventServiceMock = mock_service.NewMockBoardEventService(gomock.NewController(suite.T()))
+	suite.service = service.TestNewLabelService(suite.labelRepositoryMock, suite.boardEventServiceMock)
+	suite.ctx, _ = gin.CreateTestContext(httptest.NewRecorder())
+	suite.currentUser = model.User{ID: 1}
+	suite.ctx.Set(config.CurrentUserKey, suite.currentUser)
+}
+
+func TestLabelService(t *testing.T) {
+	suite.Run(t, new(LabelServiceTestSuite))
+}
+
+func (suite *LabelServiceTestSuite) setRequest(method string, labelConfig *factory.LabelConfig) {
+	req := httptest.NewRequest(method, "/api/labels", factory.CreateLabelRequestBody(labelConfig))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+}
+
+func (suite *LabelServiceTestSuite) TestSuccessIndex() {
+	labels := []model.Label{{ID: 1, UserID: suite.currentUser.ID}}
+	suite.labelRepositoryMock.EXPECT().FindAll(&suite.currentUser).Return(labels, nil)
+	rLabels, err := suite.service.Index(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal(labels, rLabels)
+}
+
+func (suite *LabelServiceTestSuite) TestSuccessCreate() {
+	suite.setRequest("POST", &factory.LabelConfig{Name: "bug", Color: "#00ff00"})
+	suite.labelRepositoryMock.EXPECT().Create(&model.Label{Name: "bug", Color: "#00ff00", UserID: suite.currentUser.ID}).Return(nil)
+	label, err := suite.service.Create(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal("bug", label.Name)
+	suite.Equal(suite.currentUser.ID, label.UserID)
+}
+
+func (suite *LabelServiceTestSuite) TestBadCreateWithValidationError() {
+	suite.setRequest("POST", &factory.LabelConfig{Color: "red"})
+	_, err := suite.service.Create(suite.ctx)
+
+	_, ok := err.(validator.ValidationErrors)
+	suite.True(ok)
+}
+
+func (suite *LabelServiceTestSuite) TestSuccessUpdate() {
+	suite.setRequest("PUT", &factory.LabelConfig{Name: "feature", Color: "#0000ff"})
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "2"}}
+	label := model.Label{ID: 2, UserID: suite.currentUser.ID}
+	suite.labelRepositoryMock.EXPECT().Find(2).Return(label, nil)
+	suite.labelRepositoryMock.EXPECT().Update(&label, model.Label{Name: "feature", Color: "#0000ff"}).Return(nil)
+	_, err := suite.service.Update(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *LabelServiceTestSuite) TestBadUpdateWithForbidden() {
+	suite.setRequest("PUT", &factory.LabelConfig{})
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "2"}}
+	suite.labelRepositoryMock.EXPECT().Find(2).Return(model.Label{ID: 2, UserID: 100}, nil)
+	_, err := suite.service.Update(suite.ctx)
+
+	suite.Equal(config.ForbiddenError, err)
+}
+
+func (suite *LabelServiceTestSuite) TestBadUpdateWithInvalidID() {
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "a"}}
+	_, err := suite.service.Update(suite.ctx)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *LabelServiceTestSuite) TestSuccessDestroy() {
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "2"}}
+	label := model.Label{ID: 2, UserID: suite.currentUser.ID}
+	suite.labelRepositoryMock.EXPECT().Find(2).Return(label, nil)
+	suite.labelRepositoryMock.EXPECT().Destroy(&label).Return(nil)
+	err := suite.service.Destroy(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *LabelServiceTestSuite) TestBadDestroyWithRecordNotFound() {
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "2"}}
+	suite.labelRepositoryMock.EXPECT().Find(2).Return(model.Label{}, gorm.ErrRecordNotFound)
+	err := suite.service.Destroy(suite.ctx)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *LabelServiceTestSuite) TestSuccessAttach() {
+	card := model.Card{ID: 3}
+	suite.ctx.Set(config.CardKey, card)
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "3"}, {Key: "labelID", Value: "2"}}
+	label := model.Label{ID: 2, UserID: suite.currentUser.ID}
+	suite.labelRepositoryMock.EXPECT().Find(2).Return(label, nil)
+	suite.labelRepositoryMock.EXPECT().Attach(&card, &label).Return(nil)
+	suite.boardEventServiceMock.EXPECT().PublishCardChanged(3)
+	_, err := suite.service.Attach(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *LabelServiceTestSuite) TestSuccessAttachWithAttachedLabel() {
+	label := model.Label{ID: 2, UserID: suite.currentUser.ID}
+	card := model.Card{ID: 3, Labels: []model.Label{label}}
+	suite.ctx.Set(config.CardKey, card)
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "3"}, {Key: "labelID", Value: "2"}}
+	suite.labelRepositoryMock.EXPECT().Find(2).Return(label, nil)
+	rCard, err := suite.service.Attach(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal(card, rCard)
+}
+
+func (suite *LabelServiceTestSuite) TestBadAttachWithForbidden() {
+	suite.ctx.Set(config.CardKey, model.Card{ID: 3})
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "3"}, {Key: "labelID", Value: "2"}}
+	suite.labelRepositoryMock.EXPECT().Find(2).Return(model.Label{ID: 2, UserID: 100}, nil)
+	_, err := suite.service.Attach(suite.ctx)
+
+	suite.Equal(config.ForbiddenError, err)
+}
+
+func (suite *LabelServiceTestSuite) TestSuccessDetach() {
+	label := model.Label{ID: 2, UserID: suite.currentUser.ID}
+	card := model.Card{ID: 3, Labels: []model.Label{label}}
+	suite.ctx.Set(config.CardKey, card)
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "3"}, {Key: "labelID", Value: "2"}}
+	suite.labelRepositoryMock.EXPECT().Find(2).Return(label, nil)
+	suite.labelRepositoryMock.EXPECT().Detach(&card, &label).Return(nil)
+	suite.boardEventServiceMock.EXPECT().PublishCardChanged(3)
+	_, err := suite.service.Detach(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *LabelServiceTestSuite) TestBadDetachWithDBError() {
+	label := model.Label{ID: 2, UserID: suite.currentUser.ID}
+	card := model.Card{ID: 3, Labels: []model.Label{label}}
+	suite.ctx.Set(config.CardKey, card)
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "3"}, {Key: "labelID", Value: "2"}}
+	dbError := errors.New("db error")
+	suite.labelRepositoryMock.EXPECT().Find(2).Return(label, nil)
+	suite.labelRepositoryMock.EXPECT().Detach(&card, &label).Return(dbError)
+	_, err := suite.service.Detach(suite.ctx)
+
+	suite.Equal(dbError, err)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/kuritaeiji/todo-gin-back/config"
 	"github.com/kuritaeiji/todo-gin-back/factory"
 	"github.com/kuritaeiji/todo-gin-back/mock_repository"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
 	"github.com/kuritaeiji/todo-gin-back/model"
 	"github.com/kuritaeiji/todo-gin-back/service"
 	"github.com/stretchr/testify/suite"
@@ -18,9 +19,10 @@ import (
 
 type ListMiddlewareServiceTestSuite struct {
 	suite.Suite
-	service            service.ListMiddlewareServive
-	listRepositoryMock *mock_repository.MockListRepository
-	ctx                *gin.Context
+	service                service.ListMiddlewareServive
+	listRepositoryMock     *mock_repository.MockListRepository
+	boardMemberServiceMock *mock_service.MockBoardMemberService
+	ctx                    *gin.Context
 }
 
 func (suite *ListMiddlewareServiceTestSuite) SetupSuite() {
@@ -29,8 +31,10 @@ func (suite *ListMiddlewareServiceTestSuite) SetupSuite() {
 
 func (suite *ListMiddlewareServiceTestSuite) SetupTest() {
 	suite.listRepositoryMock = mock_repository.NewMockListRepository(gomock.NewController(suite.T()))
-	suite.service = service.TestNewListMiddlewareService(suite.listRepositoryMock)
+	suite.boardMemberServiceMock = mock_service.NewMockBoardMemberService(gomock.NewController(suite.T()))
+	suite.service = service.TestNewListMiddlewareService(suite.listRepositoryMock, suite.boardMemberServiceMock)
 	suite.ctx, _ = gin.CreateTestContext(httptest.NewRecorder())
+	suite.ctx.Request = httptest.NewRequest("PUT", "/", nil)
 }
 
 func TestListMiddlewareService(t *testing.T) {
@@ -43,8 +47,8 @@ func (suite *ListMiddlewareServiceTestSuite) TestSuccessAuthorizeWithListIDParam
 	list := factory.NewList(&factory.ListConfig{})
 	suite.listRepositoryMock.EXPECT().Find(listID).Return(list, nil)
 	user := factory.NewUser(&factory.UserConfig{})
-	user.ID = list.ID
 	suite.ctx.Set(config.CurrentUserKey, user)
+	suite.boardMemberServiceMock.EXPECT().Authorize(list.BoardID, user, model.BoardRoleEditor).Return(nil)
 	rlist, err := suite.service.Authorize(suite.ctx)
 
 	suite.Equal(list, rlist)
@@ -57,8 +61,8 @@ func (suite *ListMiddlewareServiceTestSuite) TestSuccessAuthorizeWithIDParam() {
 	list := factory.NewList(&factory.ListConfig{})
 	suite.listRepositoryMock.EXPECT().Find(listID).Return(list, nil)
 	user := factory.NewUser(&factory.UserConfig{})
-	user.ID = list.ID
 	suite.ctx.Set(config.CurrentUserKey, user)
+	suite.boardMemberServiceMock.EXPECT().Authorize(list.BoardID, user, model.BoardRoleEditor).Return(nil)
 	rlist, err := suite.service.Authorize(suite.ctx)
 
 	suite.Equal(list, rlist)
@@ -87,9 +91,23 @@ func (suite *ListMiddlewareServiceTestSuite) TestBadAuthorizeWithForbiddenError(
 	list := factory.NewList(&factory.ListConfig{})
 	suite.listRepositoryMock.EXPECT().Find(listID).Return(list, nil)
 	user := factory.NewUser(&factory.UserConfig{})
-	user.ID = list.ID + 1
 	suite.ctx.Set(config.CurrentUserKey, user)
+	suite.boardMemberServiceMock.EXPECT().Authorize(list.BoardID, user, model.BoardRoleEditor).Return(config.ForbiddenError)
 	_, err := suite.service.Authorize(suite.ctx)
 
 	suite.Equal(config.ForbiddenError, err)
 }
+
+func (suite *ListMiddlewareServiceTestSuite) TestAuthorizeRequiresViewerForGet() {
+	listID := 1
+	suite.ctx.Params = gin.Params{gin.Param{Key: "id", Value: strconv.Itoa(listID)}}
+	suite.ctx.Request = httptest.NewRequest("GET", "/", nil)
+	list := factory.NewList(&factory.ListConfig{})
+	suite.listRepositoryMock.EXPECT().Find(listID).Return(list, nil)
+	user := factory.NewUser(&factory.UserConfig{})
+	suite.ctx.Set(config.CurrentUserKey, user)
+	suite.boardMemberServiceMock.EXPECT().Authorize(list.BoardID, user, model.BoardRoleViewer).Return(nil)
+	_, err := suite.service.Authorize(suite.ctx)
+
+	suite.Nil(err)
+}
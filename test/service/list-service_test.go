@@ -13,6 +13,7 @@ import (
 	"github.com/kuritaeiji/todo-gin-back/config"
 	"github.com/kuritaeiji/todo-gin-back/factory"
 	"github.com/kuritaeiji/todo-gin-back/mock_repository"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
 	"github.com/kuritaeiji/todo-gin-back/model"
 	"github.com/kuritaeiji/todo-gin-back/service"
 	"github.com/kuritaeiji/todo-gin-back/validators"
@@ -21,9 +22,10 @@ import (
 
 type ListServiceTestSuite struct {
 	suite.Suite
-	service            service.ListService
-	listRepositoryMock *mock_repository.MockListRepository
-	ctx                *gin.Context
+	service               service.ListService
+	listRepositoryMock    *mock_repository.MockListRepository
+	boardEventServiceMock *mock_service.MockBoardEventService
+	ctx                   *gin.Context
 }
 
 func (suite *ListServiceTestSuite) SetupSuite() {
@@ -33,7 +35,8 @@ func (suite *ListServiceTestSuite) SetupSuite() {
 
 func (suite *ListServiceTestSuite) SetupTest() {
 	suite.listRepositoryMock = mock_repository.NewMockListRepository(gomock.NewController(suite.T()))
-	suite.service = service.TestNewListService(suite.listRepositoryMock)
+	suite.boardEventServiceMock = mock_service.NewMockBoardEventService(gomock.NewController(suite.T()))
+	suite.service = service.TestNewListService(suite.listRepositoryMock, suite.boardEventServiceMock)
 	suite.ctx, _ = gin.CreateTestContext(httptest.NewRecorder())
 }
 
@@ -42,34 +45,58 @@ func TestListServiceSuite(t *testing.T) {
 }
 
 func (suite *ListServiceTestSuite) TestSuccessIndex() {
-	user := factory.NewUser(&factory.UserConfig{})
-	suite.ctx.Set(config.CurrentUserKey, user)
-	suite.listRepositoryMock.EXPECT().FindListsWithCards(&user).Return(nil)
+	board := factory.NewBoard(&factory.BoardConfig{})
+	suite.ctx.Set(config.BoardKey, board)
+	suite.ctx.Request = httptest.NewRequest("GET", "/api/boards/1/lists", nil)
+	suite.listRepositoryMock.EXPECT().FindListsWithCards(&board).Return(nil)
 
 	lists, err := suite.service.Index(suite.ctx)
 	suite.Nil(err)
-	suite.Equal(user.Lists, lists)
+	suite.Equal(board.Lists, lists)
 }
 
 func (suite *ListServiceTestSuite) TestBadIndexWithDBError() {
-	user := factory.NewUser(&factory.UserConfig{})
-	suite.ctx.Set(config.CurrentUserKey, user)
+	board := factory.NewBoard(&factory.BoardConfig{})
+	suite.ctx.Set(config.BoardKey, board)
+	suite.ctx.Request = httptest.NewRequest("GET", "/api/boards/1/lists", nil)
 	err := errors.New("db error")
-	suite.listRepositoryMock.EXPECT().FindListsWithCards(&user).Return(err)
+	suite.listRepositoryMock.EXPECT().FindListsWithCards(&board).Return(err)
 	lists, rerr := suite.service.Index(suite.ctx)
 
 	suite.Equal(err, rerr)
-	suite.Equal(user.Lists, lists)
+	suite.Equal(board.Lists, lists)
+}
+
+func (suite *ListServiceTestSuite) TestSuccessIndexWithLabels() {
+	board := factory.NewBoard(&factory.BoardConfig{})
+	suite.ctx.Set(config.BoardKey, board)
+	suite.ctx.Request = httptest.NewRequest("GET", "/api/boards/1/lists?label=1&label=2", nil)
+	suite.listRepositoryMock.EXPECT().FindListsWithCardsByLabels(&board, []int{1, 2}).Return(nil)
+
+	lists, err := suite.service.Index(suite.ctx)
+	suite.Nil(err)
+	suite.Equal(board.Lists, lists)
+}
+
+func (suite *ListServiceTestSuite) TestBadIndexWithInvalidLabel() {
+	board := factory.NewBoard(&factory.BoardConfig{})
+	suite.ctx.Set(config.BoardKey, board)
+	suite.ctx.Request = httptest.NewRequest("GET", "/api/boards/1/lists?label=0", nil)
+
+	_, err := suite.service.Index(suite.ctx)
+	_, ok := err.(validator.ValidationErrors)
+	suite.True(ok)
 }
 
 func (suite *ListServiceTestSuite) TestSuccessCreate() {
-	currentUser := model.User{}
-	suite.ctx.Set(config.CurrentUserKey, currentUser)
+	board := model.Board{ID: 1}
+	suite.ctx.Set(config.BoardKey, board)
 	listConfig := &factory.ListConfig{}
-	req := httptest.NewRequest("POST", "/api/lists", factory.CreateListRequestBody(listConfig))
+	req := httptest.NewRequest("POST", "/api/boards/1/lists", factory.CreateListRequestBody(listConfig))
 	suite.ctx.Request = req
 	list := factory.NewList(listConfig)
-	suite.listRepositoryMock.EXPECT().Create(&currentUser, &list).Return(nil)
+	suite.listRepositoryMock.EXPECT().Create(&board, &list).Return(nil)
+	suite.boardEventServiceMock.EXPECT().PublishList(service.ListCreatedEvent, list)
 	rList, err := suite.service.Create(suite.ctx)
 
 	suite.Nil(err)
@@ -77,7 +104,7 @@ func (suite *ListServiceTestSuite) TestSuccessCreate() {
 }
 
 func (suite *ListServiceTestSuite) TestBadCreateWithValidationError() {
-	req := httptest.NewRequest("POST", "/api/lists", factory.CreateListRequestBody(&factory.ListConfig{NotUseDefaultValue: true}))
+	req := httptest.NewRequest("POST", "/api/boards/1/lists", factory.CreateListRequestBody(&factory.ListConfig{NotUseDefaultValue: true}))
 	suite.ctx.Request = req
 	list, err := suite.service.Create(suite.ctx)
 
@@ -87,13 +114,13 @@ func (suite *ListServiceTestSuite) TestBadCreateWithValidationError() {
 
 func (suite *ListServiceTestSuite) TestBadCreateWithDBError() {
 	listConfig := &factory.ListConfig{}
-	req := httptest.NewRequest("POST", "/api/lists", factory.CreateListRequestBody(listConfig))
+	req := httptest.NewRequest("POST", "/api/boards/1/lists", factory.CreateListRequestBody(listConfig))
 	suite.ctx.Request = req
-	var currentUser model.User
-	suite.ctx.Set(config.CurrentUserKey, currentUser)
+	board := model.Board{ID: 1}
+	suite.ctx.Set(config.BoardKey, board)
 	err := errors.New("DB error")
 	list := factory.NewList(listConfig)
-	suite.listRepositoryMock.EXPECT().Create(&currentUser, &list).Return(err)
+	suite.listRepositoryMock.EXPECT().Create(&board, &list).Return(err)
 	_, rerr := suite.service.Create(suite.ctx)
 
 	suite.Equal(err, rerr)
@@ -109,6 +136,7 @@ func (suite *ListServiceTestSuite) TestSuccessUpdate() {
 	suite.ctx.Set(config.CurrentUserKey, user)
 	suite.ctx.Set(config.ListKey, list)
 	suite.listRepositoryMock.EXPECT().Update(&list, gomock.Any()).Do(func(l *model.List, updatingList model.List) {
+		suite.boardEventServiceMock.EXPECT().PublishList(service.ListUpdatedEvent, gomock.Any())
 		suite.Equal(updatingListConfig.Title, updatingList.Title)
 	})
 	rList, err := suite.service.Update(suite.ctx)
@@ -150,8 +178,9 @@ func (suite *ListServiceTestSuite) TestSuccessDestroy() {
 	suite.ctx.Set(config.ListKey, list)
 	currentUser := factory.NewUser(&factory.UserConfig{})
 	suite.ctx.Set(config.CurrentUserKey, currentUser)
-	list.UserID = currentUser.ID
+	list.Board.UserID = currentUser.ID
 	suite.listRepositoryMock.EXPECT().Destroy(&list).Return(nil)
+	suite.boardEventServiceMock.EXPECT().PublishList(service.ListDeletedEvent, list)
 	err := suite.service.Destroy(suite.ctx)
 
 	suite.Nil(err)
@@ -177,7 +206,10 @@ func (suite *ListServiceTestSuite) TestSuccessMove() {
 	toIndex := 1
 	req := httptest.NewRequest("PUT", "/api/lists/1/move", factory.CreateListRequestBody(&factory.ListConfig{Index: toIndex}))
 	suite.ctx.Request = req
-	suite.listRepositoryMock.EXPECT().Move(&list, toIndex, &user).Return(nil)
+	suite.listRepositoryMock.EXPECT().Move(&list, toIndex).Return(nil)
+	suite.boardEventServiceMock.EXPECT().PublishList(service.ListMovedEvent, gomock.Any()).Do(func(eventType string, movedList model.List) {
+		suite.Equal(toIndex, movedList.Index)
+	})
 	err := suite.service.Move(suite.ctx)
 
 	suite.Nil(err)
@@ -204,8 +236,28 @@ func (suite *ListServiceTestSuite) TestBadMoveWithDBError() {
 	req := httptest.NewRequest("PUT", "/api/lists/1/move", factory.CreateListRequestBody(&factory.ListConfig{Index: toIndex}))
 	suite.ctx.Request = req
 	err := errors.New("db error")
-	suite.listRepositoryMock.EXPECT().Move(&list, toIndex, &currentUser).Return(err)
+	suite.listRepositoryMock.EXPECT().Move(&list, toIndex).Return(err)
 	rerr := suite.service.Move(suite.ctx)
 
 	suite.Equal(err, rerr)
 }
+
+func (suite *ListServiceTestSuite) TestSuccessArchive() {
+	list := factory.NewList(&factory.ListConfig{})
+	suite.ctx.Set(config.ListKey, list)
+	suite.listRepositoryMock.EXPECT().Archive(&list).Return(nil)
+	suite.boardEventServiceMock.EXPECT().PublishList(service.ListArchivedEvent, list)
+	err := suite.service.Archive(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *ListServiceTestSuite) TestBadArchiveWithDBError() {
+	list := factory.NewList(&factory.ListConfig{})
+	suite.ctx.Set(config.ListKey, list)
+	err := errors.New("db error")
+	suite.listRepositoryMock.EXPECT().Archive(&list).Return(err)
+	rerr := suite.service.Archive(suite.ctx)
+
+	suite.Equal(err, rerr)
+}
@@ -0,0 +1,139 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/mock_repository"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type LoginAttemptServiceTestSuite struct {
+	suite.Suite
+	service                    service.LoginAttemptService
+	loginAttemptRepositoryMock *mock_repository.MockLoginAttemptRepository
+	emailServiceMock           *mock_service.MockEmailService
+}
+
+func (suite *LoginAttemptServiceTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *LoginAttemptServiceTestSuite) SetupTest() {
+	suite.loginAttemptRepositoryMock = mock_repository.NewMockLoginAttemptRepository(gomock.NewController(suite.T()))
+	suite.emailServiceMock = mock_service.NewMockEmailService(gomock.NewController(suite.T()))
+	suite.service = service.TestNewLoginAttemptService(suite.loginAttemptRepositoryMock, suite.emailServiceMock)
+}
+
+func TestLoginAttemptService(t *testing.T) {
+	suite.Run(t, new(LoginAttemptServiceTestSuite))
+}
+
+const loginIP = "192.0.2.1"
+
+func (suite *LoginAttemptServiceTestSuite) TestSuccessCheckIP() {
+	suite.loginAttemptRepositoryMock.EXPECT().FindByKey("ip:"+loginIP).Return(model.LoginAttempt{}, gorm.ErrRecordNotFound)
+	err := suite.service.CheckIP(loginIP)
+
+	suite.Nil(err)
+}
+
+func (suite *LoginAttemptServiceTestSuite) TestBadCheckIPWithLocked() {
+	lockedUntil := time.Now().Add(time.Minute)
+	suite.loginAttemptRepositoryMock.EXPECT().FindByKey("ip:"+loginIP).Return(model.LoginAttempt{LockedUntil: &lockedUntil}, nil)
+	err := suite.service.CheckIP(loginIP)
+
+	suite.Equal(config.TooManyLoginAttemptsError, err)
+}
+
+func (suite *LoginAttemptServiceTestSuite) TestSuccessCheckUserWithExpiredLock() {
+	lockedUntil := time.Now().Add(-time.Minute)
+	suite.loginAttemptRepositoryMock.EXPECT().FindByKey("user:1").Return(model.LoginAttempt{LockedUntil: &lockedUntil}, nil)
+	err := suite.service.CheckUser(model.User{ID: 1})
+
+	suite.Nil(err)
+}
+
+func (suite *LoginAttemptServiceTestSuite) TestBadCheckUserWithLocked() {
+	lockedUntil := time.Now().Add(time.Minute)
+	suite.loginAttemptRepositoryMock.EXPECT().FindByKey("user:1").Return(model.LoginAttempt{LockedUntil: &lockedUntil}, nil)
+	err := suite.service.CheckUser(model.User{ID: 1})
+
+	suite.Equal(config.AccountLockedError, err)
+}
+
+func (suite *LoginAttemptServiceTestSuite) TestSuccessFailWithNotFoundUser() {
+	suite.loginAttemptRepositoryMock.EXPECT().Increment("ip:"+loginIP, gomock.Any(), gomock.Any()).Return(model.LoginAttempt{ID: 1, Key: "ip:" + loginIP, Failures: 1}, nil).Do(func(_ string, failedAt time.Time, resetBefore time.Time) {
+		suite.WithinDuration(time.Now(), failedAt, time.Second)
+		suite.Equal(failedAt.Add(-service.HourResetLoginFailures*time.Hour), resetBefore)
+	})
+	err := suite.service.Fail(loginIP, nil)
+
+	suite.Nil(err)
+}
+
+func (suite *LoginAttemptServiceTestSuite) TestSuccessFailWithLockout() {
+	user := model.User{ID: 1, Email: "user@example.com"}
+	attempt := model.LoginAttempt{ID: 1, Key: "user:1", Failures: service.MaxUserLoginFailures}
+	suite.loginAttemptRepositoryMock.EXPECT().Increment("ip:"+loginIP, gomock.Any(), gomock.Any()).Return(model.LoginAttempt{ID: 2, Failures: 1}, nil)
+	suite.loginAttemptRepositoryMock.EXPECT().Increment("user:1", gomock.Any(), gomock.Any()).Return(attempt, nil)
+	var lockedUntil time.Time
+	suite.loginAttemptRepositoryMock.EXPECT().Lock(gomock.Any(), gomock.Any()).Return(nil).Do(func(a *model.LoginAttempt, until time.Time) {
+		suite.WithinDuration(time.Now().Add(service.MinuteBaseLoginLockout*time.Minute), until, time.Second)
+		lockedUntil = until
+		a.LockedUntil = &until
+	})
+	suite.emailServiceMock.EXPECT().AccountLockedEmail(user, gomock.Any()).Return(nil).Do(func(_ model.User, until time.Time) {
+		suite.Equal(lockedUntil, until)
+	})
+	err := suite.service.Fail(loginIP, &user)
+
+	suite.Nil(err)
+}
+
+func (suite *LoginAttemptServiceTestSuite) TestSuccessFailWithExponentialBackoff() {
+	user := model.User{ID: 1}
+	suite.loginAttemptRepositoryMock.EXPECT().Increment("ip:"+loginIP, gomock.Any(), gomock.Any()).Return(model.LoginAttempt{ID: 2, Failures: 1}, nil)
+	suite.loginAttemptRepositoryMock.EXPECT().Increment("user:1", gomock.Any(), gomock.Any()).Return(model.LoginAttempt{ID: 1, Key: "user:1", Failures: service.MaxUserLoginFailures + 2}, nil)
+	suite.loginAttemptRepositoryMock.EXPECT().Lock(gomock.Any(), gomock.Any()).Return(nil).Do(func(_ *model.LoginAttempt, until time.Time) {
+		suite.WithinDuration(time.Now().Add(4*service.MinuteBaseLoginLockout*time.Minute), until, time.Second)
+	})
+	// ロックされた後の失敗では通知しない
+	suite.emailServiceMock.EXPECT().AccountLockedEmail(gomock.Any(), gomock.Any()).Times(0)
+	err := suite.service.Fail(loginIP, &user)
+
+	suite.Nil(err)
+}
+
+func (suite *LoginAttemptServiceTestSuite) TestSuccessFailBelowMaxFailures() {
+	user := model.User{ID: 1}
+	suite.loginAttemptRepositoryMock.EXPECT().Increment("ip:"+loginIP, gomock.Any(), gomock.Any()).Return(model.LoginAttempt{ID: 2, Failures: 1}, nil)
+	suite.loginAttemptRepositoryMock.EXPECT().Increment("user:1", gomock.Any(), gomock.Any()).Return(model.LoginAttempt{ID: 1, Key: "user:1", Failures: service.MaxUserLoginFailures - 1}, nil)
+	suite.loginAttemptRepositoryMock.EXPECT().Lock(gomock.Any(), gomock.Any()).Times(0)
+	err := suite.service.Fail(loginIP, &user)
+
+	suite.Nil(err)
+}
+
+func (suite *LoginAttemptServiceTestSuite) TestBadFailWithDBError() {
+	err := errors.New("error")
+	suite.loginAttemptRepositoryMock.EXPECT().Increment("ip:"+loginIP, gomock.Any(), gomock.Any()).Return(model.LoginAttempt{}, err)
+	rerr := suite.service.Fail(loginIP, &model.User{ID: 1})
+
+	suite.Equal(err, rerr)
+}
+
+func (suite *LoginAttemptServiceTestSuite) TestSuccessSucceed() {
+	suite.loginAttemptRepositoryMock.EXPECT().DestroyByKey("user:1").Return(nil)
+	err := suite.service.Succeed(model.User{ID: 1})
+
+	suite.Nil(err)
+}
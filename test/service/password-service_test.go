@@ -0,0 +1,153 @@
+package service_test
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/mock_repository"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"github.com/kuritaeiji/todo-gin-back/validators"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type PasswordServiceTestSuite struct {
+	suite.Suite
+	service                     service.PasswordService
+	passwordResetRepositoryMock *mock_repository.MockPasswordResetRepository
+	userRepositoryMock          *mock_repository.MockUserRepository
+	emailServiceMock            *mock_service.MockEmailService
+	auditServiceMock            *mock_service.MockAuditService
+	ctx                         *gin.Context
+}
+
+func (suite *PasswordServiceTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	validators.Init()
+}
+
+func (suite *PasswordServiceTestSuite) SetupTest() {
+	suite.passwordResetRepositoryMock = mock_repository.NewMockPasswordResetRepository(gomock.NewController(suite.T()))
+	suite.userRepositoryMock = mock_repository.NewMockUserRepository(gomock.NewController(suite.T()))
+	suite.emailServiceMock = mock_service.NewMockEmailService(gomock.NewController(suite.T()))
+	suite.auditServiceMock = mock_service.NewMockAuditService(gomock.NewController(suite.T()))
+	suite.service = service.TestNewPasswordService(suite.passwordResetRepositoryMock, suite.userRepositoryMock, suite.emailServiceMock, suite.auditServiceMock)
+	suite.ctx, _ = gin.CreateTestContext(httptest.NewRecorder())
+}
+
+func TestPasswordService(t *testing.T) {
+	suite.Run(t, new(PasswordServiceTestSuite))
+}
+
+func (suite *PasswordServiceTestSuite) setRequest(method, path string, body io.Reader) {
+	req := httptest.NewRequest(method, path, body)
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+}
+
+func (suite *PasswordServiceTestSuite) TestSuccessForgot() {
+	user := factory.NewUser(&factory.UserConfig{ID: 1})
+	var tokenString string
+	suite.userRepositoryMock.EXPECT().FindByEmail(user.Email).Return(user, nil)
+	suite.passwordResetRepositoryMock.EXPECT().Create(gomock.Any()).Return(nil).Do(func(reset *model.PasswordReset) {
+		suite.Equal(user.ID, reset.UserID)
+		suite.InEpsilon(time.Now().Add(service.MinuteFromNowPasswordResetToken*time.Minute).Unix(), reset.ExpiresAt.Unix(), 30)
+	})
+	suite.emailServiceMock.EXPECT().PasswordResetEmail(user, gomock.Any()).Return(nil).Do(func(_ model.User, token string) {
+		tokenString = token
+	})
+	suite.setRequest("POST", "/api/password/forgot", factory.CreateForgotPasswordRequestBody(user.Email))
+	err := suite.service.Forgot(suite.ctx)
+
+	suite.Nil(err)
+	suite.NotEmpty(tokenString)
+}
+
+func (suite *PasswordServiceTestSuite) TestSuccessForgotWithNotFoundUser() {
+	const email = "notfound@example.com"
+	suite.userRepositoryMock.EXPECT().FindByEmail(email).Return(model.User{}, gorm.ErrRecordNotFound)
+	suite.setRequest("POST", "/api/password/forgot", factory.CreateForgotPasswordRequestBody(email))
+	err := suite.service.Forgot(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *PasswordServiceTestSuite) TestSuccessForgotWithEmailClientError() {
+	user := factory.NewUser(&factory.UserConfig{ID: 1})
+	suite.userRepositoryMock.EXPECT().FindByEmail(user.Email).Return(user, nil)
+	suite.passwordResetRepositoryMock.EXPECT().Create(gomock.Any()).Return(nil)
+	suite.emailServiceMock.EXPECT().PasswordResetEmail(user, gomock.Any()).Return(config.EmailClientError)
+	suite.setRequest("POST", "/api/password/forgot", factory.CreateForgotPasswordRequestBody(user.Email))
+	err := suite.service.Forgot(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *PasswordServiceTestSuite) TestBadForgotWithValidationError() {
+	suite.setRequest("POST", "/api/password/forgot", factory.CreateForgotPasswordRequestBody("invalid"))
+	err := suite.service.Forgot(suite.ctx)
+
+	suite.IsType(validator.ValidationErrors{}, err)
+}
+
+func (suite *PasswordServiceTestSuite) TestSuccessReset() {
+	suite.auditServiceMock.EXPECT().Record(gomock.Any(), model.AuditEventPasswordReset, gomock.Any(), "")
+	user := factory.NewUser(&factory.UserConfig{ID: 1})
+	const newPassword = "NewPassword1010"
+	reset := model.PasswordReset{ID: 1, ExpiresAt: time.Now().Add(time.Minute), UserID: user.ID, User: user}
+	suite.passwordResetRepositoryMock.EXPECT().FindByDigest(gomock.Any()).Return(reset, nil)
+	suite.userRepositoryMock.EXPECT().UpdatePassword(gomock.Any()).Return(nil).Do(func(u *model.User) {
+		suite.Equal(user.ID, u.ID)
+		suite.True(u.Authenticate(newPassword))
+	})
+	suite.setRequest("PUT", "/api/password/reset", factory.CreateResetPasswordRequestBody("token", newPassword))
+	err := suite.service.Reset(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *PasswordServiceTestSuite) TestBadResetWithValidationError() {
+	suite.setRequest("PUT", "/api/password/reset", factory.CreateResetPasswordRequestBody("token", "password"))
+	err := suite.service.Reset(suite.ctx)
+
+	suite.IsType(validator.ValidationErrors{}, err)
+}
+
+func (suite *PasswordServiceTestSuite) TestBadResetWithNotFoundToken() {
+	suite.passwordResetRepositoryMock.EXPECT().FindByDigest(gomock.Any()).Return(model.PasswordReset{}, gorm.ErrRecordNotFound)
+	suite.setRequest("PUT", "/api/password/reset", factory.CreateResetPasswordRequestBody("token", factory.DefualtPassword))
+	err := suite.service.Reset(suite.ctx)
+
+	suite.Equal(config.InvalidPasswordResetTokenError, err)
+}
+
+func (suite *PasswordServiceTestSuite) TestBadResetWithExpiredToken() {
+	reset := model.PasswordReset{ID: 1, ExpiresAt: time.Now().Add(-time.Minute), User: model.User{ID: 1}}
+	suite.passwordResetRepositoryMock.EXPECT().FindByDigest(gomock.Any()).Return(reset, nil)
+	suite.setRequest("PUT", "/api/password/reset", factory.CreateResetPasswordRequestBody("token", factory.DefualtPassword))
+	err := suite.service.Reset(suite.ctx)
+
+	suite.Equal(config.InvalidPasswordResetTokenError, err)
+}
+
+func (suite *PasswordServiceTestSuite) TestBadResetWithDBError() {
+	err := errors.New("error")
+	reset := model.PasswordReset{ID: 1, ExpiresAt: time.Now().Add(time.Minute), User: model.User{ID: 1}}
+	suite.passwordResetRepositoryMock.EXPECT().FindByDigest(gomock.Any()).Return(reset, nil)
+	suite.userRepositoryMock.EXPECT().UpdatePassword(gomock.Any()).Return(err)
+	suite.setRequest("PUT", "/api/password/reset", factory.CreateResetPasswordRequestBody("token", factory.DefualtPassword))
+	rerr := suite.service.Reset(suite.ctx)
+
+	suite.Equal(err, rerr)
+}
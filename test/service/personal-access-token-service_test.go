@@ -0,0 +1,148 @@
+package service_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/mock_repository"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type PersonalAccessTokenServiceTestSuite struct {
+	suite.Suite
+	service                           service.PersonalAccessTokenService
+	personalAccessTokenRepositoryMock *mock_repository.MockPersonalAccessTokenRepository
+	auditServiceMock                  *mock_service.MockAuditService
+	rec                               *httptest.ResponseRecorder
+	ctx                               *gin.Context
+}
+
+func (suite *PersonalAccessTokenServiceTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *PersonalAccessTokenServiceTestSuite) SetupTest() {
+	suite.personalAccessTokenRepositoryMock = mock_repository.NewMockPersonalAccessTokenRepository(gomock.NewController(suite.T()))
+	suite.auditServiceMock = mock_service.NewMockAuditService(gomock.NewController(suite.T()))
+	suite.service = service.TestNewPersonalAccessTokenService(suite.personalAccessTokenRepositoryMock, suite.auditServiceMock)
+	suite.rec = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.rec)
+}
+
+func TestPersonalAccessTokenService(t *testing.T) {
+	suite.Run(t, new(PersonalAccessTokenServiceTestSuite))
+}
+
+func (suite *PersonalAccessTokenServiceTestSuite) setCreateRequest(body string) {
+	req := httptest.NewRequest("POST", "/api/users/tokens", strings.NewReader(body))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	suite.ctx.Set(config.CurrentUserKey, model.User{ID: 1})
+}
+
+func (suite *PersonalAccessTokenServiceTestSuite) TestSuccessCreate() {
+	suite.setCreateRequest(`{"name":"script","scopes":["lists:read","cards:write","lists:read"],"expiresInDays":30}`)
+	var saved model.PersonalAccessToken
+	suite.personalAccessTokenRepositoryMock.EXPECT().Create(gomock.Any()).Return(nil).Do(func(token *model.PersonalAccessToken) {
+		saved = *token
+	})
+	suite.auditServiceMock.EXPECT().Record(suite.ctx, model.AuditEventPersonalAccessTokenCreated, gomock.Any(), gomock.Any())
+	token, tokenString, err := suite.service.Create(suite.ctx)
+
+	suite.Nil(err)
+	suite.True(strings.HasPrefix(tokenString, model.PersonalAccessTokenPrefix))
+	suite.Equal(factory.Digest(tokenString), saved.Digest)
+	suite.Equal("lists:read cards:write", token.Scopes)
+	suite.Equal("script", token.Name)
+	suite.Equal(1, token.UserID)
+	suite.WithinDuration(time.Now().AddDate(0, 0, 30), *token.ExpiresAt, time.Minute)
+}
+
+func (suite *PersonalAccessTokenServiceTestSuite) TestSuccessCreateWithoutExpiry() {
+	suite.setCreateRequest(`{"name":"script","scopes":["lists:read"]}`)
+	suite.personalAccessTokenRepositoryMock.EXPECT().Create(gomock.Any()).Return(nil)
+	suite.auditServiceMock.EXPECT().Record(suite.ctx, model.AuditEventPersonalAccessTokenCreated, gomock.Any(), gomock.Any())
+	token, _, err := suite.service.Create(suite.ctx)
+
+	suite.Nil(err)
+	suite.Nil(token.ExpiresAt)
+}
+
+func (suite *PersonalAccessTokenServiceTestSuite) TestBadCreateWithUnknownScope() {
+	suite.setCreateRequest(`{"name":"script","scopes":["users:write"]}`)
+	_, _, err := suite.service.Create(suite.ctx)
+
+	suite.IsType(validator.ValidationErrors{}, err)
+}
+
+func (suite *PersonalAccessTokenServiceTestSuite) TestSuccessIndex() {
+	user := model.User{ID: 1}
+	suite.ctx.Set(config.CurrentUserKey, user)
+	tokens := []model.PersonalAccessToken{{ID: 1}}
+	suite.personalAccessTokenRepositoryMock.EXPECT().FindAll(&user).Return(tokens, nil)
+	rTokens, err := suite.service.Index(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal(tokens, rTokens)
+}
+
+func (suite *PersonalAccessTokenServiceTestSuite) TestSuccessDestroy() {
+	user := model.User{ID: 1}
+	suite.ctx.Set(config.CurrentUserKey, user)
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "2"}}
+	suite.personalAccessTokenRepositoryMock.EXPECT().Destroy(&user, 2).Return(nil)
+	suite.auditServiceMock.EXPECT().Record(suite.ctx, model.AuditEventPersonalAccessTokenRevoked, &user, "2")
+	err := suite.service.Destroy(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *PersonalAccessTokenServiceTestSuite) TestSuccessAuthenticate() {
+	tokenString := model.PersonalAccessTokenPrefix + "token"
+	token := model.PersonalAccessToken{ID: 1, Scopes: "lists:read"}
+	suite.personalAccessTokenRepositoryMock.EXPECT().FindByDigest(factory.Digest(tokenString)).Return(token, nil)
+	suite.personalAccessTokenRepositoryMock.EXPECT().UpdateLastUsedAt(gomock.Any(), gomock.Any()).Return(nil)
+	rToken, err := suite.service.Authenticate(tokenString)
+
+	suite.Nil(err)
+	suite.Equal(token.ID, rToken.ID)
+}
+
+func (suite *PersonalAccessTokenServiceTestSuite) TestSuccessAuthenticateWithRecentlyUsed() {
+	tokenString := model.PersonalAccessTokenPrefix + "token"
+	lastUsedAt := time.Now().Add(-10 * time.Second)
+	token := model.PersonalAccessToken{ID: 1, LastUsedAt: &lastUsedAt}
+	suite.personalAccessTokenRepositoryMock.EXPECT().FindByDigest(factory.Digest(tokenString)).Return(token, nil)
+	_, err := suite.service.Authenticate(tokenString)
+
+	suite.Nil(err)
+}
+
+func (suite *PersonalAccessTokenServiceTestSuite) TestBadAuthenticateWithExpired() {
+	tokenString := model.PersonalAccessTokenPrefix + "token"
+	expiresAt := time.Now().Add(-time.Minute)
+	suite.personalAccessTokenRepositoryMock.EXPECT().FindByDigest(factory.Digest(tokenString)).Return(model.PersonalAccessToken{ExpiresAt: &expiresAt}, nil)
+	_, err := suite.service.Authenticate(tokenString)
+
+	suite.Equal(config.InvalidPersonalAccessTokenError, err)
+}
+
+func (suite *PersonalAccessTokenServiceTestSuite) TestBadAuthenticateWithRecordNotFound() {
+	tokenString := model.PersonalAccessTokenPrefix + "token"
+	suite.personalAccessTokenRepositoryMock.EXPECT().FindByDigest(factory.Digest(tokenString)).Return(model.PersonalAccessToken{}, gorm.ErrRecordNotFound)
+	_, err := suite.service.Authenticate(tokenString)
+
+	suite.Equal(config.InvalidPersonalAccessTokenError, err)
+}
@@ -0,0 +1,127 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/mock_repository"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type RefreshTokenServiceTestSuite struct {
+	suite.Suite
+	service                    service.RefreshTokenService
+	refreshTokenRepositoryMock *mock_repository.MockRefreshTokenRepository
+	sessionRepositoryMock      *mock_repository.MockSessionRepository
+}
+
+func (suite *RefreshTokenServiceTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *RefreshTokenServiceTestSuite) SetupTest() {
+	suite.refreshTokenRepositoryMock = mock_repository.NewMockRefreshTokenRepository(gomock.NewController(suite.T()))
+	suite.sessionRepositoryMock = mock_repository.NewMockSessionRepository(gomock.NewController(suite.T()))
+	suite.service = service.TestNewRefreshTokenService(suite.refreshTokenRepositoryMock, suite.sessionRepositoryMock)
+}
+
+func TestRefreshTokenService(t *testing.T) {
+	suite.Run(t, new(RefreshTokenServiceTestSuite))
+}
+
+func (suite *RefreshTokenServiceTestSuite) TestSuccessCreate() {
+	user := model.User{ID: 1}
+	const family = "family"
+	var token model.RefreshToken
+	suite.refreshTokenRepositoryMock.EXPECT().Create(gomock.Any()).Return(nil).Do(func(t *model.RefreshToken) {
+		token = *t
+	})
+	tokenString, err := suite.service.Create(user, family)
+
+	suite.Nil(err)
+	suite.NotEmpty(tokenString)
+	suite.NotEqual(tokenString, token.Digest)
+	suite.Equal(family, token.Family)
+	suite.True(token.SessionTracked)
+	suite.Equal(user.ID, token.UserID)
+	suite.InEpsilon(time.Now().AddDate(0, 0, service.DayFromNowRefreshToken).Unix(), token.ExpiresAt.Unix(), 30)
+}
+
+func (suite *RefreshTokenServiceTestSuite) TestSuccessRotate() {
+	user := model.User{ID: 1}
+	token := model.RefreshToken{ID: 1, Family: "family", ExpiresAt: time.Now().Add(time.Hour), UserID: user.ID, User: user}
+	suite.refreshTokenRepositoryMock.EXPECT().FindByDigest(gomock.Any()).Return(token, nil)
+	suite.refreshTokenRepositoryMock.EXPECT().Use(&token).Return(nil)
+	suite.refreshTokenRepositoryMock.EXPECT().Create(gomock.Any()).Return(nil).Do(func(t *model.RefreshToken) {
+		suite.Equal(token.Family, t.Family)
+	})
+	usedToken, newTokenString, err := suite.service.Rotate("tokenString")
+
+	suite.Nil(err)
+	suite.Equal(user, usedToken.User)
+	suite.Equal(token.Family, usedToken.Family)
+	suite.NotEmpty(newTokenString)
+}
+
+func (suite *RefreshTokenServiceTestSuite) TestBadRotateWithNotFound() {
+	suite.refreshTokenRepositoryMock.EXPECT().FindByDigest(gomock.Any()).Return(model.RefreshToken{}, gorm.ErrRecordNotFound)
+	_, _, err := suite.service.Rotate("tokenString")
+
+	suite.Equal(config.InvalidRefreshTokenError, err)
+}
+
+func (suite *RefreshTokenServiceTestSuite) TestBadRotateWithExpired() {
+	user := model.User{ID: 1}
+	token := model.RefreshToken{ID: 1, ExpiresAt: time.Now().Add(-time.Hour), User: user}
+	suite.refreshTokenRepositoryMock.EXPECT().FindByDigest(gomock.Any()).Return(token, nil)
+	_, _, err := suite.service.Rotate("tokenString")
+
+	suite.Equal(config.InvalidRefreshTokenError, err)
+}
+
+func (suite *RefreshTokenServiceTestSuite) TestBadRotateWithUsedToken() {
+	token := model.RefreshToken{ID: 1, Family: "family", Used: true, ExpiresAt: time.Now().Add(time.Hour), User: model.User{ID: 1}}
+	suite.refreshTokenRepositoryMock.EXPECT().FindByDigest(gomock.Any()).Return(token, nil)
+	suite.sessionRepositoryMock.EXPECT().DestroyBySessionID(token.Family).Return(nil)
+	suite.refreshTokenRepositoryMock.EXPECT().DestroyFamily(token.Family).Return(nil)
+	_, _, err := suite.service.Rotate("tokenString")
+
+	suite.Equal(config.ReusedRefreshTokenError, err)
+}
+
+func (suite *RefreshTokenServiceTestSuite) TestBadRotateWithConcurrentUse() {
+	token := model.RefreshToken{ID: 1, Family: "family", ExpiresAt: time.Now().Add(time.Hour), User: model.User{ID: 1}}
+	suite.refreshTokenRepositoryMock.EXPECT().FindByDigest(gomock.Any()).Return(token, nil)
+	suite.refreshTokenRepositoryMock.EXPECT().Use(&token).Return(config.ReusedRefreshTokenError)
+	suite.sessionRepositoryMock.EXPECT().DestroyBySessionID(token.Family).Return(nil)
+	suite.refreshTokenRepositoryMock.EXPECT().DestroyFamily(token.Family).Return(nil)
+	_, _, err := suite.service.Rotate("tokenString")
+
+	suite.Equal(config.ReusedRefreshTokenError, err)
+}
+
+func (suite *RefreshTokenServiceTestSuite) TestBadRotateWithDestroyFamilyError() {
+	err := errors.New("error")
+	token := model.RefreshToken{ID: 1, Family: "family", Used: true, ExpiresAt: time.Now().Add(time.Hour), User: model.User{ID: 1}}
+	suite.refreshTokenRepositoryMock.EXPECT().FindByDigest(gomock.Any()).Return(token, nil)
+	suite.sessionRepositoryMock.EXPECT().DestroyBySessionID(token.Family).Return(nil)
+	suite.refreshTokenRepositoryMock.EXPECT().DestroyFamily(token.Family).Return(err)
+	_, _, rerr := suite.service.Rotate("tokenString")
+
+	suite.Equal(err, rerr)
+}
+
+func (suite *RefreshTokenServiceTestSuite) TestSuccessRevoke() {
+	const family = "family"
+	suite.refreshTokenRepositoryMock.EXPECT().DestroyFamily(family).Return(nil)
+	err := suite.service.Revoke(family)
+
+	suite.Nil(err)
+}
@@ -0,0 +1,139 @@
+package service_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/mock_repository"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type SessionServiceTestSuite struct {
+	suite.Suite
+	service                 service.SessionService
+	sessionRepositoryMock   *mock_repository.MockSessionRepository
+	refreshTokenServiceMock *mock_service.MockRefreshTokenService
+	auditServiceMock        *mock_service.MockAuditService
+	rec                     *httptest.ResponseRecorder
+	ctx                     *gin.Context
+}
+
+func (suite *SessionServiceTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *SessionServiceTestSuite) SetupTest() {
+	suite.sessionRepositoryMock = mock_repository.NewMockSessionRepository(gomock.NewController(suite.T()))
+	suite.refreshTokenServiceMock = mock_service.NewMockRefreshTokenService(gomock.NewController(suite.T()))
+	suite.auditServiceMock = mock_service.NewMockAuditService(gomock.NewController(suite.T()))
+	suite.service = service.TestNewSessionService(suite.sessionRepositoryMock, suite.refreshTokenServiceMock, suite.auditServiceMock)
+	suite.rec = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.rec)
+	suite.ctx.Request = httptest.NewRequest("POST", "/api/login", nil)
+	suite.ctx.Set(config.CurrentUserKey, model.User{ID: 1})
+}
+
+func TestSessionService(t *testing.T) {
+	suite.Run(t, new(SessionServiceTestSuite))
+}
+
+func (suite *SessionServiceTestSuite) TestSuccessCreate() {
+	suite.ctx.Request.Header.Set("User-Agent", strings.Repeat("a", 300))
+	suite.sessionRepositoryMock.EXPECT().Create(gomock.Any()).Return(nil)
+	session, err := suite.service.Create(suite.ctx, model.User{ID: 1})
+
+	suite.Nil(err)
+	suite.NotEmpty(session.SessionID)
+	suite.Equal(1, session.UserID)
+	suite.Len(session.UserAgent, 255)
+	suite.Equal(suite.ctx.ClientIP(), session.IP)
+	suite.WithinDuration(time.Now(), session.LastSeenAt, time.Second)
+}
+
+func (suite *SessionServiceTestSuite) TestBadCreateWithDBError() {
+	err := errors.New("db error")
+	suite.sessionRepositoryMock.EXPECT().Create(gomock.Any()).Return(err)
+	_, rerr := suite.service.Create(suite.ctx, model.User{ID: 1})
+
+	suite.Equal(err, rerr)
+}
+
+func (suite *SessionServiceTestSuite) TestSuccessDestroy() {
+	suite.auditServiceMock.EXPECT().Record(gomock.Any(), model.AuditEventSessionRevoked, &model.User{ID: 1}, "2")
+	session := model.Session{ID: 2, SessionID: "sid", UserID: 1}
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "2"}}
+	suite.sessionRepositoryMock.EXPECT().Find(&model.User{ID: 1}, 2).Return(session, nil)
+	suite.sessionRepositoryMock.EXPECT().DestroyBySessionID(session.SessionID).Return(nil)
+	suite.refreshTokenServiceMock.EXPECT().Revoke(session.SessionID).Return(nil)
+	err := suite.service.Destroy(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *SessionServiceTestSuite) TestBadDestroyWithNotFound() {
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "2"}}
+	suite.sessionRepositoryMock.EXPECT().Find(&model.User{ID: 1}, 2).Return(model.Session{}, gorm.ErrRecordNotFound)
+	err := suite.service.Destroy(suite.ctx)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *SessionServiceTestSuite) TestBadDestroyWithInvalidID() {
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "abc"}}
+	err := suite.service.Destroy(suite.ctx)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *SessionServiceTestSuite) TestSuccessTouch() {
+	session := model.Session{SessionID: "sid", UserID: 1, LastSeenAt: time.Now().Add(-2 * time.Minute)}
+	suite.sessionRepositoryMock.EXPECT().FindActive(session.SessionID, 1, "jti").Return(session, nil)
+	suite.sessionRepositoryMock.EXPECT().UpdateLastSeenAt(gomock.Any(), gomock.Any()).Return(nil)
+	err := suite.service.Touch(session.SessionID, "jti", model.User{ID: 1})
+
+	suite.Nil(err)
+}
+
+func (suite *SessionServiceTestSuite) TestSuccessTouchWithinInterval() {
+	session := model.Session{SessionID: "sid", UserID: 1, LastSeenAt: time.Now()}
+	suite.sessionRepositoryMock.EXPECT().FindActive(session.SessionID, 1, "jti").Return(session, nil)
+	err := suite.service.Touch(session.SessionID, "jti", model.User{ID: 1})
+
+	suite.Nil(err)
+}
+
+func (suite *SessionServiceTestSuite) TestBadTouchWithRevokedSession() {
+	suite.sessionRepositoryMock.EXPECT().FindActive("sid", 1, "jti").Return(model.Session{}, gorm.ErrRecordNotFound)
+	err := suite.service.Touch("sid", "jti", model.User{ID: 1})
+
+	suite.Equal(config.SessionRevokedError, err)
+}
+
+func (suite *SessionServiceTestSuite) TestSuccessResumeWithoutSession() {
+	suite.sessionRepositoryMock.EXPECT().FindBySessionID("family").Return(model.Session{}, gorm.ErrRecordNotFound)
+	suite.sessionRepositoryMock.EXPECT().Create(gomock.Any()).Return(nil).Do(func(session *model.Session) {
+		suite.Equal("family", session.SessionID)
+		suite.Equal(1, session.UserID)
+	})
+	err := suite.service.Resume(suite.ctx, model.RefreshToken{Family: "family", User: model.User{ID: 1}})
+
+	suite.Nil(err)
+}
+
+func (suite *SessionServiceTestSuite) TestBadResumeWithRevokedSession() {
+	suite.sessionRepositoryMock.EXPECT().FindBySessionID("family").Return(model.Session{}, gorm.ErrRecordNotFound)
+	suite.refreshTokenServiceMock.EXPECT().Revoke("family").Return(nil)
+	err := suite.service.Resume(suite.ctx, model.RefreshToken{Family: "family", SessionTracked: true, User: model.User{ID: 1}})
+
+	suite.Equal(config.SessionRevokedError, err)
+}
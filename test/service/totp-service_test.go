@@ -0,0 +1,271 @@
+package service_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/factory"
+	"github.com/kuritaeiji/todo-gin-back/mock_repository"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type TotpServiceTestSuite struct {
+	suite.Suite
+	service            service.TotpService
+	totpRepositoryMock *mock_repository.MockTotpRepository
+	auditServiceMock   *mock_service.MockAuditService
+	loginAttemptMock   *mock_service.MockLoginAttemptService
+	rec                *httptest.ResponseRecorder
+	ctx                *gin.Context
+}
+
+func (suite *TotpServiceTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *TotpServiceTestSuite) SetupTest() {
+	suite.totpRepositoryMock = mock_repository.NewMockTotpRepository(gomock.NewController(suite.T()))
+	suite.auditServiceMock = mock_service.NewMockAuditService(gomock.NewController(suite.T()))
+	suite.loginAttemptMock = mock_service.NewMockLoginAttemptService(gomock.NewController(suite.T()))
+	suite.service = service.TestNewTotpService(suite.totpRepositoryMock, suite.auditServiceMock, suite.loginAttemptMock)
+	suite.rec = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.rec)
+}
+
+func TestTotpService(t *testing.T) {
+	suite.Run(t, new(TotpServiceTestSuite))
+}
+
+const totpSecret = "JBSWY3DPEHPK3PXP"
+
+func (suite *TotpServiceTestSuite) setCodeRequest(code string) {
+	req := httptest.NewRequest("PUT", "/api/users/totp", strings.NewReader(`{"code":"`+code+`"}`))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+}
+
+func (suite *TotpServiceTestSuite) setDisableRequest(password, code string) {
+	req := httptest.NewRequest("DELETE", "/api/users/totp", strings.NewReader(`{"password":"`+password+`","code":"`+code+`"}`))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+}
+
+func (suite *TotpServiceTestSuite) expectAttemptsChecked(user model.User) {
+	suite.loginAttemptMock.EXPECT().CheckIP(gomock.Any()).Return(nil)
+	suite.loginAttemptMock.EXPECT().CheckUser(user).Return(nil)
+}
+
+func (suite *TotpServiceTestSuite) TestSuccessEnroll() {
+	user := model.User{ID: 1, Email: "user@example.com"}
+	suite.ctx.Set(config.CurrentUserKey, user)
+	var savedSecret string
+	suite.totpRepositoryMock.EXPECT().SaveSecret(gomock.Any(), gomock.Any()).Return(nil).Do(func(_ *model.User, secret string) {
+		savedSecret = secret
+	})
+	secret, uri, err := suite.service.Enroll(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal(savedSecret, secret)
+	suite.Contains(uri, "otpauth://totp/")
+	suite.Contains(uri, "secret="+secret)
+}
+
+func (suite *TotpServiceTestSuite) TestBadEnrollWithAlreadyEnabled() {
+	suite.ctx.Set(config.CurrentUserKey, model.User{ID: 1, TotpEnabled: true})
+	_, _, err := suite.service.Enroll(suite.ctx)
+
+	suite.Equal(config.TotpAlreadyEnabledError, err)
+}
+
+func (suite *TotpServiceTestSuite) TestSuccessEnable() {
+	user := model.User{ID: 1, TotpSecret: totpSecret}
+	suite.ctx.Set(config.CurrentUserKey, user)
+	now := time.Now()
+	code, _ := totp.GenerateCode(totpSecret, now)
+	suite.setCodeRequest(code)
+	var digests []string
+	suite.expectAttemptsChecked(user)
+	suite.totpRepositoryMock.EXPECT().UseTimeStep(gomock.Any(), now.Unix()/service.TotpPeriod).Return(nil)
+	suite.loginAttemptMock.EXPECT().Succeed(user).Return(nil)
+	suite.totpRepositoryMock.EXPECT().Enable(gomock.Any(), gomock.Any()).Return(nil).Do(func(_ *model.User, d []string) {
+		digests = d
+	})
+	suite.auditServiceMock.EXPECT().Record(suite.ctx, model.AuditEventTotpEnabled, gomock.Any(), "")
+	recoveryCodes, err := suite.service.Enable(suite.ctx)
+
+	suite.Nil(err)
+	suite.Len(recoveryCodes, service.RecoveryCodeCount)
+	suite.Len(digests, service.RecoveryCodeCount)
+	suite.Equal(factory.Digest(recoveryCodes[0]), digests[0])
+}
+
+func (suite *TotpServiceTestSuite) TestBadEnableWithNotEnrolled() {
+	suite.ctx.Set(config.CurrentUserKey, model.User{ID: 1})
+	suite.setCodeRequest("123456")
+	_, err := suite.service.Enable(suite.ctx)
+
+	suite.Equal(config.TotpNotEnrolledError, err)
+}
+
+func (suite *TotpServiceTestSuite) TestBadEnableWithInvalidCode() {
+	user := model.User{ID: 1, TotpSecret: totpSecret}
+	suite.ctx.Set(config.CurrentUserKey, user)
+	suite.setCodeRequest("invalid")
+	suite.expectAttemptsChecked(user)
+	suite.auditServiceMock.EXPECT().Record(suite.ctx, model.AuditEventTotpFailed, gomock.Any(), "enable")
+	suite.loginAttemptMock.EXPECT().Fail(gomock.Any(), gomock.Any()).Return(nil)
+	_, err := suite.service.Enable(suite.ctx)
+
+	suite.Equal(config.InvalidTotpCodeError, err)
+}
+
+func (suite *TotpServiceTestSuite) TestBadEnableWithLockedAccount() {
+	user := model.User{ID: 1, TotpSecret: totpSecret}
+	suite.ctx.Set(config.CurrentUserKey, user)
+	suite.setCodeRequest("123456")
+	suite.loginAttemptMock.EXPECT().CheckIP(gomock.Any()).Return(nil)
+	suite.loginAttemptMock.EXPECT().CheckUser(user).Return(config.AccountLockedError)
+	_, err := suite.service.Enable(suite.ctx)
+
+	suite.Equal(config.AccountLockedError, err)
+}
+
+func (suite *TotpServiceTestSuite) TestSuccessDisable() {
+	user := factory.NewUser(&factory.UserConfig{ID: 1})
+	user.TotpSecret = totpSecret
+	user.TotpEnabled = true
+	suite.ctx.Set(config.CurrentUserKey, user)
+	code, _ := totp.GenerateCode(totpSecret, time.Now())
+	suite.setDisableRequest(factory.DefualtPassword, code)
+	suite.expectAttemptsChecked(user)
+	suite.totpRepositoryMock.EXPECT().UseTimeStep(gomock.Any(), gomock.Any()).Return(nil)
+	suite.loginAttemptMock.EXPECT().Succeed(user).Return(nil)
+	suite.totpRepositoryMock.EXPECT().Disable(gomock.Any()).Return(nil)
+	suite.auditServiceMock.EXPECT().Record(suite.ctx, model.AuditEventTotpDisabled, gomock.Any(), "")
+	err := suite.service.Disable(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *TotpServiceTestSuite) TestBadDisableWithWrongPassword() {
+	user := factory.NewUser(&factory.UserConfig{ID: 1})
+	user.TotpSecret = totpSecret
+	user.TotpEnabled = true
+	suite.ctx.Set(config.CurrentUserKey, user)
+	code, _ := totp.GenerateCode(totpSecret, time.Now())
+	suite.setDisableRequest("wrongPassword", code)
+	suite.expectAttemptsChecked(user)
+	suite.auditServiceMock.EXPECT().Record(suite.ctx, model.AuditEventTotpFailed, gomock.Any(), "password")
+	suite.loginAttemptMock.EXPECT().Fail(gomock.Any(), gomock.Any()).Return(nil)
+	err := suite.service.Disable(suite.ctx)
+
+	suite.Equal(config.PasswordAuthenticationError, err)
+}
+
+func (suite *TotpServiceTestSuite) TestBadDisableWithInvalidCode() {
+	user := factory.NewUser(&factory.UserConfig{ID: 1})
+	user.TotpSecret = totpSecret
+	user.TotpEnabled = true
+	suite.ctx.Set(config.CurrentUserKey, user)
+	suite.setDisableRequest(factory.DefualtPassword, "invalid")
+	suite.expectAttemptsChecked(user)
+	suite.totpRepositoryMock.EXPECT().UseRecoveryCode(gomock.Any(), gomock.Any()).Return(gorm.ErrRecordNotFound)
+	suite.auditServiceMock.EXPECT().Record(suite.ctx, model.AuditEventTotpFailed, gomock.Any(), "disable")
+	suite.loginAttemptMock.EXPECT().Fail(gomock.Any(), gomock.Any()).Return(nil)
+	err := suite.service.Disable(suite.ctx)
+
+	suite.Equal(config.InvalidTotpCodeError, err)
+}
+
+func (suite *TotpServiceTestSuite) TestBadDisableWithLockedAccount() {
+	user := model.User{ID: 1, TotpSecret: totpSecret, TotpEnabled: true}
+	suite.ctx.Set(config.CurrentUserKey, user)
+	suite.setDisableRequest(factory.DefualtPassword, "123456")
+	suite.loginAttemptMock.EXPECT().CheckIP(gomock.Any()).Return(config.TooManyLoginAttemptsError)
+	err := suite.service.Disable(suite.ctx)
+
+	suite.Equal(config.TooManyLoginAttemptsError, err)
+}
+
+func (suite *TotpServiceTestSuite) TestBadDisableWithNotEnabled() {
+	suite.ctx.Set(config.CurrentUserKey, model.User{ID: 1})
+	suite.setDisableRequest(factory.DefualtPassword, "123456")
+	err := suite.service.Disable(suite.ctx)
+
+	suite.Equal(config.TotpNotEnrolledError, err)
+}
+
+func (suite *TotpServiceTestSuite) TestSuccessVerifyWithTotpCode() {
+	user := model.User{ID: 1, TotpSecret: totpSecret, TotpEnabled: true}
+	now := time.Now()
+	code, _ := totp.GenerateCode(totpSecret, now)
+	suite.totpRepositoryMock.EXPECT().UseTimeStep(gomock.Any(), now.Unix()/service.TotpPeriod).Return(nil)
+	ok, err := suite.service.Verify(user, code)
+
+	suite.Nil(err)
+	suite.True(ok)
+}
+
+func (suite *TotpServiceTestSuite) TestSuccessVerifyWithPreviousTotpCode() {
+	user := model.User{ID: 1, TotpSecret: totpSecret, TotpEnabled: true}
+	previous := time.Now().Add(-service.TotpPeriod * time.Second)
+	code, _ := totp.GenerateCode(totpSecret, previous)
+	suite.totpRepositoryMock.EXPECT().UseTimeStep(gomock.Any(), previous.Unix()/service.TotpPeriod).Return(nil)
+	ok, err := suite.service.Verify(user, code)
+
+	suite.Nil(err)
+	suite.True(ok)
+}
+
+func (suite *TotpServiceTestSuite) TestBadVerifyWithReusedTotpCode() {
+	user := model.User{ID: 1, TotpSecret: totpSecret, TotpEnabled: true}
+	code, _ := totp.GenerateCode(totpSecret, time.Now())
+	suite.totpRepositoryMock.EXPECT().UseTimeStep(gomock.Any(), gomock.Any()).Return(gorm.ErrRecordNotFound)
+	ok, err := suite.service.Verify(user, code)
+
+	suite.Nil(err)
+	suite.False(ok)
+}
+
+func (suite *TotpServiceTestSuite) TestBadEnableWithReusedCode() {
+	user := model.User{ID: 1, TotpSecret: totpSecret}
+	suite.ctx.Set(config.CurrentUserKey, user)
+	code, _ := totp.GenerateCode(totpSecret, time.Now())
+	suite.setCodeRequest(code)
+	suite.expectAttemptsChecked(user)
+	suite.totpRepositoryMock.EXPECT().UseTimeStep(gomock.Any(), gomock.Any()).Return(gorm.ErrRecordNotFound)
+	suite.auditServiceMock.EXPECT().Record(suite.ctx, model.AuditEventTotpFailed, gomock.Any(), "enable")
+	suite.loginAttemptMock.EXPECT().Fail(gomock.Any(), gomock.Any()).Return(nil)
+	_, err := suite.service.Enable(suite.ctx)
+
+	suite.Equal(config.InvalidTotpCodeError, err)
+}
+
+func (suite *TotpServiceTestSuite) TestSuccessVerifyWithRecoveryCode() {
+	user := model.User{ID: 1, TotpSecret: totpSecret, TotpEnabled: true}
+	suite.totpRepositoryMock.EXPECT().UseRecoveryCode(&user, factory.Digest("0123456789")).Return(nil)
+	ok, err := suite.service.Verify(user, "01234-56789")
+
+	suite.Nil(err)
+	suite.True(ok)
+}
+
+func (suite *TotpServiceTestSuite) TestBadVerifyWithInvalidCode() {
+	user := model.User{ID: 1, TotpSecret: totpSecret, TotpEnabled: true}
+	suite.totpRepositoryMock.EXPECT().UseRecoveryCode(&user, gomock.Any()).Return(gorm.ErrRecordNotFound)
+	ok, err := suite.service.Verify(user, "invalid")
+
+	suite.Nil(err)
+	suite.False(ok)
+}
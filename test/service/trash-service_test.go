@@ -0,0 +1,208 @@
+package service_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kuritaeiji/todo-gin-back/config"
+	"github.com/kuritaeiji/todo-gin-back/mock_repository"
+	"github.com/kuritaeiji/todo-gin-back/mock_service"
+	"github.com/kuritaeiji/todo-gin-back/model"
+	"github.com/kuritaeiji/todo-gin-back/service"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type TrashServiceTestSuite struct {
+	suite.Suite
+	service                 service.TrashService
+	listRepositoryMock      *mock_repository.MockListRepository
+	cardRepositoryMock      *mock_repository.MockCardRepository
+	cardReminderServiceMock *mock_service.MockCardReminderService
+	attachmentServiceMock   *mock_service.MockAttachmentService
+	boardMemberServiceMock  *mock_service.MockBoardMemberService
+	boardEventServiceMock   *mock_service.MockBoardEventService
+	ctx                     *gin.Context
+	currentUser             model.User
+	deletedAt               gorm.DeletedAt
+}
+
+func (suite *TrashServiceTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *TrashServiceTestSuite) SetupTest() {
+	suite.listRepositoryMock = mock_repository.NewMockListRepository(gomock.NewController(suite.T()))
+	suite.cardRepositoryMock = mock_repository.NewMockCardRepository(gomock.NewController(suite.T()))
+	suite.cardReminderServiceMock = mock_service.NewMockCardReminderService(gomock.NewController(suite.T()))
+	suite.attachmentServiceMock = mock_service.NewMockAttachmentService(gomock.NewController(suite.T()))
+	suite.boardMemberServiceMock = mock_service.NewMockBoardMemberService(gomock.NewController(suite.T()))
+	suite.boardEventServiceMock = mock_service.NewMockBoardEventService(gomock.NewController(suite.T()))
+	suite.service = service.TestNewTrashService(suite.listRepositoryMock, suite.cardRepositoryMock, suite.cardReminderServiceMock, suite.attachmentServiceMock, suite.boardMemberServiceMock, suite.boardEventServiceMock)
+	suite.ctx, _ = gin.CreateTestContext(httptest.NewRecorder())
+	suite.currentUser = model.User{ID: 1}
+	suite.ctx.Set(config.CurrentUserKey, suite.currentUser)
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "2"}}
+	suite.deletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+}
+
+func TestTrashService(t *testing.T) {
+	suite.Run(t, new(TrashServiceTestSuite))
+}
+
+func (suite *TrashServiceTestSuite) trashedList() model.List {
+	return model.List{ID: 2, BoardID: 5, Model: gorm.Model{DeletedAt: suite.deletedAt}}
+}
+
+func (suite *TrashServiceTestSuite) trashedCard() model.Card {
+	return model.Card{ID: 2, ListID: 3, List: model.List{ID: 3, BoardID: 5}, Model: gorm.Model{DeletedAt: suite.deletedAt}}
+}
+
+func (suite *TrashServiceTestSuite) TestSuccessIndex() {
+	lists := []model.List{suite.trashedList()}
+	cards := []model.Card{suite.trashedCard()}
+	suite.listRepositoryMock.EXPECT().FindTrashed(&suite.currentUser).Return(lists, nil)
+	suite.cardRepositoryMock.EXPECT().FindTrashed(&suite.currentUser).Return(cards, nil)
+	rLists, rCards, err := suite.service.Index(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal(lists, rLists)
+	suite.Equal(cards, rCards)
+}
+
+func (suite *TrashServiceTestSuite) TestSuccessRestoreList() {
+	list := suite.trashedList()
+	suite.listRepositoryMock.EXPECT().FindWithTrashed(2).Return(list, nil)
+	suite.boardMemberServiceMock.EXPECT().Authorize(5, suite.currentUser, model.BoardRoleEditor).Return(nil)
+	suite.listRepositoryMock.EXPECT().Restore(&list).Return(nil)
+	suite.boardEventServiceMock.EXPECT().PublishList(service.ListRestoredEvent, list)
+	suite.cardRepositoryMock.EXPECT().FindWithDueAtByList(&list).Return([]model.Card{}, nil)
+	_, err := suite.service.RestoreList(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *TrashServiceTestSuite) TestSuccessRestoreListSchedulesReminders() {
+	list := suite.trashedList()
+	dueAt := time.Now().Add(time.Hour)
+	cards := []model.Card{{ID: 1, ListID: 2, DueAt: &dueAt}, {ID: 2, ListID: 2, DueAt: &dueAt}}
+	suite.listRepositoryMock.EXPECT().FindWithTrashed(2).Return(list, nil)
+	suite.boardMemberServiceMock.EXPECT().Authorize(5, suite.currentUser, model.BoardRoleEditor).Return(nil)
+	suite.listRepositoryMock.EXPECT().Restore(&list).Return(nil)
+	suite.boardEventServiceMock.EXPECT().PublishList(service.ListRestoredEvent, list)
+	suite.cardRepositoryMock.EXPECT().FindWithDueAtByList(&list).Return(cards, nil)
+	suite.cardReminderServiceMock.EXPECT().Schedule(cards[0]).Return(nil)
+	suite.cardReminderServiceMock.EXPECT().Schedule(cards[1]).Return(nil)
+	_, err := suite.service.RestoreList(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *TrashServiceTestSuite) TestBadRestoreListWithInvalidID() {
+	suite.ctx.Params = gin.Params{{Key: "id", Value: "a"}}
+	_, err := suite.service.RestoreList(suite.ctx)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *TrashServiceTestSuite) TestBadRestoreListWithoutEditorRole() {
+	list := suite.trashedList()
+	suite.listRepositoryMock.EXPECT().FindWithTrashed(2).Return(list, nil)
+	suite.boardMemberServiceMock.EXPECT().Authorize(5, suite.currentUser, model.BoardRoleEditor).Return(config.ForbiddenError)
+	_, err := suite.service.RestoreList(suite.ctx)
+
+	suite.Equal(config.ForbiddenError, err)
+}
+
+func (suite *TrashServiceTestSuite) TestBadRestoreListNotInTrash() {
+	list := model.List{ID: 2, BoardID: 5}
+	suite.listRepositoryMock.EXPECT().FindWithTrashed(2).Return(list, nil)
+	suite.boardMemberServiceMock.EXPECT().Authorize(5, suite.currentUser, model.BoardRoleEditor).Return(nil)
+	_, err := suite.service.RestoreList(suite.ctx)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (suite *TrashServiceTestSuite) TestSuccessRestoreArchivedCardWithDueAt() {
+	archivedAt := time.Now()
+	dueAt := time.Now().Add(time.Hour)
+	card := model.Card{ID: 2, ListID: 3, List: model.List{ID: 3, BoardID: 5}, ArchivedAt: &archivedAt, DueAt: &dueAt}
+	suite.cardRepositoryMock.EXPECT().FindWithTrashed(2).Return(card, nil)
+	suite.boardMemberServiceMock.EXPECT().Authorize(5, suite.currentUser, model.BoardRoleEditor).Return(nil)
+	suite.cardRepositoryMock.EXPECT().Restore(&card).Return(nil).Do(func(argCard *model.Card) {
+		argCard.ArchivedAt = nil
+	})
+	suite.boardEventServiceMock.EXPECT().PublishCard(service.CardRestoredEvent, gomock.Any())
+	suite.cardReminderServiceMock.EXPECT().Schedule(gomock.Any()).Return(nil)
+	rCard, err := suite.service.RestoreCard(suite.ctx)
+
+	suite.Nil(err)
+	suite.Nil(rCard.ArchivedAt)
+}
+
+func (suite *TrashServiceTestSuite) TestBadRestoreCardWhenListIsInTrash() {
+	card := suite.trashedCard()
+	card.List.DeletedAt = suite.deletedAt
+	suite.cardRepositoryMock.EXPECT().FindWithTrashed(2).Return(card, nil)
+	suite.boardMemberServiceMock.EXPECT().Authorize(5, suite.currentUser, model.BoardRoleEditor).Return(nil)
+	_, err := suite.service.RestoreCard(suite.ctx)
+
+	suite.Equal(config.ListInTrashError, err)
+}
+
+func (suite *TrashServiceTestSuite) TestBadRestoreCardWithoutEditorRole() {
+	card := suite.trashedCard()
+	suite.cardRepositoryMock.EXPECT().FindWithTrashed(2).Return(card, nil)
+	suite.boardMemberServiceMock.EXPECT().Authorize(5, suite.currentUser, model.BoardRoleEditor).Return(config.ForbiddenError)
+	_, err := suite.service.RestoreCard(suite.ctx)
+
+	suite.Equal(config.ForbiddenError, err)
+}
+
+func (suite *TrashServiceTestSuite) TestSuccessDestroyList() {
+	list := suite.trashedList()
+	suite.listRepositoryMock.EXPECT().FindWithTrashed(2).Return(list, nil)
+	suite.boardMemberServiceMock.EXPECT().Authorize(5, suite.currentUser, model.BoardRoleEditor).Return(nil)
+	suite.attachmentServiceMock.EXPECT().DestroyByList(list).Return(nil)
+	suite.listRepositoryMock.EXPECT().DestroyPermanently(&list).Return(nil)
+	suite.boardEventServiceMock.EXPECT().PublishList(service.ListDestroyedEvent, list)
+	err := suite.service.DestroyList(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *TrashServiceTestSuite) TestBadDestroyListWithStorageError() {
+	list := suite.trashedList()
+	storageError := errors.New("storage error")
+	suite.listRepositoryMock.EXPECT().FindWithTrashed(2).Return(list, nil)
+	suite.boardMemberServiceMock.EXPECT().Authorize(5, suite.currentUser, model.BoardRoleEditor).Return(nil)
+	suite.attachmentServiceMock.EXPECT().DestroyByList(list).Return(storageError)
+	err := suite.service.DestroyList(suite.ctx)
+
+	suite.Equal(storageError, err)
+}
+
+func (suite *TrashServiceTestSuite) TestSuccessDestroyCard() {
+	card := suite.trashedCard()
+	suite.cardRepositoryMock.EXPECT().FindWithTrashed(2).Return(card, nil)
+	suite.boardMemberServiceMock.EXPECT().Authorize(5, suite.currentUser, model.BoardRoleEditor).Return(nil)
+	suite.attachmentServiceMock.EXPECT().DestroyByCard(card).Return(nil)
+	suite.cardRepositoryMock.EXPECT().DestroyPermanently(&card).Return(nil)
+	suite.boardEventServiceMock.EXPECT().PublishCard(service.CardDestroyedEvent, card)
+	err := suite.service.DestroyCard(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *TrashServiceTestSuite) TestBadDestroyCardNotInTrash() {
+	card := model.Card{ID: 2, List: model.List{BoardID: 5}}
+	suite.cardRepositoryMock.EXPECT().FindWithTrashed(2).Return(card, nil)
+	suite.boardMemberServiceMock.EXPECT().Authorize(5, suite.currentUser, model.BoardRoleEditor).Return(nil)
+	err := suite.service.DestroyCard(suite.ctx)
+
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -21,15 +22,22 @@ import (
 	"github.com/kuritaeiji/todo-gin-back/validators"
 	"github.com/stretchr/testify/suite"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 type UserServiceTestSuite struct {
 	suite.Suite
-	service            service.UserService
-	userRepositoryMock *mock_repository.MockUserRepository
-	jwtServiceMock     *mock_service.MockJWTService
-	rec                *httptest.ResponseRecorder
-	ctx                *gin.Context
+	service                   service.UserService
+	userRepositoryMock        *mock_repository.MockUserRepository
+	emailChangeRepositoryMock *mock_repository.MockEmailChangeRepository
+	jwtServiceMock            *mock_service.MockJWTService
+	refreshTokenServiceMock   *mock_service.MockRefreshTokenService
+	sessionServiceMock        *mock_service.MockSessionService
+	auditServiceMock          *mock_service.MockAuditService
+	emailServiceMock          *mock_service.MockEmailService
+	attachmentServiceMock     *mock_service.MockAttachmentService
+	rec                       *httptest.ResponseRecorder
+	ctx                       *gin.Context
 }
 
 func (suite *UserServiceTestSuite) SetupSuite() {
@@ -39,8 +47,23 @@ func (suite *UserServiceTestSuite) SetupSuite() {
 
 func (suite *UserServiceTestSuite) SetupTest() {
 	suite.userRepositoryMock = mock_repository.NewMockUserRepository(gomock.NewController(suite.T()))
+	suite.emailChangeRepositoryMock = mock_repository.NewMockEmailChangeRepository(gomock.NewController(suite.T()))
 	suite.jwtServiceMock = mock_service.NewMockJWTService(gomock.NewController(suite.T()))
-	suite.service = service.TestNewUserService(suite.jwtServiceMock, suite.userRepositoryMock)
+	suite.refreshTokenServiceMock = mock_service.NewMockRefreshTokenService(gomock.NewController(suite.T()))
+	suite.sessionServiceMock = mock_service.NewMockSessionService(gomock.NewController(suite.T()))
+	suite.auditServiceMock = mock_service.NewMockAuditService(gomock.NewController(suite.T()))
+	suite.emailServiceMock = mock_service.NewMockEmailService(gomock.NewController(suite.T()))
+	suite.attachmentServiceMock = mock_service.NewMockAttachmentService(gomock.NewController(suite.T()))
+	suite.service = service.TestNewUserService(
+		suite.jwtServiceMock,
+		suite.refreshTokenServiceMock,
+		suite.sessionServiceMock,
+		suite.auditServiceMock,
+		suite.emailServiceMock,
+		suite.attachmentServiceMock,
+		suite.userRepositoryMock,
+		suite.emailChangeRepositoryMock,
+	)
 	suite.rec = httptest.NewRecorder()
 	suite.ctx, _ = gin.CreateTestContext(suite.rec)
 }
@@ -119,8 +142,9 @@ func (suite *UserServiceTestSuite) TestBadIsUniqueWithDBError() {
 }
 
 func (suite *UserServiceTestSuite) TestSuccessActivate() {
+	suite.auditServiceMock.EXPECT().Record(gomock.Any(), model.AuditEventActivated, gomock.Any(), "")
 	user := model.User{}
-	tokenString := factory.CreateAccessToken(user)
+	tokenString := "tokenString"
 	claim := factory.CreateUserClaim(user)
 	suite.jwtServiceMock.EXPECT().VerifyJWT(tokenString).Return(&claim, nil)
 	suite.userRepositoryMock.EXPECT().Find(claim.ID).Return(user, nil)
@@ -186,9 +210,11 @@ func (suite *UserServiceTestSuite) TestBadActivateWithDBError() {
 }
 
 func (suite *UserServiceTestSuite) TestSuccessDestroy() {
+	suite.auditServiceMock.EXPECT().Record(gomock.Any(), model.AuditEventUserDeleted, gomock.Any(), "")
 	currentUser := factory.NewUser(&factory.UserConfig{})
 	suite.ctx.Set(config.CurrentUserKey, currentUser)
 	suite.userRepositoryMock.EXPECT().Destroy(&currentUser).Return(nil)
+	suite.attachmentServiceMock.EXPECT().DestroyByUser(currentUser).Return(nil)
 	err := suite.service.Destroy(suite.ctx)
 	suite.Nil(err)
 }
@@ -201,3 +227,192 @@ func (suite *UserServiceTestSuite) TestBadDestroyWithRepositoryReturnsError() {
 	returnErr := suite.service.Destroy(suite.ctx)
 	suite.Equal(err, returnErr)
 }
+
+func (suite *UserServiceTestSuite) TestSuccessChangePassword() {
+	suite.auditServiceMock.EXPECT().Record(gomock.Any(), model.AuditEventPasswordChanged, gomock.Any(), "")
+	currentUser := factory.NewUser(&factory.UserConfig{ID: 1})
+	const newPassword = "NewPassword1010"
+	claim := &service.UserClaim{ID: currentUser.ID, SessionID: "sessionID"}
+	session := model.Session{SessionID: "newSessionID"}
+	suite.ctx.Set(config.CurrentUserKey, currentUser)
+	suite.ctx.Set(config.ClaimKey, claim)
+	suite.userRepositoryMock.EXPECT().UpdatePassword(gomock.Any()).Return(nil).Do(func(user *model.User) {
+		suite.True(user.Authenticate(newPassword))
+	})
+	suite.sessionServiceMock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(session, nil)
+	suite.refreshTokenServiceMock.EXPECT().Create(gomock.Any(), session.SessionID).Return("refreshToken", nil)
+	suite.jwtServiceMock.EXPECT().CreateAccessJWT(gomock.Any(), session.SessionID).Return("accessToken")
+
+	body := fmt.Sprintf(`{"currentPassword":"%v","password":"%v"}`, factory.DefualtPassword, newPassword)
+	req := httptest.NewRequest("PUT", "/api/users/password", strings.NewReader(body))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	tokenPair, err := suite.service.ChangePassword(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal("accessToken", tokenPair.AccessToken)
+	suite.Equal("refreshToken", tokenPair.RefreshToken)
+}
+
+func (suite *UserServiceTestSuite) TestBadChangePasswordWithValidationError() {
+	req := httptest.NewRequest("PUT", "/api/users/password", strings.NewReader(`{"currentPassword":"password","password":"password"}`))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	_, err := suite.service.ChangePassword(suite.ctx)
+
+	suite.IsType(validator.ValidationErrors{}, err)
+}
+
+func (suite *UserServiceTestSuite) TestBadChangePasswordWithWrongCurrentPassword() {
+	suite.ctx.Set(config.CurrentUserKey, factory.NewUser(&factory.UserConfig{ID: 1}))
+	req := httptest.NewRequest("PUT", "/api/users/password", strings.NewReader(`{"currentPassword":"Invalid1010","password":"NewPassword1010"}`))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	_, err := suite.service.ChangePassword(suite.ctx)
+
+	suite.Equal(config.PasswordAuthenticationError, err)
+}
+
+func (suite *UserServiceTestSuite) TestSuccessChangeEmail() {
+	currentUser := factory.NewUser(&factory.UserConfig{ID: 1})
+	const newEmail = "new@example.com"
+	suite.ctx.Set(config.CurrentUserKey, currentUser)
+	suite.userRepositoryMock.EXPECT().IsUnique(newEmail).Return(true, nil)
+	suite.emailChangeRepositoryMock.EXPECT().Create(gomock.Any()).Return(nil).Do(func(change *model.EmailChange) {
+		suite.Equal(newEmail, change.Email)
+		suite.Equal(currentUser.ID, change.UserID)
+		suite.InEpsilon(time.Now().AddDate(0, 0, service.DayFromNowEmailChangeToken).Unix(), change.ExpiresAt.Unix(), 30)
+	})
+	suite.emailServiceMock.EXPECT().EmailChangeEmail(newEmail, gomock.Any()).Return(nil)
+
+	body := fmt.Sprintf(`{"email":"%v","password":"%v"}`, newEmail, factory.DefualtPassword)
+	req := httptest.NewRequest("PUT", "/api/users/email", strings.NewReader(body))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	err := suite.service.ChangeEmail(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *UserServiceTestSuite) TestBadChangeEmailWithNotUniqueEmail() {
+	currentUser := factory.NewUser(&factory.UserConfig{ID: 1})
+	const newEmail = "new@example.com"
+	suite.ctx.Set(config.CurrentUserKey, currentUser)
+	suite.userRepositoryMock.EXPECT().IsUnique(newEmail).Return(false, nil)
+
+	body := fmt.Sprintf(`{"email":"%v","password":"%v"}`, newEmail, factory.DefualtPassword)
+	req := httptest.NewRequest("PUT", "/api/users/email", strings.NewReader(body))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	err := suite.service.ChangeEmail(suite.ctx)
+
+	suite.Equal(config.UniqueUserError, err)
+}
+
+func (suite *UserServiceTestSuite) TestBadChangeEmailWithWrongPassword() {
+	suite.ctx.Set(config.CurrentUserKey, factory.NewUser(&factory.UserConfig{ID: 1}))
+	req := httptest.NewRequest("PUT", "/api/users/email", strings.NewReader(`{"email":"new@example.com","password":"Invalid1010"}`))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	err := suite.service.ChangeEmail(suite.ctx)
+
+	suite.Equal(config.PasswordAuthenticationError, err)
+}
+
+func (suite *UserServiceTestSuite) TestSuccessConfirmEmail() {
+	change := model.EmailChange{ID: 1, Email: "new@example.com", ExpiresAt: time.Now().Add(time.Hour), UserID: 1, User: model.User{ID: 1}}
+	suite.emailChangeRepositoryMock.EXPECT().FindByDigest(factory.Digest("token")).Return(change, nil)
+	suite.emailChangeRepositoryMock.EXPECT().Confirm(&change).Return(nil)
+	suite.auditServiceMock.EXPECT().Record(suite.ctx, model.AuditEventEmailChanged, &change.User, change.Email)
+	suite.ctx.Request = httptest.NewRequest("PUT", "/api/users/email/confirm?token=token", nil)
+	err := suite.service.ConfirmEmail(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *UserServiceTestSuite) TestBadConfirmEmailWithNotFoundToken() {
+	suite.emailChangeRepositoryMock.EXPECT().FindByDigest(factory.Digest("token")).Return(model.EmailChange{}, gorm.ErrRecordNotFound)
+	suite.ctx.Request = httptest.NewRequest("PUT", "/api/users/email/confirm?token=token", nil)
+	err := suite.service.ConfirmEmail(suite.ctx)
+
+	suite.Equal(config.InvalidEmailChangeTokenError, err)
+}
+
+func (suite *UserServiceTestSuite) TestBadConfirmEmailWithExpiredToken() {
+	change := model.EmailChange{ID: 1, ExpiresAt: time.Now().Add(-time.Hour), User: model.User{ID: 1}}
+	suite.emailChangeRepositoryMock.EXPECT().FindByDigest(factory.Digest("token")).Return(change, nil)
+	suite.ctx.Request = httptest.NewRequest("PUT", "/api/users/email/confirm?token=token", nil)
+	err := suite.service.ConfirmEmail(suite.ctx)
+
+	suite.Equal(config.InvalidEmailChangeTokenError, err)
+}
+
+func (suite *UserServiceTestSuite) TestSuccessResendActivation() {
+	sentAt := time.Now().Add(-(service.MinuteResendActivationInterval + 1) * time.Minute)
+	user := factory.NewUser(&factory.UserConfig{ID: 1})
+	user.ActivationSentAt = &sentAt
+	suite.userRepositoryMock.EXPECT().FindByEmail(user.Email).Return(user, nil)
+	suite.userRepositoryMock.EXPECT().UpdateActivationSentAt(&user, gomock.Any()).Return(nil)
+	suite.emailServiceMock.EXPECT().ActivationUserEmail(user).Return(nil)
+
+	req := httptest.NewRequest("POST", "/api/users/activation/resend", strings.NewReader(fmt.Sprintf(`{"email":"%v"}`, user.Email)))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	err := suite.service.ResendActivation(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *UserServiceTestSuite) TestSuccessResendActivationWithNotFoundUser() {
+	const email = "notfound@example.com"
+	suite.userRepositoryMock.EXPECT().FindByEmail(email).Return(model.User{}, gorm.ErrRecordNotFound)
+
+	req := httptest.NewRequest("POST", "/api/users/activation/resend", strings.NewReader(fmt.Sprintf(`{"email":"%v"}`, email)))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	err := suite.service.ResendActivation(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *UserServiceTestSuite) TestSuccessResendActivationWithActivatedUser() {
+	user := factory.NewUser(&factory.UserConfig{ID: 1, Activated: true})
+	suite.userRepositoryMock.EXPECT().FindByEmail(user.Email).Return(user, nil)
+
+	req := httptest.NewRequest("POST", "/api/users/activation/resend", strings.NewReader(fmt.Sprintf(`{"email":"%v"}`, user.Email)))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	err := suite.service.ResendActivation(suite.ctx)
+
+	suite.Nil(err)
+}
+
+func (suite *UserServiceTestSuite) TestSuccessResendActivationWithThrottled() {
+	sentAt := time.Now().Add(-time.Minute)
+	user := factory.NewUser(&factory.UserConfig{ID: 1})
+	user.ActivationSentAt = &sentAt
+	suite.userRepositoryMock.EXPECT().FindByEmail(user.Email).Return(user, nil)
+
+	req := httptest.NewRequest("POST", "/api/users/activation/resend", strings.NewReader(fmt.Sprintf(`{"email":"%v"}`, user.Email)))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	err := suite.service.ResendActivation(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal(config.ActivationEmailThrottledError, suite.ctx.Errors.Last().Err)
+}
+
+func (suite *UserServiceTestSuite) TestSuccessResendActivationWithEmailClientError() {
+	user := factory.NewUser(&factory.UserConfig{ID: 1})
+	suite.userRepositoryMock.EXPECT().FindByEmail(user.Email).Return(user, nil)
+	suite.userRepositoryMock.EXPECT().UpdateActivationSentAt(&user, gomock.Any()).Return(nil)
+	suite.emailServiceMock.EXPECT().ActivationUserEmail(user).Return(config.EmailClientError)
+
+	req := httptest.NewRequest("POST", "/api/users/activation/resend", strings.NewReader(fmt.Sprintf(`{"email":"%v"}`, user.Email)))
+	req.Header.Add("Content-Type", binding.MIMEJSON)
+	suite.ctx.Request = req
+	err := suite.service.ResendActivation(suite.ctx)
+
+	suite.Nil(err)
+	suite.Equal(config.EmailClientError, suite.ctx.Errors.Last().Err)
+}
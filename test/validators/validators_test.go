@@ -2,6 +2,7 @@ package validators_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -42,3 +43,25 @@ func TestValidator(t *testing.T) {
 		})
 	}
 }
+
+func TestTimezoneValidator(t *testing.T) {
+	assert := assert.New(t)
+	assert.Nil(validate.Var("Asia/Tokyo", "timezone"))
+	assert.Nil(validate.Var("UTC", "timezone"))
+	assert.Error(validate.Var("Mars/Olympus", "timezone"))
+}
+
+func TestAfterFieldValidator(t *testing.T) {
+	type schedule struct {
+		StartAt *time.Time
+		DueAt   *time.Time `binding:"omitempty,afterfield=StartAt"`
+	}
+
+	assert := assert.New(t)
+	startAt := time.Now()
+	dueAt := startAt.Add(time.Hour)
+	assert.Nil(validate.Struct(schedule{StartAt: &startAt, DueAt: &dueAt}))
+	assert.Nil(validate.Struct(schedule{DueAt: &dueAt}))
+	assert.Nil(validate.Struct(schedule{StartAt: &startAt}))
+	assert.Error(validate.Struct(schedule{StartAt: &dueAt, DueAt: &startAt}))
+}
@@ -1,7 +1,9 @@
 package validators
 
 import (
+	"reflect"
 	"regexp"
+	"time"
 
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
@@ -13,6 +15,8 @@ func Init() {
 	var ok bool
 	if validate, ok = binding.Validator.Engine().(*validator.Validate); ok {
 		validate.RegisterValidation("password", password)
+		validate.RegisterValidation("timezone", timezone)
+		validate.RegisterValidation("afterfield", afterField)
 	}
 }
 
@@ -32,3 +36,32 @@ func password(fl validator.FieldLevel) bool {
 	}
 	return false
 }
+
+// IANAのタイムゾーン名 (Asia/Tokyo等)
+func timezone(fl validator.FieldLevel) bool {
+	_, err := time.LoadLocation(fl.Field().String())
+	return err == nil
+}
+
+// パラメーターで指定したフィールドの日時より後であること
+// gtfieldと異なり比較するフィールドがnilの場合は検証しない
+func afterField(fl validator.FieldLevel) bool {
+	value, ok := fl.Field().Interface().(time.Time)
+	if !ok {
+		return false
+	}
+
+	other := fl.Parent().FieldByName(fl.Param())
+	if !other.IsValid() {
+		return false
+	}
+	if other.Kind() == reflect.Ptr {
+		if other.IsNil() {
+			return true
+		}
+		other = other.Elem()
+	}
+
+	otherValue, ok := other.Interface().(time.Time)
+	return ok && value.After(otherValue)
+}